@@ -1,16 +1,16 @@
 package main
 
 import (
-	"bufio"
 	"context"
 	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
 	"path"
-	"strings"
 	"syscall"
 	"time"
 
@@ -22,11 +22,16 @@ import (
 	"github.com/MowlCoder/goph-keeper/internal/commands/handlers"
 	"github.com/MowlCoder/goph-keeper/internal/config"
 	"github.com/MowlCoder/goph-keeper/internal/domain"
-	fileRepositories "github.com/MowlCoder/goph-keeper/internal/repositories/file"
+	"github.com/MowlCoder/goph-keeper/internal/presenter"
+	"github.com/MowlCoder/goph-keeper/internal/profile"
+	boltRepositories "github.com/MowlCoder/goph-keeper/internal/repositories/bolt"
 	clientServices "github.com/MowlCoder/goph-keeper/internal/services/client"
 	"github.com/MowlCoder/goph-keeper/internal/session"
+	"github.com/MowlCoder/goph-keeper/internal/storage/bolt"
 	"github.com/MowlCoder/goph-keeper/internal/storage/file"
+	"github.com/MowlCoder/goph-keeper/internal/tracing"
 	"github.com/MowlCoder/goph-keeper/internal/utils/cryptor"
+	"github.com/MowlCoder/goph-keeper/pkg/input"
 )
 
 var (
@@ -36,97 +41,321 @@ var (
 	dataSecret string
 )
 
+// tokenRefreshBefore - how long before expiration token is exchanged for a new one on client start
+const tokenRefreshBefore = 12 * time.Hour
+
 func main() {
+	os.Exit(run())
+}
+
+// run - start client and return its exit code. Client executes single command if it is given
+// in arguments after flags, otherwise it reads commands from console
+func run() int {
 	err := godotenv.Load(".env.client")
 	if err != nil {
 		log.Println("No .env.client provided")
 	}
 
 	clientConfig := &config.Client{}
-	clientConfig.Parse()
+	if err := clientConfig.Parse(); err != nil {
+		log.Println(err)
+		return commands.ExitCodeUsage
+	}
+
+	// words after flags are single command to execute, e.g. "lp get --id 5 --field password"
+	commandArgs := flag.Args()
+	isSingleCommand := len(commandArgs) > 0
+	if isSingleCommand {
+		input.SetInteractive(false)
+	}
+
+	outputFormat, err := presenter.ParseFormat(clientConfig.Output)
+	if err != nil {
+		log.Println(err)
+		return commands.ExitCodeUsage
+	}
+	outputPresenter := presenter.NewPresenter(outputFormat, os.Stdout)
+
+	conflictResolver, err := clientsync.NewConflictResolver(clientConfig.MergePolicy)
+	if err != nil {
+		log.Println(err)
+		return commands.ExitCodeError
+	}
+
+	if clientConfig.AutoSyncInterval > 0 && clientConfig.MergePolicy == clientsync.MergePolicyInteractive {
+		log.Println(domain.ErrInteractiveAutoSync)
+		return commands.ExitCodeError
+	}
+
+	if isSingleCommand && clientConfig.MergePolicy == clientsync.MergePolicyInteractive {
+		log.Println(domain.ErrInteractiveOneShot)
+		return commands.ExitCodeError
+	}
+
+	shutdownTracing, err := tracing.Init(context.Background(), tracing.Config{
+		ServiceName:    "goph-keeper-client",
+		ServiceVersion: buildVersion,
+		OTLPEndpoint:   clientConfig.OTLPEndpoint,
+		OTLPInsecure:   clientConfig.OTLPInsecure,
+	})
+	if err != nil {
+		log.Println(err)
+		return commands.ExitCodeError
+	}
 
 	httpClient := &http.Client{
 		Transport: http.DefaultTransport,
 		Timeout:   time.Second * time.Duration(clientConfig.ApiServerTimeout),
 	}
 
-	userCache, err := os.UserCacheDir()
+	appRootDirPath := clientConfig.DataDir
+	if appRootDirPath == "" {
+		appRootDirPath, err = file.DefaultDataDir()
+		if err != nil {
+			log.Println(err)
+			return commands.ExitCodeError
+		}
+	}
+
+	// previous versions kept vault in cache directory, which is wiped by cleaners, so it is moved
+	legacyDataDirPath, err := file.LegacyDataDir()
+	if err != nil {
+		log.Println(err)
+		return commands.ExitCodeError
+	}
+
+	if err := file.PrepareDataDir(appRootDirPath, legacyDataDirPath); err != nil {
+		log.Println(err)
+		return commands.ExitCodeError
+	}
+
+	profileRegistry, err := profile.NewRegistry(appRootDirPath)
 	if err != nil {
 		log.Println(err)
-		return
+		return commands.ExitCodeError
 	}
 
-	appDataDirPath := path.Join(userCache, "goph-keeper")
-	if err := os.Mkdir(appDataDirPath, os.ModePerm); err != nil && !errors.Is(err, os.ErrExist) {
-		log.Fatal(err)
+	currentProfile, err := profileRegistry.Open(clientConfig.Profile, clientConfig.ServerBaseAddr, clientConfig.RunServerAddr)
+	if err != nil {
+		log.Println(err)
+		return commands.ExitCodeError
+	}
+
+	// every profile has its own vault, session and sync cursor and talks only to its own server
+	appDataDirPath := profileRegistry.DataDir(currentProfile.Name)
+	serverBaseAddr := currentProfile.ServerAddr
+
+	for _, privatePath := range []string{
+		appDataDirPath,
+		path.Join(appDataDirPath, "vault.db"),
+		path.Join(appDataDirPath, "session.json"),
+	} {
+		if err := file.CheckPrivate(privatePath); err != nil {
+			log.Println(privatePath, "-", err)
+			return commands.ExitCodeError
+		}
+	}
+
+	// second client would overwrite changes of the first one, so only one client works with data directory
+	dataDirLock, err := file.LockDir(appDataDirPath)
+	if err != nil {
+		log.Println(err)
+		return commands.ExitCodeError
+	}
+	defer dataDirLock.Unlock()
+
+	vaultStorage, err := bolt.InitBoltStorage(path.Join(appDataDirPath, "vault.db"))
+	if err != nil {
+		log.Println(err)
+		return commands.ExitCodeError
 	}
+	defer vaultStorage.Close()
 
-	userStoredDataStorage, err := file.InitFileStorage(path.Join(appDataDirPath, "user_stored_data.json"))
+	// session and vault are readable only with vault key, so copied data directory is useless without client
+	vaultSealer, err := cryptor.NewSealer(dataSecret)
 	if err != nil {
 		log.Println(err)
-		return
+		return commands.ExitCodeError
 	}
-	defer userStoredDataStorage.Close()
 
-	sessionStorage, err := file.InitFileStorage(path.Join(appDataDirPath, "session.json"))
+	clientSession, err := session.NewClientSession(path.Join(appDataDirPath, "session.json"), vaultSealer)
 	if err != nil {
 		log.Println(err)
-		return
+		return commands.ExitCodeError
 	}
-	defer sessionStorage.Close()
 
-	clientSession := session.NewClientSession(sessionStorage)
-	userStoredDataAPI := api.NewUserStoredDataAPI(clientConfig.ServerBaseAddr, httpClient, clientSession)
-	userAPI := api.NewUserAPI(clientConfig.ServerBaseAddr, httpClient, clientSession)
+	userStoredDataAPI := api.NewUserStoredDataAPI(serverBaseAddr, httpClient, clientSession)
+	userAPI := api.NewUserAPI(serverBaseAddr, httpClient, clientSession)
+	shareAPI := api.NewShareAPI(serverBaseAddr, httpClient, clientSession)
+	organizationAPI := api.NewOrganizationAPI(serverBaseAddr, httpClient, clientSession)
+	emergencyAccessAPI := api.NewEmergencyAccessAPI(serverBaseAddr, httpClient, clientSession)
+	auditAPI := api.NewAuditAPI(serverBaseAddr, httpClient, clientSession)
+	deviceAPI := api.NewDeviceAPI(serverBaseAddr, httpClient, clientSession)
+
+	// token is refreshed before it expires, so user who uses client every day does not have to log in again
+	if clientSession.IsAuth() && clientSession.TokenExpiresWithin(tokenRefreshBefore) {
+		if err := userAPI.RefreshToken(context.Background()); err != nil {
+			log.Println("failed to refresh token:", err)
+		}
+	}
+
+	userStoredDataRepository, err := boltRepositories.NewUserStoredDataRepository(vaultStorage, vaultSealer)
+	if err != nil {
+		log.Println(err)
+		return commands.ExitCodeError
+	}
 
-	userStoredDataRepository := fileRepositories.NewUserStoredDataRepository(userStoredDataStorage)
+	migrated, err := userStoredDataRepository.MigrateFromJSONFile(
+		context.Background(),
+		path.Join(appDataDirPath, "user_stored_data.json"),
+	)
+	if err != nil {
+		log.Println(err)
+		return commands.ExitCodeError
+	}
+	if migrated > 0 {
+		log.Printf("%d records are moved from user_stored_data.json to vault.db\n", migrated)
+	}
 
 	dataCryptor := cryptor.New(dataSecret)
 
 	userStoredDataService := clientServices.NewUserStoredDataService(userStoredDataRepository, dataCryptor)
+	vaultService := clientServices.NewVaultService(userStoredDataService, userStoredDataAPI, clientSession)
 
 	userHandler := handlers.NewUserHandler(httpClient, clientSession, userAPI)
-	logPassHandler := handlers.NewLogPassHandler(clientSession, userStoredDataService)
-	cardHandler := handlers.NewCardHandler(clientSession, userStoredDataService)
-	textHandler := handlers.NewTextHandler(clientSession, userStoredDataService)
-	fileHandler := handlers.NewFileHandler(clientSession, userStoredDataService)
+	logPassHandler := handlers.NewLogPassHandler(clientSession, vaultService)
+	cardHandler := handlers.NewCardHandler(clientSession, vaultService)
+	textHandler := handlers.NewTextHandler(clientSession, vaultService)
+	fileHandler := handlers.NewFileHandler(clientSession, vaultService)
+	shareHandler := handlers.NewShareHandler(clientSession, shareAPI, vaultService)
+	organizationHandler := handlers.NewOrganizationHandler(clientSession, organizationAPI)
+	emergencyAccessHandler := handlers.NewEmergencyAccessHandler(clientSession, emergencyAccessAPI)
+	auditHandler := handlers.NewAuditHandler(clientSession, auditAPI)
+	deviceHandler := handlers.NewDeviceHandler(clientSession, deviceAPI)
+	profileHandler := handlers.NewProfileHandler(profileRegistry, currentProfile.Name)
+	conflictsHandler := handlers.NewConflictsHandler(clientSession)
 
 	dataSyncer := clientsync.NewBaseSyncer(
 		clientSession,
 		userStoredDataAPI,
 		userStoredDataService,
-		userStoredDataRepository,
+		conflictResolver,
 	)
 
-	commandManager := commands.NewCommandManager()
+	commandManager := commands.NewCommandManager(outputPresenter)
+
+	autoSyncCtx, autoSyncCancel := context.WithCancel(context.Background())
+	defer autoSyncCancel()
+
+	// changes made on other devices are synced as soon as server tells about them, local changes only when
+	// background sync is enabled
+	if !isSingleCommand {
+		autoSyncer := clientsync.NewAutoSyncer(
+			dataSyncer,
+			commandManager,
+			time.Second*time.Duration(clientConfig.AutoSyncInterval),
+			time.Second*time.Duration(clientConfig.AutoSyncDebounce),
+		)
+		if clientConfig.AutoSyncInterval > 0 {
+			commandManager.AddExecHook(autoSyncer.CommandExecutedHook)
+		}
 
-	registerSystemCommands(commandManager, dataSyncer, appDataDirPath)
+		// event stream is open all the time, so its client has no timeout
+		eventsAPI := api.NewEventsAPI(serverBaseAddr, &http.Client{Transport: http.DefaultTransport}, clientSession)
+
+		go autoSyncer.Run(autoSyncCtx)
+		go autoSyncer.ListenServerEvents(autoSyncCtx, eventsAPI)
+	}
+
+	registerSystemCommands(commandManager, dataSyncer, conflictsHandler, appDataDirPath)
 	registerUserCommands(commandManager, userHandler)
 	registerLogPassCommands(commandManager, logPassHandler)
 	registerCardCommands(commandManager, cardHandler)
 	registerTextCommands(commandManager, textHandler)
 	registerFileCommands(commandManager, fileHandler)
+	registerShareCommands(commandManager, shareHandler)
+	registerOrganizationCommands(commandManager, organizationHandler)
+	registerEmergencyAccessCommands(commandManager, emergencyAccessHandler)
+	registerAuditCommands(commandManager, auditHandler)
+	registerDeviceCommands(commandManager, deviceHandler)
+	registerProfileCommands(commandManager, profileHandler)
+
+	if isSingleCommand {
+		exitCode := runSingleCommand(commandManager, outputPresenter, commandArgs)
+
+		shutdownCtx, shutdownCtxCancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer shutdownCtxCancel()
+
+		if err := shutdownTracing(shutdownCtx); err != nil {
+			log.Println("failed to flush traces:", err)
+		}
+
+		return exitCode
+	}
+
+	commandManager.SetRecordRefs(func() []string {
+		dataSet, err := userStoredDataRepository.GetAll(context.Background())
+		if err != nil {
+			return nil
+		}
+
+		refs := make([]string, 0, len(dataSet))
+		for _, data := range dataSet {
+			refs = append(refs, data.UUID)
+		}
 
-	reader := bufio.NewReader(os.Stdin)
+		return refs
+	})
+
+	lineEditor, err := input.NewEditor(
+		path.Join(appDataDirPath, "history"),
+		commandManager.Complete,
+		commandManager.IsSecret,
+	)
+	if err != nil {
+		log.Println(err)
+		return commands.ExitCodeError
+	}
+	defer lineEditor.Close()
 
 	fmt.Println("Goph Keeper")
 	fmt.Println("Type 'help' to get command list")
 
+	// Ctrl-C cancels prompt or command, so it does not stop the app
+	signal.Ignore(syscall.SIGINT)
+
 	sig := make(chan os.Signal, 1)
-	signal.Notify(sig, syscall.SIGHUP, syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
+	signal.Notify(sig, syscall.SIGHUP, syscall.SIGTERM, syscall.SIGQUIT)
 
 	go func() {
 		for {
+			prompt := ""
+			if currentProfile.Name != domain.DefaultProfileName {
+				prompt += fmt.Sprintf("[%s] ", currentProfile.Name)
+			}
 			if !clientSession.IsAuth() {
-				fmt.Print("(no auth) ")
+				prompt += "(no auth) "
+			}
+			if !clientSession.IsPersonalVault() {
+				prompt += fmt.Sprintf("[collection %d] ", clientSession.GetActiveCollectionID())
 			}
 
-			fmt.Print("> ")
-			text, _ := reader.ReadString('\n')
-			text = strings.Trim(text, "\n\r")
-			parts := strings.Split(text, " ")
+			words, err := lineEditor.ReadCommand(prompt + "> ")
+			if errors.Is(err, io.EOF) {
+				sig <- syscall.SIGQUIT
+				break
+			}
+			if errors.Is(err, input.ErrInputCanceled) || (err == nil && len(words) == 0) {
+				continue
+			}
+			if err != nil {
+				fmt.Println("invalid command line -", err.Error())
+				continue
+			}
+
+			name, args := commandManager.ResolveCommand(words)
 
-			err := commandManager.ExecCommandWithName(parts[0], parts[1:])
+			err = commandManager.ExecCommandWithName(name, args)
 
 			if errors.Is(err, domain.ErrQuitApp) {
 				sig <- syscall.SIGQUIT
@@ -140,6 +369,7 @@ func main() {
 	}()
 
 	<-sig
+	autoSyncCancel()
 
 	shutdownCtx, shutdownCtxCancel := context.WithTimeout(context.Background(), 90*time.Second)
 	defer shutdownCtxCancel()
@@ -151,11 +381,35 @@ func main() {
 			os.Exit(1)
 		}
 	}()
+
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		log.Println("failed to flush traces:", err)
+	}
+
+	return commands.ExitCodeOK
+}
+
+// runSingleCommand - execute command given in arguments, result is reported only by exit code and errors
+// are written to stderr, so output of command can be used by scripts
+func runSingleCommand(
+	commandManager *commands.CommandManager,
+	outputPresenter *presenter.Presenter,
+	commandArgs []string,
+) int {
+	name, args := commandManager.ResolveCommand(commandArgs)
+
+	err := commandManager.ExecCommandWithName(name, args)
+	if err != nil && !errors.Is(err, domain.ErrQuitApp) {
+		outputPresenter.PresentError(os.Stderr, err)
+	}
+
+	return commands.ExitCode(err)
 }
 
 func registerSystemCommands(
 	commandManager *commands.CommandManager,
 	dataSyncer *clientsync.BaseSyncer,
+	conflictsHandler *handlers.ConflictsHandler,
 
 	fileStoragePath string,
 ) {
@@ -164,12 +418,12 @@ func registerSystemCommands(
 		"get version of client binary",
 		"system",
 		"version",
-		func(args []string) error {
-			fmt.Println("==========================================")
-			fmt.Printf("Version: %s\n", buildVersion)
-			fmt.Printf("Build date: %s\n", buildDate)
-			fmt.Println("==========================================")
-			return nil
+		func(args []string) (*presenter.Result, error) {
+			return presenter.NewRecord(
+				"Version",
+				[]string{"version", "build_date"},
+				[]any{buildVersion, buildDate},
+			), nil
 		},
 	)
 	commandManager.RegisterCommand(
@@ -177,9 +431,8 @@ func registerSystemCommands(
 		"show path to directory where data stores",
 		"system",
 		"storage",
-		func(args []string) error {
-			fmt.Println(fileStoragePath)
-			return nil
+		func(args []string) (*presenter.Result, error) {
+			return presenter.NewValue("path", fileStoragePath), nil
 		},
 	)
 	commandManager.RegisterCommand(
@@ -189,6 +442,27 @@ func registerSystemCommands(
 		"sync [need auth]",
 		dataSyncer.SyncCommandHandler,
 	)
+	commandManager.RegisterCommand(
+		"sync-status",
+		"show result of last sync and number of local changes waiting for it",
+		"system",
+		"sync-status",
+		dataSyncer.SyncStatusCommandHandler,
+	)
+	commandManager.RegisterCommand(
+		"conflicts",
+		"list records changed both locally and on server, which local versions were saved as conflicted copies",
+		"system",
+		"conflicts",
+		conflictsHandler.GetAll,
+	)
+	commandManager.RegisterCommand(
+		"conflict-resolve",
+		"mark sync conflict of record as resolved",
+		"system",
+		"conflict-resolve <record_uuid>",
+		conflictsHandler.Resolve,
+	)
 }
 
 func registerUserCommands(
@@ -199,14 +473,14 @@ func registerUserCommands(
 		"login",
 		"start user session",
 		"user",
-		"login",
+		"login [--email <email>] [--password <password|->]",
 		userHandler.Authorize,
 	)
 	commandManager.RegisterCommand(
 		"register",
 		"create user",
 		"user",
-		"register",
+		"register [--email <email>] [--password <password|->]",
 		userHandler.Register,
 	)
 }
@@ -219,28 +493,28 @@ func registerLogPassCommands(
 		"lp-save",
 		"save login password pair",
 		"login password",
-		"lp-save",
+		"lp-save [--login <login>] [--password <password|->] [--source <source>]",
 		logPassHandler.AddPair,
 	)
 	commandManager.RegisterCommand(
 		"lp-get",
 		"get login password pairs",
 		"login password",
-		"lp-get <page:int>",
+		"lp-get [page:int] | lp-get --id <uuid> [--field id|version|login|password|source]",
 		logPassHandler.GetPairs,
 	)
 	commandManager.RegisterCommand(
 		"lp-upd",
 		"update logpass pair by id",
 		"login password",
-		"lp-upd <id:int>",
+		"lp-upd <uuid> [--login <login>] [--password <password|->] [--source <source>]",
 		logPassHandler.UpdatePair,
 	)
 	commandManager.RegisterCommand(
 		"lp-del",
 		"delete login password pair by id",
 		"login password",
-		"lp-del <id:int>",
+		"lp-del <uuid>",
 		logPassHandler.DeletePair,
 	)
 }
@@ -253,28 +527,28 @@ func registerCardCommands(
 		"card-save",
 		"save new card",
 		"card",
-		"card-save",
+		"card-save [--number <number>] [--expired_at <MM/YY>] [--cvv <cvv|->] [--meta <meta>]",
 		cardHandler.AddCard,
 	)
 	commandManager.RegisterCommand(
 		"card-get",
 		"get cards",
 		"card",
-		"card-get <page:int>",
+		"card-get [page:int] | card-get --id <uuid> [--field id|version|number|expired_at|cvv|meta]",
 		cardHandler.GetCards,
 	)
 	commandManager.RegisterCommand(
 		"card-upd",
 		"update card by id",
 		"card",
-		"card-upd <id:int>",
+		"card-upd <uuid> [--number <number>] [--expired_at <MM/YY>] [--cvv <cvv|->] [--meta <meta>]",
 		cardHandler.UpdateCard,
 	)
 	commandManager.RegisterCommand(
 		"card-del",
 		"delete card by id",
 		"card",
-		"card-del <id:int>",
+		"card-del <uuid>",
 		cardHandler.DeleteCard,
 	)
 }
@@ -287,28 +561,28 @@ func registerTextCommands(
 		"text-save",
 		"save new text",
 		"text",
-		"text-save",
+		"text-save [--title <title>] [--text <text|->]",
 		textHandler.AddText,
 	)
 	commandManager.RegisterCommand(
 		"text-get",
 		"get texts",
 		"text",
-		"text-get <page:int>",
+		"text-get [page:int] | text-get --id <uuid> [--field id|version|title|text]",
 		textHandler.GetTexts,
 	)
 	commandManager.RegisterCommand(
 		"text-upd",
 		"update text by id",
 		"text",
-		"text-upd <id:int>",
+		"text-upd <uuid> [--title <title>] [--text <text|->]",
 		textHandler.UpdateText,
 	)
 	commandManager.RegisterCommand(
 		"text-del",
 		"delete text by id",
 		"text",
-		"text-del <id:int>",
+		"text-del <uuid>",
 		textHandler.DeleteText,
 	)
 }
@@ -321,35 +595,239 @@ func registerFileCommands(
 		"file-save",
 		"save file",
 		"file",
-		"file-save",
+		"file-save [--path <path>] [--meta <meta>]",
 		fileHandler.AddFile,
 	)
 	commandManager.RegisterCommand(
 		"file-get",
 		"get files",
 		"file",
-		"file-get <page:int>",
+		"file-get [page:int] | file-get --id <uuid> [--field id|version|name|meta]",
 		fileHandler.GetFiles,
 	)
 	commandManager.RegisterCommand(
 		"file-decrypt",
 		"decrypt file to given directory",
 		"file",
-		"file-decrypt <id:int>",
+		"file-decrypt <uuid> [--dir <directory>]",
 		fileHandler.DecryptFile,
 	)
 	commandManager.RegisterCommand(
 		"file-upd",
 		"update file by id",
 		"file",
-		"file-upd <id:int>",
+		"file-upd <uuid> [--path <path>] [--meta <meta>]",
 		fileHandler.UpdateFile,
 	)
 	commandManager.RegisterCommand(
 		"file-del",
 		"delete file by id",
 		"file",
-		"file-del <id:int>",
+		"file-del <uuid>",
 		fileHandler.DeleteFile,
 	)
 }
+
+func registerShareCommands(
+	commandManager *commands.CommandManager,
+	shareHandler *handlers.ShareHandler,
+) {
+	commandManager.RegisterCommand(
+		"share",
+		"share synced record with another user (read by default)",
+		"share",
+		"share <uuid> <email> [read|write] [need auth]",
+		shareHandler.Share,
+	)
+	commandManager.RegisterCommand(
+		"unshare",
+		"revoke share by share id",
+		"share",
+		"unshare <share_id:int> [need auth]",
+		shareHandler.Unshare,
+	)
+	commandManager.RegisterCommand(
+		"shared",
+		"list records shared with you and shares created by you",
+		"share",
+		"shared [need auth]",
+		shareHandler.Shared,
+	)
+}
+
+func registerOrganizationCommands(
+	commandManager *commands.CommandManager,
+	organizationHandler *handlers.OrganizationHandler,
+) {
+	commandManager.RegisterCommand(
+		"org-create",
+		"create organization, you become its owner",
+		"organization",
+		"org-create <name> [need auth]",
+		organizationHandler.CreateOrganization,
+	)
+	commandManager.RegisterCommand(
+		"orgs",
+		"list your organizations and pending invites",
+		"organization",
+		"orgs [need auth]",
+		organizationHandler.GetOrganizations,
+	)
+	commandManager.RegisterCommand(
+		"org-invite",
+		"invite user to organization (member by default)",
+		"organization",
+		"org-invite <org_id:int> <email> [admin|member|read-only] [need auth]",
+		organizationHandler.Invite,
+	)
+	commandManager.RegisterCommand(
+		"org-accept",
+		"accept invite to organization",
+		"organization",
+		"org-accept <org_id:int> [need auth]",
+		organizationHandler.Accept,
+	)
+	commandManager.RegisterCommand(
+		"org-members",
+		"list organization members",
+		"organization",
+		"org-members <org_id:int> [need auth]",
+		organizationHandler.GetMembers,
+	)
+	commandManager.RegisterCommand(
+		"col-create",
+		"create collection in organization",
+		"organization",
+		"col-create <org_id:int> <name> [need auth]",
+		organizationHandler.CreateCollection,
+	)
+	commandManager.RegisterCommand(
+		"cols",
+		"list organization collections",
+		"organization",
+		"cols <org_id:int> [need auth]",
+		organizationHandler.GetCollections,
+	)
+	commandManager.RegisterCommand(
+		"vault",
+		"show active vault or switch data commands to personal vault or organization collection",
+		"organization",
+		"vault [personal|<collection_id:int>]",
+		organizationHandler.SwitchVault,
+	)
+}
+
+func registerEmergencyAccessCommands(
+	commandManager *commands.CommandManager,
+	emergencyAccessHandler *handlers.EmergencyAccessHandler,
+) {
+	commandManager.RegisterCommand(
+		"emergency-invite",
+		"designate user as trusted emergency contact, access is granted after waiting period",
+		"emergency",
+		"emergency-invite <email> <wait_days:int> [need auth]",
+		emergencyAccessHandler.Invite,
+	)
+	commandManager.RegisterCommand(
+		"emergency-granted",
+		"list your emergency contacts",
+		"emergency",
+		"emergency-granted [need auth]",
+		emergencyAccessHandler.GetGranted,
+	)
+	commandManager.RegisterCommand(
+		"emergency-approve",
+		"approve emergency access request without waiting",
+		"emergency",
+		"emergency-approve <id:int> [need auth]",
+		emergencyAccessHandler.Approve,
+	)
+	commandManager.RegisterCommand(
+		"emergency-reject",
+		"reject emergency access request",
+		"emergency",
+		"emergency-reject <id:int> [need auth]",
+		emergencyAccessHandler.Reject,
+	)
+	commandManager.RegisterCommand(
+		"emergency-revoke",
+		"remove emergency access",
+		"emergency",
+		"emergency-revoke <id:int> [need auth]",
+		emergencyAccessHandler.Revoke,
+	)
+	commandManager.RegisterCommand(
+		"emergency-trusted",
+		"list users who trusted you as emergency contact",
+		"emergency",
+		"emergency-trusted [need auth]",
+		emergencyAccessHandler.GetTrusted,
+	)
+	commandManager.RegisterCommand(
+		"emergency-confirm",
+		"agree to be emergency contact",
+		"emergency",
+		"emergency-confirm <id:int> [need auth]",
+		emergencyAccessHandler.Confirm,
+	)
+	commandManager.RegisterCommand(
+		"emergency-request",
+		"request access to vault of user who trusted you, starts waiting period",
+		"emergency",
+		"emergency-request <id:int> [need auth]",
+		emergencyAccessHandler.Initiate,
+	)
+	commandManager.RegisterCommand(
+		"emergency-vault",
+		"view vault of user who trusted you after access is approved",
+		"emergency",
+		"emergency-vault <id:int> [need auth]",
+		emergencyAccessHandler.GetVault,
+	)
+}
+
+func registerAuditCommands(
+	commandManager *commands.CommandManager,
+	auditHandler *handlers.AuditHandler,
+) {
+	commandManager.RegisterCommand(
+		"audit",
+		"show your security history (logins, data changes, shares), newest first",
+		"audit",
+		"audit [page:int] [need auth]",
+		auditHandler.GetEvents,
+	)
+}
+
+func registerDeviceCommands(
+	commandManager *commands.CommandManager,
+	deviceHandler *handlers.DeviceHandler,
+) {
+	commandManager.RegisterCommand(
+		"devices",
+		"list devices you are logged in from",
+		"devices",
+		"devices [need auth]",
+		deviceHandler.GetDevices,
+	)
+	commandManager.RegisterCommand(
+		"device-revoke",
+		"log out device, e.g. lost laptop",
+		"devices",
+		"device-revoke <id:int> [need auth]",
+		deviceHandler.Revoke,
+	)
+}
+
+func registerProfileCommands(
+	commandManager *commands.CommandManager,
+	profileHandler *handlers.ProfileHandler,
+) {
+	commandManager.RegisterCommand(
+		"profile",
+		"manage local profiles, every profile has its own vault, session and server",
+		"profile",
+		"profile list | create <name> [server_addr] | switch <name> | delete <name>",
+		profileHandler.Handle,
+	)
+}
@@ -2,77 +2,215 @@ package main
 
 import (
 	"context"
-	"log"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/go-chi/chi/v5"
-	"github.com/go-chi/chi/v5/middleware"
 	"github.com/joho/godotenv"
 
 	"github.com/MowlCoder/goph-keeper/internal/config"
+	"github.com/MowlCoder/goph-keeper/internal/events"
 	"github.com/MowlCoder/goph-keeper/internal/handlers"
+	"github.com/MowlCoder/goph-keeper/internal/metrics"
 	customMiddleware "github.com/MowlCoder/goph-keeper/internal/middleware"
 	dbRepositories "github.com/MowlCoder/goph-keeper/internal/repositories/postgresql"
 	serverServices "github.com/MowlCoder/goph-keeper/internal/services/server"
 	"github.com/MowlCoder/goph-keeper/internal/storage/postgresql"
+	"github.com/MowlCoder/goph-keeper/internal/tracing"
 	"github.com/MowlCoder/goph-keeper/internal/utils/cryptor"
+	"github.com/MowlCoder/goph-keeper/internal/utils/logger"
 	"github.com/MowlCoder/goph-keeper/internal/utils/password"
 	"github.com/MowlCoder/goph-keeper/internal/utils/token"
+	"github.com/MowlCoder/goph-keeper/internal/workers"
+)
+
+var (
+	buildVersion string
+	buildDate    string
 )
 
 func main() {
-	err := godotenv.Load(".env.server")
-	if err != nil {
-		log.Println("No .env.server provided")
-	}
+	envErr := godotenv.Load(".env.server")
 
 	serverConfig := &config.Server{}
-	serverConfig.Parse()
+	configErr := serverConfig.Parse()
+
+	appLogger := logger.New(os.Stdout, serverConfig.LogLevel)
+	slog.SetDefault(appLogger)
+
+	if envErr != nil {
+		appLogger.Info("no .env.server provided")
+	}
+
+	if configErr != nil {
+		fatal(appLogger, "invalid config", configErr)
+	}
 
-	dbPool, err := postgresql.InitPool(serverConfig.DatabaseDSN)
+	shutdownTracing, err := tracing.Init(context.Background(), tracing.Config{
+		ServiceName:    "goph-keeper-server",
+		ServiceVersion: buildVersion,
+		OTLPEndpoint:   serverConfig.OTLPEndpoint,
+		OTLPInsecure:   serverConfig.OTLPInsecure,
+	})
 	if err != nil {
-		log.Fatal(err)
+		fatal(appLogger, "failed to init tracing", err)
+	}
+
+	dbPool, err := postgresql.InitPool(serverConfig.DatabaseDSN, appLogger)
+	if err != nil {
+		fatal(appLogger, "failed to init database pool", err)
 	}
 	defer dbPool.Close()
 
-	if err := postgresql.RunMigrations(serverConfig.DatabaseDSN); err != nil {
-		log.Fatal(err)
+	if err := postgresql.RunMigrations(serverConfig.DatabaseDSN, appLogger); err != nil {
+		fatal(appLogger, "failed to run migrations", err)
 	}
 
+	healthChecker, err := postgresql.NewHealthChecker(dbPool)
+	if err != nil {
+		fatal(appLogger, "failed to init health checker", err)
+	}
+
+	serverMetrics := metrics.New()
+
 	passwordHasher := password.NewHasher()
 	tokenGenerator := token.NewGenerator()
 	tokenParser := token.NewParser()
 
-	dataCryptor := cryptor.New(serverConfig.DataSecretKey)
+	dataCryptor := metrics.NewInstrumentedCryptor(cryptor.New(serverConfig.DataSecretKey), serverMetrics)
 
-	authMiddleware := customMiddleware.NewAuthMiddleware(tokenParser)
+	requestIDMiddleware := customMiddleware.NewRequestIDMiddleware()
+	loggerMiddleware := customMiddleware.NewLoggerMiddleware(appLogger)
+	metricsMiddleware := customMiddleware.NewMetricsMiddleware(serverMetrics)
+	tracingMiddleware := customMiddleware.NewTracingMiddleware()
 
 	userRepository := dbRepositories.NewUserRepository(dbPool)
-	userStoredDataRepository := dbRepositories.NewUserStoredDataRepository(dbPool)
+	userStoredDataRepository := dbRepositories.NewUserStoredDataRepository(dbPool, appLogger)
+	shareRepository := dbRepositories.NewShareRepository(dbPool)
+	organizationRepository := dbRepositories.NewOrganizationRepository(dbPool, appLogger)
+	emergencyAccessRepository := dbRepositories.NewEmergencyAccessRepository(dbPool)
+	auditRepository := dbRepositories.NewAuditRepository(dbPool)
+	deviceRepository := dbRepositories.NewDeviceRepository(dbPool)
+
+	if err := serverMetrics.Register(
+		metrics.NewPoolCollector(dbPool),
+		metrics.NewStoredRecordsCollector(userStoredDataRepository, 5*time.Second, appLogger),
+	); err != nil {
+		fatal(appLogger, "failed to register metrics collectors", err)
+	}
+
+	changeBroker := events.NewBroker()
+	changeNotifier := postgresql.NewChangeNotifier(dbPool, changeBroker, appLogger)
 
 	userService := serverServices.NewUserService(
 		userRepository,
 		passwordHasher,
 	)
-	userStoredDataService := serverServices.NewUserStoredDataService(userStoredDataRepository, dataCryptor)
-
-	userHandler := handlers.NewUserHandler(userService, tokenGenerator)
-	userStoredDataHandler := handlers.NewUserStoredDataHandler(userStoredDataService)
+	userStoredDataService := serverServices.NewUserStoredDataService(
+		userStoredDataRepository,
+		shareRepository,
+		organizationRepository,
+		dataCryptor,
+		changeNotifier,
+		changeBroker,
+		appLogger,
+	)
+	shareService := serverServices.NewShareService(
+		shareRepository,
+		userRepository,
+		userStoredDataRepository,
+		dataCryptor,
+	)
+	organizationService := serverServices.NewOrganizationService(organizationRepository, userRepository)
+	emergencyAccessService := serverServices.NewEmergencyAccessService(
+		emergencyAccessRepository,
+		userRepository,
+		userStoredDataRepository,
+		dataCryptor,
+	)
+	auditService := serverServices.NewAuditService(auditRepository, userRepository, appLogger)
+	deviceService := serverServices.NewDeviceService(deviceRepository)
+
+	authMiddleware := customMiddleware.NewAuthMiddleware(tokenParser, deviceService, serverMetrics)
+
+	userHandler := handlers.NewUserHandler(userService, tokenGenerator, deviceService, auditService, serverMetrics)
+	userStoredDataHandler := handlers.NewUserStoredDataHandler(userStoredDataService, auditService)
+	shareHandler := handlers.NewShareHandler(shareService, auditService)
+	organizationHandler := handlers.NewOrganizationHandler(organizationService)
+	emergencyAccessHandler := handlers.NewEmergencyAccessHandler(emergencyAccessService, auditService)
+	auditHandler := handlers.NewAuditHandler(auditService)
+	syncHandler := handlers.NewSyncHandler(userStoredDataService, deviceService, auditService)
+	eventsHandler := handlers.NewEventsHandler(userStoredDataService, appLogger)
+	deviceHandler := handlers.NewDeviceHandler(deviceService, auditService)
+	healthHandler := handlers.NewHealthHandler(healthChecker, buildVersion, buildDate, appLogger)
 
 	server := &http.Server{
 		Addr: serverConfig.HTTPAddr,
 		Handler: makeHTTPRouter(
 			authMiddleware,
+			requestIDMiddleware,
+			loggerMiddleware,
+			metricsMiddleware,
+			tracingMiddleware,
 			userHandler,
 			userStoredDataHandler,
+			shareHandler,
+			organizationHandler,
+			emergencyAccessHandler,
+			auditHandler,
+			syncHandler,
+			eventsHandler,
+			deviceHandler,
+			healthHandler,
 		),
 	}
+	// event streams never become idle, they are finished by closing their subscriptions
+	server.RegisterOnShutdown(changeBroker.Close)
 
-	log.Println("goph-keeper server is running on", serverConfig.HTTPAddr)
+	workersCtx, workersCtxCancel := context.WithCancel(context.Background())
+	workersWg := &sync.WaitGroup{}
+
+	emergencyAccessWorker := workers.NewEmergencyAccessWorker(
+		emergencyAccessService,
+		time.Second*time.Duration(serverConfig.EmergencyCheckInterval),
+		appLogger,
+	)
+
+	tombstoneWorker := workers.NewTombstoneWorker(
+		userStoredDataService,
+		time.Second*time.Duration(serverConfig.TombstoneGCInterval),
+		appLogger,
+	)
+
+	workersWg.Add(1)
+	go func() {
+		defer workersWg.Done()
+		emergencyAccessWorker.Run(workersCtx)
+	}()
+
+	workersWg.Add(1)
+	go func() {
+		defer workersWg.Done()
+		tombstoneWorker.Run(workersCtx)
+	}()
+
+	workersWg.Add(1)
+	go func() {
+		defer workersWg.Done()
+		changeNotifier.Run(workersCtx)
+	}()
+
+	appLogger.Info(
+		"goph-keeper server is running",
+		"addr", serverConfig.HTTPAddr,
+		"version", buildVersion,
+		"build_date", buildDate,
+	)
 
 	go func() {
 		var err error
@@ -84,16 +222,38 @@ func main() {
 		}
 
 		if err != nil && err != http.ErrServerClosed {
-			log.Fatal(err)
+			fatal(appLogger, "server stopped unexpectedly", err)
 		}
 	}()
 
+	var metricsServer *http.Server
+	if serverConfig.MetricsAddr != "" {
+		metricsServer = &http.Server{
+			Addr:    serverConfig.MetricsAddr,
+			Handler: serverMetrics.Handler(),
+		}
+
+		appLogger.Info("goph-keeper metrics are served", "addr", serverConfig.MetricsAddr)
+
+		go func() {
+			if err := metricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				fatal(appLogger, "metrics server stopped unexpectedly", err)
+			}
+		}()
+	}
+
 	sig := make(chan os.Signal, 1)
 	signal.Notify(sig, syscall.SIGHUP, syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
 
 	<-sig
 
-	log.Println("goph-keeper server started shutdown process")
+	appLogger.Info("goph-keeper server started shutdown process")
+
+	// let load balancers see failing readiness probe and drain traffic before listener is closed
+	healthHandler.SetShuttingDown()
+	if serverConfig.ShutdownDelay > 0 {
+		time.Sleep(time.Second * time.Duration(serverConfig.ShutdownDelay))
+	}
 
 	shutdownCtx, shutdownCtxCancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer shutdownCtxCancel()
@@ -101,15 +261,33 @@ func main() {
 	go func() {
 		<-shutdownCtx.Done()
 		if shutdownCtx.Err() == context.DeadlineExceeded {
-			log.Fatal("graceful shutdown timed out... forcing exit")
+			fatal(appLogger, "graceful shutdown timed out, forcing exit", shutdownCtx.Err())
 		}
 	}()
 
 	if err := server.Shutdown(shutdownCtx); err != nil {
-		log.Fatal(err)
+		fatal(appLogger, "failed to shutdown server", err)
+	}
+
+	if metricsServer != nil {
+		if err := metricsServer.Shutdown(shutdownCtx); err != nil {
+			fatal(appLogger, "failed to shutdown metrics server", err)
+		}
+	}
+
+	workersCtxCancel()
+	workersWg.Wait()
+
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		appLogger.Error("failed to flush traces", "error", err.Error())
 	}
 
-	log.Println("goph-keeper server shutdown process successfully completed")
+	appLogger.Info("goph-keeper server shutdown process successfully completed")
+}
+
+func fatal(logger *slog.Logger, msg string, err error) {
+	logger.Error(msg, "error", err.Error())
+	os.Exit(1)
 }
 
 // @title Goph Keeper
@@ -122,28 +300,101 @@ func main() {
 // @name						Authorization
 func makeHTTPRouter(
 	authMiddleware *customMiddleware.AuthMiddleware,
+	requestIDMiddleware *customMiddleware.RequestIDMiddleware,
+	loggerMiddleware *customMiddleware.LoggerMiddleware,
+	metricsMiddleware *customMiddleware.MetricsMiddleware,
+	tracingMiddleware *customMiddleware.TracingMiddleware,
 
 	userHandler *handlers.UserHandler,
 	userStoredDataHandler *handlers.UserStoredDataHandler,
+	shareHandler *handlers.ShareHandler,
+	organizationHandler *handlers.OrganizationHandler,
+	emergencyAccessHandler *handlers.EmergencyAccessHandler,
+	auditHandler *handlers.AuditHandler,
+	syncHandler *handlers.SyncHandler,
+	eventsHandler *handlers.EventsHandler,
+	deviceHandler *handlers.DeviceHandler,
+	healthHandler *handlers.HealthHandler,
 ) http.Handler {
 	router := chi.NewRouter()
 
-	router.Use(middleware.Logger)
+	router.Use(requestIDMiddleware.Middleware)
+	router.Use(tracingMiddleware.Middleware)
+	router.Use(metricsMiddleware.Middleware)
+	router.Use(loggerMiddleware.Middleware)
+
+	router.Get("/healthz", healthHandler.Liveness)
+	router.Get("/readyz", healthHandler.Readiness)
+	router.Get("/version", healthHandler.Version)
 
 	router.Route("/api/v1", func(apiRouter chi.Router) {
 		apiRouter.Route("/user", func(userRouter chi.Router) {
 			userRouter.Post("/register", userHandler.Register)
 			userRouter.Post("/authorize", userHandler.Authorize)
+			userRouter.With(authMiddleware.Middleware).Post("/refresh", userHandler.Refresh)
 		})
 
 		apiRouter.Route("/data", func(dataRouter chi.Router) {
 			dataRouter.Use(authMiddleware.Middleware)
 			dataRouter.Put("/update/{id}", userStoredDataHandler.UpdateOne)
+			dataRouter.Get("/record/{id}", userStoredDataHandler.GetOne)
 			dataRouter.Get("/{type}", userStoredDataHandler.GetOfType)
 			dataRouter.Post("/{type}", userStoredDataHandler.Add)
 			dataRouter.Get("/", userStoredDataHandler.GetUserAll)
 			dataRouter.Delete("/", userStoredDataHandler.DeleteBatch)
 		})
+
+		apiRouter.Route("/share", func(shareRouter chi.Router) {
+			shareRouter.Use(authMiddleware.Middleware)
+			shareRouter.Post("/", shareHandler.Create)
+			shareRouter.Get("/with-me", shareHandler.GetSharedWithMe)
+			shareRouter.Get("/by-me", shareHandler.GetSharedByMe)
+			shareRouter.Delete("/{id}", shareHandler.Delete)
+		})
+
+		apiRouter.Route("/organization", func(organizationRouter chi.Router) {
+			organizationRouter.Use(authMiddleware.Middleware)
+			organizationRouter.Post("/", organizationHandler.Create)
+			organizationRouter.Get("/", organizationHandler.GetMy)
+			organizationRouter.Post("/{id}/invite", organizationHandler.Invite)
+			organizationRouter.Post("/{id}/accept", organizationHandler.Accept)
+			organizationRouter.Get("/{id}/members", organizationHandler.GetMembers)
+			organizationRouter.Post("/{id}/collection", organizationHandler.CreateCollection)
+			organizationRouter.Get("/{id}/collection", organizationHandler.GetCollections)
+		})
+
+		apiRouter.Route("/emergency", func(emergencyRouter chi.Router) {
+			emergencyRouter.Use(authMiddleware.Middleware)
+			emergencyRouter.Post("/", emergencyAccessHandler.Invite)
+			emergencyRouter.Get("/granted", emergencyAccessHandler.GetGranted)
+			emergencyRouter.Get("/trusted", emergencyAccessHandler.GetTrusted)
+			emergencyRouter.Post("/{id}/confirm", emergencyAccessHandler.Confirm)
+			emergencyRouter.Post("/{id}/initiate", emergencyAccessHandler.Initiate)
+			emergencyRouter.Post("/{id}/approve", emergencyAccessHandler.Approve)
+			emergencyRouter.Post("/{id}/reject", emergencyAccessHandler.Reject)
+			emergencyRouter.Get("/{id}/vault", emergencyAccessHandler.GetVault)
+			emergencyRouter.Delete("/{id}", emergencyAccessHandler.Delete)
+		})
+
+		apiRouter.Route("/audit", func(auditRouter chi.Router) {
+			auditRouter.Use(authMiddleware.Middleware)
+			auditRouter.Get("/", auditHandler.GetMy)
+		})
+
+		apiRouter.Route("/sync", func(syncRouter chi.Router) {
+			syncRouter.Use(authMiddleware.Middleware)
+			syncRouter.Get("/changes", syncHandler.GetChanges)
+			syncRouter.Post("/push", syncHandler.Push)
+			syncRouter.Post("/ack", syncHandler.Ack)
+		})
+
+		apiRouter.Route("/devices", func(deviceRouter chi.Router) {
+			deviceRouter.Use(authMiddleware.Middleware)
+			deviceRouter.Get("/", deviceHandler.GetMy)
+			deviceRouter.Delete("/{id}", deviceHandler.Revoke)
+		})
+
+		apiRouter.With(authMiddleware.Middleware).Get("/events", eventsHandler.Stream)
 	})
 
 	return router
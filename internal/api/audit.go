@@ -0,0 +1,87 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+	"github.com/MowlCoder/goph-keeper/internal/session"
+	"github.com/MowlCoder/goph-keeper/pkg/httputils"
+)
+
+// AuditAPI - struct responsible for communicating with external API
+type AuditAPI struct {
+	baseHTTPAddress string
+	httpClient      *http.Client
+	session         *session.ClientSession
+}
+
+// NewAuditAPI - constructor for AuditAPI struct
+func NewAuditAPI(
+	baseHTTPAddress string,
+	httpClient *http.Client,
+	session *session.ClientSession,
+) *AuditAPI {
+	return &AuditAPI{
+		baseHTTPAddress: baseHTTPAddress,
+		httpClient:      httpClient,
+		session:         session,
+	}
+}
+
+type auditEventsPage struct {
+	Data        []domain.AuditEvent `json:"data"`
+	CurrentPage int                 `json:"current_page"`
+	Count       int                 `json:"count"`
+	PageCount   int                 `json:"page_count"`
+}
+
+// GetEvents - get page of security history of current user, newest first
+func (api *AuditAPI) GetEvents(ctx context.Context, page int, count int) (*domain.PaginatedResult, error) {
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		fmt.Sprintf("%s/api/v1/audit?page=%d&count=%d", api.baseHTTPAddress, page, count),
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+api.session.GetToken())
+
+	resp, err := api.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp httputils.HTTPError
+		if err := json.Unmarshal(data, &errResp); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("api error: " + errResp.Error)
+	}
+
+	var respBody auditEventsPage
+	if err := json.Unmarshal(data, &respBody); err != nil {
+		return nil, err
+	}
+
+	return &domain.PaginatedResult{
+		Data:        respBody.Data,
+		CurrentPage: respBody.CurrentPage,
+		Count:       respBody.Count,
+		PageCount:   respBody.PageCount,
+	}, nil
+}
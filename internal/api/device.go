@@ -0,0 +1,101 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+	"github.com/MowlCoder/goph-keeper/internal/session"
+	"github.com/MowlCoder/goph-keeper/pkg/httputils"
+)
+
+// DeviceAPI - struct responsible for communicating with external API
+type DeviceAPI struct {
+	baseHTTPAddress string
+	httpClient      *http.Client
+	session         *session.ClientSession
+}
+
+// NewDeviceAPI - constructor for DeviceAPI struct
+func NewDeviceAPI(
+	baseHTTPAddress string,
+	httpClient *http.Client,
+	session *session.ClientSession,
+) *DeviceAPI {
+	return &DeviceAPI{
+		baseHTTPAddress: baseHTTPAddress,
+		httpClient:      httpClient,
+		session:         session,
+	}
+}
+
+// GetDevices - get devices current user is logged in from
+func (api *DeviceAPI) GetDevices(ctx context.Context) ([]domain.Device, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/api/v1/devices", api.baseHTTPAddress), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+api.session.GetToken())
+
+	resp, err := api.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp httputils.HTTPError
+		if err := json.Unmarshal(data, &errResp); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("api error: " + errResp.Error)
+	}
+
+	var devices []domain.Device
+	if err := json.Unmarshal(data, &devices); err != nil {
+		return nil, err
+	}
+
+	return devices, nil
+}
+
+// Revoke - log out device with given id
+func (api *DeviceAPI) Revoke(ctx context.Context, id int) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, fmt.Sprintf("%s/api/v1/devices/%d", api.baseHTTPAddress, id), nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+api.session.GetToken())
+
+	resp, err := api.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusNoContent {
+		var errResp httputils.HTTPError
+		if err := json.Unmarshal(data, &errResp); err != nil {
+			return err
+		}
+		return errors.New("api error: " + errResp.Error)
+	}
+
+	return nil
+}
@@ -0,0 +1,176 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+	"github.com/MowlCoder/goph-keeper/internal/dtos"
+	"github.com/MowlCoder/goph-keeper/internal/session"
+	"github.com/MowlCoder/goph-keeper/pkg/httputils"
+)
+
+// EmergencyAccessAPI - struct responsible for communicating with external API
+type EmergencyAccessAPI struct {
+	baseHTTPAddress string
+	httpClient      *http.Client
+	session         *session.ClientSession
+}
+
+// NewEmergencyAccessAPI - constructor for EmergencyAccessAPI struct
+func NewEmergencyAccessAPI(
+	baseHTTPAddress string,
+	httpClient *http.Client,
+	session *session.ClientSession,
+) *EmergencyAccessAPI {
+	return &EmergencyAccessAPI{
+		baseHTTPAddress: baseHTTPAddress,
+		httpClient:      httpClient,
+		session:         session,
+	}
+}
+
+// Invite - designate user with given email as trusted emergency contact
+func (api *EmergencyAccessAPI) Invite(ctx context.Context, email string, waitDays int) (*domain.EmergencyAccess, error) {
+	var respBody domain.EmergencyAccess
+	body := dtos.InviteEmergencyContactBody{
+		Email:    email,
+		WaitDays: waitDays,
+	}
+
+	if err := api.do(ctx, http.MethodPost, "/api/v1/emergency", body, http.StatusCreated, &respBody); err != nil {
+		return nil, err
+	}
+
+	return &respBody, nil
+}
+
+// GetGranted - get emergency accesses granted by current user
+func (api *EmergencyAccessAPI) GetGranted(ctx context.Context) ([]domain.EmergencyAccess, error) {
+	var respBody []domain.EmergencyAccess
+	if err := api.do(ctx, http.MethodGet, "/api/v1/emergency/granted", nil, http.StatusOK, &respBody); err != nil {
+		return nil, err
+	}
+
+	return respBody, nil
+}
+
+// GetTrusted - get emergency accesses where current user is trusted contact
+func (api *EmergencyAccessAPI) GetTrusted(ctx context.Context) ([]domain.EmergencyAccess, error) {
+	var respBody []domain.EmergencyAccess
+	if err := api.do(ctx, http.MethodGet, "/api/v1/emergency/trusted", nil, http.StatusOK, &respBody); err != nil {
+		return nil, err
+	}
+
+	return respBody, nil
+}
+
+// Confirm - agree to be trusted contact
+func (api *EmergencyAccessAPI) Confirm(ctx context.Context, id int) error {
+	return api.action(ctx, id, "confirm")
+}
+
+// Initiate - request access to grantor vault, waiting period starts
+func (api *EmergencyAccessAPI) Initiate(ctx context.Context, id int) error {
+	return api.action(ctx, id, "initiate")
+}
+
+// Approve - approve recovery without waiting
+func (api *EmergencyAccessAPI) Approve(ctx context.Context, id int) error {
+	return api.action(ctx, id, "approve")
+}
+
+// Reject - reject recovery before waiting period is over
+func (api *EmergencyAccessAPI) Reject(ctx context.Context, id int) error {
+	return api.action(ctx, id, "reject")
+}
+
+// Revoke - remove emergency access
+func (api *EmergencyAccessAPI) Revoke(ctx context.Context, id int) error {
+	path := fmt.Sprintf("/api/v1/emergency/%d", id)
+	return api.do(ctx, http.MethodDelete, path, nil, http.StatusNoContent, nil)
+}
+
+// GetVault - get grantor vault with approved emergency access
+func (api *EmergencyAccessAPI) GetVault(ctx context.Context, id int) ([]domain.UserStoredData, error) {
+	var respBody []domain.UserStoredData
+
+	path := fmt.Sprintf("/api/v1/emergency/%d/vault", id)
+	if err := api.do(ctx, http.MethodGet, path, nil, http.StatusOK, &respBody); err != nil {
+		return nil, err
+	}
+
+	for idx, data := range respBody {
+		jsonData, err := json.Marshal(data.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		respBody[idx].Data, err = domain.ParseUserStoredData(data.DataType, jsonData)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return respBody, nil
+}
+
+func (api *EmergencyAccessAPI) action(ctx context.Context, id int, action string) error {
+	path := fmt.Sprintf("/api/v1/emergency/%d/%s", id, action)
+	return api.do(ctx, http.MethodPost, path, nil, http.StatusNoContent, nil)
+}
+
+func (api *EmergencyAccessAPI) do(
+	ctx context.Context,
+	method string,
+	path string,
+	body interface{},
+	expectedStatusCode int,
+	respBody interface{},
+) error {
+	var reqBody io.Reader
+	if body != nil {
+		b, _ := json.Marshal(body)
+		reqBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, api.baseHTTPAddress+path, reqBody)
+	if err != nil {
+		return err
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Authorization", "Bearer "+api.session.GetToken())
+
+	resp, err := api.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != expectedStatusCode {
+		var errResp httputils.HTTPError
+		if err := json.Unmarshal(data, &errResp); err != nil {
+			return err
+		}
+		return errors.New("api error: " + errResp.Error)
+	}
+
+	if respBody == nil {
+		return nil
+	}
+
+	return json.Unmarshal(data, respBody)
+}
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+	"github.com/MowlCoder/goph-keeper/internal/session"
+	"github.com/MowlCoder/goph-keeper/pkg/httputils"
+)
+
+// EventsAPI - struct responsible for receiving server-sent events. Stream is open for hours, so http client
+// must be without timeout
+type EventsAPI struct {
+	baseHTTPAddress string
+	httpClient      *http.Client
+	session         *session.ClientSession
+}
+
+// NewEventsAPI - constructor for EventsAPI struct
+func NewEventsAPI(
+	baseHTTPAddress string,
+	httpClient *http.Client,
+	session *session.ClientSession,
+) *EventsAPI {
+	return &EventsAPI{
+		baseHTTPAddress: baseHTTPAddress,
+		httpClient:      httpClient,
+		session:         session,
+	}
+}
+
+// Listen - call onChange for every change of personal vault until stream is closed or ctx is done
+func (api *EventsAPI) Listen(ctx context.Context, onChange func(event domain.DataChangeEvent)) error {
+	if !api.session.IsAuth() {
+		return domain.ErrNotAuth
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, api.baseHTTPAddress+"/api/v1/events", nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+api.session.GetToken())
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := api.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		data, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return err
+		}
+
+		var errResp httputils.HTTPError
+		if err := json.Unmarshal(data, &errResp); err != nil {
+			return fmt.Errorf("api error: status %d", resp.StatusCode)
+		}
+		return errors.New("api error: " + errResp.Error)
+	}
+
+	return readEvents(resp.Body, onChange)
+}
+
+// readEvents - parse text/event-stream, lines starting with colon are comments used as heartbeat
+func readEvents(body io.Reader, onChange func(event domain.DataChangeEvent)) error {
+	scanner := bufio.NewScanner(body)
+
+	var name, data string
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case line == "":
+			if name == "change" {
+				var event domain.DataChangeEvent
+				if err := json.Unmarshal([]byte(data), &event); err == nil {
+					onChange(event)
+				}
+			}
+			name, data = "", ""
+		case strings.HasPrefix(line, "event:"):
+			name = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			data += strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	return io.ErrUnexpectedEOF
+}
@@ -0,0 +1,171 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+	"github.com/MowlCoder/goph-keeper/internal/dtos"
+	"github.com/MowlCoder/goph-keeper/internal/session"
+	"github.com/MowlCoder/goph-keeper/pkg/httputils"
+)
+
+// OrganizationAPI - struct responsible for communicating with external API
+type OrganizationAPI struct {
+	baseHTTPAddress string
+	httpClient      *http.Client
+	session         *session.ClientSession
+}
+
+// NewOrganizationAPI - constructor for OrganizationAPI struct
+func NewOrganizationAPI(
+	baseHTTPAddress string,
+	httpClient *http.Client,
+	session *session.ClientSession,
+) *OrganizationAPI {
+	return &OrganizationAPI{
+		baseHTTPAddress: baseHTTPAddress,
+		httpClient:      httpClient,
+		session:         session,
+	}
+}
+
+// Create - create organization, current user becomes its owner
+func (api *OrganizationAPI) Create(ctx context.Context, name string) (*domain.Organization, error) {
+	var respBody domain.Organization
+	body := dtos.CreateOrganizationBody{
+		Name: name,
+	}
+
+	if err := api.do(ctx, http.MethodPost, "/api/v1/organization", body, http.StatusCreated, &respBody); err != nil {
+		return nil, err
+	}
+
+	return &respBody, nil
+}
+
+// GetMy - get organizations of current user including pending invites
+func (api *OrganizationAPI) GetMy(ctx context.Context) ([]domain.OrganizationMembership, error) {
+	var respBody []domain.OrganizationMembership
+	if err := api.do(ctx, http.MethodGet, "/api/v1/organization", nil, http.StatusOK, &respBody); err != nil {
+		return nil, err
+	}
+
+	return respBody, nil
+}
+
+// Invite - invite user with given email to organization
+func (api *OrganizationAPI) Invite(ctx context.Context, organizationID int, email string, role string) (*domain.OrganizationMember, error) {
+	var respBody domain.OrganizationMember
+	body := dtos.InviteOrganizationMemberBody{
+		Email: email,
+		Role:  role,
+	}
+
+	path := fmt.Sprintf("/api/v1/organization/%d/invite", organizationID)
+	if err := api.do(ctx, http.MethodPost, path, body, http.StatusCreated, &respBody); err != nil {
+		return nil, err
+	}
+
+	return &respBody, nil
+}
+
+// Accept - accept invite to organization
+func (api *OrganizationAPI) Accept(ctx context.Context, organizationID int) error {
+	path := fmt.Sprintf("/api/v1/organization/%d/accept", organizationID)
+	return api.do(ctx, http.MethodPost, path, nil, http.StatusNoContent, nil)
+}
+
+// GetMembers - get members of organization
+func (api *OrganizationAPI) GetMembers(ctx context.Context, organizationID int) ([]domain.OrganizationMember, error) {
+	var respBody []domain.OrganizationMember
+
+	path := fmt.Sprintf("/api/v1/organization/%d/members", organizationID)
+	if err := api.do(ctx, http.MethodGet, path, nil, http.StatusOK, &respBody); err != nil {
+		return nil, err
+	}
+
+	return respBody, nil
+}
+
+// CreateCollection - create collection in organization
+func (api *OrganizationAPI) CreateCollection(ctx context.Context, organizationID int, name string) (*domain.Collection, error) {
+	var respBody domain.Collection
+	body := dtos.CreateCollectionBody{
+		Name: name,
+	}
+
+	path := fmt.Sprintf("/api/v1/organization/%d/collection", organizationID)
+	if err := api.do(ctx, http.MethodPost, path, body, http.StatusCreated, &respBody); err != nil {
+		return nil, err
+	}
+
+	return &respBody, nil
+}
+
+// GetCollections - get collections of organization
+func (api *OrganizationAPI) GetCollections(ctx context.Context, organizationID int) ([]domain.Collection, error) {
+	var respBody []domain.Collection
+
+	path := fmt.Sprintf("/api/v1/organization/%d/collection", organizationID)
+	if err := api.do(ctx, http.MethodGet, path, nil, http.StatusOK, &respBody); err != nil {
+		return nil, err
+	}
+
+	return respBody, nil
+}
+
+func (api *OrganizationAPI) do(
+	ctx context.Context,
+	method string,
+	path string,
+	body interface{},
+	expectedStatusCode int,
+	respBody interface{},
+) error {
+	var reqBody io.Reader
+	if body != nil {
+		b, _ := json.Marshal(body)
+		reqBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequest(method, api.baseHTTPAddress+path, reqBody)
+	if err != nil {
+		return err
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Authorization", "Bearer "+api.session.GetToken())
+
+	resp, err := api.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != expectedStatusCode {
+		var errResp httputils.HTTPError
+		if err := json.Unmarshal(data, &errResp); err != nil {
+			return err
+		}
+		return errors.New("api error: " + errResp.Error)
+	}
+
+	if respBody == nil {
+		return nil
+	}
+
+	return json.Unmarshal(data, respBody)
+}
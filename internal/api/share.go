@@ -0,0 +1,173 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+	"github.com/MowlCoder/goph-keeper/internal/dtos"
+	"github.com/MowlCoder/goph-keeper/internal/session"
+	"github.com/MowlCoder/goph-keeper/pkg/httputils"
+)
+
+// ShareAPI - struct responsible for communicating with external API
+type ShareAPI struct {
+	baseHTTPAddress string
+	httpClient      *http.Client
+	session         *session.ClientSession
+}
+
+// NewShareAPI - constructor for ShareAPI struct
+func NewShareAPI(
+	baseHTTPAddress string,
+	httpClient *http.Client,
+	session *session.ClientSession,
+) *ShareAPI {
+	return &ShareAPI{
+		baseHTTPAddress: baseHTTPAddress,
+		httpClient:      httpClient,
+		session:         session,
+	}
+}
+
+// Share - share record with given id with user with given email
+func (api *ShareAPI) Share(ctx context.Context, dataID int, email string, permission string) (*domain.Share, error) {
+	body := dtos.CreateShareBody{
+		DataID:     dataID,
+		Email:      email,
+		Permission: permission,
+	}
+	b, _ := json.Marshal(body)
+
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/api/v1/share", api.baseHTTPAddress), bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+api.session.GetToken())
+
+	resp, err := api.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		var errResp httputils.HTTPError
+		if err := json.Unmarshal(data, &errResp); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("api error: " + errResp.Error)
+	}
+
+	var respBody domain.Share
+	if err := json.Unmarshal(data, &respBody); err != nil {
+		return nil, err
+	}
+
+	return &respBody, nil
+}
+
+// Unshare - revoke share with given id
+func (api *ShareAPI) Unshare(ctx context.Context, shareID int) error {
+	req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/api/v1/share/%d", api.baseHTTPAddress, shareID), nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+api.session.GetToken())
+
+	resp, err := api.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusNoContent {
+		var errResp httputils.HTTPError
+		if err := json.Unmarshal(data, &errResp); err != nil {
+			return err
+		}
+		return errors.New("api error: " + errResp.Error)
+	}
+
+	return nil
+}
+
+// GetSharedWithMe - get records which other users shared with current user
+func (api *ShareAPI) GetSharedWithMe(ctx context.Context) ([]domain.SharedUserStoredData, error) {
+	var respBody []domain.SharedUserStoredData
+	if err := api.get(ctx, "/api/v1/share/with-me", &respBody); err != nil {
+		return nil, err
+	}
+
+	for idx, shared := range respBody {
+		jsonData, err := json.Marshal(shared.Data.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		respBody[idx].Data.Data, err = domain.ParseUserStoredData(shared.Data.DataType, jsonData)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return respBody, nil
+}
+
+// GetSharedByMe - get shares created by current user
+func (api *ShareAPI) GetSharedByMe(ctx context.Context) ([]domain.Share, error) {
+	var respBody []domain.Share
+	if err := api.get(ctx, "/api/v1/share/by-me", &respBody); err != nil {
+		return nil, err
+	}
+
+	return respBody, nil
+}
+
+func (api *ShareAPI) get(ctx context.Context, path string, respBody interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, api.baseHTTPAddress+path, nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+api.session.GetToken())
+
+	resp, err := api.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp httputils.HTTPError
+		if err := json.Unmarshal(data, &errResp); err != nil {
+			return err
+		}
+		return errors.New("api error: " + errResp.Error)
+	}
+
+	return json.Unmarshal(data, respBody)
+}
@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"os"
+	"runtime"
 
 	"github.com/MowlCoder/goph-keeper/internal/dtos"
 	"github.com/MowlCoder/goph-keeper/internal/session"
@@ -37,8 +39,9 @@ func NewUserAPI(
 // Register - register user and return JWT token
 func (api *UserAPI) Register(ctx context.Context, email string, password string) (string, error) {
 	body := dtos.RegisterBody{
-		Email:    email,
-		Password: password,
+		Email:      email,
+		Password:   password,
+		DeviceInfo: currentDeviceInfo(),
 	}
 
 	if !body.Validate() {
@@ -84,9 +87,12 @@ func (api *UserAPI) Register(ctx context.Context, email string, password string)
 // Authorize - authorize user and return JWT token
 func (api *UserAPI) Authorize(ctx context.Context, email string, password string) (string, error) {
 	body := dtos.AuthorizeBody{
-		Email:    email,
-		Password: password,
+		Email:      email,
+		Password:   password,
+		DeviceInfo: currentDeviceInfo(),
 	}
+	// device of previous login is reused, so logging in again does not register one more device
+	body.DeviceID = api.session.GetDeviceID()
 
 	if !body.Validate() {
 		return "", errors.New("invalid arguments")
@@ -127,3 +133,52 @@ func (api *UserAPI) Authorize(ctx context.Context, email string, password string
 
 	return respBody.Token, nil
 }
+
+// RefreshToken - exchange current token for a new one issued for the same device and save it in session
+func (api *UserAPI) RefreshToken(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/api/v1/user/refresh", api.baseHTTPAddress), nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+api.session.GetToken())
+
+	resp, err := api.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp httputils.HTTPError
+		if err := json.Unmarshal(data, &errResp); err != nil {
+			return err
+		}
+		return errors.New(errResp.Error)
+	}
+
+	var respBody dtos.AuthorizeResponse
+	if err := json.Unmarshal(data, &respBody); err != nil {
+		return err
+	}
+
+	return api.session.SetToken(respBody.Token)
+}
+
+// currentDeviceInfo - describe machine client is running on, so user can recognize it in list of devices
+func currentDeviceInfo() dtos.DeviceInfo {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = ""
+	}
+
+	return dtos.DeviceInfo{
+		DeviceName:     hostname,
+		DevicePlatform: runtime.GOOS + "/" + runtime.GOARCH,
+	}
+}
@@ -8,10 +8,19 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/MowlCoder/goph-keeper/internal/domain"
 	"github.com/MowlCoder/goph-keeper/internal/dtos"
 	"github.com/MowlCoder/goph-keeper/internal/session"
+	"github.com/MowlCoder/goph-keeper/internal/tracing"
 	"github.com/MowlCoder/goph-keeper/pkg/httputils"
 )
 
@@ -37,14 +46,14 @@ func NewUserStoredDataAPI(
 
 // GetAll - get all users records
 func (api *UserStoredDataAPI) GetAll(ctx context.Context) ([]domain.UserStoredData, error) {
-	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/api/v1/data", api.baseHTTPAddress), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/api/v1/data", api.baseHTTPAddress), nil)
 	if err != nil {
 		return nil, err
 	}
 
 	req.Header.Set("Authorization", "Bearer "+api.session.GetToken())
 
-	resp, err := api.httpClient.Do(req)
+	resp, err := api.send(req)
 	if err != nil {
 		return nil, err
 	}
@@ -79,6 +88,7 @@ func (api *UserStoredDataAPI) GetAll(ctx context.Context) ([]domain.UserStoredDa
 }
 
 type addBody struct {
+	UUID string      `json:"uuid,omitempty"`
 	Data interface{} `json:"data"`
 	Meta string      `json:"meta"`
 }
@@ -86,12 +96,13 @@ type addBody struct {
 // Add - add user record to external service
 func (api *UserStoredDataAPI) Add(ctx context.Context, entity domain.UserStoredData) (*domain.UserStoredData, error) {
 	body := &addBody{
+		UUID: entity.UUID,
 		Data: entity.Data,
 		Meta: entity.Meta,
 	}
 	b, _ := json.Marshal(body)
 
-	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/api/v1/data/%s", api.baseHTTPAddress, entity.DataType), bytes.NewReader(b))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/api/v1/data/%s", api.baseHTTPAddress, entity.DataType), bytes.NewReader(b))
 	if err != nil {
 		return nil, err
 	}
@@ -99,7 +110,7 @@ func (api *UserStoredDataAPI) Add(ctx context.Context, entity domain.UserStoredD
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Bearer "+api.session.GetToken())
 
-	resp, err := api.httpClient.Do(req)
+	resp, err := api.send(req)
 	if err != nil {
 		return nil, err
 	}
@@ -126,23 +137,25 @@ func (api *UserStoredDataAPI) Add(ctx context.Context, entity domain.UserStoredD
 	return &respBody, nil
 }
 
-// UpdateByID - update record with given id at external service
-func (api *UserStoredDataAPI) UpdateByID(ctx context.Context, id int, data interface{}, meta string) (*domain.UserStoredData, error) {
+// UpdateByID - update record with given id at external service. Record is updated only if nobody changed it
+// since client read it in expected version, otherwise ErrDataVersionConflict is returned
+func (api *UserStoredDataAPI) UpdateByID(ctx context.Context, id int, expectedVersion int, data interface{}, meta string) (*domain.UserStoredData, error) {
 	body := &addBody{
 		Data: data,
 		Meta: meta,
 	}
 	b, _ := json.Marshal(body)
 
-	req, err := http.NewRequest(http.MethodPut, fmt.Sprintf("%s/api/v1/data/update/%d", api.baseHTTPAddress, id), bytes.NewReader(b))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, fmt.Sprintf("%s/api/v1/data/update/%s%d", api.baseHTTPAddress, domain.RecordIDRefPrefix, id), bytes.NewReader(b))
 	if err != nil {
 		return nil, err
 	}
 
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Bearer "+api.session.GetToken())
+	req.Header.Set("If-Match", fmt.Sprintf("%q", strconv.Itoa(expectedVersion)))
 
-	resp, err := api.httpClient.Do(req)
+	resp, err := api.send(req)
 	if err != nil {
 		return nil, err
 	}
@@ -153,6 +166,10 @@ func (api *UserStoredDataAPI) UpdateByID(ctx context.Context, id int, data inter
 		return nil, err
 	}
 
+	if resp.StatusCode == http.StatusConflict {
+		return nil, domain.ErrDataVersionConflict
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		var errResp httputils.HTTPError
 		if err := json.Unmarshal(respData, &errResp); err != nil {
@@ -176,7 +193,7 @@ func (api *UserStoredDataAPI) DeleteBatch(ctx context.Context, ids []int) error
 	}
 	b, _ := json.Marshal(body)
 
-	req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/api/v1/data", api.baseHTTPAddress), bytes.NewReader(b))
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, fmt.Sprintf("%s/api/v1/data", api.baseHTTPAddress), bytes.NewReader(b))
 	if err != nil {
 		return err
 	}
@@ -184,7 +201,7 @@ func (api *UserStoredDataAPI) DeleteBatch(ctx context.Context, ids []int) error
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Bearer "+api.session.GetToken())
 
-	resp, err := api.httpClient.Do(req)
+	resp, err := api.send(req)
 	if err != nil {
 		return err
 	}
@@ -206,6 +223,361 @@ func (api *UserStoredDataAPI) DeleteBatch(ctx context.Context, ids []int) error
 	return nil
 }
 
+// GetByRef - get one record with given uuid or uuid prefix from external service
+func (api *UserStoredDataAPI) GetByRef(ctx context.Context, ref string) (*domain.UserStoredData, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/api/v1/data/record/%s", api.baseHTTPAddress, url.PathEscape(ref)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+api.session.GetToken())
+
+	resp, err := api.send(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp httputils.HTTPError
+		if err := json.Unmarshal(data, &errResp); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("api error: " + errResp.Error)
+	}
+
+	var respBody domain.UserStoredData
+	if err := json.Unmarshal(data, &respBody); err != nil {
+		return nil, err
+	}
+
+	respBody.Data, err = api.parseData(respBody)
+	if err != nil {
+		return nil, err
+	}
+
+	return &respBody, nil
+}
+
+// GetChanges - get page of personal vault changes made after since cursor
+func (api *UserStoredDataAPI) GetChanges(ctx context.Context, since int64, limit int) (*domain.ChangeFeed, error) {
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		fmt.Sprintf("%s/api/v1/sync/changes?since=%d&limit=%d", api.baseHTTPAddress, since, limit),
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+api.session.GetToken())
+
+	resp, err := api.send(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusGone {
+		return nil, domain.ErrSyncCursorExpired
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp httputils.HTTPError
+		if err := json.Unmarshal(data, &errResp); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("api error: " + errResp.Error)
+	}
+
+	var feed domain.ChangeFeed
+	if err := json.Unmarshal(data, &feed); err != nil {
+		return nil, err
+	}
+
+	for _, change := range feed.Changes {
+		if change.Data == nil {
+			continue
+		}
+
+		change.Data.Data, err = api.parseData(*change.Data)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &feed, nil
+}
+
+// Push - apply batch of changes at external service, batch is applied only as a whole.
+// Rejected batch is not an error, response contains status of every item
+func (api *UserStoredDataAPI) Push(ctx context.Context, items []domain.SyncPushItem) (*domain.SyncPushResponse, error) {
+	body := &struct {
+		Items []domain.SyncPushItem `json:"items"`
+	}{
+		Items: items,
+	}
+	b, _ := json.Marshal(body)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/api/v1/sync/push", api.baseHTTPAddress), bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+api.session.GetToken())
+
+	resp, err := api.send(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusConflict {
+		var errResp httputils.HTTPError
+		if err := json.Unmarshal(data, &errResp); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("api error: " + errResp.Error)
+	}
+
+	var respBody domain.SyncPushResponse
+	if err := json.Unmarshal(data, &respBody); err != nil {
+		return nil, err
+	}
+
+	return &respBody, nil
+}
+
+// AcknowledgeSync - tell external service that personal vault changes up to cursor are applied locally
+func (api *UserStoredDataAPI) AcknowledgeSync(ctx context.Context, cursor int64) error {
+	b, _ := json.Marshal(dtos.SyncAckBody{Cursor: cursor})
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/api/v1/sync/ack", api.baseHTTPAddress), bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+api.session.GetToken())
+
+	resp, err := api.send(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusNoContent {
+		var errResp httputils.HTTPError
+		if err := json.Unmarshal(data, &errResp); err != nil {
+			return err
+		}
+		return errors.New("api error: " + errResp.Error)
+	}
+
+	return nil
+}
+
+type collectionDataPage struct {
+	Data        []domain.UserStoredData `json:"data"`
+	CurrentPage int                     `json:"current_page"`
+	Count       int                     `json:"count"`
+	PageCount   int                     `json:"page_count"`
+}
+
+// GetCollectionData - get page of records with given type from organization collection
+func (api *UserStoredDataAPI) GetCollectionData(ctx context.Context, collectionID int, dataType string, page int, count int) (*domain.PaginatedResult, error) {
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		fmt.Sprintf("%s/api/v1/data/%s?collection_id=%d&page=%d&count=%d", api.baseHTTPAddress, dataType, collectionID, page, count),
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+api.session.GetToken())
+
+	resp, err := api.send(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp httputils.HTTPError
+		if err := json.Unmarshal(data, &errResp); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("api error: " + errResp.Error)
+	}
+
+	var respBody collectionDataPage
+	if err := json.Unmarshal(data, &respBody); err != nil {
+		return nil, err
+	}
+
+	for idx, data := range respBody.Data {
+		respBody.Data[idx].Data, err = api.parseData(data)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &domain.PaginatedResult{
+		Data:        respBody.Data,
+		CurrentPage: respBody.CurrentPage,
+		Count:       respBody.Count,
+		PageCount:   respBody.PageCount,
+	}, nil
+}
+
+// AddToCollection - add record to organization collection
+func (api *UserStoredDataAPI) AddToCollection(ctx context.Context, collectionID int, entity domain.UserStoredData) (*domain.UserStoredData, error) {
+	body := &addBody{
+		UUID: entity.UUID,
+		Data: entity.Data,
+		Meta: entity.Meta,
+	}
+	b, _ := json.Marshal(body)
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		fmt.Sprintf("%s/api/v1/data/%s?collection_id=%d", api.baseHTTPAddress, entity.DataType, collectionID),
+		bytes.NewReader(b),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+api.session.GetToken())
+
+	resp, err := api.send(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		var errResp httputils.HTTPError
+		if err := json.Unmarshal(data, &errResp); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("api error: " + errResp.Error)
+	}
+
+	var respBody domain.UserStoredData
+	if err := json.Unmarshal(data, &respBody); err != nil {
+		return nil, err
+	}
+
+	return &respBody, nil
+}
+
+// DeleteCollectionBatch - delete several records with given ids from organization collection
+func (api *UserStoredDataAPI) DeleteCollectionBatch(ctx context.Context, collectionID int, ids []int) error {
+	body := &dtos.DeleteBatchBody{
+		IDs: ids,
+	}
+	b, _ := json.Marshal(body)
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodDelete,
+		fmt.Sprintf("%s/api/v1/data?collection_id=%d", api.baseHTTPAddress, collectionID),
+		bytes.NewReader(b),
+	)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+api.session.GetToken())
+
+	resp, err := api.send(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusNoContent {
+		var errResp httputils.HTTPError
+		if err := json.Unmarshal(data, &errResp); err != nil {
+			return err
+		}
+		return errors.New("api error: " + errResp.Error)
+	}
+
+	return nil
+}
+
+// send - do request inside client span, trace context is passed to server in traceparent header
+func (api *UserStoredDataAPI) send(req *http.Request) (*http.Response, error) {
+	ctx, span := tracing.Start(
+		req.Context(),
+		"UserStoredDataAPI "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.HTTPMethod(req.Method), semconv.URLPath(req.URL.Path)),
+	)
+	defer span.End()
+
+	req = req.WithContext(ctx)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	resp, err := api.httpClient.Do(req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(semconv.HTTPStatusCode(resp.StatusCode))
+	if resp.StatusCode >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, resp.Status)
+	}
+
+	return resp, nil
+}
+
 func (api *UserStoredDataAPI) parseData(userData domain.UserStoredData) (interface{}, error) {
 	jsonData, err := json.Marshal(userData.Data)
 	if err != nil {
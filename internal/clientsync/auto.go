@@ -0,0 +1,223 @@
+package clientsync
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+)
+
+const (
+	autoSyncMinBackoff = 5 * time.Second
+	autoSyncMaxBackoff = 5 * time.Minute
+
+	// autoSyncNever - delay of periodic sync, when it is disabled
+	autoSyncNever = 100 * 365 * 24 * time.Hour
+)
+
+type autoSyncTarget interface {
+	Sync(ctx context.Context) error
+	PendingChanges(ctx context.Context) (int, error)
+}
+
+type serverEvents interface {
+	Listen(ctx context.Context, onChange func(event domain.DataChangeEvent)) error
+}
+
+// commandExecutor - runs background job only between commands, because commands change local store too
+type commandExecutor interface {
+	RunExclusive(fn func())
+}
+
+// AutoSyncer - runs sync in background on interval and shortly after local or server changes. Zero interval
+// disables periodic sync, changes made on server are still synced. When server is unreachable, attempts are
+// delayed exponentially until sync succeeds
+type AutoSyncer struct {
+	syncer   autoSyncTarget
+	executor commandExecutor
+
+	interval time.Duration
+	debounce time.Duration
+
+	changed       chan struct{}
+	serverChanged chan struct{}
+}
+
+func NewAutoSyncer(
+	syncer autoSyncTarget,
+	executor commandExecutor,
+	interval time.Duration,
+	debounce time.Duration,
+) *AutoSyncer {
+	return &AutoSyncer{
+		syncer:   syncer,
+		executor: executor,
+
+		interval: interval,
+		debounce: debounce,
+
+		changed:       make(chan struct{}, 1),
+		serverChanged: make(chan struct{}, 1),
+	}
+}
+
+// Notify - report possible local change, sync starts after debounce if no other change follows
+func (a *AutoSyncer) Notify() {
+	select {
+	case a.changed <- struct{}{}:
+	default:
+	}
+}
+
+// NotifyServerChange - records were changed on server by other device, sync starts after debounce even
+// without local changes
+func (a *AutoSyncer) NotifyServerChange() {
+	select {
+	case a.serverChanged <- struct{}{}:
+	default:
+	}
+}
+
+// CommandExecutedHook - notify about change after every command, commands which changed nothing are
+// filtered by pending changes check before sync
+func (a *AutoSyncer) CommandExecutedHook(name string) {
+	a.Notify()
+}
+
+// Run - blocks until ctx is done
+func (a *AutoSyncer) Run(ctx context.Context) {
+	nextPeriodic := a.nextPeriodic()
+	timer := time.NewTimer(time.Until(nextPeriodic))
+	defer timer.Stop()
+
+	failures := 0
+	serverChanged := false
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-a.changed:
+			// while backing off changes wait for next retry, so unreachable server is not hit on every command
+			if failures == 0 {
+				resetTimer(timer, min(a.debounce, time.Until(nextPeriodic)))
+			}
+		case <-a.serverChanged:
+			if failures == 0 {
+				serverChanged = true
+				resetTimer(timer, min(a.debounce, time.Until(nextPeriodic)))
+			}
+		case <-timer.C:
+			periodic := !time.Now().Before(nextPeriodic)
+			synced, err := a.syncIfNeeded(ctx, !periodic && !serverChanged)
+			serverChanged = false
+
+			if isNetworkError(err) {
+				failures++
+				nextPeriodic = time.Now().Add(backoff(failures))
+				resetTimer(timer, time.Until(nextPeriodic))
+				continue
+			}
+
+			// other errors are not retried sooner, they are shown by sync-status instead of breaking prompt
+			failures = 0
+			if synced || err != nil {
+				nextPeriodic = a.nextPeriodic()
+			}
+			resetTimer(timer, time.Until(nextPeriodic))
+		}
+	}
+}
+
+// nextPeriodic - time of next periodic sync, it never comes when periodic sync is disabled
+func (a *AutoSyncer) nextPeriodic() time.Time {
+	if a.interval <= 0 {
+		return time.Now().Add(autoSyncNever)
+	}
+
+	return time.Now().Add(a.interval)
+}
+
+// syncIfNeeded - when onlyChanges is true, sync is skipped if there are no local changes to push
+func (a *AutoSyncer) syncIfNeeded(ctx context.Context, onlyChanges bool) (bool, error) {
+	var (
+		synced bool
+		err    error
+	)
+
+	a.executor.RunExclusive(func() {
+		if onlyChanges {
+			var pending int
+			pending, err = a.syncer.PendingChanges(ctx)
+			if err != nil || pending == 0 {
+				return
+			}
+		}
+
+		synced = true
+		err = a.syncer.Sync(ctx)
+	})
+
+	return synced, err
+}
+
+// ListenServerEvents - keep stream of server events open until ctx is done and sync on every change made by
+// other devices. Changes made while stream was reconnecting are caught by periodic sync or by next change
+func (a *AutoSyncer) ListenServerEvents(ctx context.Context, events serverEvents) {
+	failures := 0
+
+	for {
+		connectedAt := time.Now()
+		err := events.Listen(ctx, func(event domain.DataChangeEvent) {
+			a.NotifyServerChange()
+		})
+		if ctx.Err() != nil {
+			return
+		}
+
+		// stream which worked for a long time was closed by server or proxy, not refused
+		if time.Since(connectedAt) > autoSyncMaxBackoff {
+			failures = 0
+		}
+
+		// user is not logged in yet, stream is opened soon after login
+		delay := autoSyncMinBackoff
+		if !errors.Is(err, domain.ErrNotAuth) {
+			failures++
+			delay = backoff(failures)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+	}
+}
+
+func backoff(failures int) time.Duration {
+	delay := autoSyncMinBackoff
+	for i := 1; i < failures && delay < autoSyncMaxBackoff; i++ {
+		delay *= 2
+	}
+
+	return min(delay, autoSyncMaxBackoff)
+}
+
+func isNetworkError(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// resetTimer - stop timer and drain its channel, so Reset does not leave stale tick
+func resetTimer(timer *time.Timer, d time.Duration) {
+	if !timer.Stop() {
+		select {
+		case <-timer.C:
+		default:
+		}
+	}
+	timer.Reset(d)
+}
@@ -0,0 +1,151 @@
+package clientsync
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	mock_clientsync "github.com/MowlCoder/goph-keeper/internal/clientsync/mocks"
+)
+
+func TestAutoSyncer_Run(t *testing.T) {
+	testCases := []struct {
+		name         string
+		serverChange bool
+		pending      int
+		expected     bool
+	}{
+		{
+			name:     "local change is synced after debounce",
+			pending:  1,
+			expected: true,
+		},
+		{
+			name:     "command without changes does not start sync",
+			pending:  0,
+			expected: false,
+		},
+		{
+			name:         "server change is synced without local changes",
+			serverChange: true,
+			expected:     true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			target := mock_clientsync.NewMockautoSyncTarget(ctrl)
+			executor := mock_clientsync.NewMockcommandExecutor(ctrl)
+
+			checked := make(chan struct{})
+			synced := make(chan struct{}, 1)
+
+			executor.EXPECT().RunExclusive(gomock.Any()).Do(func(fn func()) {
+				fn()
+				close(checked)
+			})
+			if !testCase.serverChange {
+				target.EXPECT().PendingChanges(gomock.Any()).Return(testCase.pending, nil)
+			}
+			if testCase.expected {
+				target.EXPECT().Sync(gomock.Any()).DoAndReturn(func(ctx context.Context) error {
+					synced <- struct{}{}
+					return nil
+				})
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan struct{})
+
+			autoSyncer := NewAutoSyncer(target, executor, time.Hour, 10*time.Millisecond)
+			go func() {
+				autoSyncer.Run(ctx)
+				close(done)
+			}()
+
+			if testCase.serverChange {
+				autoSyncer.NotifyServerChange()
+			} else {
+				autoSyncer.CommandExecutedHook("logpass-add")
+			}
+
+			select {
+			case <-checked:
+			case <-time.After(time.Second):
+				t.Fatal("sync was not started after debounce")
+			}
+
+			cancel()
+			<-done
+
+			assert.Equal(t, testCase.expected, len(synced) == 1)
+		})
+	}
+}
+
+func TestAutoSyncer_RunWithoutInterval(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	target := mock_clientsync.NewMockautoSyncTarget(ctrl)
+	executor := mock_clientsync.NewMockcommandExecutor(ctrl)
+
+	synced := make(chan struct{}, 2)
+	executor.EXPECT().RunExclusive(gomock.Any()).Do(func(fn func()) { fn() })
+	target.EXPECT().Sync(gomock.Any()).DoAndReturn(func(ctx context.Context) error {
+		synced <- struct{}{}
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	// periodic sync is disabled, only server change starts sync
+	autoSyncer := NewAutoSyncer(target, executor, 0, 10*time.Millisecond)
+	go func() {
+		autoSyncer.Run(ctx)
+		close(done)
+	}()
+
+	autoSyncer.NotifyServerChange()
+
+	select {
+	case <-synced:
+	case <-time.After(time.Second):
+		t.Fatal("server change was not synced")
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	<-done
+
+	assert.Empty(t, synced)
+}
+
+func TestBackoff(t *testing.T) {
+	testCases := []struct {
+		failures int
+		expected time.Duration
+	}{
+		{failures: 1, expected: autoSyncMinBackoff},
+		{failures: 2, expected: 2 * autoSyncMinBackoff},
+		{failures: 4, expected: 8 * autoSyncMinBackoff},
+		{failures: 100, expected: autoSyncMaxBackoff},
+	}
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expected, backoff(testCase.failures))
+	}
+}
+
+func TestIsNetworkError(t *testing.T) {
+	netErr := &url.Error{Op: "Get", URL: "http://localhost", Err: errors.New("connection refused")}
+
+	assert.True(t, isNetworkError(netErr))
+	assert.False(t, isNetworkError(errors.New("some error")))
+	assert.False(t, isNetworkError(nil))
+}
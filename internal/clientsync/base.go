@@ -1,185 +1,376 @@
 package clientsync
 
 import (
-	"bufio"
 	"context"
+	"errors"
 	"fmt"
-	"os"
-	"strings"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
 
 	"github.com/MowlCoder/goph-keeper/internal/domain"
+	"github.com/MowlCoder/goph-keeper/internal/presenter"
 	"github.com/MowlCoder/goph-keeper/internal/session"
+	"github.com/MowlCoder/goph-keeper/internal/tracing"
 )
 
+// changesPageSize - changes requested per call, sync keeps requesting pages until server has no more
+const changesPageSize = domain.SyncChangesDefaultLimit
+
 type serverApi interface {
-	GetAll(ctx context.Context) ([]domain.UserStoredData, error)
-	Add(ctx context.Context, entity domain.UserStoredData) (*domain.UserStoredData, error)
-	UpdateByID(ctx context.Context, id int, data interface{}, meta string) (*domain.UserStoredData, error)
-	DeleteBatch(ctx context.Context, ids []int) error
+	GetChanges(ctx context.Context, since int64, limit int) (*domain.ChangeFeed, error)
+	Push(ctx context.Context, items []domain.SyncPushItem) (*domain.SyncPushResponse, error)
+	AcknowledgeSync(ctx context.Context, cursor int64) error
 }
 
 type localService interface {
 	GetAll(ctx context.Context) ([]domain.UserStoredData, error)
-	Add(ctx context.Context, dataType string, data interface{}, meta string) (*domain.UserStoredData, error)
-	UpdateByID(ctx context.Context, id int, data interface{}, meta string) (*domain.UserStoredData, error)
-	DeleteBatch(ctx context.Context, ids []int) error
+	ApplySync(ctx context.Context, changes domain.LocalSyncChanges) error
+}
+
+// serverChanges - latest state of records changed on server since last sync by record uuid.
+// Full changes are read from the beginning after server collected deletions device has not seen yet,
+// so they mention every record server still has
+type serverChanges struct {
+	Changed map[string]domain.UserStoredData
+	Deleted map[string]struct{}
+	Cursor  int64
+	Full    bool
 }
 
+// preparedData - records to delete from client are given by uuid, records to delete from server also by
+// version client expects them to have
 type preparedData struct {
-	DelFromServer []int
-	DelFromClient []int
+	DelFromServer []domain.UserStoredData
+	DelFromClient []string
 
 	EditOnClient []domain.UserStoredData
 	EditOnServer []domain.UserStoredData
 
 	AddToServer []domain.UserStoredData
 	AddToClient []domain.UserStoredData
+
+	ConflictCopies []conflictCopy
 }
 
-type localRepository interface {
-	SyncUpdate(ctx context.Context, oldID, newID, version int) error
+// conflictCopy - local version of record kept as separate record, because it conflicts with server one
+type conflictCopy struct {
+	Record domain.UserStoredData
+	Fields []string
 }
 
 type BaseSyncer struct {
 	clientSession *session.ClientSession
 
-	serverApi       serverApi
-	localService    localService
-	localRepository localRepository
+	serverApi    serverApi
+	localService localService
+
+	resolver ConflictResolver
+
+	statusMu sync.RWMutex
+	status   domain.SyncStatus
 }
 
 func NewBaseSyncer(
 	clientSession *session.ClientSession,
 	serverApi serverApi,
 	localService localService,
-	localRepository localRepository,
+	resolver ConflictResolver,
 ) *BaseSyncer {
 	return &BaseSyncer{
 		clientSession: clientSession,
 
-		serverApi:       serverApi,
-		localService:    localService,
-		localRepository: localRepository,
+		serverApi:    serverApi,
+		localService: localService,
+
+		resolver: resolver,
 	}
 }
 
+// Sync - synchronize local store with server and remember result for sync-status. Callers running sync in
+// background must serialize it with commands, which also change local store
 func (s *BaseSyncer) Sync(ctx context.Context) error {
 	if !s.clientSession.IsAuth() {
 		return nil
 	}
 
-	serverDataMap, err := s.getServerData(ctx)
+	ctx, span := tracing.Start(ctx, "BaseSyncer.Sync")
+	defer span.End()
+
+	err := s.sync(ctx)
+
+	s.statusMu.Lock()
+	defer s.statusMu.Unlock()
+
+	s.status.LastAttemptAt = time.Now()
+	s.status.LastError = err
+	if err == nil {
+		s.status.LastSuccessAt = s.status.LastAttemptAt
+	}
+
+	return err
+}
+
+// Status - result of last sync and number of local changes waiting for it
+func (s *BaseSyncer) Status(ctx context.Context) (domain.SyncStatus, error) {
+	pending, err := s.PendingChanges(ctx)
 	if err != nil {
-		return err
+		return domain.SyncStatus{}, err
 	}
+
+	s.statusMu.RLock()
+	defer s.statusMu.RUnlock()
+
+	status := s.status
+	status.PendingChanges = pending
+
+	return status, nil
+}
+
+// PendingChanges - count of records created, edited or deleted locally since last sync
+func (s *BaseSyncer) PendingChanges(ctx context.Context) (int, error) {
+	clientData, err := s.localService.GetAll(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	pending := len(s.clientSession.GetDeleted())
+	for _, data := range clientData {
+		if data.IsLocal() || s.clientSession.IsEdited(data.UUID) {
+			pending++
+		}
+	}
+
+	return pending, nil
+}
+
+func (s *BaseSyncer) sync(ctx context.Context) error {
 	clientDataMap, err := s.getClientData(ctx)
 	if err != nil {
 		return err
 	}
 
-	data := s.prepareData(serverDataMap, clientDataMap)
+	// local store was wiped or never filled, everything must be downloaded again. Versions of records
+	// deleted by previous versions of client are unknown, whole vault tells them
+	cursor := s.clientSession.GetSyncCursor()
+	full := s.clientSession.HasDeletedWithoutVersion()
+	if len(clientDataMap) == 0 || full {
+		cursor = 0
+	}
 
-	if len(data.DelFromClient) > 0 {
-		if err := s.localService.DeleteBatch(ctx, data.DelFromClient); err != nil {
-			return err
+	changes, err := s.getServerChanges(ctx, cursor)
+	if err == nil && full {
+		changes.Full = true
+	}
+	if errors.Is(err, domain.ErrSyncCursorExpired) && cursor > 0 {
+		// deletions made after cursor are already collected on server, only whole vault tells what is left
+		changes, err = s.getServerChanges(ctx, 0)
+		if err == nil {
+			changes.Full = true
 		}
 	}
+	if err != nil {
+		return err
+	}
 
-	if len(data.DelFromServer) > 0 {
-		err := s.serverApi.DeleteBatch(ctx, data.DelFromServer)
-		if err != nil {
+	data, err := s.prepareData(changes, clientDataMap)
+	if err != nil {
+		return err
+	}
+
+	// server is changed first and as a whole, local store is touched only after server accepted the batch
+	pushed, err := s.push(ctx, data)
+	if err != nil {
+		return err
+	}
+
+	localChanges, conflicts := s.localChanges(data, pushed)
+
+	// all local changes are made in one transaction, so interrupted sync does not leave local store half
+	// synced, it is synced again next time
+	if err := s.localService.ApplySync(ctx, localChanges); err != nil {
+		return err
+	}
+
+	for _, conflict := range conflicts {
+		if err := s.clientSession.AddConflict(conflict); err != nil {
 			return err
 		}
 	}
 
-	if len(data.EditOnClient) > 0 {
-		for _, editData := range data.EditOnClient {
-			_, err := s.localService.UpdateByID(ctx, editData.ID, editData.Data, editData.Meta)
-			if err != nil {
-				return err
-			}
+	if err := s.clientSession.ClearDeleted(); err != nil {
+		return err
+	}
 
-			s.localRepository.SyncUpdate(
-				context.Background(),
-				editData.ID,
-				editData.ID,
-				editData.Version,
-			)
-		}
+	if err := s.clientSession.ClearEdited(); err != nil {
+		return err
 	}
 
-	if len(data.EditOnServer) > 0 {
-		for _, editData := range data.EditOnServer {
-			updateData, err := s.serverApi.UpdateByID(ctx, editData.ID, editData.Data, editData.Meta)
-			if err != nil {
-				return err
-			}
+	if err := s.clientSession.SetSyncCursor(changes.Cursor); err != nil {
+		return err
+	}
 
-			s.localRepository.SyncUpdate(
-				context.Background(),
-				editData.ID,
-				updateData.ID,
-				updateData.Version,
-			)
-		}
+	// server collects deletions only after every device of user has acknowledged them
+	return s.serverApi.AcknowledgeSync(ctx, changes.Cursor)
+}
+
+func (s *BaseSyncer) SyncCommandHandler(args []string) (*presenter.Result, error) {
+	if !s.clientSession.IsAuth() {
+		return nil, domain.ErrInvalidCommandUsage
 	}
 
-	if len(data.AddToServer) > 0 {
-		for _, data := range data.AddToServer {
-			newData, err := s.serverApi.Add(
-				ctx,
-				data,
-			)
-			if err != nil {
-				fmt.Println("AddToServer:", err)
-				continue
-			}
+	if err := s.Sync(context.Background()); err != nil {
+		return nil, err
+	}
 
-			s.localRepository.SyncUpdate(
-				context.Background(),
-				data.ID,
-				newData.ID,
-				newData.Version,
-			)
-		}
+	return presenter.NewMessage("Data is synchronized"), nil
+}
+
+func (s *BaseSyncer) SyncStatusCommandHandler(args []string) (*presenter.Result, error) {
+	if len(args) != 0 {
+		return nil, domain.ErrInvalidCommandUsage
 	}
 
-	if len(data.AddToClient) > 0 {
-		for _, d := range data.AddToClient {
-			newData, _ := s.localService.Add(
-				ctx,
-				d.DataType,
-				d.Data,
-				d.Meta,
-			)
-
-			s.localRepository.SyncUpdate(
-				ctx,
-				newData.ID,
-				d.ID,
-				d.Version,
-			)
-		}
+	status, err := s.Status(context.Background())
+	if err != nil {
+		return nil, err
 	}
 
-	s.clientSession.ClearDeleted()
-	s.clientSession.ClearEdited()
+	var lastError any
+	if status.LastError != nil {
+		lastError = status.LastError.Error()
+	}
 
-	return nil
+	return presenter.NewRecord(
+		"Sync status",
+		[]string{"last_success_at", "last_attempt_at", "last_error", "pending_changes"},
+		[]any{syncTime(status.LastSuccessAt), syncTime(status.LastAttemptAt), lastError, status.PendingChanges},
+	), nil
 }
 
-func (s *BaseSyncer) SyncCommandHandler(args []string) error {
-	if !s.clientSession.IsAuth() {
-		return domain.ErrInvalidCommandUsage
+// syncTime - time of sync or nothing if sync never happened
+func syncTime(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+
+	return t
+}
+
+// push - send local changes to server in one batch. Returns results of created and updated records, which
+// tell server ids and versions of local records
+func (s *BaseSyncer) push(ctx context.Context, data *preparedData) ([]domain.SyncPushResult, error) {
+	items := make([]domain.SyncPushItem, 0, len(data.DelFromServer)+len(data.EditOnServer)+len(data.AddToServer))
+
+	for _, delData := range data.DelFromServer {
+		items = append(items, domain.SyncPushItem{
+			UUID:      delData.UUID,
+			Operation: domain.SyncOperationDelete,
+			Version:   delData.Version,
+		})
+	}
+
+	for _, editData := range data.EditOnServer {
+		items = append(items, domain.SyncPushItem{
+			UUID:      editData.UUID,
+			Operation: domain.SyncOperationUpdate,
+			Version:   editData.Version,
+			DataType:  editData.DataType,
+			Data:      editData.Data,
+			Meta:      editData.Meta,
+		})
+	}
+
+	for _, addData := range data.AddToServer {
+		items = append(items, domain.SyncPushItem{
+			UUID:      addData.UUID,
+			Operation: domain.SyncOperationCreate,
+			DataType:  addData.DataType,
+			Data:      addData.Data,
+			Meta:      addData.Meta,
+		})
+	}
+
+	if len(items) == 0 {
+		return nil, nil
+	}
+
+	// all changes go in one request, server applies them in one transaction or rejects them all
+	response, err := s.serverApi.Push(ctx, items)
+	if err != nil {
+		return nil, err
+	}
+
+	if !response.Applied {
+		return nil, domain.ErrSyncPushRejected
 	}
 
-	return s.Sync(context.Background())
+	pushed := make([]domain.SyncPushResult, 0, len(response.Results))
+	for idx, result := range response.Results {
+		if items[idx].Operation != domain.SyncOperationDelete {
+			pushed = append(pushed, result)
+		}
+	}
+
+	return pushed, nil
+}
+
+// localChanges - changes of local store planned by sync and conflicts of copies made in it. Records pushed
+// to server get ids and versions server gave them, conflicted copies are new local records
+func (s *BaseSyncer) localChanges(
+	data *preparedData,
+	pushed []domain.SyncPushResult,
+) (domain.LocalSyncChanges, []domain.SyncConflict) {
+	changes := domain.LocalSyncChanges{
+		Synced:  pushed,
+		Deleted: data.DelFromClient,
+		Updated: make([]domain.UserStoredData, 0, len(data.EditOnClient)),
+		Added:   data.AddToClient,
+		Copies:  make([]domain.UserStoredData, 0, len(data.ConflictCopies)),
+	}
+
+	pushedVersions := make(map[string]int, len(pushed))
+	for _, result := range pushed {
+		pushedVersions[result.UUID] = result.Version
+	}
+
+	for _, editData := range data.EditOnClient {
+		// merged record was pushed too, so it has version given by server on push
+		if version, ok := pushedVersions[editData.UUID]; ok {
+			editData.Version = version
+		}
+		changes.Updated = append(changes.Updated, editData)
+	}
+
+	conflicts := make([]domain.SyncConflict, 0, len(data.ConflictCopies))
+	for _, c := range data.ConflictCopies {
+		detectedAt := time.Now()
+		copyData := domain.UserStoredData{
+			UUID:     uuid.NewString(),
+			DataType: c.Record.DataType,
+			Data:     c.Record.Data,
+			Meta:     fmt.Sprintf("%s (conflicted copy %s)", c.Record.Meta, detectedAt.Format(time.DateTime)),
+		}
+		changes.Copies = append(changes.Copies, copyData)
+
+		conflicts = append(conflicts, domain.SyncConflict{
+			RecordUUID: c.Record.UUID,
+			CopyUUID:   copyData.UUID,
+			DataType:   c.Record.DataType,
+			Fields:     c.Fields,
+			DetectedAt: detectedAt,
+		})
+	}
+
+	return changes, conflicts
 }
 
-func (s *BaseSyncer) prepareData(serverData map[int]domain.UserStoredData, clientData map[int]domain.UserStoredData) *preparedData {
+func (s *BaseSyncer) prepareData(changes *serverChanges, clientData map[string]domain.UserStoredData) (*preparedData, error) {
 	pd := &preparedData{
-		DelFromServer: make([]int, 0),
-		DelFromClient: make([]int, 0),
+		DelFromServer: make([]domain.UserStoredData, 0),
+		DelFromClient: make([]string, 0),
 
 		EditOnServer: make([]domain.UserStoredData, 0),
 		EditOnClient: make([]domain.UserStoredData, 0),
@@ -188,23 +379,24 @@ func (s *BaseSyncer) prepareData(serverData map[int]domain.UserStoredData, clien
 		AddToClient: make([]domain.UserStoredData, 0),
 	}
 
-	for _, data := range serverData {
-		if s.clientSession.IsDeleted(data.ID) {
-			pd.DelFromServer = append(pd.DelFromServer, data.ID)
+	for _, data := range changes.Changed {
+		// local deletion wins over server change, record is deleted in version client has just seen
+		if s.clientSession.IsDeleted(data.UUID) {
+			pd.DelFromServer = append(pd.DelFromServer, domain.UserStoredData{UUID: data.UUID, Version: data.Version})
 			continue
 		}
 
-		if s.clientSession.IsEdited(data.ID) {
-			if data.Version == clientData[data.ID].Version {
-				pd.EditOnServer = append(pd.EditOnServer, clientData[data.ID])
-			} else {
-				s.userMerge(pd, clientData[data.ID], data)
+		if s.clientSession.IsEdited(data.UUID) {
+			if data.Version == clientData[data.UUID].Version {
+				pd.EditOnServer = append(pd.EditOnServer, clientData[data.UUID])
+			} else if err := s.merge(pd, clientData[data.UUID], data); err != nil {
+				return nil, err
 			}
 
 			continue
 		}
 
-		if dateFromClient, ok := clientData[data.ID]; !ok {
+		if dateFromClient, ok := clientData[data.UUID]; !ok {
 			pd.AddToClient = append(pd.AddToClient, data)
 		} else {
 			if dateFromClient.Version != data.Version {
@@ -213,69 +405,137 @@ func (s *BaseSyncer) prepareData(serverData map[int]domain.UserStoredData, clien
 		}
 	}
 
+	for recordUUID := range changes.Deleted {
+		if data, ok := clientData[recordUUID]; ok {
+			s.deleteFromClient(pd, data)
+		}
+	}
+
+	// records not mentioned in changes are the same on server as at last sync, so only local changes are sent
 	for _, data := range clientData {
-		_, ok := serverData[data.ID]
+		if changes.isMentioned(data.UUID) {
+			continue
+		}
 
-		if !ok {
-			if data.IsLocal() {
-				pd.AddToServer = append(pd.AddToServer, data)
-			} else {
-				pd.DelFromClient = append(pd.DelFromClient, data.ID)
-			}
+		if data.IsLocal() {
+			pd.AddToServer = append(pd.AddToServer, data)
+		} else if changes.Full {
+			// record was deleted on server and its deletion is already collected
+			s.deleteFromClient(pd, data)
+		} else if s.clientSession.IsEdited(data.UUID) {
+			pd.EditOnServer = append(pd.EditOnServer, data)
 		}
 	}
 
-	return pd
+	// record not changed on server since last sync still has version it had when it was deleted locally
+	for _, recordUUID := range s.clientSession.GetDeleted() {
+		if !changes.isMentioned(recordUUID) && !changes.Full {
+			pd.DelFromServer = append(pd.DelFromServer, domain.UserStoredData{
+				UUID:    recordUUID,
+				Version: s.clientSession.GetDeletedVersion(recordUUID),
+			})
+		}
+	}
+
+	sort.Slice(pd.DelFromServer, func(i, j int) bool {
+		return pd.DelFromServer[i].UUID < pd.DelFromServer[j].UUID
+	})
+	sort.Strings(pd.DelFromClient)
+
+	return pd, nil
 }
 
-func (s *BaseSyncer) userMerge(pd *preparedData, clientData domain.UserStoredData, serverData domain.UserStoredData) {
-	fmt.Printf("\nYou need to merge data with id - %d\n", serverData.ID)
-	fmt.Print("Client data: ")
-	fmt.Println(clientData.Data, clientData.Meta)
-	fmt.Print("Server data: ")
-	fmt.Println(serverData.Data, serverData.Meta)
-	reader := bufio.NewReader(os.Stdin)
+// deleteFromClient - plan deletion of record deleted on server. Record edited on client is not lost, its
+// local version is kept as conflicted copy, which is pushed to server as new record on next sync
+func (s *BaseSyncer) deleteFromClient(pd *preparedData, data domain.UserStoredData) {
+	pd.DelFromClient = append(pd.DelFromClient, data.UUID)
 
-	for {
-		fmt.Print("Enter 'server' or 'client': ")
-		text, _ := reader.ReadString('\n')
-		text = strings.Trim(text, "\n\r")
-
-		if text == "server" {
-			pd.EditOnClient = append(pd.EditOnClient, serverData)
-			break
-		} else if text == "client" {
-			pd.EditOnServer = append(pd.EditOnServer, clientData)
-			break
-		}
+	if s.clientSession.IsEdited(data.UUID) {
+		pd.ConflictCopies = append(pd.ConflictCopies, conflictCopy{
+			Record: data,
+			Fields: []string{domain.SyncConflictDeletedOnServer},
+		})
 	}
 }
 
-func (s *BaseSyncer) getServerData(ctx context.Context) (map[int]domain.UserStoredData, error) {
-	serverData, err := s.serverApi.GetAll(ctx)
+// merge - plan record changed both on client and on server according to result of three-way merge
+func (s *BaseSyncer) merge(pd *preparedData, clientData domain.UserStoredData, serverData domain.UserStoredData) error {
+	result, err := s.mergeRecords(clientData, serverData)
 	if err != nil {
-		return nil, err
+		return err
+	}
+
+	if result.ChangesServer {
+		pd.EditOnServer = append(pd.EditOnServer, result.Record)
+	}
+
+	// local copy also gets server version, even if its data is already the same as merged
+	if result.ChangesClient || !result.ChangesServer {
+		pd.EditOnClient = append(pd.EditOnClient, result.Record)
+	}
+
+	if len(result.CopyFields) > 0 {
+		pd.ConflictCopies = append(pd.ConflictCopies, conflictCopy{
+			Record: clientData,
+			Fields: result.CopyFields,
+		})
 	}
 
-	serverDataMap := make(map[int]domain.UserStoredData)
-	for _, data := range serverData {
-		serverDataMap[data.ID] = data
+	return nil
+}
+
+// getServerChanges - get all pages of changes made on server after cursor
+func (s *BaseSyncer) getServerChanges(ctx context.Context, cursor int64) (*serverChanges, error) {
+	changes := &serverChanges{
+		Changed: make(map[string]domain.UserStoredData),
+		Deleted: make(map[string]struct{}),
+		Cursor:  cursor,
 	}
 
-	return serverDataMap, nil
+	for {
+		feed, err := s.serverApi.GetChanges(ctx, changes.Cursor, changesPageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		// changes are ordered, so later change of the same record replaces earlier one
+		for _, change := range feed.Changes {
+			if change.Deleted {
+				delete(changes.Changed, change.UUID)
+				changes.Deleted[change.UUID] = struct{}{}
+			} else {
+				delete(changes.Deleted, change.UUID)
+				changes.Changed[change.UUID] = *change.Data
+			}
+		}
+		changes.Cursor = feed.Cursor
+
+		if !feed.HasMore {
+			return changes, nil
+		}
+	}
 }
 
-func (s *BaseSyncer) getClientData(ctx context.Context) (map[int]domain.UserStoredData, error) {
+func (s *BaseSyncer) getClientData(ctx context.Context) (map[string]domain.UserStoredData, error) {
 	dataSet, err := s.localService.GetAll(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	clientData := make(map[int]domain.UserStoredData)
+	clientData := make(map[string]domain.UserStoredData)
 
 	for _, data := range dataSet {
-		clientData[data.ID] = data
+		clientData[data.UUID] = data
 	}
 
 	return clientData, nil
 }
+
+func (c *serverChanges) isMentioned(recordUUID string) bool {
+	if _, ok := c.Changed[recordUUID]; ok {
+		return true
+	}
+
+	_, ok := c.Deleted[recordUUID]
+	return ok
+}
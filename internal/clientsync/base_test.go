@@ -2,7 +2,9 @@ package clientsync
 
 import (
 	"context"
-	"os"
+	"errors"
+	"fmt"
+	"reflect"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -11,26 +13,23 @@ import (
 	mock_clientsync "github.com/MowlCoder/goph-keeper/internal/clientsync/mocks"
 	"github.com/MowlCoder/goph-keeper/internal/domain"
 	"github.com/MowlCoder/goph-keeper/internal/session"
+	"github.com/MowlCoder/goph-keeper/internal/utils/cryptor"
 )
 
 type baseSyncerTestSuite struct {
 	suite.Suite
 
-	session         *session.ClientSession
-	serverApi       *mock_clientsync.MockserverApi
-	localService    *mock_clientsync.MocklocalService
-	localRepository *mock_clientsync.MocklocalRepository
+	session      *session.ClientSession
+	serverApi    *mock_clientsync.MockserverApi
+	localService *mock_clientsync.MocklocalService
 
-	file   *os.File
 	syncer *BaseSyncer
 }
 
 func (suite *baseSyncerTestSuite) SetupSuite() {
-	suite.file, _ = os.Create(suite.T().TempDir() + "/temp.json")
 }
 
 func (suite *baseSyncerTestSuite) TearDownSuite() {
-	suite.file.Close()
 }
 
 func (suite *baseSyncerTestSuite) SetupTest() {
@@ -38,14 +37,21 @@ func (suite *baseSyncerTestSuite) SetupTest() {
 
 	suite.serverApi = mock_clientsync.NewMockserverApi(ctrl)
 	suite.localService = mock_clientsync.NewMocklocalService(ctrl)
-	suite.localRepository = mock_clientsync.NewMocklocalRepository(ctrl)
-	suite.session = session.NewClientSession(suite.file)
+	suite.serverApi.EXPECT().AcknowledgeSync(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	suite.SetupSubTest()
+}
+
+// SetupSubTest - start every case with empty session, so cursor and pending ids do not leak between cases
+func (suite *baseSyncerTestSuite) SetupSubTest() {
+	sealer, _ := cryptor.NewSealer("test-vault-key")
+	suite.session, _ = session.NewClientSession(suite.T().TempDir()+"/temp.json", sealer)
 
 	suite.syncer = NewBaseSyncer(
 		suite.session,
 		suite.serverApi,
 		suite.localService,
-		suite.localRepository,
+		fixedResolver(KeepServer),
 	)
 }
 
@@ -56,6 +62,62 @@ func TestBaseSyncer(t *testing.T) {
 	suite.Run(t, new(baseSyncerTestSuite))
 }
 
+// localChangesMatcher - matches local changes of sync, empty and nil lists are the same
+type localChangesMatcher struct {
+	expected domain.LocalSyncChanges
+}
+
+func (m localChangesMatcher) Matches(x any) bool {
+	actual, ok := x.(domain.LocalSyncChanges)
+	if !ok {
+		return false
+	}
+
+	return reflect.DeepEqual(normalizeLocalChanges(actual), normalizeLocalChanges(m.expected))
+}
+
+func (m localChangesMatcher) String() string {
+	return fmt.Sprintf("is equal to %+v", m.expected)
+}
+
+func normalizeLocalChanges(changes domain.LocalSyncChanges) domain.LocalSyncChanges {
+	if len(changes.Synced) == 0 {
+		changes.Synced = nil
+	}
+	if len(changes.Deleted) == 0 {
+		changes.Deleted = nil
+	}
+	if len(changes.Updated) == 0 {
+		changes.Updated = nil
+	}
+	if len(changes.Added) == 0 {
+		changes.Added = nil
+	}
+	if len(changes.Copies) == 0 {
+		changes.Copies = nil
+	}
+
+	return changes
+}
+
+// expectApplySync - expect all local changes of sync to be applied at once
+func (suite *baseSyncerTestSuite) expectApplySync(changes domain.LocalSyncChanges) {
+	suite.localService.
+		EXPECT().
+		ApplySync(gomock.Any(), localChangesMatcher{expected: changes}).
+		Return(nil)
+}
+
+const (
+	uuid1 = "00000000-0000-4000-8000-000000000001"
+	uuid2 = "00000000-0000-4000-8000-000000000002"
+	uuid3 = "00000000-0000-4000-8000-000000000003"
+	uuid4 = "00000000-0000-4000-8000-000000000004"
+	uuid5 = "00000000-0000-4000-8000-000000000005"
+	uuid6 = "00000000-0000-4000-8000-000000000006"
+	uuid7 = "00000000-0000-4000-8000-000000000007"
+)
+
 func (suite *baseSyncerTestSuite) TestBaseSyncer_Sync() {
 	testCases := []struct {
 		name    string
@@ -66,7 +128,7 @@ func (suite *baseSyncerTestSuite) TestBaseSyncer_Sync() {
 			name: "success",
 			prepare: func() {
 				localData := domain.UserStoredData{
-					ID:       -2,
+					UUID:     uuid2,
 					Version:  -1,
 					DataType: domain.TextDataType,
 					Data: domain.TextData{
@@ -76,6 +138,7 @@ func (suite *baseSyncerTestSuite) TestBaseSyncer_Sync() {
 
 				serverData := domain.UserStoredData{
 					ID:       1,
+					UUID:     uuid1,
 					Version:  1,
 					DataType: domain.CardDataType,
 					Data: domain.CardData{
@@ -87,40 +150,46 @@ func (suite *baseSyncerTestSuite) TestBaseSyncer_Sync() {
 
 				suite.session.SetToken("some-token")
 
-				suite.serverApi.
-					EXPECT().
-					GetAll(gomock.Any()).
-					Return([]domain.UserStoredData{serverData}, nil)
-
 				suite.localService.
 					EXPECT().
 					GetAll(gomock.Any()).
-					Return([]domain.UserStoredData{localData, {ID: 3, Version: 1}}, nil)
-
-				suite.localService.
-					EXPECT().
-					DeleteBatch(gomock.Any(), []int{3}).
-					Return(nil)
+					Return([]domain.UserStoredData{localData, {ID: 3, UUID: uuid3, Version: 1}}, nil)
 
 				suite.serverApi.
 					EXPECT().
-					Add(gomock.Any(), localData).
-					Return(&domain.UserStoredData{ID: 10, Version: 1}, nil)
-
-				suite.localRepository.
-					EXPECT().
-					SyncUpdate(gomock.Any(), localData.ID, 10, 1).
-					Return(nil)
-
-				suite.localService.
-					EXPECT().
-					Add(gomock.Any(), serverData.DataType, serverData.Data, "").
-					Return(&domain.UserStoredData{ID: 12}, nil)
+					GetChanges(gomock.Any(), int64(0), changesPageSize).
+					Return(&domain.ChangeFeed{
+						Changes: []domain.DataChange{
+							{Seq: 5, ID: serverData.ID, UUID: serverData.UUID, Data: &serverData},
+							{Seq: 7, ID: 3, UUID: uuid3, Deleted: true},
+						},
+						Cursor: 7,
+					}, nil)
 
-				suite.localRepository.
+				suite.serverApi.
 					EXPECT().
-					SyncUpdate(gomock.Any(), 12, serverData.ID, 1).
-					Return(nil)
+					Push(gomock.Any(), []domain.SyncPushItem{
+						{
+							UUID:      localData.UUID,
+							Operation: domain.SyncOperationCreate,
+							DataType:  localData.DataType,
+							Data:      localData.Data,
+						},
+					}).
+					Return(&domain.SyncPushResponse{
+						Applied: true,
+						Results: []domain.SyncPushResult{
+							{UUID: localData.UUID, Status: domain.SyncPushStatusApplied, ID: 10, Version: 1},
+						},
+					}, nil)
+
+				suite.expectApplySync(domain.LocalSyncChanges{
+					Synced: []domain.SyncPushResult{
+						{UUID: localData.UUID, Status: domain.SyncPushStatusApplied, ID: 10, Version: 1},
+					},
+					Deleted: []string{uuid3},
+					Added:   []domain.UserStoredData{serverData},
+				})
 			},
 			err: nil,
 		},
@@ -135,9 +204,15 @@ func (suite *baseSyncerTestSuite) TestBaseSyncer_Sync() {
 			name: "err getting data from server",
 			prepare: func() {
 				suite.session.SetToken("some-token")
-				suite.serverApi.
+
+				suite.localService.
 					EXPECT().
 					GetAll(gomock.Any()).
+					Return([]domain.UserStoredData{}, nil)
+
+				suite.serverApi.
+					EXPECT().
+					GetChanges(gomock.Any(), int64(0), changesPageSize).
 					Return(nil, domain.ErrInternal)
 			},
 			err: domain.ErrInternal,
@@ -146,10 +221,6 @@ func (suite *baseSyncerTestSuite) TestBaseSyncer_Sync() {
 			name: "err getting data from client",
 			prepare: func() {
 				suite.session.SetToken("some-token")
-				suite.serverApi.
-					EXPECT().
-					GetAll(gomock.Any()).
-					Return([]domain.UserStoredData{}, nil)
 
 				suite.localService.
 					EXPECT().
@@ -163,20 +234,27 @@ func (suite *baseSyncerTestSuite) TestBaseSyncer_Sync() {
 			prepare: func() {
 				suite.session.SetToken("some-token")
 
-				suite.serverApi.
-					EXPECT().
-					GetAll(gomock.Any()).
-					Return([]domain.UserStoredData{{ID: 5, Version: 1}}, nil)
-
 				suite.localService.
 					EXPECT().
 					GetAll(gomock.Any()).
-					Return([]domain.UserStoredData{{ID: 3, Version: 1}, {ID: 4, Version: 1}, {ID: 5, Version: 1}}, nil)
+					Return([]domain.UserStoredData{
+						{ID: 3, UUID: uuid3, Version: 1},
+						{ID: 4, UUID: uuid4, Version: 1},
+						{ID: 5, UUID: uuid5, Version: 1},
+					}, nil)
 
-				suite.localService.
+				suite.serverApi.
 					EXPECT().
-					DeleteBatch(gomock.Any(), []int{3, 4}).
-					Return(nil)
+					GetChanges(gomock.Any(), int64(0), changesPageSize).
+					Return(&domain.ChangeFeed{
+						Changes: []domain.DataChange{
+							{Seq: 8, ID: 4, UUID: uuid4, Deleted: true},
+							{Seq: 9, ID: 3, UUID: uuid3, Deleted: true},
+						},
+						Cursor: 9,
+					}, nil)
+
+				suite.expectApplySync(domain.LocalSyncChanges{Deleted: []string{uuid3, uuid4}})
 			},
 			err: nil,
 		},
@@ -184,23 +262,39 @@ func (suite *baseSyncerTestSuite) TestBaseSyncer_Sync() {
 			name: "delete from server",
 			prepare: func() {
 				suite.session.SetToken("some-token")
-				suite.session.AddDeleted(5)
-				suite.session.AddDeleted(6)
+				suite.session.AddDeleted(uuid6, 1)
+				suite.session.AddDeleted(uuid5, 1)
 
-				suite.serverApi.
+				suite.localService.
 					EXPECT().
 					GetAll(gomock.Any()).
-					Return([]domain.UserStoredData{{ID: 5, Version: 1}, {ID: 6, Version: 1}, {ID: 7, Version: 1}}, nil)
+					Return([]domain.UserStoredData{{ID: 7, UUID: uuid7, Version: 1}}, nil)
 
-				suite.localService.
+				suite.serverApi.
 					EXPECT().
-					GetAll(gomock.Any()).
-					Return([]domain.UserStoredData{{ID: 7, Version: 1}}, nil)
+					GetChanges(gomock.Any(), int64(0), changesPageSize).
+					Return(&domain.ChangeFeed{
+						Changes: []domain.DataChange{
+							{Seq: 3, ID: 6, UUID: uuid6, Data: &domain.UserStoredData{ID: 6, UUID: uuid6, Version: 2}},
+						},
+						Cursor: 3,
+					}, nil)
 
 				suite.serverApi.
 					EXPECT().
-					DeleteBatch(gomock.Any(), []int{5, 6}).
-					Return(nil)
+					Push(gomock.Any(), []domain.SyncPushItem{
+						{UUID: uuid5, Operation: domain.SyncOperationDelete, Version: 1},
+						{UUID: uuid6, Operation: domain.SyncOperationDelete, Version: 2},
+					}).
+					Return(&domain.SyncPushResponse{
+						Applied: true,
+						Results: []domain.SyncPushResult{
+							{UUID: uuid5, Status: domain.SyncPushStatusApplied, ID: 5},
+							{UUID: uuid6, Status: domain.SyncPushStatusApplied, ID: 6},
+						},
+					}, nil)
+
+				suite.expectApplySync(domain.LocalSyncChanges{})
 			},
 			err: nil,
 		},
@@ -210,6 +304,7 @@ func (suite *baseSyncerTestSuite) TestBaseSyncer_Sync() {
 				suite.session.SetToken("some-token")
 				addToClientData := domain.UserStoredData{
 					ID:       5,
+					UUID:     uuid5,
 					Version:  1,
 					DataType: domain.TextDataType,
 					Data: domain.TextData{
@@ -217,25 +312,22 @@ func (suite *baseSyncerTestSuite) TestBaseSyncer_Sync() {
 					},
 				}
 
-				suite.serverApi.
-					EXPECT().
-					GetAll(gomock.Any()).
-					Return([]domain.UserStoredData{addToClientData, {ID: 6, Version: 1}}, nil)
-
 				suite.localService.
 					EXPECT().
 					GetAll(gomock.Any()).
-					Return([]domain.UserStoredData{{ID: 6, Version: 1}}, nil)
+					Return([]domain.UserStoredData{{ID: 6, UUID: uuid6, Version: 1}}, nil)
 
-				suite.localService.
+				suite.serverApi.
 					EXPECT().
-					Add(gomock.Any(), addToClientData.DataType, addToClientData.Data, "").
-					Return(&addToClientData, nil)
+					GetChanges(gomock.Any(), int64(0), changesPageSize).
+					Return(&domain.ChangeFeed{
+						Changes: []domain.DataChange{
+							{Seq: 1, ID: addToClientData.ID, UUID: addToClientData.UUID, Data: &addToClientData},
+						},
+						Cursor: 1,
+					}, nil)
 
-				suite.localRepository.
-					EXPECT().
-					SyncUpdate(gomock.Any(), addToClientData.ID, addToClientData.ID, addToClientData.Version).
-					Return(nil)
+				suite.expectApplySync(domain.LocalSyncChanges{Added: []domain.UserStoredData{addToClientData}})
 			},
 			err: nil,
 		},
@@ -244,19 +336,14 @@ func (suite *baseSyncerTestSuite) TestBaseSyncer_Sync() {
 			prepare: func() {
 				suite.session.SetToken("some-token")
 				addToServerData := domain.UserStoredData{
-					ID:       -5,
-					Version:  1,
+					UUID:     uuid5,
+					Version:  -1,
 					DataType: domain.TextDataType,
 					Data: domain.TextData{
 						Text: "Text",
 					},
 				}
 
-				suite.serverApi.
-					EXPECT().
-					GetAll(gomock.Any()).
-					Return([]domain.UserStoredData{}, nil)
-
 				suite.localService.
 					EXPECT().
 					GetAll(gomock.Any()).
@@ -264,13 +351,31 @@ func (suite *baseSyncerTestSuite) TestBaseSyncer_Sync() {
 
 				suite.serverApi.
 					EXPECT().
-					Add(gomock.Any(), addToServerData).
-					Return(&domain.UserStoredData{ID: 10, Version: 1}, nil)
+					GetChanges(gomock.Any(), int64(0), changesPageSize).
+					Return(&domain.ChangeFeed{Changes: []domain.DataChange{}}, nil)
 
-				suite.localRepository.
+				suite.serverApi.
 					EXPECT().
-					SyncUpdate(gomock.Any(), addToServerData.ID, 10, 1).
-					Return(nil)
+					Push(gomock.Any(), []domain.SyncPushItem{
+						{
+							UUID:      addToServerData.UUID,
+							Operation: domain.SyncOperationCreate,
+							DataType:  addToServerData.DataType,
+							Data:      addToServerData.Data,
+						},
+					}).
+					Return(&domain.SyncPushResponse{
+						Applied: true,
+						Results: []domain.SyncPushResult{
+							{UUID: addToServerData.UUID, Status: domain.SyncPushStatusApplied, ID: 10, Version: 1},
+						},
+					}, nil)
+
+				suite.expectApplySync(domain.LocalSyncChanges{
+					Synced: []domain.SyncPushResult{
+						{UUID: addToServerData.UUID, Status: domain.SyncPushStatusApplied, ID: 10, Version: 1},
+					},
+				})
 			},
 			err: nil,
 		},
@@ -280,6 +385,7 @@ func (suite *baseSyncerTestSuite) TestBaseSyncer_Sync() {
 				suite.session.SetToken("some-token")
 				editOnClientData := domain.UserStoredData{
 					ID:      1,
+					UUID:    uuid1,
 					Version: 2,
 					Data: domain.TextData{
 						Text: "123",
@@ -287,25 +393,22 @@ func (suite *baseSyncerTestSuite) TestBaseSyncer_Sync() {
 					Meta: "123",
 				}
 
-				suite.serverApi.
-					EXPECT().
-					GetAll(gomock.Any()).
-					Return([]domain.UserStoredData{editOnClientData}, nil)
-
 				suite.localService.
 					EXPECT().
 					GetAll(gomock.Any()).
-					Return([]domain.UserStoredData{{ID: 1, Version: 1}}, nil)
+					Return([]domain.UserStoredData{{ID: 1, UUID: uuid1, Version: 1}}, nil)
 
-				suite.localService.
+				suite.serverApi.
 					EXPECT().
-					UpdateByID(gomock.Any(), editOnClientData.ID, editOnClientData.Data, editOnClientData.Meta).
-					Return(nil, nil)
+					GetChanges(gomock.Any(), int64(0), changesPageSize).
+					Return(&domain.ChangeFeed{
+						Changes: []domain.DataChange{
+							{Seq: 4, ID: editOnClientData.ID, UUID: editOnClientData.UUID, Data: &editOnClientData},
+						},
+						Cursor: 4,
+					}, nil)
 
-				suite.localRepository.
-					EXPECT().
-					SyncUpdate(gomock.Any(), editOnClientData.ID, editOnClientData.ID, editOnClientData.Version).
-					Return(nil)
+				suite.expectApplySync(domain.LocalSyncChanges{Updated: []domain.UserStoredData{editOnClientData}})
 			},
 			err: nil,
 		},
@@ -313,9 +416,10 @@ func (suite *baseSyncerTestSuite) TestBaseSyncer_Sync() {
 			name: "edit on server",
 			prepare: func() {
 				suite.session.SetToken("some-token")
-				suite.session.AddEdited(1)
+				suite.session.AddEdited(uuid1)
 				editOnServer := domain.UserStoredData{
 					ID:      1,
+					UUID:    uuid1,
 					Version: 1,
 					Data: domain.TextData{
 						Text: "123",
@@ -323,28 +427,152 @@ func (suite *baseSyncerTestSuite) TestBaseSyncer_Sync() {
 					Meta: "123",
 				}
 
-				suite.serverApi.
+				suite.localService.
 					EXPECT().
 					GetAll(gomock.Any()).
-					Return([]domain.UserStoredData{{ID: 1, Version: 1}}, nil)
+					Return([]domain.UserStoredData{editOnServer}, nil)
+
+				suite.serverApi.
+					EXPECT().
+					GetChanges(gomock.Any(), int64(0), changesPageSize).
+					Return(&domain.ChangeFeed{Changes: []domain.DataChange{}}, nil)
+
+				suite.serverApi.
+					EXPECT().
+					Push(gomock.Any(), []domain.SyncPushItem{
+						{
+							UUID:      editOnServer.UUID,
+							Operation: domain.SyncOperationUpdate,
+							Version:   editOnServer.Version,
+							Data:      editOnServer.Data,
+							Meta:      editOnServer.Meta,
+						},
+					}).
+					Return(&domain.SyncPushResponse{
+						Applied: true,
+						Results: []domain.SyncPushResult{
+							{UUID: editOnServer.UUID, Status: domain.SyncPushStatusApplied, ID: 1, Version: 2},
+						},
+					}, nil)
+
+				suite.expectApplySync(domain.LocalSyncChanges{
+					Synced: []domain.SyncPushResult{
+						{UUID: editOnServer.UUID, Status: domain.SyncPushStatusApplied, ID: 1, Version: 2},
+					},
+				})
+			},
+			err: nil,
+		},
+		{
+			name: "merge fields changed on different sides",
+			prepare: func() {
+				suite.session.SetToken("some-token")
+				suite.session.AddEdited(uuid1)
+				clientData := domain.UserStoredData{
+					ID:       1,
+					UUID:     uuid1,
+					Version:  1,
+					DataType: domain.LogPassDataType,
+					Data:     domain.LogPassData{Login: "login", Password: "new-local"},
+					Meta:     "site",
+					Base: &domain.SyncBase{
+						Data: domain.LogPassData{Login: "login", Password: "old"},
+						Meta: "site",
+					},
+				}
+				serverData := domain.UserStoredData{
+					ID:       1,
+					UUID:     uuid1,
+					Version:  2,
+					DataType: domain.LogPassDataType,
+					Data:     domain.LogPassData{Login: "login", Password: "old"},
+					Meta:     "renamed site",
+				}
+				merged := domain.LogPassData{Login: "login", Password: "new-local"}
 
 				suite.localService.
 					EXPECT().
 					GetAll(gomock.Any()).
-					Return([]domain.UserStoredData{editOnServer}, nil)
+					Return([]domain.UserStoredData{clientData}, nil)
 
 				suite.serverApi.
 					EXPECT().
-					UpdateByID(gomock.Any(), editOnServer.ID, editOnServer.Data, editOnServer.Meta).
-					Return(&domain.UserStoredData{ID: 1, Version: 2}, nil)
+					GetChanges(gomock.Any(), int64(0), changesPageSize).
+					Return(&domain.ChangeFeed{
+						Changes: []domain.DataChange{{Seq: 2, ID: 1, UUID: uuid1, Data: &serverData}},
+						Cursor:  2,
+					}, nil)
 
-				suite.localRepository.
+				suite.serverApi.
+					EXPECT().
+					Push(gomock.Any(), []domain.SyncPushItem{
+						{
+							UUID:      uuid1,
+							Operation: domain.SyncOperationUpdate,
+							Version:   2,
+							DataType:  domain.LogPassDataType,
+							Data:      merged,
+							Meta:      "renamed site",
+						},
+					}).
+					Return(&domain.SyncPushResponse{
+						Applied: true,
+						Results: []domain.SyncPushResult{{UUID: uuid1, Status: domain.SyncPushStatusApplied, ID: 1, Version: 3}},
+					}, nil)
+
+				// merged record gets version server gave it on push
+				suite.localService.
 					EXPECT().
-					SyncUpdate(gomock.Any(), editOnServer.ID, 1, 2).
-					Return(nil)
+					ApplySync(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, changes domain.LocalSyncChanges) error {
+						suite.Equal([]domain.SyncPushResult{{UUID: uuid1, Status: domain.SyncPushStatusApplied, ID: 1, Version: 3}}, changes.Synced)
+						suite.Require().Len(changes.Updated, 1)
+						suite.Equal(uuid1, changes.Updated[0].UUID)
+						suite.Equal(3, changes.Updated[0].Version)
+						suite.Equal(merged, changes.Updated[0].Data)
+						suite.Equal("renamed site", changes.Updated[0].Meta)
+						return nil
+					})
 			},
 			err: nil,
 		},
+		{
+			name: "rejected push keeps local store untouched",
+			prepare: func() {
+				suite.session.SetToken("some-token")
+				suite.session.AddEdited(uuid1)
+				suite.session.SetSyncCursor(3)
+				localData := domain.UserStoredData{UUID: uuid3, Version: -1, DataType: domain.TextDataType}
+
+				suite.localService.
+					EXPECT().
+					GetAll(gomock.Any()).
+					Return([]domain.UserStoredData{
+						{ID: 1, UUID: uuid1, Version: 1},
+						{ID: 2, UUID: uuid2, Version: 1},
+						localData,
+					}, nil)
+
+				suite.serverApi.
+					EXPECT().
+					GetChanges(gomock.Any(), int64(3), changesPageSize).
+					Return(&domain.ChangeFeed{
+						Changes: []domain.DataChange{{Seq: 4, ID: 2, UUID: uuid2, Deleted: true}},
+						Cursor:  4,
+					}, nil)
+
+				suite.serverApi.
+					EXPECT().
+					Push(gomock.Any(), gomock.Len(2)).
+					Return(&domain.SyncPushResponse{
+						Results: []domain.SyncPushResult{
+							{UUID: uuid1, Status: domain.SyncPushStatusConflict, ID: 1, Version: 3},
+							{UUID: localData.UUID, Status: domain.SyncPushStatusSkipped},
+						},
+					}, nil)
+			},
+			err: domain.ErrSyncPushRejected,
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -355,3 +583,376 @@ func (suite *baseSyncerTestSuite) TestBaseSyncer_Sync() {
 		})
 	}
 }
+
+func (suite *baseSyncerTestSuite) TestBaseSyncer_SyncCursor() {
+	testCases := []struct {
+		name           string
+		prepare        func()
+		expectedCursor int64
+	}{
+		{
+			name: "all pages are requested starting from saved cursor",
+			prepare: func() {
+				suite.session.SetToken("some-token")
+				suite.session.SetSyncCursor(10)
+
+				suite.localService.
+					EXPECT().
+					GetAll(gomock.Any()).
+					Return([]domain.UserStoredData{{ID: 1, UUID: uuid1, Version: 1}}, nil)
+
+				gomock.InOrder(
+					suite.serverApi.
+						EXPECT().
+						GetChanges(gomock.Any(), int64(10), changesPageSize).
+						Return(&domain.ChangeFeed{
+							Changes: []domain.DataChange{
+								{Seq: 20, ID: 1, UUID: uuid1, Data: &domain.UserStoredData{ID: 1, UUID: uuid1, Version: 2}},
+							},
+							Cursor:  20,
+							HasMore: true,
+						}, nil),
+					suite.serverApi.
+						EXPECT().
+						GetChanges(gomock.Any(), int64(20), changesPageSize).
+						Return(&domain.ChangeFeed{
+							Changes: []domain.DataChange{{Seq: 25, ID: 1, UUID: uuid1, Deleted: true}},
+							Cursor:  25,
+						}, nil),
+				)
+
+				suite.expectApplySync(domain.LocalSyncChanges{Deleted: []string{uuid1}})
+			},
+			expectedCursor: 25,
+		},
+		{
+			name: "collected cursor downloads whole vault",
+			prepare: func() {
+				suite.session.SetToken("some-token")
+				suite.session.SetSyncCursor(10)
+				suite.session.AddDeleted(uuid3, 1)
+
+				suite.localService.
+					EXPECT().
+					GetAll(gomock.Any()).
+					Return([]domain.UserStoredData{
+						{ID: 1, UUID: uuid1, Version: 1},
+						{ID: 2, UUID: uuid2, Version: 1},
+						{UUID: uuid4},
+					}, nil)
+
+				gomock.InOrder(
+					suite.serverApi.
+						EXPECT().
+						GetChanges(gomock.Any(), int64(10), changesPageSize).
+						Return(nil, domain.ErrSyncCursorExpired),
+					suite.serverApi.
+						EXPECT().
+						GetChanges(gomock.Any(), int64(0), changesPageSize).
+						Return(&domain.ChangeFeed{
+							Changes: []domain.DataChange{
+								{Seq: 30, ID: 1, UUID: uuid1, Data: &domain.UserStoredData{ID: 1, UUID: uuid1, Version: 1}},
+							},
+							Cursor: 30,
+						}, nil),
+				)
+
+				suite.serverApi.
+					EXPECT().
+					Push(gomock.Any(), []domain.SyncPushItem{{UUID: uuid4, Operation: domain.SyncOperationCreate}}).
+					Return(&domain.SyncPushResponse{
+						Applied: true,
+						Results: []domain.SyncPushResult{{UUID: uuid4, ID: 4, Version: 1}},
+					}, nil)
+
+				suite.expectApplySync(domain.LocalSyncChanges{
+					Synced:  []domain.SyncPushResult{{UUID: uuid4, ID: 4, Version: 1}},
+					Deleted: []string{uuid2},
+				})
+			},
+			expectedCursor: 30,
+		},
+		{
+			name: "deletions without version download whole vault",
+			prepare: func() {
+				suite.session.SetToken("some-token")
+				suite.session.SetSyncCursor(10)
+				suite.session.AddDeleted(uuid3, 0)
+
+				suite.localService.
+					EXPECT().
+					GetAll(gomock.Any()).
+					Return([]domain.UserStoredData{{ID: 1, UUID: uuid1, Version: 1}}, nil)
+
+				suite.serverApi.
+					EXPECT().
+					GetChanges(gomock.Any(), int64(0), changesPageSize).
+					Return(&domain.ChangeFeed{
+						Changes: []domain.DataChange{
+							{Seq: 30, ID: 1, UUID: uuid1, Data: &domain.UserStoredData{ID: 1, UUID: uuid1, Version: 1}},
+							{Seq: 31, ID: 3, UUID: uuid3, Data: &domain.UserStoredData{ID: 3, UUID: uuid3, Version: 2}},
+						},
+						Cursor: 31,
+					}, nil)
+
+				suite.serverApi.
+					EXPECT().
+					Push(gomock.Any(), []domain.SyncPushItem{{UUID: uuid3, Operation: domain.SyncOperationDelete, Version: 2}}).
+					Return(&domain.SyncPushResponse{
+						Applied: true,
+						Results: []domain.SyncPushResult{{UUID: uuid3, Status: domain.SyncPushStatusApplied, ID: 3}},
+					}, nil)
+
+				suite.expectApplySync(domain.LocalSyncChanges{})
+			},
+			expectedCursor: 31,
+		},
+		{
+			name: "empty local store is downloaded from the beginning",
+			prepare: func() {
+				suite.session.SetToken("some-token")
+				suite.session.SetSyncCursor(10)
+
+				suite.localService.
+					EXPECT().
+					GetAll(gomock.Any()).
+					Return([]domain.UserStoredData{}, nil)
+
+				suite.serverApi.
+					EXPECT().
+					GetChanges(gomock.Any(), int64(0), changesPageSize).
+					Return(&domain.ChangeFeed{Changes: []domain.DataChange{}, Cursor: 0}, nil)
+
+				suite.expectApplySync(domain.LocalSyncChanges{})
+			},
+			expectedCursor: 0,
+		},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.name, func() {
+			testCase.prepare()
+			suite.NoError(suite.syncer.Sync(context.Background()))
+			suite.Equal(testCase.expectedCursor, suite.session.GetSyncCursor())
+		})
+	}
+}
+
+func (suite *baseSyncerTestSuite) TestBaseSyncer_SyncLargeBatch() {
+	suite.session.SetToken("some-token")
+
+	localData := make([]domain.UserStoredData, 0, 1200)
+	results := make([]domain.SyncPushResult, 0, 1200)
+	for idx := 1; idx <= 1200; idx++ {
+		recordUUID := fmt.Sprintf("00000000-0000-4000-8000-%012d", idx)
+		localData = append(localData, domain.UserStoredData{
+			UUID:     recordUUID,
+			Version:  -1,
+			DataType: domain.TextDataType,
+			Data:     domain.TextData{Text: "text"},
+		})
+		results = append(results, domain.SyncPushResult{UUID: recordUUID, ID: idx, Version: 1})
+	}
+
+	suite.localService.
+		EXPECT().
+		GetAll(gomock.Any()).
+		Return(localData, nil)
+
+	suite.serverApi.
+		EXPECT().
+		GetChanges(gomock.Any(), int64(0), changesPageSize).
+		Return(&domain.ChangeFeed{Cursor: 1}, nil)
+
+	// the whole batch is one request, so server applies it in one transaction or not at all
+	suite.serverApi.
+		EXPECT().
+		Push(gomock.Any(), gomock.Len(len(localData))).
+		Return(&domain.SyncPushResponse{Applied: true, Results: results}, nil).
+		Times(1)
+
+	suite.expectApplySync(domain.LocalSyncChanges{Synced: results})
+
+	suite.NoError(suite.syncer.Sync(context.Background()))
+}
+
+func (suite *baseSyncerTestSuite) TestBaseSyncer_SyncConflictedCopy() {
+	suite.session.SetToken("some-token")
+	suite.session.AddEdited(uuid1)
+	suite.syncer.resolver = fixedResolver(KeepBoth)
+
+	clientData := domain.UserStoredData{
+		ID:       1,
+		UUID:     uuid1,
+		Version:  1,
+		DataType: domain.LogPassDataType,
+		Data:     domain.LogPassData{Login: "login", Password: "local"},
+		Meta:     "site",
+		Base: &domain.SyncBase{
+			Data: domain.LogPassData{Login: "login", Password: "old"},
+			Meta: "site",
+		},
+	}
+	serverData := domain.UserStoredData{
+		ID:       1,
+		UUID:     uuid1,
+		Version:  2,
+		DataType: domain.LogPassDataType,
+		Data:     domain.LogPassData{Login: "login", Password: "server"},
+		Meta:     "site",
+	}
+
+	suite.localService.
+		EXPECT().
+		GetAll(gomock.Any()).
+		Return([]domain.UserStoredData{clientData}, nil)
+
+	suite.serverApi.
+		EXPECT().
+		GetChanges(gomock.Any(), int64(0), changesPageSize).
+		Return(&domain.ChangeFeed{
+			Changes: []domain.DataChange{{Seq: 2, ID: 1, UUID: uuid1, Data: &serverData}},
+			Cursor:  2,
+		}, nil)
+
+	var copyData domain.UserStoredData
+	suite.localService.
+		EXPECT().
+		ApplySync(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, changes domain.LocalSyncChanges) error {
+			suite.Require().Len(changes.Updated, 1)
+			suite.Equal(serverData.Data, changes.Updated[0].Data)
+			suite.Equal(2, changes.Updated[0].Version)
+			suite.Require().Len(changes.Copies, 1)
+			copyData = changes.Copies[0]
+			return nil
+		})
+
+	suite.NoError(suite.syncer.Sync(context.Background()))
+
+	suite.Equal(domain.LogPassDataType, copyData.DataType)
+	suite.Equal(clientData.Data, copyData.Data)
+	suite.Contains(copyData.Meta, "site (conflicted copy")
+
+	conflicts := suite.session.GetConflicts()
+	suite.Require().Len(conflicts, 1)
+	suite.Equal(uuid1, conflicts[0].RecordUUID)
+	suite.Equal(copyData.UUID, conflicts[0].CopyUUID)
+	suite.Equal([]string{"password"}, conflicts[0].Fields)
+}
+
+func (suite *baseSyncerTestSuite) TestBaseSyncer_SyncEditedRecordDeletedOnServer() {
+	suite.session.SetToken("some-token")
+	suite.session.AddEdited(uuid1)
+
+	clientData := domain.UserStoredData{
+		ID:       1,
+		UUID:     uuid1,
+		Version:  1,
+		DataType: domain.TextDataType,
+		Data:     domain.TextData{Text: "local"},
+		Meta:     "note",
+	}
+
+	suite.localService.
+		EXPECT().
+		GetAll(gomock.Any()).
+		Return([]domain.UserStoredData{clientData}, nil)
+
+	suite.serverApi.
+		EXPECT().
+		GetChanges(gomock.Any(), int64(0), changesPageSize).
+		Return(&domain.ChangeFeed{
+			Changes: []domain.DataChange{{Seq: 2, ID: 1, UUID: uuid1, Deleted: true}},
+			Cursor:  2,
+		}, nil)
+
+	// local edit is kept as new record, which is pushed on next sync
+	var copyData domain.UserStoredData
+	suite.localService.
+		EXPECT().
+		ApplySync(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, changes domain.LocalSyncChanges) error {
+			suite.Equal([]string{uuid1}, changes.Deleted)
+			suite.Require().Len(changes.Copies, 1)
+			copyData = changes.Copies[0]
+			return nil
+		})
+
+	suite.NoError(suite.syncer.Sync(context.Background()))
+
+	suite.NotEqual(uuid1, copyData.UUID)
+	suite.Equal(clientData.Data, copyData.Data)
+
+	conflicts := suite.session.GetConflicts()
+	suite.Require().Len(conflicts, 1)
+	suite.Equal(uuid1, conflicts[0].RecordUUID)
+	suite.Equal(copyData.UUID, conflicts[0].CopyUUID)
+	suite.Equal([]string{domain.SyncConflictDeletedOnServer}, conflicts[0].Fields)
+}
+
+func (suite *baseSyncerTestSuite) TestBaseSyncer_SyncFailedLocalChanges() {
+	suite.session.SetToken("some-token")
+	suite.session.SetSyncCursor(3)
+	suite.session.AddEdited(uuid1)
+	applyErr := errors.New("disk is full")
+
+	suite.localService.
+		EXPECT().
+		GetAll(gomock.Any()).
+		Return([]domain.UserStoredData{{ID: 1, UUID: uuid1, Version: 1}}, nil)
+
+	suite.serverApi.
+		EXPECT().
+		GetChanges(gomock.Any(), int64(3), changesPageSize).
+		Return(&domain.ChangeFeed{Cursor: 3}, nil)
+
+	suite.serverApi.
+		EXPECT().
+		Push(gomock.Any(), gomock.Len(1)).
+		Return(&domain.SyncPushResponse{
+			Applied: true,
+			Results: []domain.SyncPushResult{{UUID: uuid1, Status: domain.SyncPushStatusApplied, ID: 1, Version: 2}},
+		}, nil)
+
+	suite.localService.
+		EXPECT().
+		ApplySync(gomock.Any(), gomock.Any()).
+		Return(applyErr)
+
+	// nothing of sync is saved, so record is pushed again on next sync
+	suite.Equal(applyErr, suite.syncer.Sync(context.Background()))
+	suite.True(suite.session.IsEdited(uuid1))
+	suite.Equal(int64(3), suite.session.GetSyncCursor())
+}
+
+func (suite *baseSyncerTestSuite) TestBaseSyncer_Status() {
+	suite.session.SetToken("some-token")
+	suite.session.AddEdited(uuid1)
+	suite.session.AddDeleted(uuid2, 1)
+	syncErr := errors.New("server is down")
+
+	suite.localService.
+		EXPECT().
+		GetAll(gomock.Any()).
+		Return([]domain.UserStoredData{
+			{ID: 1, UUID: uuid1, Version: 1},
+			{UUID: uuid4, Version: -1},
+			{ID: 3, UUID: uuid3, Version: 1},
+		}, nil).
+		Times(2)
+
+	suite.serverApi.
+		EXPECT().
+		GetChanges(gomock.Any(), int64(0), changesPageSize).
+		Return(nil, syncErr)
+
+	suite.Equal(syncErr, suite.syncer.Sync(context.Background()))
+
+	status, err := suite.syncer.Status(context.Background())
+	suite.NoError(err)
+	suite.Equal(3, status.PendingChanges)
+	suite.Equal(syncErr, status.LastError)
+	suite.True(status.LastSuccessAt.IsZero())
+	suite.False(status.LastAttemptAt.IsZero())
+}
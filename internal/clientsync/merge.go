@@ -0,0 +1,181 @@
+package clientsync
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+)
+
+// metaField - meta is merged as one more field of record
+const metaField = "meta"
+
+// recordFields - data fields of record as json values plus meta
+type recordFields map[string]json.RawMessage
+
+type mergeResult struct {
+	// Record - merged record with server id and version
+	Record domain.UserStoredData
+	// ChangesServer - merged record differs from server one and must be pushed
+	ChangesServer bool
+	// ChangesClient - merged record differs from local one and must be saved locally
+	ChangesClient bool
+	// CopyFields - conflicting fields resolved by keeping both versions, local record must be saved as copy
+	CopyFields []string
+}
+
+// mergeRecords - three-way merge of record changed both on client and on server. Field changed only on one
+// side is taken from that side, field changed on both sides to different values is resolved by conflict resolver.
+// Without base every differing field is conflict
+func (s *BaseSyncer) mergeRecords(client domain.UserStoredData, server domain.UserStoredData) (*mergeResult, error) {
+	clientFields, err := toRecordFields(client.Data, client.Meta)
+	if err != nil {
+		return nil, err
+	}
+
+	serverFields, err := toRecordFields(server.Data, server.Meta)
+	if err != nil {
+		return nil, err
+	}
+
+	var baseFields recordFields
+	if client.Base != nil {
+		baseFields, err = toRecordFields(client.Base.Data, client.Base.Meta)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	mergedFields := make(recordFields, len(serverFields))
+	var copyFields []string
+	for _, field := range fieldNames(clientFields, serverFields) {
+		clientValue, serverValue := clientFields[field], serverFields[field]
+
+		switch {
+		case bytes.Equal(clientValue, serverValue):
+			mergedFields[field] = serverValue
+		case baseFields != nil && bytes.Equal(baseFields[field], clientValue):
+			mergedFields[field] = serverValue
+		case baseFields != nil && bytes.Equal(baseFields[field], serverValue):
+			mergedFields[field] = clientValue
+		default:
+			_, secret := secretFields[field]
+			resolution, err := s.resolver.ResolveField(FieldConflict{
+				RecordUUID:      server.UUID,
+				DataType:        server.DataType,
+				Field:           field,
+				Secret:          secret,
+				Client:          clientValue,
+				Server:          serverValue,
+				ClientUpdatedAt: client.UpdatedAt,
+				ServerUpdatedAt: server.UpdatedAt,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			switch resolution {
+			case KeepClient:
+				mergedFields[field] = clientValue
+			case KeepBoth:
+				mergedFields[field] = serverValue
+				copyFields = append(copyFields, field)
+			default:
+				mergedFields[field] = serverValue
+			}
+		}
+	}
+
+	merged := server
+	merged.Base = nil
+	merged.Data, merged.Meta, err = mergedFields.toData(server.DataType)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mergeResult{
+		Record:        merged,
+		ChangesServer: !mergedFields.equal(serverFields),
+		ChangesClient: !mergedFields.equal(clientFields),
+		CopyFields:    copyFields,
+	}, nil
+}
+
+func toRecordFields(data interface{}, meta string) (recordFields, error) {
+	rawData, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make(recordFields)
+	if err := json.Unmarshal(rawData, &fields); err != nil {
+		return nil, err
+	}
+
+	fields[metaField], err = json.Marshal(meta)
+	if err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
+
+func (fields recordFields) toData(dataType string) (interface{}, string, error) {
+	var meta string
+	if err := json.Unmarshal(fields[metaField], &meta); err != nil {
+		return nil, "", err
+	}
+
+	dataFields := make(recordFields, len(fields))
+	for field, value := range fields {
+		if field != metaField {
+			dataFields[field] = value
+		}
+	}
+
+	rawData, err := json.Marshal(dataFields)
+	if err != nil {
+		return nil, "", err
+	}
+
+	data, err := domain.ParseUserStoredData(dataType, rawData)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return data, meta, nil
+}
+
+// equal - records with equal fields have the same data and meta
+func (fields recordFields) equal(other recordFields) bool {
+	if len(fields) != len(other) {
+		return false
+	}
+
+	for field, value := range fields {
+		if !bytes.Equal(value, other[field]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// fieldNames - sorted names of fields of both records, so user is asked about conflicts in stable order
+func fieldNames(records ...recordFields) []string {
+	unique := make(map[string]struct{})
+	for _, fields := range records {
+		for field := range fields {
+			unique[field] = struct{}{}
+		}
+	}
+
+	names := make([]string, 0, len(unique))
+	for field := range unique {
+		names = append(names, field)
+	}
+	sort.Strings(names)
+
+	return names
+}
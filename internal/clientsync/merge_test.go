@@ -0,0 +1,212 @@
+package clientsync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+)
+
+func TestBaseSyncer_mergeRecords(t *testing.T) {
+	now := time.Now()
+
+	testCases := []struct {
+		name          string
+		resolver      ConflictResolver
+		client        domain.UserStoredData
+		server        domain.UserStoredData
+		expected      domain.LogPassData
+		expectedMeta  string
+		changesServer bool
+		changesClient bool
+		copyFields    []string
+		err           error
+	}{
+		{
+			name:     "fields changed on different sides are both kept",
+			resolver: fixedResolver(KeepServer),
+			client: domain.UserStoredData{
+				DataType: domain.LogPassDataType,
+				Data:     domain.LogPassData{Login: "new-login", Password: "password"},
+				Meta:     "meta",
+				Base:     &domain.SyncBase{Data: domain.LogPassData{Login: "login", Password: "password"}, Meta: "meta"},
+			},
+			server: domain.UserStoredData{
+				DataType: domain.LogPassDataType,
+				Data:     domain.LogPassData{Login: "login", Password: "new-password"},
+				Meta:     "new-meta",
+			},
+			expected:      domain.LogPassData{Login: "new-login", Password: "new-password"},
+			expectedMeta:  "new-meta",
+			changesServer: true,
+			changesClient: true,
+		},
+		{
+			name: "same change on both sides is not conflict",
+			client: domain.UserStoredData{
+				DataType: domain.LogPassDataType,
+				Data:     domain.LogPassData{Login: "login", Password: "new"},
+				Base:     &domain.SyncBase{Data: domain.LogPassData{Login: "login", Password: "old"}},
+			},
+			server: domain.UserStoredData{
+				DataType: domain.LogPassDataType,
+				Data:     domain.LogPassData{Login: "login", Password: "new"},
+			},
+			expected: domain.LogPassData{Login: "login", Password: "new"},
+		},
+		{
+			name:     "conflict prefer server",
+			resolver: fixedResolver(KeepServer),
+			client: domain.UserStoredData{
+				DataType: domain.LogPassDataType,
+				Data:     domain.LogPassData{Login: "login", Password: "client"},
+				Base:     &domain.SyncBase{Data: domain.LogPassData{Login: "login", Password: "old"}},
+			},
+			server: domain.UserStoredData{
+				DataType: domain.LogPassDataType,
+				Data:     domain.LogPassData{Login: "login", Password: "server"},
+			},
+			expected:      domain.LogPassData{Login: "login", Password: "server"},
+			changesClient: true,
+		},
+		{
+			name:     "conflict prefer client",
+			resolver: fixedResolver(KeepClient),
+			client: domain.UserStoredData{
+				DataType: domain.LogPassDataType,
+				Data:     domain.LogPassData{Login: "login", Password: "client"},
+				Base:     &domain.SyncBase{Data: domain.LogPassData{Login: "login", Password: "old"}},
+			},
+			server: domain.UserStoredData{
+				DataType: domain.LogPassDataType,
+				Data:     domain.LogPassData{Login: "login", Password: "server"},
+			},
+			expected:      domain.LogPassData{Login: "login", Password: "client"},
+			changesServer: true,
+		},
+		{
+			name:     "conflict prefer newest",
+			resolver: newestResolver{},
+			client: domain.UserStoredData{
+				DataType:  domain.LogPassDataType,
+				Data:      domain.LogPassData{Login: "client", Password: "client"},
+				UpdatedAt: now,
+			},
+			server: domain.UserStoredData{
+				DataType:  domain.LogPassDataType,
+				Data:      domain.LogPassData{Login: "server", Password: "server"},
+				UpdatedAt: now.Add(time.Minute),
+			},
+			expected:      domain.LogPassData{Login: "server", Password: "server"},
+			changesClient: true,
+		},
+		{
+			name:     "without base every difference is conflict",
+			resolver: fixedResolver(KeepClient),
+			client: domain.UserStoredData{
+				DataType: domain.LogPassDataType,
+				Data:     domain.LogPassData{Login: "login", Password: "client"},
+				Meta:     "client",
+			},
+			server: domain.UserStoredData{
+				DataType: domain.LogPassDataType,
+				Data:     domain.LogPassData{Login: "login", Password: "server"},
+				Meta:     "server",
+			},
+			expected:      domain.LogPassData{Login: "login", Password: "client"},
+			expectedMeta:  "client",
+			changesServer: true,
+		},
+		{
+			name:     "conflict keep both",
+			resolver: fixedResolver(KeepBoth),
+			client: domain.UserStoredData{
+				DataType: domain.LogPassDataType,
+				Data:     domain.LogPassData{Login: "login", Password: "client"},
+				Base:     &domain.SyncBase{Data: domain.LogPassData{Login: "login", Password: "old"}},
+			},
+			server: domain.UserStoredData{
+				DataType: domain.LogPassDataType,
+				Data:     domain.LogPassData{Login: "login", Password: "server"},
+			},
+			expected:      domain.LogPassData{Login: "login", Password: "server"},
+			changesClient: true,
+			copyFields:    []string{"password"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			syncer := &BaseSyncer{resolver: testCase.resolver}
+
+			result, err := syncer.mergeRecords(testCase.client, testCase.server)
+			assert.Equal(t, testCase.err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, testCase.expected, result.Record.Data)
+			assert.Equal(t, testCase.expectedMeta, result.Record.Meta)
+			assert.Equal(t, testCase.changesServer, result.ChangesServer)
+			assert.Equal(t, testCase.changesClient, result.ChangesClient)
+			assert.Equal(t, testCase.copyFields, result.CopyFields)
+		})
+	}
+}
+
+func TestNewConflictResolver(t *testing.T) {
+	conflict := FieldConflict{
+		Field:           "login",
+		ClientUpdatedAt: time.Now(),
+		ServerUpdatedAt: time.Now().Add(-time.Minute),
+	}
+
+	testCases := []struct {
+		name     string
+		policy   string
+		expected ConflictResolution
+		err      error
+	}{
+		{
+			name:     "conflicted copy",
+			policy:   MergePolicyConflictedCopy,
+			expected: KeepBoth,
+		},
+		{
+			name:     "prefer server",
+			policy:   MergePolicyPreferServer,
+			expected: KeepServer,
+		},
+		{
+			name:     "prefer client",
+			policy:   MergePolicyPreferClient,
+			expected: KeepClient,
+		},
+		{
+			name:     "prefer newest",
+			policy:   MergePolicyPreferNewest,
+			expected: KeepClient,
+		},
+		{
+			name:   "invalid policy",
+			policy: "unknown",
+			err:    domain.ErrInvalidMergePolicy,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			resolver, err := NewConflictResolver(testCase.policy)
+			assert.Equal(t, testCase.err, err)
+			if err != nil {
+				return
+			}
+
+			resolution, err := resolver.ResolveField(conflict)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expected, resolution)
+		})
+	}
+}
@@ -0,0 +1,141 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/clientsync/auto.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/clientsync/auto.go -destination=./internal/clientsync/mocks/auto.go
+//
+// Package mock_clientsync is a generated GoMock package.
+package mock_clientsync
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/MowlCoder/goph-keeper/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockautoSyncTarget is a mock of autoSyncTarget interface.
+type MockautoSyncTarget struct {
+	ctrl     *gomock.Controller
+	recorder *MockautoSyncTargetMockRecorder
+}
+
+// MockautoSyncTargetMockRecorder is the mock recorder for MockautoSyncTarget.
+type MockautoSyncTargetMockRecorder struct {
+	mock *MockautoSyncTarget
+}
+
+// NewMockautoSyncTarget creates a new mock instance.
+func NewMockautoSyncTarget(ctrl *gomock.Controller) *MockautoSyncTarget {
+	mock := &MockautoSyncTarget{ctrl: ctrl}
+	mock.recorder = &MockautoSyncTargetMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockautoSyncTarget) EXPECT() *MockautoSyncTargetMockRecorder {
+	return m.recorder
+}
+
+// PendingChanges mocks base method.
+func (m *MockautoSyncTarget) PendingChanges(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PendingChanges", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PendingChanges indicates an expected call of PendingChanges.
+func (mr *MockautoSyncTargetMockRecorder) PendingChanges(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingChanges", reflect.TypeOf((*MockautoSyncTarget)(nil).PendingChanges), ctx)
+}
+
+// Sync mocks base method.
+func (m *MockautoSyncTarget) Sync(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sync", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Sync indicates an expected call of Sync.
+func (mr *MockautoSyncTargetMockRecorder) Sync(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sync", reflect.TypeOf((*MockautoSyncTarget)(nil).Sync), ctx)
+}
+
+// MockserverEvents is a mock of serverEvents interface.
+type MockserverEvents struct {
+	ctrl     *gomock.Controller
+	recorder *MockserverEventsMockRecorder
+}
+
+// MockserverEventsMockRecorder is the mock recorder for MockserverEvents.
+type MockserverEventsMockRecorder struct {
+	mock *MockserverEvents
+}
+
+// NewMockserverEvents creates a new mock instance.
+func NewMockserverEvents(ctrl *gomock.Controller) *MockserverEvents {
+	mock := &MockserverEvents{ctrl: ctrl}
+	mock.recorder = &MockserverEventsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockserverEvents) EXPECT() *MockserverEventsMockRecorder {
+	return m.recorder
+}
+
+// Listen mocks base method.
+func (m *MockserverEvents) Listen(ctx context.Context, onChange func(domain.DataChangeEvent)) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Listen", ctx, onChange)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Listen indicates an expected call of Listen.
+func (mr *MockserverEventsMockRecorder) Listen(ctx, onChange any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Listen", reflect.TypeOf((*MockserverEvents)(nil).Listen), ctx, onChange)
+}
+
+// MockcommandExecutor is a mock of commandExecutor interface.
+type MockcommandExecutor struct {
+	ctrl     *gomock.Controller
+	recorder *MockcommandExecutorMockRecorder
+}
+
+// MockcommandExecutorMockRecorder is the mock recorder for MockcommandExecutor.
+type MockcommandExecutorMockRecorder struct {
+	mock *MockcommandExecutor
+}
+
+// NewMockcommandExecutor creates a new mock instance.
+func NewMockcommandExecutor(ctrl *gomock.Controller) *MockcommandExecutor {
+	mock := &MockcommandExecutor{ctrl: ctrl}
+	mock.recorder = &MockcommandExecutorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcommandExecutor) EXPECT() *MockcommandExecutorMockRecorder {
+	return m.recorder
+}
+
+// RunExclusive mocks base method.
+func (m *MockcommandExecutor) RunExclusive(fn func()) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RunExclusive", fn)
+}
+
+// RunExclusive indicates an expected call of RunExclusive.
+func (mr *MockcommandExecutorMockRecorder) RunExclusive(fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunExclusive", reflect.TypeOf((*MockcommandExecutor)(nil).RunExclusive), fn)
+}
@@ -0,0 +1,134 @@
+package clientsync
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+	"github.com/MowlCoder/goph-keeper/pkg/input"
+)
+
+// Policies of resolving field changed differently on client and on server since last sync
+const (
+	MergePolicyConflictedCopy = "conflicted-copy"
+	MergePolicyPreferNewest   = "prefer-newest"
+	MergePolicyPreferServer   = "prefer-server"
+	MergePolicyPreferClient   = "prefer-client"
+	MergePolicyInteractive    = "interactive"
+)
+
+type ConflictResolution int
+
+const (
+	// KeepServer - server value of field is kept, local one is lost
+	KeepServer ConflictResolution = iota
+	// KeepClient - local value of field is kept and pushed to server
+	KeepClient
+	// KeepBoth - record gets server value, local version of record is saved as conflicted copy
+	KeepBoth
+)
+
+// FieldConflict - field changed on both sides to different values since last sync. Values of secret
+// fields must not be shown to anybody
+type FieldConflict struct {
+	RecordUUID      string
+	DataType        string
+	Field           string
+	Secret          bool
+	Client          json.RawMessage
+	Server          json.RawMessage
+	ClientUpdatedAt time.Time
+	ServerUpdatedAt time.Time
+}
+
+// ConflictResolver - decides which value of conflicting field is kept. Sync can be run by scripts and in
+// background, so only resolvers created for interactive policy may wait for user
+type ConflictResolver interface {
+	ResolveField(conflict FieldConflict) (ConflictResolution, error)
+}
+
+// secretFields - values of these fields are never printed when user resolves conflict
+var secretFields = map[string]struct{}{
+	"password": {},
+	"number":   {},
+	"cvv":      {},
+	"text":     {},
+	"content":  {},
+}
+
+// NewConflictResolver - resolver for merge policy from client config
+func NewConflictResolver(policy string) (ConflictResolver, error) {
+	switch policy {
+	case MergePolicyConflictedCopy:
+		return fixedResolver(KeepBoth), nil
+	case MergePolicyPreferServer:
+		return fixedResolver(KeepServer), nil
+	case MergePolicyPreferClient:
+		return fixedResolver(KeepClient), nil
+	case MergePolicyPreferNewest:
+		return newestResolver{}, nil
+	case MergePolicyInteractive:
+		return InteractiveResolver{}, nil
+	default:
+		return nil, domain.ErrInvalidMergePolicy
+	}
+}
+
+// fixedResolver - resolves every conflict the same way
+type fixedResolver ConflictResolution
+
+func (r fixedResolver) ResolveField(conflict FieldConflict) (ConflictResolution, error) {
+	return ConflictResolution(r), nil
+}
+
+// newestResolver - keeps value of record changed later, relies on clocks of client and server
+type newestResolver struct{}
+
+func (r newestResolver) ResolveField(conflict FieldConflict) (ConflictResolution, error) {
+	if conflict.ClientUpdatedAt.After(conflict.ServerUpdatedAt) {
+		return KeepClient, nil
+	}
+
+	return KeepServer, nil
+}
+
+// InteractiveResolver - asks user in console about every conflicting field
+type InteractiveResolver struct{}
+
+func (r InteractiveResolver) ResolveField(conflict FieldConflict) (ConflictResolution, error) {
+	fmt.Printf("\nField %q of %s record %s was changed both locally and on server\n", conflict.Field, conflict.DataType, domain.ShortUUID(conflict.RecordUUID))
+
+	if conflict.Secret {
+		fmt.Println("Values are secret and not shown")
+	} else {
+		fmt.Println("Local: ", printableValue(conflict.Client))
+		fmt.Println("Server:", printableValue(conflict.Server))
+	}
+
+	for {
+		answer, err := input.GetConsoleInput("Keep 'client', 'server' or 'both' (conflicted copy): ", "")
+		if err != nil {
+			return 0, err
+		}
+
+		switch strings.ToLower(answer) {
+		case "client":
+			return KeepClient, nil
+		case "server":
+			return KeepServer, nil
+		case "both":
+			return KeepBoth, nil
+		}
+	}
+}
+
+func printableValue(value json.RawMessage) string {
+	var text string
+	if err := json.Unmarshal(value, &text); err == nil {
+		return text
+	}
+
+	return string(value)
+}
@@ -0,0 +1,91 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+	"github.com/MowlCoder/goph-keeper/pkg/input"
+)
+
+// Exit codes of client executing single command
+const (
+	ExitCodeOK       = 0
+	ExitCodeError    = 1
+	ExitCodeUsage    = 2
+	ExitCodeNotFound = 3
+)
+
+// ExitCode - exit code of client, which executed single command with given result
+func ExitCode(err error) int {
+	switch {
+	case err == nil, errors.Is(err, domain.ErrQuitApp):
+		return ExitCodeOK
+	case errors.Is(err, domain.ErrCommandNotFound),
+		errors.Is(err, domain.ErrInvalidCommandUsage),
+		errors.Is(err, domain.ErrInvalidInputValue),
+		errors.Is(err, domain.ErrInvalidRecordRef),
+		errors.Is(err, domain.ErrUnknownRecordField):
+		return ExitCodeUsage
+	case errors.Is(err, domain.ErrUserStoredDataNotFound),
+		errors.Is(err, domain.ErrNotFound),
+		errors.Is(err, domain.ErrAmbiguousRecordRef):
+		return ExitCodeNotFound
+	default:
+		return ExitCodeError
+	}
+}
+
+// parseFlags - split command arguments into positional ones and flags. Flag is given as --name=value or
+// --name value, every flag of commands has value, so flag without it is invalid usage instead of being
+// saved as some default. Everything after "--" is positional
+func parseFlags(args []string) ([]string, map[string]string, error) {
+	positional := make([]string, 0, len(args))
+	flags := make(map[string]string)
+
+	for idx := 0; idx < len(args); idx++ {
+		arg := args[idx]
+
+		if arg == "--" {
+			positional = append(positional, args[idx+1:]...)
+			break
+		}
+
+		if !strings.HasPrefix(arg, "--") || len(arg) == 2 {
+			positional = append(positional, arg)
+			continue
+		}
+
+		name, value, hasValue := strings.Cut(arg[2:], "=")
+		if !hasValue {
+			if idx+1 == len(args) || strings.HasPrefix(args[idx+1], "--") {
+				return nil, nil, fmt.Errorf("%w: flag --%s has no value", domain.ErrInvalidCommandUsage, name)
+			}
+
+			value = args[idx+1]
+			idx++
+		}
+
+		flags[name] = value
+	}
+
+	// stdin is read as a whole by the first of such flags, so the next one would silently get nothing
+	stdinFlags := make([]string, 0)
+	for name, value := range flags {
+		if value == input.StdinValue {
+			stdinFlags = append(stdinFlags, "--"+name)
+		}
+	}
+	if len(stdinFlags) > 1 {
+		sort.Strings(stdinFlags)
+		return nil, nil, fmt.Errorf(
+			"%w: only one flag can be read from stdin, got %s",
+			domain.ErrInvalidCommandUsage,
+			strings.Join(stdinFlags, ", "),
+		)
+	}
+
+	return positional, flags, nil
+}
@@ -3,8 +3,12 @@ package commands
 import (
 	"errors"
 	"fmt"
+	"sort"
+	"sync"
 
 	"github.com/MowlCoder/goph-keeper/internal/domain"
+	"github.com/MowlCoder/goph-keeper/internal/presenter"
+	"github.com/MowlCoder/goph-keeper/pkg/input"
 )
 
 type command struct {
@@ -12,20 +16,30 @@ type command struct {
 	Tag         string
 	Description string
 	Usage       string
-	Exec        func(args []string) error
+	Exec        func(args []string) (*presenter.Result, error)
 }
 
 // CommandManager - struct responsible for managing commands
 type CommandManager struct {
 	commands      map[string]command
 	commandsByTag map[string][]command
+
+	// execMu - commands are executed one by one and never together with background jobs
+	execMu    sync.Mutex
+	execHooks []func(name string)
+
+	presenter *presenter.Presenter
+
+	// recordRefs - uuids of records, which are completed as arguments of commands
+	recordRefs func() []string
 }
 
-// NewCommandManager - constructor for CommandManager struct
-func NewCommandManager() *CommandManager {
+// NewCommandManager - constructor for CommandManager struct, results of commands are printed by presenter
+func NewCommandManager(presenter *presenter.Presenter) *CommandManager {
 	manager := CommandManager{
 		commands:      make(map[string]command),
 		commandsByTag: make(map[string][]command),
+		presenter:     presenter,
 	}
 	manager.initAppCommands()
 
@@ -38,7 +52,7 @@ func (m *CommandManager) RegisterCommand(
 	description string,
 	tag string,
 	usage string,
-	exec func(args []string) error,
+	exec func(args []string) (*presenter.Result, error),
 ) {
 	cmd := command{
 		Name:        name,
@@ -52,24 +66,80 @@ func (m *CommandManager) RegisterCommand(
 	m.commandsByTag[tag] = append(m.commandsByTag[tag], cmd)
 }
 
-// ExecCommandWithName - execute exec function of command with given name
+// ResolveCommand - find command name in words of command line, so "lp get" is the same as "lp-get"
+func (m *CommandManager) ResolveCommand(words []string) (string, []string) {
+	if len(words) == 0 {
+		return "", nil
+	}
+
+	if len(words) > 1 {
+		if _, ok := m.commands[words[0]+"-"+words[1]]; ok {
+			return words[0] + "-" + words[1], words[2:]
+		}
+	}
+
+	return words[0], words[1:]
+}
+
+// ExecCommandWithName - execute exec function of command with given name. Flags in args are passed
+// to input, so handlers use them instead of asking user, only positional arguments are passed to command.
+// Nothing but result is written to output, so usage of invalid command is returned in error
 func (m *CommandManager) ExecCommandWithName(name string, args []string) error {
 	cmd, ok := m.commands[name]
 	if !ok {
-		fmt.Println("invalid command, type 'help' to get list of commands")
-		return nil
+		return fmt.Errorf("%w: %q, type 'help' to get list of commands", domain.ErrCommandNotFound, name)
+	}
+
+	positional, flags, err := parseFlags(args)
+	if err != nil {
+		return fmt.Errorf("%w, usage: %s", err, cmd.Usage)
+	}
+
+	result, err := m.exec(cmd, positional, flags)
+	if err == nil {
+		err = m.presenter.Present(result)
 	}
 
-	err := cmd.Exec(args)
+	for _, hook := range m.execHooks {
+		hook(cmd.Name)
+	}
 
 	if errors.Is(err, domain.ErrInvalidCommandUsage) {
-		fmt.Println("Usage:", cmd.Usage)
-		return nil
+		return fmt.Errorf("%w, usage: %s", err, cmd.Usage)
 	}
 
 	return err
 }
 
+// exec - run exec function of command. Input of value canceled by user cancels command
+func (m *CommandManager) exec(cmd command, positional []string, flags map[string]string) (*presenter.Result, error) {
+	m.execMu.Lock()
+	defer m.execMu.Unlock()
+
+	input.SetFlags(flags)
+	defer input.SetFlags(nil)
+
+	result, err := cmd.Exec(positional)
+	if errors.Is(err, input.ErrInputCanceled) {
+		return nil, domain.ErrCommandCanceled
+	}
+
+	return result, err
+}
+
+// AddExecHook - register function called after every executed command
+func (m *CommandManager) AddExecHook(hook func(name string)) {
+	m.execHooks = append(m.execHooks, hook)
+}
+
+// RunExclusive - run fn when no command is executing, commands wait until fn returns
+func (m *CommandManager) RunExclusive(fn func()) {
+	m.execMu.Lock()
+	defer m.execMu.Unlock()
+
+	fn()
+}
+
 func (m *CommandManager) initAppCommands() {
 	m.RegisterCommand(
 		"help",
@@ -88,21 +158,23 @@ func (m *CommandManager) initAppCommands() {
 	)
 }
 
-func (m *CommandManager) quitCommand(args []string) error {
-	return domain.ErrQuitApp
+func (m *CommandManager) quitCommand(args []string) (*presenter.Result, error) {
+	return nil, domain.ErrQuitApp
 }
 
-func (m *CommandManager) helpCommand(args []string) error {
-	fmt.Println("================================")
+func (m *CommandManager) helpCommand(args []string) (*presenter.Result, error) {
+	tags := make([]string, 0, len(m.commandsByTag))
+	for tag := range m.commandsByTag {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
 
-	for tag, commands := range m.commandsByTag {
-		fmt.Printf("%s commands:\n", tag)
-		for _, val := range commands {
-			fmt.Println(fmt.Sprintf("    %s - %s. Usage: %s", val.Name, val.Description, val.Usage))
+	result := presenter.NewList("Commands", "group", "name", "description", "usage")
+	for _, tag := range tags {
+		for _, cmd := range m.commandsByTag[tag] {
+			result.AddRow(tag, cmd.Name, cmd.Description, cmd.Usage)
 		}
 	}
 
-	fmt.Println("================================")
-
-	return nil
+	return result, nil
 }
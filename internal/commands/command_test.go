@@ -1,14 +1,20 @@
 package commands
 
 import (
+	"bytes"
 	"errors"
+	"io"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+	"github.com/MowlCoder/goph-keeper/internal/presenter"
+	"github.com/MowlCoder/goph-keeper/pkg/input"
 )
 
 func TestCommandManager_RegisterCommand(t *testing.T) {
-	commandManager := NewCommandManager()
+	commandManager := NewCommandManager(presenter.NewPresenter(presenter.FormatTable, io.Discard))
 
 	t.Run("add command", func(t *testing.T) {
 		commandManager.RegisterCommand(
@@ -16,8 +22,8 @@ func TestCommandManager_RegisterCommand(t *testing.T) {
 			"cmd description",
 			"tag",
 			"cmd",
-			func(args []string) error {
-				return nil
+			func(args []string) (*presenter.Result, error) {
+				return nil, nil
 			},
 		)
 
@@ -27,7 +33,7 @@ func TestCommandManager_RegisterCommand(t *testing.T) {
 }
 
 func TestCommandManager_ExecCommandWithName(t *testing.T) {
-	commandManager := NewCommandManager()
+	commandManager := NewCommandManager(presenter.NewPresenter(presenter.FormatTable, io.Discard))
 	err := errors.New("command error")
 	cmdName := "cmd"
 
@@ -36,8 +42,8 @@ func TestCommandManager_ExecCommandWithName(t *testing.T) {
 		"cmd description",
 		"tag",
 		"cmd",
-		func(args []string) error {
-			return err
+		func(args []string) (*presenter.Result, error) {
+			return nil, err
 		},
 	)
 
@@ -45,3 +51,231 @@ func TestCommandManager_ExecCommandWithName(t *testing.T) {
 		assert.Equal(t, err, commandManager.ExecCommandWithName(cmdName, []string{}))
 	})
 }
+
+func TestCommandManager_AddExecHook(t *testing.T) {
+	commandManager := NewCommandManager(presenter.NewPresenter(presenter.FormatTable, io.Discard))
+	executed := make([]string, 0)
+
+	commandManager.AddExecHook(func(name string) {
+		executed = append(executed, name)
+	})
+	commandManager.RegisterCommand(
+		"cmd",
+		"cmd description",
+		"tag",
+		"cmd",
+		func(args []string) (*presenter.Result, error) {
+			return nil, nil
+		},
+	)
+
+	t.Run("hook is called after command", func(t *testing.T) {
+		assert.NoError(t, commandManager.ExecCommandWithName("cmd", []string{}))
+		assert.Equal(t, []string{"cmd"}, executed)
+	})
+
+	t.Run("hook is not called for unknown command", func(t *testing.T) {
+		assert.ErrorIs(t, commandManager.ExecCommandWithName("unknown", []string{}), domain.ErrCommandNotFound)
+		assert.Equal(t, []string{"cmd"}, executed)
+	})
+}
+
+func TestCommandManager_ExecCommandWithFlags(t *testing.T) {
+	commandManager := NewCommandManager(presenter.NewPresenter(presenter.FormatTable, io.Discard))
+
+	var gotArgs []string
+	var gotField string
+	commandManager.RegisterCommand(
+		"lp-get",
+		"cmd description",
+		"tag",
+		"cmd",
+		func(args []string) (*presenter.Result, error) {
+			gotArgs = args
+			gotField, _ = input.GetFlag("field")
+			return nil, nil
+		},
+	)
+
+	name, args := commandManager.ResolveCommand([]string{"lp", "get", "--id", "5", "--field=password"})
+	assert.Equal(t, "lp-get", name)
+
+	assert.NoError(t, commandManager.ExecCommandWithName(name, args))
+	assert.Empty(t, gotArgs)
+	assert.Equal(t, "password", gotField)
+
+	// flags are forgotten after command
+	_, ok := input.GetFlag("field")
+	assert.False(t, ok)
+}
+
+func TestCommandManager_ResolveCommand(t *testing.T) {
+	commandManager := NewCommandManager(presenter.NewPresenter(presenter.FormatTable, io.Discard))
+	commandManager.RegisterCommand("lp-get", "", "tag", "", func(args []string) (*presenter.Result, error) { return nil, nil })
+	commandManager.RegisterCommand("profile", "", "tag", "", func(args []string) (*presenter.Result, error) { return nil, nil })
+
+	testCases := []struct {
+		name         string
+		words        []string
+		expectedName string
+		expectedArgs []string
+	}{
+		{
+			name:         "joined name",
+			words:        []string{"lp-get", "2"},
+			expectedName: "lp-get",
+			expectedArgs: []string{"2"},
+		},
+		{
+			name:         "name in two words",
+			words:        []string{"lp", "get", "2"},
+			expectedName: "lp-get",
+			expectedArgs: []string{"2"},
+		},
+		{
+			name:         "subcommand",
+			words:        []string{"profile", "list"},
+			expectedName: "profile",
+			expectedArgs: []string{"list"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			name, args := commandManager.ResolveCommand(testCase.words)
+			assert.Equal(t, testCase.expectedName, name)
+			assert.Equal(t, testCase.expectedArgs, args)
+		})
+	}
+}
+
+func TestParseFlags(t *testing.T) {
+	testCases := []struct {
+		name               string
+		args               []string
+		expectedPositional []string
+		expectedFlags      map[string]string
+		err                error
+	}{
+		{
+			name:               "positional only",
+			args:               []string{"abc", "2"},
+			expectedPositional: []string{"abc", "2"},
+			expectedFlags:      map[string]string{},
+		},
+		{
+			name:               "flags with values",
+			args:               []string{"abc", "--login", "user", "--password=-"},
+			expectedPositional: []string{"abc"},
+			expectedFlags:      map[string]string{"login": "user", "password": "-"},
+		},
+		{
+			name:               "end of flags",
+			args:               []string{"--", "--id"},
+			expectedPositional: []string{"--id"},
+			expectedFlags:      map[string]string{},
+		},
+		{
+			name: "flag without value at the end",
+			args: []string{"--login", "user", "--password"},
+			err:  domain.ErrInvalidCommandUsage,
+		},
+		{
+			name: "flag without value before another flag",
+			args: []string{"--password", "--meta", "x"},
+			err:  domain.ErrInvalidCommandUsage,
+		},
+		{
+			name:               "empty value",
+			args:               []string{"--meta="},
+			expectedPositional: []string{},
+			expectedFlags:      map[string]string{"meta": ""},
+		},
+		{
+			name: "several flags from stdin",
+			args: []string{"--password", "-", "--cvv=-"},
+			err:  domain.ErrInvalidCommandUsage,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			positional, flags, err := parseFlags(testCase.args)
+			assert.ErrorIs(t, err, testCase.err)
+			assert.Equal(t, testCase.expectedPositional, positional)
+			assert.Equal(t, testCase.expectedFlags, flags)
+		})
+	}
+}
+
+func TestCommandManager_ExecCommandWithoutFlagValue(t *testing.T) {
+	commandManager := NewCommandManager(presenter.NewPresenter(presenter.FormatTable, io.Discard))
+
+	executed := false
+	commandManager.RegisterCommand(
+		"lp-save",
+		"cmd description",
+		"tag",
+		"cmd",
+		func(args []string) (*presenter.Result, error) {
+			executed = true
+			return nil, nil
+		},
+	)
+
+	err := commandManager.ExecCommandWithName("lp-save", []string{"--login", "user", "--password"})
+	assert.ErrorIs(t, err, domain.ErrInvalidCommandUsage)
+	assert.False(t, executed)
+}
+
+func TestExitCode(t *testing.T) {
+	assert.Equal(t, ExitCodeOK, ExitCode(nil))
+	assert.Equal(t, ExitCodeOK, ExitCode(domain.ErrQuitApp))
+	assert.Equal(t, ExitCodeUsage, ExitCode(domain.ErrInvalidCommandUsage))
+	assert.Equal(t, ExitCodeUsage, ExitCode(domain.ErrCommandNotFound))
+	assert.Equal(t, ExitCodeNotFound, ExitCode(domain.ErrUserStoredDataNotFound))
+	assert.Equal(t, ExitCodeError, ExitCode(domain.ErrCommandCanceled))
+	assert.Equal(t, ExitCodeError, ExitCode(errors.New("server is unavailable")))
+}
+
+func TestCommandManager_ExecCanceledCommand(t *testing.T) {
+	commandManager := NewCommandManager(presenter.NewPresenter(presenter.FormatTable, io.Discard))
+	commandManager.RegisterCommand(
+		"cmd",
+		"cmd description",
+		"tag",
+		"cmd",
+		func(args []string) (*presenter.Result, error) {
+			return nil, input.ErrInputCanceled
+		},
+	)
+
+	assert.ErrorIs(t, commandManager.ExecCommandWithName("cmd", nil), domain.ErrCommandCanceled)
+}
+
+func TestCommandManager_ExecInvalidCommand(t *testing.T) {
+	output := &bytes.Buffer{}
+	commandManager := NewCommandManager(presenter.NewPresenter(presenter.FormatJSON, output))
+	commandManager.RegisterCommand(
+		"lp-del",
+		"cmd description",
+		"tag",
+		"lp-del <uuid>",
+		func(args []string) (*presenter.Result, error) {
+			return nil, domain.ErrInvalidCommandUsage
+		},
+	)
+
+	err := commandManager.ExecCommandWithName("unknown", nil)
+	assert.ErrorIs(t, err, domain.ErrCommandNotFound)
+
+	err = commandManager.ExecCommandWithName("lp-del", nil)
+	assert.ErrorIs(t, err, domain.ErrInvalidCommandUsage)
+	assert.ErrorContains(t, err, "lp-del <uuid>")
+
+	err = commandManager.ExecCommandWithName("lp-del", []string{"--id"})
+	assert.ErrorIs(t, err, domain.ErrInvalidCommandUsage)
+	assert.ErrorContains(t, err, "lp-del <uuid>")
+
+	assert.Empty(t, output.String())
+}
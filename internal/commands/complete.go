@@ -0,0 +1,103 @@
+package commands
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/MowlCoder/goph-keeper/pkg/input"
+)
+
+// secretFlags - flags with secret values, command lines with them are not put in history
+var secretFlags = []string{"password", "cvv", "number", "text"}
+
+var (
+	usageFlagRegexp = regexp.MustCompile(`--([a-z_]+)`)
+	// recordRefUsage - usage of commands, which first argument is record uuid
+	recordRefUsage = regexp.MustCompile(`^\S+ <(record_)?uuid>`)
+)
+
+// SetRecordRefs - set function returning uuids of records in vault, they are completed as record arguments
+func (m *CommandManager) SetRecordRefs(recordRefs func() []string) {
+	m.recordRefs = recordRefs
+}
+
+// Complete - candidates for the last of words of command line: command names, flags of command or uuids
+// of records. Candidates are not filtered by the last word
+func (m *CommandManager) Complete(words []string) []string {
+	if len(words) <= 1 {
+		return m.commandNames()
+	}
+
+	last := words[len(words)-1]
+	name, args := m.ResolveCommand(words[:len(words)-1])
+
+	cmd, ok := m.commands[name]
+	if !ok {
+		// second word of command like "lp get"
+		if len(words) == 2 {
+			return m.subcommandNames(words[0])
+		}
+
+		return nil
+	}
+
+	if strings.HasPrefix(last, "--") {
+		flags := make([]string, 0)
+		for _, match := range usageFlagRegexp.FindAllStringSubmatch(cmd.Usage, -1) {
+			flags = append(flags, "--"+match[1])
+		}
+
+		return flags
+	}
+
+	positional, _, err := parseFlags(args)
+	isRefArgument := err == nil && len(positional) == 0 && recordRefUsage.MatchString(cmd.Usage)
+	if len(args) > 0 && args[len(args)-1] == "--id" {
+		isRefArgument = true
+	}
+
+	if isRefArgument && m.recordRefs != nil {
+		return m.recordRefs()
+	}
+
+	return nil
+}
+
+// IsSecret - whether command line has secret values in flags, value "-" is read from user, so it is not secret.
+// Invalid line is treated as secret, e.g. value of secret flag may be lost in it after "#"
+func (m *CommandManager) IsSecret(words []string) bool {
+	_, flags, err := parseFlags(words)
+	if err != nil {
+		return true
+	}
+
+	for _, name := range secretFlags {
+		if value, ok := flags[name]; ok && value != input.StdinValue {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *CommandManager) commandNames() []string {
+	names := make([]string, 0, len(m.commands))
+	for name := range m.commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func (m *CommandManager) subcommandNames(group string) []string {
+	names := make([]string, 0)
+	for _, name := range m.commandNames() {
+		if subcommand, ok := strings.CutPrefix(name, group+"-"); ok {
+			names = append(names, subcommand)
+		}
+	}
+
+	return names
+}
@@ -0,0 +1,110 @@
+package commands
+
+import (
+	"io"
+	"testing"
+
+	"github.com/google/shlex"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MowlCoder/goph-keeper/internal/presenter"
+)
+
+func TestCommandManager_Complete(t *testing.T) {
+	commandManager := NewCommandManager(presenter.NewPresenter(presenter.FormatTable, io.Discard))
+	exec := func(args []string) (*presenter.Result, error) {
+		return nil, nil
+	}
+	commandManager.RegisterCommand("lp-get", "", "lp", "lp-get [page:int] | lp-get --id <uuid> [--field id|login]", exec)
+	commandManager.RegisterCommand("lp-del", "", "lp", "lp-del <uuid>", exec)
+	commandManager.RegisterCommand("lp-save", "", "lp", "lp-save [--login <login>] [--password <password|->]", exec)
+	commandManager.SetRecordRefs(func() []string {
+		return []string{"0a1b2c3d-uuid"}
+	})
+
+	testCases := []struct {
+		name     string
+		words    []string
+		expected []string
+	}{
+		{
+			name:     "command name",
+			words:    []string{"lp"},
+			expected: []string{"help", "lp-del", "lp-get", "lp-save", "quit"},
+		},
+		{
+			name:     "second word of command",
+			words:    []string{"lp", "s"},
+			expected: []string{"del", "get", "save"},
+		},
+		{
+			name:     "flags of command",
+			words:    []string{"lp", "save", "--"},
+			expected: []string{"--login", "--password"},
+		},
+		{
+			name:     "record uuid argument",
+			words:    []string{"lp-del", ""},
+			expected: []string{"0a1b2c3d-uuid"},
+		},
+		{
+			name:     "record uuid in id flag",
+			words:    []string{"lp-get", "--id", "0a"},
+			expected: []string{"0a1b2c3d-uuid"},
+		},
+		{
+			name:     "second argument",
+			words:    []string{"lp-del", "0a1b2c3d-uuid", ""},
+			expected: nil,
+		},
+		{
+			name:     "unknown command",
+			words:    []string{"unknown", "arg", ""},
+			expected: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, commandManager.Complete(testCase.words))
+		})
+	}
+}
+
+func TestCommandManager_IsSecret(t *testing.T) {
+	commandManager := NewCommandManager(presenter.NewPresenter(presenter.FormatTable, io.Discard))
+
+	testCases := []struct {
+		name   string
+		line   string
+		secret bool
+	}{
+		{name: "no flags", line: "lp get 2"},
+		{name: "not secret flags", line: "lp-get --id 0a1b --field login"},
+		{name: "secret read from user", line: "login --email user@mail.com --password -"},
+		{name: "quoted value", line: `lp save --login "john doe" --password 's3 cret'`, secret: true},
+		{name: "value after equal sign", line: "card-save --cvv=123", secret: true},
+		{name: "value cut by comment", line: "login --password #abc", secret: true},
+		{name: "several flags from stdin", line: "card-save --cvv - --number -", secret: true},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// editor splits command line the same way before it is checked
+			words, err := shlex.Split(testCase.line)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.secret, commandManager.IsSecret(words))
+		})
+	}
+}
+
+func TestCommandManager_IsSecretFlags(t *testing.T) {
+	commandManager := NewCommandManager(presenter.NewPresenter(presenter.FormatTable, io.Discard))
+
+	for _, flag := range secretFlags {
+		assert.True(t, commandManager.IsSecret([]string{"cmd", "--" + flag, "value"}), flag)
+		assert.True(t, commandManager.IsSecret([]string{"cmd", "--" + flag + "=value"}), flag)
+		assert.False(t, commandManager.IsSecret([]string{"cmd", "--" + flag, "-"}), flag)
+	}
+}
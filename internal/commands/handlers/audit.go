@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+	"github.com/MowlCoder/goph-keeper/internal/presenter"
+	"github.com/MowlCoder/goph-keeper/internal/session"
+)
+
+const auditEventsPerPage = 20
+
+type auditApi interface {
+	GetEvents(ctx context.Context, page int, count int) (*domain.PaginatedResult, error)
+}
+
+type AuditHandler struct {
+	clientSession *session.ClientSession
+	auditApi      auditApi
+}
+
+func NewAuditHandler(
+	clientSession *session.ClientSession,
+	auditApi auditApi,
+) *AuditHandler {
+	return &AuditHandler{
+		clientSession: clientSession,
+		auditApi:      auditApi,
+	}
+}
+
+func (h *AuditHandler) GetEvents(args []string) (*presenter.Result, error) {
+	if !h.clientSession.IsAuth() || len(args) > 1 {
+		return nil, domain.ErrInvalidCommandUsage
+	}
+
+	page := 1
+	if len(args) == 1 {
+		var err error
+		page, err = strconv.Atoi(args[0])
+		if err != nil || page <= 0 {
+			return nil, domain.ErrInvalidCommandUsage
+		}
+	}
+
+	eventsPage, err := h.auditApi.GetEvents(context.Background(), page, auditEventsPerPage)
+	if err != nil {
+		return nil, err
+	}
+
+	result := presenter.NewList("Security history", "created_at", "type", "record_id", "client_ip", "user_agent")
+
+	events, _ := eventsPage.Data.([]domain.AuditEvent)
+
+	for _, event := range events {
+		var recordID any
+		if event.RecordID != 0 {
+			recordID = event.RecordID
+		}
+
+		result.AddRow(event.CreatedAt, event.Type, recordID, event.ClientIP, event.UserAgent)
+	}
+
+	return result.WithPage(eventsPage.CurrentPage, eventsPage.PageCount), nil
+}
@@ -6,6 +6,7 @@ import (
 	"strconv"
 
 	"github.com/MowlCoder/goph-keeper/internal/domain"
+	"github.com/MowlCoder/goph-keeper/internal/presenter"
 	"github.com/MowlCoder/goph-keeper/internal/session"
 	"github.com/MowlCoder/goph-keeper/internal/validators"
 	"github.com/MowlCoder/goph-keeper/pkg/input"
@@ -27,25 +28,37 @@ func NewCardHandler(
 	}
 }
 
-func (h *CardHandler) AddCard(args []string) error {
-	cardNumber := input.GetConsoleInput("Enter card number: ", "")
+func (h *CardHandler) AddCard(args []string) (*presenter.Result, error) {
+	cardNumber, err := input.GetInput("number", "Enter card number: ", "")
+	if err != nil {
+		return nil, err
+	}
 	if !validators.ValidateCardNumber(cardNumber) {
-		return domain.ErrInvalidCardNumber
+		return nil, domain.ErrInvalidCardNumber
 	}
 
-	expiredAt := input.GetConsoleInput("Enter expired date (e.g. 04/30): ", "")
+	expiredAt, err := input.GetInput("expired_at", "Enter expired date (e.g. 04/30): ", "")
+	if err != nil {
+		return nil, err
+	}
 	if !validators.ValidateExpiredAt(expiredAt) {
-		return domain.ErrInvalidCardExpiredAt
+		return nil, domain.ErrInvalidCardExpiredAt
 	}
 
-	cvv := input.GetConsoleInput("Enter card cvv: ", "")
+	cvv, err := input.GetInput("cvv", "Enter card cvv: ", "")
+	if err != nil {
+		return nil, err
+	}
 	if !validators.ValidateCVV(cvv) {
-		return domain.ErrInvalidCardCVV
+		return nil, domain.ErrInvalidCardCVV
 	}
 
-	meta := input.GetConsoleInput("Enter meta information: ", "")
+	meta, err := input.GetInput("meta", "Enter meta information: ", "")
+	if err != nil {
+		return nil, err
+	}
 
-	_, err := h.userStoredDataService.Add(
+	_, err = h.userStoredDataService.Add(
 		context.Background(),
 		domain.CardDataType,
 		domain.CardData{
@@ -56,80 +69,86 @@ func (h *CardHandler) AddCard(args []string) error {
 		meta,
 	)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	fmt.Println("Successfully saved new card!")
-
-	return nil
+	return presenter.NewMessage("Successfully saved new card!"), nil
 }
 
-func (h *CardHandler) DeleteCard(args []string) error {
-	if len(args) != 1 {
-		return domain.ErrInvalidCommandUsage
+func (h *CardHandler) DeleteCard(args []string) (*presenter.Result, error) {
+	ref, ok := recordRef(args)
+	if !ok {
+		return nil, domain.ErrInvalidCommandUsage
 	}
 
-	id, err := strconv.Atoi(args[0])
+	userStoredData, err := h.userStoredDataService.GetByRef(context.Background(), ref)
 	if err != nil {
-		return domain.ErrInvalidCommandUsage
+		return nil, err
 	}
 
-	err = h.userStoredDataService.DeleteByID(
+	err = h.userStoredDataService.Delete(
 		context.Background(),
-		id,
+		userStoredData,
 	)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if id >= 0 {
-		if err := h.clientSession.AddDeleted(id); err != nil {
-			return err
+	if !userStoredData.IsLocal() && h.clientSession.IsPersonalVault() {
+		if err := h.clientSession.AddDeleted(userStoredData.UUID, userStoredData.Version); err != nil {
+			return nil, err
 		}
 	}
 
-	fmt.Printf("Successfully delete card with id %d\n", id)
-
-	return nil
+	return presenter.NewMessage("Successfully delete card with uuid %s", userStoredData.UUID), nil
 }
 
-func (h *CardHandler) UpdateCard(args []string) error {
-	if len(args) != 1 {
-		return domain.ErrInvalidCommandUsage
+func (h *CardHandler) UpdateCard(args []string) (*presenter.Result, error) {
+	ref, ok := recordRef(args)
+	if !ok {
+		return nil, domain.ErrInvalidCommandUsage
 	}
 
-	id, err := strconv.Atoi(args[0])
+	userStoredData, err := h.userStoredDataService.GetByRef(context.Background(), ref)
 	if err != nil {
-		return domain.ErrInvalidCommandUsage
-	}
-
-	userStoredData, err := h.userStoredDataService.GetByID(context.Background(), id)
-	if err != nil {
-		return err
+		return nil, err
 	}
 
 	data := userStoredData.Data.(domain.CardData)
 
-	cardNumber := input.GetConsoleInput(fmt.Sprintf("Enter card number (current - %s): ", data.Number), data.Number)
+	cardNumber, err := input.GetInput("number", fmt.Sprintf("Enter card number (current - %s): ", data.Number), data.Number)
+	if err != nil {
+		return nil, err
+	}
 	if !validators.ValidateCardNumber(cardNumber) {
-		return domain.ErrInvalidCardNumber
+		return nil, domain.ErrInvalidCardNumber
 	}
 
-	expiredAt := input.GetConsoleInput(fmt.Sprintf("Enter expired date (current - %s): ", data.ExpiredAt), data.ExpiredAt)
+	expiredAt, err := input.GetInput("expired_at", fmt.Sprintf("Enter expired date (current - %s): ", data.ExpiredAt), data.ExpiredAt)
+	if err != nil {
+		return nil, err
+	}
 	if !validators.ValidateExpiredAt(expiredAt) {
-		return domain.ErrInvalidCardExpiredAt
+		return nil, domain.ErrInvalidCardExpiredAt
 	}
 
-	cvv := input.GetConsoleInput(fmt.Sprintf("Enter card cvv (current - %s): ", data.CVV), data.CVV)
+	cvv, err := input.GetInput("cvv", fmt.Sprintf("Enter card cvv (current - %s): ", data.CVV), data.CVV)
+	if err != nil {
+		return nil, err
+	}
 	if !validators.ValidateCVV(cvv) {
-		return domain.ErrInvalidCardCVV
+		return nil, domain.ErrInvalidCardCVV
 	}
 
-	meta := input.GetConsoleInput(fmt.Sprintf("Enter meta information (current - %s): ", userStoredData.Meta), userStoredData.Meta)
+	meta, err := input.GetInput("meta", fmt.Sprintf("Enter meta information (current - %s): ", userStoredData.Meta), userStoredData.Meta)
+	if err != nil {
+		return nil, err
+	}
 
-	_, err = h.userStoredDataService.UpdateByID(
+	_, err = h.userStoredDataService.Update(
 		context.Background(),
-		id,
+		userStoredData,
+		userStoredData.Version,
 		domain.CardData{
 			Number:    cardNumber,
 			ExpiredAt: expiredAt,
@@ -138,21 +157,23 @@ func (h *CardHandler) UpdateCard(args []string) error {
 		meta,
 	)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if id >= 0 {
-		if err := h.clientSession.AddEdited(id); err != nil {
-			return err
+	if !userStoredData.IsLocal() && h.clientSession.IsPersonalVault() {
+		if err := h.clientSession.AddEdited(userStoredData.UUID); err != nil {
+			return nil, err
 		}
 	}
 
-	fmt.Println("Successfully update card data")
-
-	return nil
+	return presenter.NewMessage("Successfully update card data"), nil
 }
 
-func (h *CardHandler) GetCards(args []string) error {
+func (h *CardHandler) GetCards(args []string) (*presenter.Result, error) {
+	if ref, ok := input.GetFlag("id"); ok {
+		return h.getCard(ref)
+	}
+
 	count := 15
 	var page int
 	var err error
@@ -182,23 +203,31 @@ func (h *CardHandler) GetCards(args []string) error {
 		},
 	)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	fmt.Println("================== Cards ==================")
+	result := presenter.NewList("Cards", "id", "number", "expired_at", "cvv", "meta", "version")
 
 	for _, data := range paginatedResult.Data.([]domain.UserStoredData) {
 		cardData := data.Data.(domain.CardData)
-		fmt.Println(fmt.Sprintf("ID: %d | %s %s %s | Meta: %s (version %d)", data.ID, cardData.Number, cardData.ExpiredAt, cardData.CVV, data.Meta, data.Version))
+		result.AddRow(data.ShortUUID(), cardData.Number, cardData.ExpiredAt, cardData.CVV, data.Meta, data.Version)
 	}
 
-	fmt.Println(
-		fmt.Sprintf(
-			"================== (%d/%d) ==================",
-			paginatedResult.CurrentPage,
-			paginatedResult.PageCount,
-		),
-	)
+	return result.WithPage(paginatedResult.CurrentPage, paginatedResult.PageCount), nil
+}
+
+func (h *CardHandler) getCard(ref string) (*presenter.Result, error) {
+	userStoredData, err := h.userStoredDataService.GetByRef(context.Background(), ref)
+	if err != nil {
+		return nil, err
+	}
+
+	cardData := userStoredData.Data.(domain.CardData)
 
-	return nil
+	return recordResult("Card", userStoredData, []recordField{
+		{name: "number", value: cardData.Number},
+		{name: "expired_at", value: cardData.ExpiredAt},
+		{name: "cvv", value: cardData.CVV},
+		{name: "meta", value: userStoredData.Meta},
+	})
 }
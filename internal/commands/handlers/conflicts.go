@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"strings"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+	"github.com/MowlCoder/goph-keeper/internal/presenter"
+	"github.com/MowlCoder/goph-keeper/internal/session"
+)
+
+type ConflictsHandler struct {
+	clientSession *session.ClientSession
+}
+
+func NewConflictsHandler(clientSession *session.ClientSession) *ConflictsHandler {
+	return &ConflictsHandler{
+		clientSession: clientSession,
+	}
+}
+
+func (h *ConflictsHandler) GetAll(args []string) (*presenter.Result, error) {
+	if len(args) != 0 {
+		return nil, domain.ErrInvalidCommandUsage
+	}
+
+	conflicts := h.clientSession.GetConflicts()
+
+	result := presenter.NewList("Sync conflicts", "detected_at", "record_id", "data_type", "copy_id", "fields")
+	if len(conflicts) == 0 {
+		return result.WithMessage("There are no unresolved sync conflicts"), nil
+	}
+
+	for _, conflict := range conflicts {
+		result.AddRow(
+			conflict.DetectedAt,
+			domain.ShortUUID(conflict.RecordUUID),
+			conflict.DataType,
+			domain.ShortUUID(conflict.CopyUUID),
+			strings.Join(conflict.Fields, ", "),
+		)
+	}
+
+	return result.WithMessage("Compare record with its copy, keep needed values and delete copy, then mark conflict resolved"), nil
+}
+
+func (h *ConflictsHandler) Resolve(args []string) (*presenter.Result, error) {
+	if len(args) != 1 {
+		return nil, domain.ErrInvalidCommandUsage
+	}
+
+	ref, err := domain.ParseRecordRef(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	recordUUID := ""
+	for _, conflict := range h.clientSession.GetConflicts() {
+		if !strings.HasPrefix(conflict.RecordUUID, ref) || conflict.RecordUUID == recordUUID {
+			continue
+		}
+
+		if recordUUID != "" {
+			return nil, domain.ErrAmbiguousRecordRef
+		}
+		recordUUID = conflict.RecordUUID
+	}
+
+	if recordUUID == "" {
+		return nil, domain.ErrSyncConflictNotFound
+	}
+
+	if err := h.clientSession.RemoveConflict(recordUUID); err != nil {
+		return nil, err
+	}
+
+	return presenter.NewMessage("Conflict marked as resolved"), nil
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+	"github.com/MowlCoder/goph-keeper/internal/presenter"
+	"github.com/MowlCoder/goph-keeper/internal/session"
+)
+
+type deviceApi interface {
+	GetDevices(ctx context.Context) ([]domain.Device, error)
+	Revoke(ctx context.Context, id int) error
+}
+
+type DeviceHandler struct {
+	clientSession *session.ClientSession
+	deviceApi     deviceApi
+}
+
+func NewDeviceHandler(
+	clientSession *session.ClientSession,
+	deviceApi deviceApi,
+) *DeviceHandler {
+	return &DeviceHandler{
+		clientSession: clientSession,
+		deviceApi:     deviceApi,
+	}
+}
+
+func (h *DeviceHandler) GetDevices(args []string) (*presenter.Result, error) {
+	if !h.clientSession.IsAuth() || len(args) != 0 {
+		return nil, domain.ErrInvalidCommandUsage
+	}
+
+	devices, err := h.deviceApi.GetDevices(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	result := presenter.NewList("Devices", "id", "name", "current", "platform", "last_seen_at")
+
+	for _, device := range devices {
+		result.AddRow(device.ID, device.Name, device.Current, device.Platform, device.LastSeenAt)
+	}
+
+	return result, nil
+}
+
+func (h *DeviceHandler) Revoke(args []string) (*presenter.Result, error) {
+	if !h.clientSession.IsAuth() || len(args) != 1 {
+		return nil, domain.ErrInvalidCommandUsage
+	}
+
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return nil, domain.ErrInvalidCommandUsage
+	}
+
+	devices, err := h.deviceApi.GetDevices(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.deviceApi.Revoke(context.Background(), id); err != nil {
+		return nil, err
+	}
+
+	// token of revoked device does not work anymore, user has to authorize again
+	for _, device := range devices {
+		if device.ID == id && device.Current {
+			if err := h.clientSession.SetToken(""); err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
+
+	return presenter.NewMessage("Device is revoked"), nil
+}
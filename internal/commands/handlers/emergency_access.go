@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+	"github.com/MowlCoder/goph-keeper/internal/presenter"
+	"github.com/MowlCoder/goph-keeper/internal/session"
+)
+
+type emergencyAccessApi interface {
+	Invite(ctx context.Context, email string, waitDays int) (*domain.EmergencyAccess, error)
+	GetGranted(ctx context.Context) ([]domain.EmergencyAccess, error)
+	GetTrusted(ctx context.Context) ([]domain.EmergencyAccess, error)
+	Confirm(ctx context.Context, id int) error
+	Initiate(ctx context.Context, id int) error
+	Approve(ctx context.Context, id int) error
+	Reject(ctx context.Context, id int) error
+	Revoke(ctx context.Context, id int) error
+	GetVault(ctx context.Context, id int) ([]domain.UserStoredData, error)
+}
+
+type EmergencyAccessHandler struct {
+	clientSession      *session.ClientSession
+	emergencyAccessApi emergencyAccessApi
+}
+
+func NewEmergencyAccessHandler(
+	clientSession *session.ClientSession,
+	emergencyAccessApi emergencyAccessApi,
+) *EmergencyAccessHandler {
+	return &EmergencyAccessHandler{
+		clientSession:      clientSession,
+		emergencyAccessApi: emergencyAccessApi,
+	}
+}
+
+func (h *EmergencyAccessHandler) Invite(args []string) (*presenter.Result, error) {
+	if !h.clientSession.IsAuth() || len(args) != 2 {
+		return nil, domain.ErrInvalidCommandUsage
+	}
+
+	waitDays, err := strconv.Atoi(args[1])
+	if err != nil {
+		return nil, domain.ErrInvalidCommandUsage
+	}
+
+	if !domain.IsValidEmergencyWaitDays(waitDays) {
+		return nil, domain.ErrInvalidEmergencyWaitDays
+	}
+
+	access, err := h.emergencyAccessApi.Invite(context.Background(), args[0], waitDays)
+	if err != nil {
+		return nil, err
+	}
+
+	return presenter.NewMessage("Successfully invited %s as emergency contact with id %d (wait %d days)", access.GranteeEmail, access.ID, access.WaitDays), nil
+}
+
+func (h *EmergencyAccessHandler) GetGranted(args []string) (*presenter.Result, error) {
+	if !h.clientSession.IsAuth() {
+		return nil, domain.ErrInvalidCommandUsage
+	}
+
+	accesses, err := h.emergencyAccessApi.GetGranted(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	result := presenter.NewList("Emergency contacts", "id", "to", "status", "wait_days", "approves_at")
+
+	for _, access := range accesses {
+		result.AddRow(access.ID, access.GranteeEmail, access.Status, access.WaitDays, emergencyApprovesAt(access))
+	}
+
+	return result, nil
+}
+
+func (h *EmergencyAccessHandler) GetTrusted(args []string) (*presenter.Result, error) {
+	if !h.clientSession.IsAuth() {
+		return nil, domain.ErrInvalidCommandUsage
+	}
+
+	accesses, err := h.emergencyAccessApi.GetTrusted(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	result := presenter.NewList("Trusted by", "id", "from", "status", "wait_days", "approves_at")
+
+	for _, access := range accesses {
+		result.AddRow(access.ID, access.GrantorEmail, access.Status, access.WaitDays, emergencyApprovesAt(access))
+	}
+
+	return result, nil
+}
+
+func (h *EmergencyAccessHandler) Confirm(args []string) (*presenter.Result, error) {
+	return h.handleAction(args, h.emergencyAccessApi.Confirm, "Successfully confirmed emergency access with id %d")
+}
+
+func (h *EmergencyAccessHandler) Initiate(args []string) (*presenter.Result, error) {
+	return h.handleAction(args, h.emergencyAccessApi.Initiate, "Successfully requested emergency access with id %d, waiting period started")
+}
+
+func (h *EmergencyAccessHandler) Approve(args []string) (*presenter.Result, error) {
+	return h.handleAction(args, h.emergencyAccessApi.Approve, "Successfully approved emergency access with id %d")
+}
+
+func (h *EmergencyAccessHandler) Reject(args []string) (*presenter.Result, error) {
+	return h.handleAction(args, h.emergencyAccessApi.Reject, "Successfully rejected emergency access with id %d")
+}
+
+func (h *EmergencyAccessHandler) Revoke(args []string) (*presenter.Result, error) {
+	return h.handleAction(args, h.emergencyAccessApi.Revoke, "Successfully revoked emergency access with id %d")
+}
+
+func (h *EmergencyAccessHandler) GetVault(args []string) (*presenter.Result, error) {
+	if !h.clientSession.IsAuth() || len(args) != 1 {
+		return nil, domain.ErrInvalidCommandUsage
+	}
+
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return nil, domain.ErrInvalidCommandUsage
+	}
+
+	dataSet, err := h.emergencyAccessApi.GetVault(context.Background(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	result := presenter.NewList("Emergency vault", "id", "type", "data", "meta", "version")
+
+	for _, data := range dataSet {
+		result.AddRow(data.ShortUUID(), data.DataType, describeUserStoredData(data), data.Meta, data.Version)
+	}
+
+	return result, nil
+}
+
+func (h *EmergencyAccessHandler) handleAction(
+	args []string,
+	action func(ctx context.Context, id int) error,
+	successFormat string,
+) (*presenter.Result, error) {
+	if !h.clientSession.IsAuth() || len(args) != 1 {
+		return nil, domain.ErrInvalidCommandUsage
+	}
+
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return nil, domain.ErrInvalidCommandUsage
+	}
+
+	if err := action(context.Background(), id); err != nil {
+		return nil, err
+	}
+
+	return presenter.NewMessage(successFormat, id), nil
+}
+
+// emergencyApprovesAt - time access is approved automatically, known only when recovery is initiated
+func emergencyApprovesAt(access domain.EmergencyAccess) any {
+	if access.Status == domain.EmergencyAccessRecoveryInitiated {
+		return access.ApprovesAt()
+	}
+
+	return nil
+}
@@ -3,12 +3,12 @@ package handlers
 import (
 	"context"
 	"errors"
-	"fmt"
 	"os"
 	"path/filepath"
 	"strconv"
 
 	"github.com/MowlCoder/goph-keeper/internal/domain"
+	"github.com/MowlCoder/goph-keeper/internal/presenter"
 	"github.com/MowlCoder/goph-keeper/internal/session"
 	"github.com/MowlCoder/goph-keeper/pkg/input"
 )
@@ -29,17 +29,23 @@ func NewFileHandler(
 	}
 }
 
-func (h *FileHandler) AddFile(args []string) error {
-	filePath := input.GetConsoleInput("Enter file path: ", "")
+func (h *FileHandler) AddFile(args []string) (*presenter.Result, error) {
+	filePath, err := input.GetInput("path", "Enter file path: ", "")
+	if err != nil {
+		return nil, err
+	}
 	if filePath == "" {
-		return domain.ErrInvalidInputValue
+		return nil, domain.ErrInvalidInputValue
 	}
 
-	meta := input.GetConsoleInput("Enter meta information: ", "")
+	meta, err := input.GetInput("meta", "Enter meta information: ", "")
+	if err != nil {
+		return nil, err
+	}
 
 	fileContent, err := os.ReadFile(filePath)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	fileData := domain.FileData{
@@ -54,15 +60,17 @@ func (h *FileHandler) AddFile(args []string) error {
 		meta,
 	)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	fmt.Println("Successfully saved new file!")
-
-	return nil
+	return presenter.NewMessage("Successfully saved new file!"), nil
 }
 
-func (h *FileHandler) GetFiles(args []string) error {
+func (h *FileHandler) GetFiles(args []string) (*presenter.Result, error) {
+	if ref, ok := input.GetFlag("id"); ok {
+		return h.getFile(ref)
+	}
+
 	count := 15
 	var page int
 	var err error
@@ -92,82 +100,78 @@ func (h *FileHandler) GetFiles(args []string) error {
 		},
 	)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	fmt.Println("================== Files ==================")
+	result := presenter.NewList("Files", "id", "name", "meta", "version")
 
 	for _, data := range paginatedResult.Data.([]domain.UserStoredData) {
 		fileData := data.Data.(domain.FileData)
-		fmt.Println(fmt.Sprintf("ID: %d | %s | Meta: %s (version %d)", data.ID, fileData.Name, data.Meta, data.Version))
+		result.AddRow(data.ShortUUID(), fileData.Name, data.Meta, data.Version)
 	}
 
-	fmt.Println(
-		fmt.Sprintf(
-			"================== (%d/%d) ==================",
-			paginatedResult.CurrentPage,
-			paginatedResult.PageCount,
-		),
-	)
-
-	return nil
+	return result.WithPage(paginatedResult.CurrentPage, paginatedResult.PageCount), nil
 }
 
-func (h *FileHandler) DecryptFile(args []string) error {
-	if len(args) != 1 {
-		return domain.ErrInvalidCommandUsage
+func (h *FileHandler) DecryptFile(args []string) (*presenter.Result, error) {
+	ref, ok := recordRef(args)
+	if !ok {
+		return nil, domain.ErrInvalidCommandUsage
 	}
 
-	id, err := strconv.Atoi(args[0])
+	dirPath, err := input.GetInput("dir", "Enter directory where decrypt file: ", "")
 	if err != nil {
-		return domain.ErrInvalidCommandUsage
+		return nil, err
 	}
-
-	dirPath := input.GetConsoleInput("Enter directory where decrypt file: ", "")
 	if dirPath == "" {
-		return domain.ErrInvalidInputValue
+		return nil, domain.ErrInvalidInputValue
 	}
 
-	if err := os.Mkdir(dirPath, os.ModePerm); err != nil && !errors.Is(err, os.ErrExist) {
-		return err
+	if err := os.Mkdir(dirPath, 0700); err != nil && !errors.Is(err, os.ErrExist) {
+		return nil, err
 	}
 
-	userData, err := h.userStoredDataService.GetByID(context.Background(), id)
+	userData, err := h.userStoredDataService.GetByRef(context.Background(), ref)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	parsedData := userData.Data.(domain.FileData)
 	pathToFile := filepath.Join(dirPath, parsedData.Name)
-	if err := os.WriteFile(pathToFile, parsedData.Content, os.ModePerm); err != nil {
-		return err
+	if err := os.WriteFile(pathToFile, parsedData.Content, 0600); err != nil {
+		return nil, err
 	}
 
-	fmt.Println("Successfully decrypted file")
-
-	return nil
+	return presenter.NewMessage("Successfully decrypted file"), nil
 }
 
-func (h *FileHandler) UpdateFile(args []string) error {
-	if len(args) != 1 {
-		return domain.ErrInvalidCommandUsage
+func (h *FileHandler) UpdateFile(args []string) (*presenter.Result, error) {
+	ref, ok := recordRef(args)
+	if !ok {
+		return nil, domain.ErrInvalidCommandUsage
 	}
 
-	id, err := strconv.Atoi(args[0])
+	userStoredData, err := h.userStoredDataService.GetByRef(context.Background(), ref)
 	if err != nil {
-		return domain.ErrInvalidCommandUsage
+		return nil, err
 	}
 
-	filePath := input.GetConsoleInput("Enter file path: ", "")
+	filePath, err := input.GetInput("path", "Enter file path: ", "")
+	if err != nil {
+		return nil, err
+	}
 	if filePath == "" {
-		return domain.ErrInvalidInputValue
+		return nil, domain.ErrInvalidInputValue
 	}
 
-	meta := input.GetConsoleInput("Enter meta information: ", "")
+	meta, err := input.GetInput("meta", "Enter meta information: ", "")
+	if err != nil {
+		return nil, err
+	}
 
 	fileContent, err := os.ReadFile(filePath)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	fileData := domain.FileData{
@@ -175,52 +179,65 @@ func (h *FileHandler) UpdateFile(args []string) error {
 		Name:    filepath.Base(filePath),
 	}
 
-	_, err = h.userStoredDataService.UpdateByID(
+	// file is replaced as a whole without reading it first, so any version is overwritten
+	_, err = h.userStoredDataService.Update(
 		context.Background(),
-		id,
+		userStoredData,
+		0,
 		fileData,
 		meta,
 	)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if id >= 0 {
-		if err := h.clientSession.AddEdited(id); err != nil {
-			return err
+	if !userStoredData.IsLocal() && h.clientSession.IsPersonalVault() {
+		if err := h.clientSession.AddEdited(userStoredData.UUID); err != nil {
+			return nil, err
 		}
 	}
 
-	fmt.Println("Successfully updated file data")
-
-	return nil
+	return presenter.NewMessage("Successfully updated file data"), nil
 }
 
-func (h *FileHandler) DeleteFile(args []string) error {
-	if len(args) != 1 {
-		return domain.ErrInvalidCommandUsage
+func (h *FileHandler) DeleteFile(args []string) (*presenter.Result, error) {
+	ref, ok := recordRef(args)
+	if !ok {
+		return nil, domain.ErrInvalidCommandUsage
 	}
 
-	id, err := strconv.Atoi(args[0])
+	userStoredData, err := h.userStoredDataService.GetByRef(context.Background(), ref)
 	if err != nil {
-		return domain.ErrInvalidCommandUsage
+		return nil, err
 	}
 
-	err = h.userStoredDataService.DeleteByID(
+	err = h.userStoredDataService.Delete(
 		context.Background(),
-		id,
+		userStoredData,
 	)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if id >= 0 {
-		if err := h.clientSession.AddDeleted(id); err != nil {
-			return err
+	if !userStoredData.IsLocal() && h.clientSession.IsPersonalVault() {
+		if err := h.clientSession.AddDeleted(userStoredData.UUID, userStoredData.Version); err != nil {
+			return nil, err
 		}
 	}
 
-	fmt.Printf("Successfully delete file with id %d\n", id)
+	return presenter.NewMessage("Successfully delete file with uuid %s", userStoredData.UUID), nil
+}
+
+func (h *FileHandler) getFile(ref string) (*presenter.Result, error) {
+	userStoredData, err := h.userStoredDataService.GetByRef(context.Background(), ref)
+	if err != nil {
+		return nil, err
+	}
+
+	fileData := userStoredData.Data.(domain.FileData)
 
-	return nil
+	return recordResult("File", userStoredData, []recordField{
+		{name: "name", value: fileData.Name},
+		{name: "meta", value: userStoredData.Meta},
+	})
 }
@@ -9,7 +9,7 @@ import (
 type userStoredDataService interface {
 	GetUserData(ctx context.Context, dataType string, filters *domain.StorageFilters) (*domain.PaginatedResult, error)
 	Add(ctx context.Context, dataType string, data interface{}, meta string) (*domain.UserStoredData, error)
-	GetByID(ctx context.Context, id int) (*domain.UserStoredData, error)
-	UpdateByID(ctx context.Context, id int, data interface{}, meta string) (*domain.UserStoredData, error)
-	DeleteByID(ctx context.Context, id int) error
+	GetByRef(ctx context.Context, ref string) (*domain.UserStoredData, error)
+	Update(ctx context.Context, record *domain.UserStoredData, expectedVersion int, data interface{}, meta string) (*domain.UserStoredData, error)
+	Delete(ctx context.Context, record *domain.UserStoredData) error
 }
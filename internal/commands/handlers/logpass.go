@@ -6,6 +6,7 @@ import (
 	"strconv"
 
 	"github.com/MowlCoder/goph-keeper/internal/domain"
+	"github.com/MowlCoder/goph-keeper/internal/presenter"
 	"github.com/MowlCoder/goph-keeper/internal/session"
 	"github.com/MowlCoder/goph-keeper/pkg/input"
 )
@@ -25,23 +26,32 @@ func NewLogPassHandler(
 	}
 }
 
-func (h *LogPassHandler) AddPair(args []string) error {
-	login := input.GetConsoleInput("Enter login: ", "")
+func (h *LogPassHandler) AddPair(args []string) (*presenter.Result, error) {
+	login, err := input.GetInput("login", "Enter login: ", "")
+	if err != nil {
+		return nil, err
+	}
 	if login == "" {
-		return domain.ErrInvalidInputValue
+		return nil, domain.ErrInvalidInputValue
 	}
 
-	password := input.GetConsoleInput("Enter password: ", "")
+	password, err := input.GetInput("password", "Enter password: ", "")
+	if err != nil {
+		return nil, err
+	}
 	if password == "" {
-		return domain.ErrInvalidInputValue
+		return nil, domain.ErrInvalidInputValue
 	}
 
-	meta := input.GetConsoleInput("Enter source: ", "")
+	meta, err := input.GetInput("source", "Enter source: ", "")
+	if err != nil {
+		return nil, err
+	}
 	if meta == "" {
-		return domain.ErrInvalidInputValue
+		return nil, domain.ErrInvalidInputValue
 	}
 
-	_, err := h.userStoredDataService.Add(
+	_, err = h.userStoredDataService.Add(
 		context.Background(),
 		domain.LogPassDataType,
 		&domain.LogPassData{
@@ -51,49 +61,53 @@ func (h *LogPassHandler) AddPair(args []string) error {
 		meta,
 	)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	fmt.Println("Successfully saved new log:pass pair!")
-
-	return nil
+	return presenter.NewMessage("Successfully saved new log:pass pair!"), nil
 }
 
-func (h *LogPassHandler) UpdatePair(args []string) error {
-	if len(args) != 1 {
-		return domain.ErrInvalidCommandUsage
+func (h *LogPassHandler) UpdatePair(args []string) (*presenter.Result, error) {
+	ref, ok := recordRef(args)
+	if !ok {
+		return nil, domain.ErrInvalidCommandUsage
 	}
 
-	id, err := strconv.Atoi(args[0])
+	userStoredData, err := h.userStoredDataService.GetByRef(context.Background(), ref)
 	if err != nil {
-		return domain.ErrInvalidCommandUsage
-	}
-
-	userStoredData, err := h.userStoredDataService.GetByID(context.Background(), id)
-	if err != nil {
-		return err
+		return nil, err
 	}
 
 	data := userStoredData.Data.(domain.LogPassData)
 
-	login := input.GetConsoleInput(fmt.Sprintf("Enter login (current - %s): ", data.Login), data.Login)
+	login, err := input.GetInput("login", fmt.Sprintf("Enter login (current - %s): ", data.Login), data.Login)
+	if err != nil {
+		return nil, err
+	}
 	if login == "" {
-		return domain.ErrInvalidInputValue
+		return nil, domain.ErrInvalidInputValue
 	}
 
-	password := input.GetConsoleInput(fmt.Sprintf("Enter password (current - %s): ", data.Password), data.Password)
+	password, err := input.GetInput("password", fmt.Sprintf("Enter password (current - %s): ", data.Password), data.Password)
+	if err != nil {
+		return nil, err
+	}
 	if password == "" {
-		return domain.ErrInvalidInputValue
+		return nil, domain.ErrInvalidInputValue
 	}
 
-	meta := input.GetConsoleInput(fmt.Sprintf("Enter source (current - %s): ", userStoredData.Meta), userStoredData.Meta)
+	meta, err := input.GetInput("source", fmt.Sprintf("Enter source (current - %s): ", userStoredData.Meta), userStoredData.Meta)
+	if err != nil {
+		return nil, err
+	}
 	if meta == "" {
-		return domain.ErrInvalidInputValue
+		return nil, domain.ErrInvalidInputValue
 	}
 
-	_, err = h.userStoredDataService.UpdateByID(
+	_, err = h.userStoredDataService.Update(
 		context.Background(),
-		id,
+		userStoredData,
+		userStoredData.Version,
 		domain.LogPassData{
 			Login:    login,
 			Password: password,
@@ -101,50 +115,51 @@ func (h *LogPassHandler) UpdatePair(args []string) error {
 		meta,
 	)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if id >= 0 {
-		if err := h.clientSession.AddEdited(id); err != nil {
-			return err
+	if !userStoredData.IsLocal() && h.clientSession.IsPersonalVault() {
+		if err := h.clientSession.AddEdited(userStoredData.UUID); err != nil {
+			return nil, err
 		}
 	}
 
-	fmt.Println("Successfully update logpass data")
-
-	return nil
+	return presenter.NewMessage("Successfully update logpass data"), nil
 }
 
-func (h *LogPassHandler) DeletePair(args []string) error {
-	if len(args) != 1 {
-		return domain.ErrInvalidCommandUsage
+func (h *LogPassHandler) DeletePair(args []string) (*presenter.Result, error) {
+	ref, ok := recordRef(args)
+	if !ok {
+		return nil, domain.ErrInvalidCommandUsage
 	}
 
-	id, err := strconv.Atoi(args[0])
+	userStoredData, err := h.userStoredDataService.GetByRef(context.Background(), ref)
 	if err != nil {
-		return domain.ErrInvalidCommandUsage
+		return nil, err
 	}
 
-	err = h.userStoredDataService.DeleteByID(
+	err = h.userStoredDataService.Delete(
 		context.Background(),
-		id,
+		userStoredData,
 	)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if id >= 0 {
-		if err := h.clientSession.AddDeleted(id); err != nil {
-			return err
+	if !userStoredData.IsLocal() && h.clientSession.IsPersonalVault() {
+		if err := h.clientSession.AddDeleted(userStoredData.UUID, userStoredData.Version); err != nil {
+			return nil, err
 		}
 	}
 
-	fmt.Printf("Successfully delete log:pass pair with id %d\n", id)
-
-	return nil
+	return presenter.NewMessage("Successfully delete log:pass pair with uuid %s", userStoredData.UUID), nil
 }
 
-func (h *LogPassHandler) GetPairs(args []string) error {
+func (h *LogPassHandler) GetPairs(args []string) (*presenter.Result, error) {
+	if ref, ok := input.GetFlag("id"); ok {
+		return h.getPair(ref)
+	}
+
 	count := 15
 	var page int
 	var err error
@@ -174,23 +189,30 @@ func (h *LogPassHandler) GetPairs(args []string) error {
 		},
 	)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	fmt.Println("================== Log Pass ==================")
+	result := presenter.NewList("Log Pass", "id", "login", "password", "source", "version")
 
 	for _, data := range paginatedResult.Data.([]domain.UserStoredData) {
 		logPassData := data.Data.(domain.LogPassData)
-		fmt.Println(fmt.Sprintf("ID: %d | %s:%s | Source: %s (version %d)", data.ID, logPassData.Login, logPassData.Password, data.Meta, data.Version))
+		result.AddRow(data.ShortUUID(), logPassData.Login, logPassData.Password, data.Meta, data.Version)
 	}
 
-	fmt.Println(
-		fmt.Sprintf(
-			"================== (%d/%d) ==================",
-			paginatedResult.CurrentPage,
-			paginatedResult.PageCount,
-		),
-	)
+	return result.WithPage(paginatedResult.CurrentPage, paginatedResult.PageCount), nil
+}
+
+func (h *LogPassHandler) getPair(ref string) (*presenter.Result, error) {
+	userStoredData, err := h.userStoredDataService.GetByRef(context.Background(), ref)
+	if err != nil {
+		return nil, err
+	}
+
+	logPassData := userStoredData.Data.(domain.LogPassData)
 
-	return nil
+	return recordResult("Log Pass", userStoredData, []recordField{
+		{name: "login", value: logPassData.Login},
+		{name: "password", value: logPassData.Password},
+		{name: "source", value: userStoredData.Meta},
+	})
 }
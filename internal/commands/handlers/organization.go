@@ -0,0 +1,221 @@
+package handlers
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+	"github.com/MowlCoder/goph-keeper/internal/presenter"
+	"github.com/MowlCoder/goph-keeper/internal/session"
+)
+
+type organizationApi interface {
+	Create(ctx context.Context, name string) (*domain.Organization, error)
+	GetMy(ctx context.Context) ([]domain.OrganizationMembership, error)
+	Invite(ctx context.Context, organizationID int, email string, role string) (*domain.OrganizationMember, error)
+	Accept(ctx context.Context, organizationID int) error
+	GetMembers(ctx context.Context, organizationID int) ([]domain.OrganizationMember, error)
+	CreateCollection(ctx context.Context, organizationID int, name string) (*domain.Collection, error)
+	GetCollections(ctx context.Context, organizationID int) ([]domain.Collection, error)
+}
+
+type OrganizationHandler struct {
+	clientSession   *session.ClientSession
+	organizationApi organizationApi
+}
+
+func NewOrganizationHandler(
+	clientSession *session.ClientSession,
+	organizationApi organizationApi,
+) *OrganizationHandler {
+	return &OrganizationHandler{
+		clientSession:   clientSession,
+		organizationApi: organizationApi,
+	}
+}
+
+func (h *OrganizationHandler) CreateOrganization(args []string) (*presenter.Result, error) {
+	if !h.clientSession.IsAuth() || len(args) == 0 {
+		return nil, domain.ErrInvalidCommandUsage
+	}
+
+	organization, err := h.organizationApi.Create(context.Background(), strings.Join(args, " "))
+	if err != nil {
+		return nil, err
+	}
+
+	return presenter.NewMessage("Successfully created organization %s with id %d", organization.Name, organization.ID), nil
+}
+
+func (h *OrganizationHandler) GetOrganizations(args []string) (*presenter.Result, error) {
+	if !h.clientSession.IsAuth() {
+		return nil, domain.ErrInvalidCommandUsage
+	}
+
+	memberships, err := h.organizationApi.GetMy(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	result := presenter.NewList("Organizations", "id", "name", "role", "status")
+
+	for _, membership := range memberships {
+		result.AddRow(
+			membership.Organization.ID,
+			membership.Organization.Name,
+			membership.Role,
+			membership.Status,
+		)
+	}
+
+	return result, nil
+}
+
+func (h *OrganizationHandler) Invite(args []string) (*presenter.Result, error) {
+	if !h.clientSession.IsAuth() || len(args) < 2 || len(args) > 3 {
+		return nil, domain.ErrInvalidCommandUsage
+	}
+
+	organizationID, err := strconv.Atoi(args[0])
+	if err != nil {
+		return nil, domain.ErrInvalidCommandUsage
+	}
+
+	role := domain.OrganizationMemberRole
+	if len(args) == 3 {
+		role = args[2]
+	}
+
+	if !domain.IsValidInviteRole(role) {
+		return nil, domain.ErrInvalidOrganizationRole
+	}
+
+	member, err := h.organizationApi.Invite(context.Background(), organizationID, args[1], role)
+	if err != nil {
+		return nil, err
+	}
+
+	return presenter.NewMessage("Successfully invited %s to organization with id %d as %s", member.Email, organizationID, member.Role), nil
+}
+
+func (h *OrganizationHandler) Accept(args []string) (*presenter.Result, error) {
+	if !h.clientSession.IsAuth() || len(args) != 1 {
+		return nil, domain.ErrInvalidCommandUsage
+	}
+
+	organizationID, err := strconv.Atoi(args[0])
+	if err != nil {
+		return nil, domain.ErrInvalidCommandUsage
+	}
+
+	if err := h.organizationApi.Accept(context.Background(), organizationID); err != nil {
+		return nil, err
+	}
+
+	return presenter.NewMessage("Successfully joined organization with id %d", organizationID), nil
+}
+
+func (h *OrganizationHandler) GetMembers(args []string) (*presenter.Result, error) {
+	if !h.clientSession.IsAuth() || len(args) != 1 {
+		return nil, domain.ErrInvalidCommandUsage
+	}
+
+	organizationID, err := strconv.Atoi(args[0])
+	if err != nil {
+		return nil, domain.ErrInvalidCommandUsage
+	}
+
+	members, err := h.organizationApi.GetMembers(context.Background(), organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := presenter.NewList("Members", "email", "role", "status")
+
+	for _, member := range members {
+		result.AddRow(member.Email, member.Role, member.Status)
+	}
+
+	return result, nil
+}
+
+func (h *OrganizationHandler) CreateCollection(args []string) (*presenter.Result, error) {
+	if !h.clientSession.IsAuth() || len(args) < 2 {
+		return nil, domain.ErrInvalidCommandUsage
+	}
+
+	organizationID, err := strconv.Atoi(args[0])
+	if err != nil {
+		return nil, domain.ErrInvalidCommandUsage
+	}
+
+	collection, err := h.organizationApi.CreateCollection(context.Background(), organizationID, strings.Join(args[1:], " "))
+	if err != nil {
+		return nil, err
+	}
+
+	return presenter.NewMessage("Successfully created collection %s with id %d", collection.Name, collection.ID), nil
+}
+
+func (h *OrganizationHandler) GetCollections(args []string) (*presenter.Result, error) {
+	if !h.clientSession.IsAuth() || len(args) != 1 {
+		return nil, domain.ErrInvalidCommandUsage
+	}
+
+	organizationID, err := strconv.Atoi(args[0])
+	if err != nil {
+		return nil, domain.ErrInvalidCommandUsage
+	}
+
+	collections, err := h.organizationApi.GetCollections(context.Background(), organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := presenter.NewList("Collections", "id", "name")
+
+	for _, collection := range collections {
+		result.AddRow(collection.ID, collection.Name)
+	}
+
+	return result, nil
+}
+
+// SwitchVault - switch data commands between personal vault and organization collection
+func (h *OrganizationHandler) SwitchVault(args []string) (*presenter.Result, error) {
+	if len(args) == 0 {
+		if h.clientSession.IsPersonalVault() {
+			return presenter.NewMessage("Active vault: personal"), nil
+		}
+
+		return presenter.NewMessage("Active vault: collection %d", h.clientSession.GetActiveCollectionID()), nil
+	}
+
+	if len(args) != 1 {
+		return nil, domain.ErrInvalidCommandUsage
+	}
+
+	if args[0] == "personal" {
+		if err := h.clientSession.SetActiveCollectionID(0); err != nil {
+			return nil, err
+		}
+
+		return presenter.NewMessage("Switched to personal vault"), nil
+	}
+
+	if !h.clientSession.IsAuth() {
+		return nil, domain.ErrInvalidCommandUsage
+	}
+
+	collectionID, err := strconv.Atoi(args[0])
+	if err != nil || collectionID <= 0 {
+		return nil, domain.ErrInvalidCommandUsage
+	}
+
+	if err := h.clientSession.SetActiveCollectionID(collectionID); err != nil {
+		return nil, err
+	}
+
+	return presenter.NewMessage("Switched to collection %d, records are stored on server only", collectionID), nil
+}
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"fmt"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+	"github.com/MowlCoder/goph-keeper/internal/presenter"
+	"github.com/MowlCoder/goph-keeper/pkg/input"
+)
+
+type profileRegistry interface {
+	GetAll() ([]domain.Profile, error)
+	Create(name string, serverAddr string) (*domain.Profile, error)
+	SetActive(name string) error
+	Delete(name string) error
+}
+
+type ProfileHandler struct {
+	registry       profileRegistry
+	currentProfile string
+}
+
+func NewProfileHandler(
+	registry profileRegistry,
+	currentProfile string,
+) *ProfileHandler {
+	return &ProfileHandler{
+		registry:       registry,
+		currentProfile: currentProfile,
+	}
+}
+
+func (h *ProfileHandler) Handle(args []string) (*presenter.Result, error) {
+	if len(args) == 0 {
+		return nil, domain.ErrInvalidCommandUsage
+	}
+
+	switch args[0] {
+	case "list":
+		return h.list(args[1:])
+	case "create":
+		return h.create(args[1:])
+	case "switch":
+		return h.switchActive(args[1:])
+	case "delete":
+		return h.delete(args[1:])
+	default:
+		return nil, domain.ErrInvalidCommandUsage
+	}
+}
+
+func (h *ProfileHandler) list(args []string) (*presenter.Result, error) {
+	if len(args) != 0 {
+		return nil, domain.ErrInvalidCommandUsage
+	}
+
+	profiles, err := h.registry.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	result := presenter.NewList("Profiles", "name", "open", "active", "server_addr")
+
+	for _, profile := range profiles {
+		result.AddRow(profile.Name, profile.Name == h.currentProfile, profile.Active, profile.ServerAddr)
+	}
+
+	return result, nil
+}
+
+func (h *ProfileHandler) create(args []string) (*presenter.Result, error) {
+	if len(args) < 1 || len(args) > 2 {
+		return nil, domain.ErrInvalidCommandUsage
+	}
+
+	serverAddr := ""
+	if len(args) == 2 {
+		serverAddr = args[1]
+	}
+
+	profile, err := h.registry.Create(args[0], serverAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	return presenter.NewMessage("Profile %s is created, run 'profile switch %s' or start client with --profile %s to use it", profile.Name, profile.Name, profile.Name), nil
+}
+
+func (h *ProfileHandler) switchActive(args []string) (*presenter.Result, error) {
+	if len(args) != 1 {
+		return nil, domain.ErrInvalidCommandUsage
+	}
+
+	if err := h.registry.SetActive(args[0]); err != nil {
+		return nil, err
+	}
+
+	// vault and session of open profile are used by every command, so other profile is opened on next start
+	if args[0] == h.currentProfile {
+		return presenter.NewMessage("Profile %s is active", args[0]), nil
+	}
+
+	return presenter.NewMessage("Profile %s is active, restart client to open it", args[0]), nil
+}
+
+func (h *ProfileHandler) delete(args []string) (*presenter.Result, error) {
+	if len(args) != 1 {
+		return nil, domain.ErrInvalidCommandUsage
+	}
+
+	if args[0] == h.currentProfile {
+		return nil, domain.ErrProfileInUse
+	}
+
+	confirm, err := input.GetConsoleInput(
+		fmt.Sprintf("Local vault of profile with not synced changes will be lost, type '%s' to confirm: ", args[0]),
+		"",
+	)
+	if err != nil {
+		return nil, err
+	}
+	if confirm != args[0] {
+		return presenter.NewMessage("Profile is not deleted"), nil
+	}
+
+	if err := h.registry.Delete(args[0]); err != nil {
+		return nil, err
+	}
+
+	return presenter.NewMessage("Profile %s is deleted", args[0]), nil
+}
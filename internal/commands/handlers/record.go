@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+	"github.com/MowlCoder/goph-keeper/internal/presenter"
+	"github.com/MowlCoder/goph-keeper/pkg/input"
+)
+
+type recordField struct {
+	name  string
+	value any
+}
+
+// recordRef - reference of record is given as the only argument or in id flag
+func recordRef(args []string) (string, bool) {
+	if len(args) == 1 {
+		return args[0], true
+	}
+
+	if len(args) == 0 {
+		ref, ok := input.GetFlag("id")
+		return ref, ok && ref != ""
+	}
+
+	return "", false
+}
+
+// recordResult - result with fields of single record. If field flag is given, only value of this field is in
+// result, so it can be used by scripts as is
+func recordResult(title string, data *domain.UserStoredData, fields []recordField) (*presenter.Result, error) {
+	fields = append([]recordField{
+		{name: "id", value: data.UUID},
+		{name: "version", value: data.Version},
+	}, fields...)
+
+	if name, ok := input.GetFlag("field"); ok {
+		for _, field := range fields {
+			if field.name == name {
+				return presenter.NewValue(field.name, field.value), nil
+			}
+		}
+
+		return nil, domain.ErrUnknownRecordField
+	}
+
+	columns := make([]string, 0, len(fields))
+	values := make([]any, 0, len(fields))
+	for _, field := range fields {
+		columns = append(columns, field.name)
+		values = append(values, field.value)
+	}
+
+	return presenter.NewRecord(title, columns, values), nil
+}
@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+	"github.com/MowlCoder/goph-keeper/internal/presenter"
+	"github.com/MowlCoder/goph-keeper/internal/session"
+)
+
+type shareApi interface {
+	Share(ctx context.Context, dataID int, email string, permission string) (*domain.Share, error)
+	Unshare(ctx context.Context, shareID int) error
+	GetSharedWithMe(ctx context.Context) ([]domain.SharedUserStoredData, error)
+	GetSharedByMe(ctx context.Context) ([]domain.Share, error)
+}
+
+type ShareHandler struct {
+	clientSession         *session.ClientSession
+	shareApi              shareApi
+	userStoredDataService userStoredDataService
+}
+
+func NewShareHandler(
+	clientSession *session.ClientSession,
+	shareApi shareApi,
+	userStoredDataService userStoredDataService,
+) *ShareHandler {
+	return &ShareHandler{
+		clientSession:         clientSession,
+		shareApi:              shareApi,
+		userStoredDataService: userStoredDataService,
+	}
+}
+
+func (h *ShareHandler) Share(args []string) (*presenter.Result, error) {
+	if !h.clientSession.IsAuth() || len(args) < 2 || len(args) > 3 {
+		return nil, domain.ErrInvalidCommandUsage
+	}
+
+	userStoredData, err := h.userStoredDataService.GetByRef(context.Background(), args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	// server knows record only after it was synced
+	if userStoredData.IsLocal() {
+		return nil, domain.ErrDataNotSynced
+	}
+
+	permission := domain.ShareReadPermission
+	if len(args) == 3 {
+		permission = args[2]
+	}
+
+	if !domain.IsValidSharePermission(permission) {
+		return nil, domain.ErrInvalidSharePermission
+	}
+
+	share, err := h.shareApi.Share(context.Background(), userStoredData.ID, args[1], permission)
+	if err != nil {
+		return nil, err
+	}
+
+	return presenter.NewMessage("Successfully shared data with uuid %s to %s (share id %d, %s)", userStoredData.UUID, share.RecipientEmail, share.ID, share.Permission), nil
+}
+
+func (h *ShareHandler) Unshare(args []string) (*presenter.Result, error) {
+	if !h.clientSession.IsAuth() || len(args) != 1 {
+		return nil, domain.ErrInvalidCommandUsage
+	}
+
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return nil, domain.ErrInvalidCommandUsage
+	}
+
+	if err := h.shareApi.Unshare(context.Background(), id); err != nil {
+		return nil, err
+	}
+
+	return presenter.NewMessage("Successfully revoked share with id %d", id), nil
+}
+
+func (h *ShareHandler) Shared(args []string) (*presenter.Result, error) {
+	if !h.clientSession.IsAuth() {
+		return nil, domain.ErrInvalidCommandUsage
+	}
+
+	sharedWithMe, err := h.shareApi.GetSharedWithMe(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	sharedByMe, err := h.shareApi.GetSharedByMe(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	result := presenter.NewList("Shares", "share_id", "direction", "user", "permission", "record_id", "data")
+
+	for _, shared := range sharedWithMe {
+		result.AddRow(
+			shared.Share.ID,
+			"from",
+			shared.Share.OwnerEmail,
+			shared.Share.Permission,
+			shared.Data.ShortUUID(),
+			describeUserStoredData(shared.Data),
+		)
+	}
+
+	for _, share := range sharedByMe {
+		result.AddRow(
+			share.ID,
+			"to",
+			share.RecipientEmail,
+			share.Permission,
+			domain.ShortUUID(share.DataUUID),
+			"",
+		)
+	}
+
+	return result, nil
+}
+
+// describeUserStoredData - secret content of record of any type in one line
+func describeUserStoredData(data domain.UserStoredData) string {
+	switch parsedData := data.Data.(type) {
+	case domain.LogPassData:
+		return fmt.Sprintf("%s:%s", parsedData.Login, parsedData.Password)
+	case domain.CardData:
+		return fmt.Sprintf("%s %s %s", parsedData.Number, parsedData.ExpiredAt, parsedData.CVV)
+	case domain.TextData:
+		return parsedData.Text
+	case domain.FileData:
+		return parsedData.Name
+	default:
+		return ""
+	}
+}
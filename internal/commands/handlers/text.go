@@ -6,6 +6,7 @@ import (
 	"strconv"
 
 	"github.com/MowlCoder/goph-keeper/internal/domain"
+	"github.com/MowlCoder/goph-keeper/internal/presenter"
 	"github.com/MowlCoder/goph-keeper/internal/session"
 	"github.com/MowlCoder/goph-keeper/pkg/input"
 )
@@ -26,18 +27,24 @@ func NewTextHandler(
 	}
 }
 
-func (h *TextHandler) AddText(args []string) error {
-	title := input.GetConsoleInput("Enter title: ", "")
+func (h *TextHandler) AddText(args []string) (*presenter.Result, error) {
+	title, err := input.GetInput("title", "Enter title: ", "")
+	if err != nil {
+		return nil, err
+	}
 	if title == "" {
-		return domain.ErrInvalidInputValue
+		return nil, domain.ErrInvalidInputValue
 	}
 
-	text := input.GetConsoleInput("Enter text: ", "")
+	text, err := input.GetInput("text", "Enter text: ", "")
+	if err != nil {
+		return nil, err
+	}
 	if text == "" {
-		return domain.ErrInvalidInputValue
+		return nil, domain.ErrInvalidInputValue
 	}
 
-	_, err := h.userStoredDataService.Add(
+	_, err = h.userStoredDataService.Add(
 		context.Background(),
 		domain.TextDataType,
 		domain.TextData{
@@ -46,94 +53,96 @@ func (h *TextHandler) AddText(args []string) error {
 		title,
 	)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	fmt.Println("Successfully saved new text!")
-
-	return nil
+	return presenter.NewMessage("Successfully saved new text!"), nil
 }
 
-func (h *TextHandler) UpdateText(args []string) error {
-	if len(args) != 1 {
-		return domain.ErrInvalidCommandUsage
+func (h *TextHandler) UpdateText(args []string) (*presenter.Result, error) {
+	ref, ok := recordRef(args)
+	if !ok {
+		return nil, domain.ErrInvalidCommandUsage
 	}
 
-	id, err := strconv.Atoi(args[0])
+	userStoredData, err := h.userStoredDataService.GetByRef(context.Background(), ref)
 	if err != nil {
-		return domain.ErrInvalidCommandUsage
-	}
-
-	userStoredData, err := h.userStoredDataService.GetByID(context.Background(), id)
-	if err != nil {
-		return err
+		return nil, err
 	}
 
 	data := userStoredData.Data.(domain.TextData)
 
-	title := input.GetConsoleInput(fmt.Sprintf("Enter title (current - %s): ", userStoredData.Meta), userStoredData.Meta)
+	title, err := input.GetInput("title", fmt.Sprintf("Enter title (current - %s): ", userStoredData.Meta), userStoredData.Meta)
+	if err != nil {
+		return nil, err
+	}
 	if title == "" {
-		return domain.ErrInvalidInputValue
+		return nil, domain.ErrInvalidInputValue
 	}
 
-	text := input.GetConsoleInput(fmt.Sprintf("Enter text (current - %s): ", data.Text), data.Text)
+	text, err := input.GetInput("text", fmt.Sprintf("Enter text (current - %s): ", data.Text), data.Text)
+	if err != nil {
+		return nil, err
+	}
 	if text == "" {
-		return domain.ErrInvalidInputValue
+		return nil, domain.ErrInvalidInputValue
 	}
 
-	_, err = h.userStoredDataService.UpdateByID(
+	_, err = h.userStoredDataService.Update(
 		context.Background(),
-		id,
+		userStoredData,
+		userStoredData.Version,
 		domain.TextData{
 			Text: text,
 		},
 		title,
 	)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if id >= 0 {
-		if err := h.clientSession.AddEdited(id); err != nil {
-			return err
+	if !userStoredData.IsLocal() && h.clientSession.IsPersonalVault() {
+		if err := h.clientSession.AddEdited(userStoredData.UUID); err != nil {
+			return nil, err
 		}
 	}
 
-	fmt.Println("Successfully update text data")
-
-	return nil
+	return presenter.NewMessage("Successfully update text data"), nil
 }
 
-func (h *TextHandler) DeleteText(args []string) error {
-	if len(args) != 1 {
-		return domain.ErrInvalidCommandUsage
+func (h *TextHandler) DeleteText(args []string) (*presenter.Result, error) {
+	ref, ok := recordRef(args)
+	if !ok {
+		return nil, domain.ErrInvalidCommandUsage
 	}
 
-	id, err := strconv.Atoi(args[0])
+	userStoredData, err := h.userStoredDataService.GetByRef(context.Background(), ref)
 	if err != nil {
-		return domain.ErrInvalidCommandUsage
+		return nil, err
 	}
 
-	err = h.userStoredDataService.DeleteByID(
+	err = h.userStoredDataService.Delete(
 		context.Background(),
-		id,
+		userStoredData,
 	)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if id >= 0 {
-		if err := h.clientSession.AddDeleted(id); err != nil {
-			return err
+	if !userStoredData.IsLocal() && h.clientSession.IsPersonalVault() {
+		if err := h.clientSession.AddDeleted(userStoredData.UUID, userStoredData.Version); err != nil {
+			return nil, err
 		}
 	}
 
-	fmt.Printf("Successfully delete card with id %d\n", id)
-
-	return nil
+	return presenter.NewMessage("Successfully delete card with uuid %s", userStoredData.UUID), nil
 }
 
-func (h *TextHandler) GetTexts(args []string) error {
+func (h *TextHandler) GetTexts(args []string) (*presenter.Result, error) {
+	if ref, ok := input.GetFlag("id"); ok {
+		return h.getText(ref)
+	}
+
 	count := 15
 	var page int
 	var err error
@@ -163,23 +172,29 @@ func (h *TextHandler) GetTexts(args []string) error {
 		},
 	)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	fmt.Println("================== Text ==================")
+	result := presenter.NewList("Text", "id", "text", "meta", "version")
 
 	for _, data := range paginatedResult.Data.([]domain.UserStoredData) {
 		textData := data.Data.(domain.TextData)
-		fmt.Println(fmt.Sprintf("ID: %d | %s | Meta: %s (version %d)", data.ID, textData.Text, data.Meta, data.Version))
+		result.AddRow(data.ShortUUID(), textData.Text, data.Meta, data.Version)
 	}
 
-	fmt.Println(
-		fmt.Sprintf(
-			"================== (%d/%d) ==================",
-			paginatedResult.CurrentPage,
-			paginatedResult.PageCount,
-		),
-	)
+	return result.WithPage(paginatedResult.CurrentPage, paginatedResult.PageCount), nil
+}
+
+func (h *TextHandler) getText(ref string) (*presenter.Result, error) {
+	userStoredData, err := h.userStoredDataService.GetByRef(context.Background(), ref)
+	if err != nil {
+		return nil, err
+	}
+
+	textData := userStoredData.Data.(domain.TextData)
 
-	return nil
+	return recordResult("Text", userStoredData, []recordField{
+		{name: "title", value: userStoredData.Meta},
+		{name: "text", value: textData.Text},
+	})
 }
@@ -2,10 +2,10 @@ package handlers
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 
 	"github.com/MowlCoder/goph-keeper/internal/domain"
+	"github.com/MowlCoder/goph-keeper/internal/presenter"
 	"github.com/MowlCoder/goph-keeper/internal/session"
 	"github.com/MowlCoder/goph-keeper/pkg/input"
 )
@@ -33,46 +33,60 @@ func NewUserHandler(
 	}
 }
 
-func (h *UserHandler) Register(args []string) error {
-	email := input.GetConsoleInput("Enter email: ", "")
+func (h *UserHandler) Register(args []string) (*presenter.Result, error) {
+	email, err := input.GetInput("email", "Enter email: ", "")
+	if err != nil {
+		return nil, err
+	}
 	if email == "" {
-		return domain.ErrInvalidInputValue
+		return nil, domain.ErrInvalidInputValue
 	}
 
-	password := input.GetConsoleInput("Enter password: ", "")
+	password, err := input.GetInput("password", "Enter password: ", "")
+	if err != nil {
+		return nil, err
+	}
 	if password == "" {
-		return domain.ErrInvalidInputValue
+		return nil, domain.ErrInvalidInputValue
 	}
 
 	token, err := h.userApi.Register(context.Background(), email, password)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	h.session.SetToken(token)
+	if err := h.session.SetToken(token); err != nil {
+		return nil, err
+	}
 
-	return nil
+	return presenter.NewMessage("You successfully registered."), nil
 }
 
-func (h *UserHandler) Authorize(args []string) error {
-	email := input.GetConsoleInput("Enter email: ", "")
+func (h *UserHandler) Authorize(args []string) (*presenter.Result, error) {
+	email, err := input.GetInput("email", "Enter email: ", "")
+	if err != nil {
+		return nil, err
+	}
 	if email == "" {
-		return domain.ErrInvalidInputValue
+		return nil, domain.ErrInvalidInputValue
 	}
 
-	password := input.GetConsoleInput("Enter password: ", "")
+	password, err := input.GetInput("password", "Enter password: ", "")
+	if err != nil {
+		return nil, err
+	}
 	if password == "" {
-		return domain.ErrInvalidInputValue
+		return nil, domain.ErrInvalidInputValue
 	}
 
 	token, err := h.userApi.Authorize(context.Background(), email, password)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	fmt.Println("You successfully authorized.")
-
-	h.session.SetToken(token)
+	if err := h.session.SetToken(token); err != nil {
+		return nil, err
+	}
 
-	return nil
+	return presenter.NewMessage("You successfully authorized."), nil
 }
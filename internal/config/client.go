@@ -2,25 +2,40 @@ package config
 
 import (
 	"flag"
-	"fmt"
 
 	"github.com/caarlos0/env/v9"
 )
 
 // Client - struct responsible for storing client config
 type Client struct {
+	DataDir          string `env:"DATA_DIR" json:"data_dir"`
+	Profile          string `env:"PROFILE" json:"profile"`
 	ServerBaseAddr   string `env:"SERVER_BASE_ADDR" json:"server_base_addr"`
+	RunServerAddr    string `json:"run_server_addr"`
 	ApiServerTimeout int    `env:"API_SERVER_TIMEOUT" json:"api_server_timeout"`
+	OTLPEndpoint     string `env:"OTLP_ENDPOINT" json:"otlp_endpoint"`
+	OTLPInsecure     bool   `env:"OTLP_INSECURE" json:"otlp_insecure"`
+	MergePolicy      string `env:"MERGE_POLICY" json:"merge_policy"`
+	AutoSyncInterval int    `env:"AUTO_SYNC_INTERVAL" json:"auto_sync_interval"`
+	AutoSyncDebounce int    `env:"AUTO_SYNC_DEBOUNCE" json:"auto_sync_debounce"`
+	Output           string `env:"OUTPUT" json:"output"`
 }
 
-// Parse - parse client config from flags and envs
-func (s *Client) Parse() {
-	flag.StringVar(&s.ServerBaseAddr, "server", "", "Base http server address")
+// Parse - parse client config from flags and envs. SERVER_BASE_ADDR is default server, profile without server
+// is bound to it, server given by flag is used only for this run
+func (s *Client) Parse() error {
+	flag.StringVar(&s.DataDir, "data-dir", "", "Directory with profiles and their vaults, platform data directory (e.g. ~/.local/share/goph-keeper) if empty")
+	flag.StringVar(&s.Profile, "profile", "", "Name of profile to open, active profile if empty")
+	flag.StringVar(&s.RunServerAddr, "server", "", "Base http server address used for this run instead of server profile is bound to, profile without server is bound to it")
 	flag.IntVar(&s.ApiServerTimeout, "api-timeout", 60, "Api server timeout in seconds")
+	flag.StringVar(&s.OTLPEndpoint, "otlp-endpoint", "", "OTLP/HTTP collector address (host:port) for traces, empty to disable")
+	flag.BoolVar(&s.OTLPInsecure, "otlp-insecure", false, "If true, traces are sent to collector without TLS")
+	flag.StringVar(&s.MergePolicy, "merge-policy", "conflicted-copy", "How sync resolves field changed both locally and on server (conflicted-copy, prefer-newest, prefer-server, prefer-client or interactive)")
+	flag.IntVar(&s.AutoSyncInterval, "auto-sync-interval", 0, "Interval in seconds between background syncs, 0 to sync only by command and on server changes")
+	flag.IntVar(&s.AutoSyncDebounce, "auto-sync-debounce", 5, "Delay in seconds before background sync after local change")
+	flag.StringVar(&s.Output, "output", "table", "Output format of commands (table, plain or json)")
 
 	flag.Parse()
 
-	if err := env.Parse(s); err != nil {
-		fmt.Printf("%+v\n", err)
-	}
+	return env.Parse(s)
 }
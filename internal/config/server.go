@@ -16,20 +16,49 @@ type Server struct {
 	SSLKeyPath  string `env:"SSL_KEY_PATH" json:"ssl_key_path"`
 
 	DataSecretKey string `env:"DATA_SECRET_KEY" json:"data_secret_key"`
+
+	EmergencyCheckInterval int `env:"EMERGENCY_CHECK_INTERVAL" json:"emergency_check_interval"`
+	TombstoneGCInterval    int `env:"TOMBSTONE_GC_INTERVAL" json:"tombstone_gc_interval"`
+
+	LogLevel string `env:"LOG_LEVEL" json:"log_level"`
+
+	MetricsAddr string `env:"METRICS_ADDR" json:"metrics_addr"`
+
+	ShutdownDelay int `env:"SHUTDOWN_DELAY" json:"shutdown_delay"`
+
+	OTLPEndpoint string `env:"OTLP_ENDPOINT" json:"otlp_endpoint"`
+	OTLPInsecure bool   `env:"OTLP_INSECURE" json:"otlp_insecure"`
 }
 
-// Parse - parse server config from flags and envs
-func (s *Server) Parse() {
+// Parse - parse server config from flags and envs, intervals of background jobs must be at least one second
+func (s *Server) Parse() error {
 	flag.StringVar(&s.HTTPAddr, "http", ":4000", "Server will listen this http address")
 	flag.StringVar(&s.DatabaseDSN, "dns", "", "Database connect uri")
 	flag.BoolVar(&s.EnableHTTPS, "https", false, "If true, server will use HTTPS")
 	flag.StringVar(&s.SSLPemPath, "pp", "", "Path to SSL pem file")
 	flag.StringVar(&s.SSLKeyPath, "kp", "", "Path to SSL key file")
 	flag.StringVar(&s.DataSecretKey, "data-secret", "secretttsecretttsecretttsecrettt", "Secret for crypt data")
+	flag.StringVar(&s.MetricsAddr, "metrics", ":9090", "Prometheus metrics will be served on this http address, empty to disable")
+	flag.StringVar(&s.OTLPEndpoint, "otlp-endpoint", "", "OTLP/HTTP collector address (host:port) for traces, empty to disable")
+	flag.BoolVar(&s.OTLPInsecure, "otlp-insecure", false, "If true, traces are sent to collector without TLS")
+	flag.StringVar(&s.LogLevel, "log-level", "info", "Log level: debug, info, warn or error")
+	flag.IntVar(&s.ShutdownDelay, "shutdown-delay", 5, "Seconds between failing readiness probe and closing listener on shutdown")
+	flag.IntVar(&s.EmergencyCheckInterval, "emergency-check-interval", 60, "Interval in seconds between checks of expired emergency access waiting periods")
+	flag.IntVar(&s.TombstoneGCInterval, "tombstone-gc-interval", 3600, "Interval in seconds between collections of deleted records acknowledged by all devices")
 
 	flag.Parse()
 
 	if err := env.Parse(s); err != nil {
-		fmt.Printf("%+v\n", err)
+		return err
 	}
+
+	if s.EmergencyCheckInterval < 1 {
+		return fmt.Errorf("emergency check interval must be at least 1 second, got %d", s.EmergencyCheckInterval)
+	}
+
+	if s.TombstoneGCInterval < 1 {
+		return fmt.Errorf("tombstone gc interval must be at least 1 second, got %d", s.TombstoneGCInterval)
+	}
+
+	return nil
 }
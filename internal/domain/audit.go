@@ -0,0 +1,33 @@
+package domain
+
+import "time"
+
+// Audit event types. RecordID of data and share events is id of data record, of share.delete it is id of
+// share, of emergency.vault_access id of emergency access and of device.revoke id of device
+const (
+	AuditEventRegister       = "user.register"
+	AuditEventLogin          = "user.login"
+	AuditEventLoginFailed    = "user.login_failed"
+	AuditEventTokenRefresh   = "user.token_refresh"
+	AuditEventDataCreate     = "data.create"
+	AuditEventDataUpdate     = "data.update"
+	AuditEventDataDelete     = "data.delete"
+	AuditEventVaultExport    = "data.export"
+	AuditEventShareCreate    = "share.create"
+	AuditEventShareDelete    = "share.delete"
+	AuditEventEmergencyVault = "emergency.vault_access"
+	AuditEventDeviceRevoke   = "device.revoke"
+)
+
+type AuditEvent struct {
+	ID        int64     `json:"id"`
+	UserID    int       `json:"user_id"`
+	Type      string    `json:"type"`
+	RecordID  int       `json:"record_id,omitempty"`
+	ClientIP  string    `json:"client_ip"`
+	UserAgent string    `json:"user_agent"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Email - used to resolve user of failed login attempt, never stored
+	Email string `json:"-"`
+}
@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+const (
+	DeviceNameMaxLength     = 64
+	DevicePlatformMaxLength = 32
+
+	// DefaultDeviceName - name of device registered by client which did not send any
+	DefaultDeviceName = "unknown device"
+
+	// DeviceInactiveAfter - device not seen this long does not hold back collection of tombstones, when it
+	// comes back after they are collected, it downloads whole vault again
+	DeviceInactiveAfter = 90 * 24 * time.Hour
+)
+
+// Device - client installation user logged in from. Every token is bound to device, revoked device
+// loses access immediately. SyncCursor is last position of personal vault change feed acknowledged by device
+type Device struct {
+	ID         int       `json:"id"`
+	UserID     int       `json:"user_id"`
+	Name       string    `json:"name"`
+	Platform   string    `json:"platform"`
+	SyncCursor int64     `json:"sync_cursor"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	// Current - device which made request
+	Current bool `json:"current"`
+}
@@ -0,0 +1,58 @@
+package domain
+
+import "time"
+
+const (
+	EmergencyAccessInvited           = "invited"
+	EmergencyAccessConfirmed         = "confirmed"
+	EmergencyAccessRecoveryInitiated = "recovery-initiated"
+	EmergencyAccessApproved          = "approved"
+	EmergencyAccessRejected          = "rejected"
+
+	EmergencyAccessMinWaitDays = 1
+	EmergencyAccessMaxWaitDays = 90
+)
+
+// emergencyAccessTransitions - allowed status changes, rejected access can be requested again
+var emergencyAccessTransitions = map[string][]string{
+	EmergencyAccessInvited:           {EmergencyAccessConfirmed},
+	EmergencyAccessConfirmed:         {EmergencyAccessRecoveryInitiated},
+	EmergencyAccessRecoveryInitiated: {EmergencyAccessApproved, EmergencyAccessRejected},
+	EmergencyAccessRejected:          {EmergencyAccessRecoveryInitiated},
+}
+
+type EmergencyAccess struct {
+	ID                  int        `json:"id"`
+	GrantorID           int        `json:"grantor_id"`
+	GrantorEmail        string     `json:"grantor_email"`
+	GranteeID           int        `json:"grantee_id"`
+	GranteeEmail        string     `json:"grantee_email"`
+	Status              string     `json:"status"`
+	WaitDays            int        `json:"wait_days"`
+	RecoveryInitiatedAt *time.Time `json:"recovery_initiated_at,omitempty"`
+	CreatedAt           time.Time  `json:"created_at"`
+}
+
+// CanTransitionTo - check if access can be moved from current status to given one
+func (access EmergencyAccess) CanTransitionTo(status string) bool {
+	for _, allowed := range emergencyAccessTransitions[access.Status] {
+		if allowed == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ApprovesAt - time when recovery will be approved automatically if grantor does not reject it
+func (access EmergencyAccess) ApprovesAt() time.Time {
+	if access.RecoveryInitiatedAt == nil {
+		return time.Time{}
+	}
+
+	return access.RecoveryInitiatedAt.Add(time.Duration(access.WaitDays) * 24 * time.Hour)
+}
+
+func IsValidEmergencyWaitDays(days int) bool {
+	return days >= EmergencyAccessMinWaitDays && days <= EmergencyAccessMaxWaitDays
+}
@@ -13,6 +13,55 @@ var (
 
 	ErrUserStoredDataNotFound = errors.New("user stored data not found")
 	ErrInvalidDataType        = errors.New("invalid data type")
+	ErrInvalidRecordRef       = errors.New("invalid record reference (uuid or at least 4 first characters of it)")
+	ErrAmbiguousRecordRef     = errors.New("several records match uuid prefix, enter more characters")
+	ErrAmbiguousRecordID      = errors.New("number matches both record id and uuid prefix, use id:<id> or more characters of uuid")
+	ErrRecordUUIDTaken        = errors.New("record with this uuid already exists")
+
+	ErrShareNotFound          = errors.New("share not found")
+	ErrShareWithYourself      = errors.New("can not share data with yourself")
+	ErrNotEnoughPermissions   = errors.New("not enough permissions")
+	ErrInvalidSharePermission = errors.New("invalid share permission (read or write)")
+	ErrDataNotSynced          = errors.New("data must be synced with server first")
+
+	ErrOrganizationNotFound       = errors.New("organization not found")
+	ErrOrganizationInviteNotFound = errors.New("organization invite not found")
+	ErrAlreadyOrganizationMember  = errors.New("user is already organization member")
+	ErrInvalidOrganizationRole    = errors.New("invalid organization role (admin, member or read-only)")
+	ErrCollectionNotFound         = errors.New("collection not found")
+
+	ErrEmergencyAccessNotFound      = errors.New("emergency access not found")
+	ErrEmergencyAccessAlreadyExists = errors.New("emergency access for this user already exists")
+	ErrEmergencyAccessInvalidStatus = errors.New("action is not allowed in current emergency access status")
+	ErrEmergencyAccessToYourself    = errors.New("can not grant emergency access to yourself")
+	ErrInvalidEmergencyWaitDays     = errors.New("invalid emergency access wait days (from 1 to 90)")
+
+	ErrInvalidSyncCursor    = errors.New("invalid sync cursor")
+	ErrSyncPushRejected     = errors.New("server data changed during sync, run sync again")
+	ErrInvalidMergePolicy   = errors.New("invalid merge policy (conflicted-copy, prefer-newest, prefer-server, prefer-client or interactive)")
+	ErrSyncConflictNotFound = errors.New("sync conflict not found")
+	ErrInteractiveAutoSync  = errors.New("background sync can not ask about conflicts, choose non-interactive merge policy")
+	ErrInteractiveOneShot   = errors.New("single command mode can not ask about conflicts, choose non-interactive merge policy")
+	ErrSyncCursorExpired    = errors.New("sync cursor is older than collected deletions, full sync is required")
+	ErrVaultInUse           = errors.New("vault is in use by another goph-keeper client, close it first")
+	ErrLocalDataCorrupted   = errors.New("local data is corrupted or encrypted with another vault key")
+	ErrVaultNotPrivate      = errors.New("vault can be accessed by other users, allow access only to owner (chmod 700 for directories, 600 for files)")
+
+	ErrDeviceNotFound = errors.New("device not found")
+
+	ErrProfileNotFound      = errors.New("profile not found")
+	ErrProfileAlreadyExists = errors.New("profile already exists")
+	ErrInvalidProfileName   = errors.New("invalid profile name (letters, digits, '-' and '_', up to 32 characters)")
+	ErrProfileInUse         = errors.New("profile is open, switch to another profile and restart client first")
+	ErrDefaultProfileDelete = errors.New("default profile can not be deleted")
+
+	ErrDataVersionConflict = errors.New("data was changed by someone else, reload it and try again")
+	ErrInvalidDataVersion  = errors.New("invalid expected data version")
+	ErrDataVersionRequired = errors.New("expected data version is required, pass ETag of record in If-Match header")
+
+	ErrDatabaseUnavailable    = errors.New("database is unavailable")
+	ErrDatabaseSchemaOutdated = errors.New("database schema is not up to date")
+	ErrShuttingDown           = errors.New("server is shutting down")
 
 	ErrInvalidCardNumber    = errors.New("invalid card number")
 	ErrInvalidCardExpiredAt = errors.New("invalid card expired at (e.g. 4/30)")
@@ -21,5 +70,8 @@ var (
 	ErrCommandNotFound     = errors.New("command not found")
 	ErrQuitApp             = errors.New("requested quit from the app")
 	ErrInvalidCommandUsage = errors.New("invalid command usage")
+	ErrCommandCanceled     = errors.New("command canceled")
 	ErrInvalidInputValue   = errors.New("invalid input value")
+	ErrUnknownRecordField  = errors.New("record does not have such field")
+	ErrInvalidOutputFormat = errors.New("invalid output format (table, plain or json)")
 )
@@ -0,0 +1,75 @@
+package domain
+
+import "time"
+
+const (
+	OrganizationOwnerRole    = "owner"
+	OrganizationAdminRole    = "admin"
+	OrganizationMemberRole   = "member"
+	OrganizationReadOnlyRole = "read-only"
+
+	OrganizationMemberInvited  = "invited"
+	OrganizationMemberAccepted = "accepted"
+)
+
+type Organization struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type OrganizationMember struct {
+	ID             int       `json:"id"`
+	OrganizationID int       `json:"organization_id"`
+	UserID         int       `json:"user_id"`
+	Email          string    `json:"email"`
+	Role           string    `json:"role"`
+	Status         string    `json:"status"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+func (member OrganizationMember) IsAccepted() bool {
+	return member.Status == OrganizationMemberAccepted
+}
+
+func (member OrganizationMember) CanRead() bool {
+	return member.IsAccepted()
+}
+
+func (member OrganizationMember) CanWrite() bool {
+	if !member.IsAccepted() {
+		return false
+	}
+
+	return member.Role == OrganizationOwnerRole ||
+		member.Role == OrganizationAdminRole ||
+		member.Role == OrganizationMemberRole
+}
+
+func (member OrganizationMember) CanManage() bool {
+	if !member.IsAccepted() {
+		return false
+	}
+
+	return member.Role == OrganizationOwnerRole || member.Role == OrganizationAdminRole
+}
+
+type OrganizationMembership struct {
+	Organization Organization `json:"organization"`
+	Role         string       `json:"role"`
+	Status       string       `json:"status"`
+}
+
+type Collection struct {
+	ID             int       `json:"id"`
+	OrganizationID int       `json:"organization_id"`
+	Name           string    `json:"name"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// IsValidInviteRole - owner role is given only to organization creator, so it can not be used in invites
+func IsValidInviteRole(role string) bool {
+	return role == OrganizationAdminRole ||
+		role == OrganizationMemberRole ||
+		role == OrganizationReadOnlyRole
+}
@@ -0,0 +1,17 @@
+package domain
+
+const (
+	// DefaultProfileName - profile used when no other is created, data of previous versions is moved into it
+	DefaultProfileName = "default"
+
+	ProfileNameMaxLength = 32
+)
+
+// Profile - named local account of client. Every profile has its own data directory with vault and session
+// and is bound to one server, so data of different profiles never mixes in sync
+type Profile struct {
+	Name       string `json:"name"`
+	ServerAddr string `json:"server_addr"`
+	// Active - profile opened when client is started without profile flag
+	Active bool `json:"-"`
+}
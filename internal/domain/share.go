@@ -0,0 +1,33 @@
+package domain
+
+import "time"
+
+const (
+	ShareReadPermission  = "read"
+	ShareWritePermission = "write"
+)
+
+type Share struct {
+	ID             int       `json:"id"`
+	DataID         int       `json:"data_id"`
+	DataUUID       string    `json:"data_uuid"`
+	OwnerID        int       `json:"owner_id"`
+	OwnerEmail     string    `json:"owner_email"`
+	RecipientID    int       `json:"recipient_id"`
+	RecipientEmail string    `json:"recipient_email"`
+	Permission     string    `json:"permission"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+func (share Share) CanWrite() bool {
+	return share.Permission == ShareWritePermission
+}
+
+type SharedUserStoredData struct {
+	Share Share          `json:"share"`
+	Data  UserStoredData `json:"data"`
+}
+
+func IsValidSharePermission(permission string) bool {
+	return permission == ShareReadPermission || permission == ShareWritePermission
+}
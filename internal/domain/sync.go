@@ -0,0 +1,109 @@
+package domain
+
+import "time"
+
+const (
+	SyncChangesDefaultLimit = 500
+	SyncChangesMaxLimit     = 1000
+)
+
+// DataChange - latest state of record changed after cursor, Data is nil for deleted record
+type DataChange struct {
+	Seq     int64           `json:"seq"`
+	ID      int             `json:"id"`
+	UUID    string          `json:"uuid"`
+	Deleted bool            `json:"deleted"`
+	Data    *UserStoredData `json:"data,omitempty"`
+}
+
+// ChangeFeed - page of changes ordered by sequence. Cursor must be passed as since to get next page,
+// it is not moved back when page is empty
+type ChangeFeed struct {
+	Changes []DataChange `json:"changes"`
+	Cursor  int64        `json:"cursor"`
+	HasMore bool         `json:"has_more"`
+}
+
+const (
+	SyncOperationCreate = "create"
+	SyncOperationUpdate = "update"
+	SyncOperationDelete = "delete"
+
+	SyncPushStatusApplied  = "applied"
+	SyncPushStatusConflict = "conflict"
+	SyncPushStatusNotFound = "not_found"
+	SyncPushStatusInvalid  = "invalid"
+	// SyncPushStatusSkipped - item was valid, but batch was rolled back because of other item
+	SyncPushStatusSkipped = "skipped"
+)
+
+// SyncPushItem - one change of batch pushed by client. Record is identified by its uuid, created record
+// keeps uuid generated by client. Version is expected current version of record on server, it is required
+// for update and delete
+type SyncPushItem struct {
+	UUID        string      `json:"uuid"`
+	Operation   string      `json:"operation"`
+	Version     int         `json:"version,omitempty"`
+	DataType    string      `json:"data_type,omitempty"`
+	Data        interface{} `json:"data,omitempty"`
+	CryptedData []byte      `json:"-"`
+	Meta        string      `json:"meta,omitempty"`
+}
+
+type SyncPushResult struct {
+	UUID    string `json:"uuid"`
+	Status  string `json:"status"`
+	ID      int    `json:"id,omitempty"`
+	Version int    `json:"version,omitempty"`
+}
+
+// SyncPushResponse - results in order of pushed items. Batch is applied only as a whole, if Applied is
+// false nothing was changed on server
+type SyncPushResponse struct {
+	Applied bool             `json:"applied"`
+	Results []SyncPushResult `json:"results"`
+}
+
+// LocalSyncChanges - changes sync makes in local store. They are applied in one transaction, so failed sync
+// leaves local store as it was before it. Records of server are saved with their server ids and versions,
+// conflicted copies are saved as new local records
+type LocalSyncChanges struct {
+	Synced  []SyncPushResult
+	Deleted []string
+	Updated []UserStoredData
+	Added   []UserStoredData
+	Copies  []UserStoredData
+}
+
+func IsValidSyncOperation(operation string) bool {
+	return operation == SyncOperationCreate || operation == SyncOperationUpdate || operation == SyncOperationDelete
+}
+
+// SyncConflict - record changed both on client and on server, local values of conflicting fields were kept
+// in conflicted copy record until user compares both records and resolves conflict
+type SyncConflict struct {
+	RecordUUID string    `json:"record_uuid"`
+	CopyUUID   string    `json:"copy_uuid"`
+	DataType   string    `json:"data_type"`
+	Fields     []string  `json:"fields"`
+	DetectedAt time.Time `json:"detected_at"`
+}
+
+// SyncConflictDeletedOnServer - conflict field of record deleted on server while it was edited on client,
+// the whole local version is kept in conflicted copy
+const SyncConflictDeletedOnServer = "deleted_on_server"
+
+// SyncStatus - state of client synchronization, it is kept only while client is running
+type SyncStatus struct {
+	LastSuccessAt  time.Time
+	LastAttemptAt  time.Time
+	LastError      error
+	PendingChanges int
+}
+
+// DataChangeEvent - records of personal vault of user (CollectionID = 0) or of organization collection were
+// changed, connected clients are notified to sync
+type DataChangeEvent struct {
+	UserID       int `json:"user_id"`
+	CollectionID int `json:"collection_id"`
+}
@@ -4,5 +4,6 @@ import "github.com/golang-jwt/jwt/v4"
 
 type TokenClaim struct {
 	jwt.RegisteredClaims
-	ID int `json:"id"`
+	ID       int `json:"id"`
+	DeviceID int `json:"device_id"`
 }
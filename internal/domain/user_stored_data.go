@@ -1,8 +1,13 @@
 package domain
 
 import (
+	"crypto/md5"
 	"encoding/json"
+	"strconv"
+	"strings"
 	"time"
+
+	"github.com/google/uuid"
 )
 
 const (
@@ -12,20 +17,91 @@ const (
 	FileDataType    = "file"
 )
 
+// UserStoredData - UUID is generated by client which created record and identifies record on every device,
+// ID is given by server and is 0 for records which were not synced yet
 type UserStoredData struct {
-	ID          int         `json:"id"`
-	UserID      int         `json:"user_id"`
-	DataType    string      `json:"data_type"`
-	Data        interface{} `json:"data"`
-	PathOnDisc  string      `json:"path_on_disc,omitempty"`
-	CryptedData []byte      `json:"crypted_data,omitempty"`
+	ID           int         `json:"id"`
+	UUID         string      `json:"uuid"`
+	UserID       int         `json:"user_id"`
+	CollectionID int         `json:"collection_id,omitempty"`
+	DataType     string      `json:"data_type"`
+	Data         interface{} `json:"data"`
+	PathOnDisc   string      `json:"path_on_disc,omitempty"`
+	CryptedData  []byte      `json:"crypted_data,omitempty"`
+	Meta         string      `json:"meta"`
+	Version      int         `json:"version"`
+	CreatedAt    time.Time   `json:"created_at"`
+	UpdatedAt    time.Time   `json:"updated_at"`
+	// Base - kept only by client for records synced with server
+	Base *SyncBase `json:"base,omitempty" swaggerignore:"true"`
+}
+
+// SyncBase - record as it was at last successful sync, common ancestor for three-way merge
+// of record changed both on client and on server
+type SyncBase struct {
+	Data        interface{} `json:"-"`
+	CryptedData []byte      `json:"crypted_data"`
 	Meta        string      `json:"meta"`
-	Version     int         `json:"version"`
-	CreatedAt   time.Time   `json:"created_at"`
+}
+
+func (data UserStoredData) IsPersonal() bool {
+	return data.CollectionID == 0
 }
 
 func (data UserStoredData) IsLocal() bool {
-	return data.Version == -1 || data.ID < 0
+	return data.Version == -1 || data.ID <= 0
+}
+
+// ShortUUID - prefix of uuid shown in lists, commands accept it instead of full uuid
+func (data UserStoredData) ShortUUID() string {
+	return ShortUUID(data.UUID)
+}
+
+const (
+	// ShortUUIDLength - length of uuid prefix shown to user
+	ShortUUIDLength = 8
+	// RecordRefMinLength - shortest uuid prefix accepted as record reference
+	RecordRefMinLength = 4
+	// RecordIDRefPrefix - record reference starting with it is id of record, never uuid prefix
+	RecordIDRefPrefix = "id:"
+)
+
+func ShortUUID(recordUUID string) string {
+	if len(recordUUID) <= ShortUUIDLength {
+		return recordUUID
+	}
+
+	return recordUUID[:ShortUUIDLength]
+}
+
+// IsValidUUID - check that value is uuid in canonical form
+func IsValidUUID(value string) bool {
+	parsed, err := uuid.Parse(value)
+	return err == nil && parsed.String() == value
+}
+
+// ParseRecordRef - normalize full uuid or its prefix given by user. Prefix may contain dashes, as it is
+// copied from uuid
+func ParseRecordRef(ref string) (string, error) {
+	ref = strings.ToLower(strings.TrimSpace(ref))
+	if len(ref) < RecordRefMinLength || len(ref) > len(uuid.Nil.String()) {
+		return "", ErrInvalidRecordRef
+	}
+
+	for _, r := range ref {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'f') && r != '-' {
+			return "", ErrInvalidRecordRef
+		}
+	}
+
+	return ref, nil
+}
+
+// LegacyRecordUUID - uuid of record created before records had uuids. Server and clients derive it from
+// server id the same way, so records synced before upgrade keep matching each other
+func LegacyRecordUUID(id int) string {
+	sum := md5.Sum([]byte("user_stored_data:" + strconv.Itoa(id)))
+	return uuid.UUID(sum).String()
 }
 
 type LogPassData struct {
@@ -52,6 +128,7 @@ type AddUserStoredDataBody interface {
 	Valid() bool
 	GetData() interface{}
 	GetMeta() string
+	GetUUID() string
 }
 
 func ParseUserStoredData(dataType string, data []byte) (interface{}, error) {
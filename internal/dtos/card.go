@@ -3,12 +3,13 @@ package dtos
 import "github.com/MowlCoder/goph-keeper/internal/domain"
 
 type AddNewCardBody struct {
+	RecordUUID
 	Data domain.CardData `json:"data"`
 	Meta string          `json:"meta"`
 }
 
 func (b *AddNewCardBody) Valid() bool {
-	if b.Data.Number == "" || b.Data.ExpiredAt == "" || b.Data.CVV == "" {
+	if !b.validUUID() || b.Data.Number == "" || b.Data.ExpiredAt == "" || b.Data.CVV == "" {
 		return false
 	}
 
@@ -1,5 +1,7 @@
 package dtos
 
+import "github.com/MowlCoder/goph-keeper/internal/domain"
+
 type DeleteBatchBody struct {
 	IDs []int `json:"ids"`
 }
@@ -11,3 +13,16 @@ func (b *DeleteBatchBody) Valid() bool {
 
 	return true
 }
+
+// RecordUUID - uuid generated by client for new record, server generates it when uuid is omitted
+type RecordUUID struct {
+	UUID string `json:"uuid,omitempty"`
+}
+
+func (b *RecordUUID) GetUUID() string {
+	return b.UUID
+}
+
+func (b *RecordUUID) validUUID() bool {
+	return b.UUID == "" || domain.IsValidUUID(b.UUID)
+}
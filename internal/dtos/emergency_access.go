@@ -0,0 +1,16 @@
+package dtos
+
+import "github.com/MowlCoder/goph-keeper/internal/domain"
+
+type InviteEmergencyContactBody struct {
+	Email    string `json:"email"`
+	WaitDays int    `json:"wait_days"`
+}
+
+func (b *InviteEmergencyContactBody) Valid() bool {
+	if b.Email == "" {
+		return false
+	}
+
+	return domain.IsValidEmergencyWaitDays(b.WaitDays)
+}
@@ -0,0 +1,55 @@
+package dtos
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInviteEmergencyContactBody_Valid(t *testing.T) {
+	testCases := []struct {
+		name  string
+		body  InviteEmergencyContactBody
+		valid bool
+	}{
+		{
+			name: "valid",
+			body: InviteEmergencyContactBody{
+				Email:    "test@gmail.com",
+				WaitDays: 7,
+			},
+			valid: true,
+		},
+		{
+			name: "no valid (empty email)",
+			body: InviteEmergencyContactBody{
+				Email:    "",
+				WaitDays: 7,
+			},
+			valid: false,
+		},
+		{
+			name: "no valid (zero wait days)",
+			body: InviteEmergencyContactBody{
+				Email:    "test@gmail.com",
+				WaitDays: 0,
+			},
+			valid: false,
+		},
+		{
+			name: "no valid (too long wait)",
+			body: InviteEmergencyContactBody{
+				Email:    "test@gmail.com",
+				WaitDays: 365,
+			},
+			valid: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			valid := testCase.body.Valid()
+			assert.Equal(t, testCase.valid, valid)
+		})
+	}
+}
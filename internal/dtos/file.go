@@ -3,12 +3,13 @@ package dtos
 import "github.com/MowlCoder/goph-keeper/internal/domain"
 
 type AddNewFileBody struct {
+	RecordUUID
 	Data domain.FileData `json:"data"`
 	Meta string          `json:"meta"`
 }
 
 func (b *AddNewFileBody) Valid() bool {
-	if len(b.Data.Content) == 0 || b.Data.Name == "" {
+	if !b.validUUID() || len(b.Data.Content) == 0 || b.Data.Name == "" {
 		return false
 	}
 
@@ -0,0 +1,11 @@
+package dtos
+
+type HealthStatusResponse struct {
+	Status string `json:"status"`
+	Reason string `json:"reason,omitempty"`
+}
+
+type VersionResponse struct {
+	Version   string `json:"version"`
+	BuildDate string `json:"build_date"`
+}
@@ -3,12 +3,13 @@ package dtos
 import "github.com/MowlCoder/goph-keeper/internal/domain"
 
 type AddNewLogPassBody struct {
+	RecordUUID
 	Data domain.LogPassData `json:"data"`
 	Meta string             `json:"meta"`
 }
 
 func (b *AddNewLogPassBody) Valid() bool {
-	if b.Data.Login == "" || b.Data.Password == "" {
+	if !b.validUUID() || b.Data.Login == "" || b.Data.Password == "" {
 		return false
 	}
 
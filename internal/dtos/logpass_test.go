@@ -54,6 +54,28 @@ func TestAddNewLogPassBody_Valid(t *testing.T) {
 			},
 			valid: false,
 		},
+		{
+			name: "valid with uuid",
+			body: AddNewLogPassBody{
+				RecordUUID: RecordUUID{UUID: testUUID1},
+				Data: domain.LogPassData{
+					Login:    "login",
+					Password: "password",
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "no valid uuid",
+			body: AddNewLogPassBody{
+				RecordUUID: RecordUUID{UUID: "123"},
+				Data: domain.LogPassData{
+					Login:    "login",
+					Password: "password",
+				},
+			},
+			valid: false,
+		},
 	}
 
 	for _, testCase := range testCases {
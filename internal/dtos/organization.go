@@ -0,0 +1,32 @@
+package dtos
+
+import "github.com/MowlCoder/goph-keeper/internal/domain"
+
+type CreateOrganizationBody struct {
+	Name string `json:"name"`
+}
+
+func (b *CreateOrganizationBody) Valid() bool {
+	return b.Name != ""
+}
+
+type InviteOrganizationMemberBody struct {
+	Email string `json:"email"`
+	Role  string `json:"role"`
+}
+
+func (b *InviteOrganizationMemberBody) Valid() bool {
+	if b.Email == "" {
+		return false
+	}
+
+	return domain.IsValidInviteRole(b.Role)
+}
+
+type CreateCollectionBody struct {
+	Name string `json:"name"`
+}
+
+func (b *CreateCollectionBody) Valid() bool {
+	return b.Name != ""
+}
@@ -0,0 +1,101 @@
+package dtos
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+)
+
+func TestCreateOrganizationBody_Valid(t *testing.T) {
+	testCases := []struct {
+		name  string
+		body  CreateOrganizationBody
+		valid bool
+	}{
+		{
+			name:  "valid",
+			body:  CreateOrganizationBody{Name: "Family"},
+			valid: true,
+		},
+		{
+			name:  "no valid (empty name)",
+			body:  CreateOrganizationBody{Name: ""},
+			valid: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			valid := testCase.body.Valid()
+			assert.Equal(t, testCase.valid, valid)
+		})
+	}
+}
+
+func TestInviteOrganizationMemberBody_Valid(t *testing.T) {
+	testCases := []struct {
+		name  string
+		body  InviteOrganizationMemberBody
+		valid bool
+	}{
+		{
+			name: "valid",
+			body: InviteOrganizationMemberBody{
+				Email: "test@gmail.com",
+				Role:  domain.OrganizationReadOnlyRole,
+			},
+			valid: true,
+		},
+		{
+			name: "no valid (empty email)",
+			body: InviteOrganizationMemberBody{
+				Email: "",
+				Role:  domain.OrganizationMemberRole,
+			},
+			valid: false,
+		},
+		{
+			name: "no valid (owner role)",
+			body: InviteOrganizationMemberBody{
+				Email: "test@gmail.com",
+				Role:  domain.OrganizationOwnerRole,
+			},
+			valid: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			valid := testCase.body.Valid()
+			assert.Equal(t, testCase.valid, valid)
+		})
+	}
+}
+
+func TestCreateCollectionBody_Valid(t *testing.T) {
+	testCases := []struct {
+		name  string
+		body  CreateCollectionBody
+		valid bool
+	}{
+		{
+			name:  "valid",
+			body:  CreateCollectionBody{Name: "Servers"},
+			valid: true,
+		},
+		{
+			name:  "no valid (empty name)",
+			body:  CreateCollectionBody{Name: ""},
+			valid: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			valid := testCase.body.Valid()
+			assert.Equal(t, testCase.valid, valid)
+		})
+	}
+}
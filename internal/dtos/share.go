@@ -0,0 +1,21 @@
+package dtos
+
+import "github.com/MowlCoder/goph-keeper/internal/domain"
+
+type CreateShareBody struct {
+	DataID     int    `json:"data_id"`
+	Email      string `json:"email"`
+	Permission string `json:"permission"`
+}
+
+func (b *CreateShareBody) Valid() bool {
+	if b.DataID <= 0 || b.Email == "" {
+		return false
+	}
+
+	if !domain.IsValidSharePermission(b.Permission) {
+		return false
+	}
+
+	return true
+}
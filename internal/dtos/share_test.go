@@ -0,0 +1,61 @@
+package dtos
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+)
+
+func TestCreateShareBody_Valid(t *testing.T) {
+	testCases := []struct {
+		name  string
+		body  CreateShareBody
+		valid bool
+	}{
+		{
+			name: "valid",
+			body: CreateShareBody{
+				DataID:     1,
+				Email:      "test@gmail.com",
+				Permission: domain.ShareReadPermission,
+			},
+			valid: true,
+		},
+		{
+			name: "no valid (empty email)",
+			body: CreateShareBody{
+				DataID:     1,
+				Email:      "",
+				Permission: domain.ShareWritePermission,
+			},
+			valid: false,
+		},
+		{
+			name: "no valid (local data id)",
+			body: CreateShareBody{
+				DataID:     -1,
+				Email:      "test@gmail.com",
+				Permission: domain.ShareReadPermission,
+			},
+			valid: false,
+		},
+		{
+			name: "no valid (permission)",
+			body: CreateShareBody{
+				DataID:     1,
+				Email:      "test@gmail.com",
+				Permission: "admin",
+			},
+			valid: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			valid := testCase.body.Valid()
+			assert.Equal(t, testCase.valid, valid)
+		})
+	}
+}
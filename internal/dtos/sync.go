@@ -0,0 +1,103 @@
+package dtos
+
+import (
+	"encoding/json"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+)
+
+type SyncPushItem struct {
+	UUID      string          `json:"uuid"`
+	Operation string          `json:"operation"`
+	Version   int             `json:"version,omitempty"`
+	DataType  string          `json:"data_type,omitempty"`
+	Data      json.RawMessage `json:"data,omitempty" swaggertype:"object"`
+	Meta      string          `json:"meta,omitempty"`
+}
+
+type SyncPushBody struct {
+	Items []SyncPushItem `json:"items"`
+}
+
+// Valid - check batch of changes. Number of items is not limited, only size of request body is, because
+// batch is applied in one transaction and split batch would leave server half-changed when its part fails
+func (b *SyncPushBody) Valid() bool {
+	if len(b.Items) == 0 {
+		return false
+	}
+
+	uuids := make(map[string]struct{}, len(b.Items))
+
+	for _, item := range b.Items {
+		if !domain.IsValidSyncOperation(item.Operation) || !domain.IsValidUUID(item.UUID) {
+			return false
+		}
+
+		// record can be changed only once per batch, otherwise expected versions make no sense
+		if _, ok := uuids[item.UUID]; ok {
+			return false
+		}
+		uuids[item.UUID] = struct{}{}
+
+		if item.Operation != domain.SyncOperationCreate && item.Version <= 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// NewUserDataBody - get empty body for data of given type
+func NewUserDataBody(dataType string) (domain.AddUserStoredDataBody, error) {
+	switch dataType {
+	case domain.LogPassDataType:
+		return &AddNewLogPassBody{}, nil
+	case domain.CardDataType:
+		return &AddNewCardBody{}, nil
+	case domain.TextDataType:
+		return &AddNewTextBody{}, nil
+	case domain.FileDataType:
+		return &AddNewFileBody{}, nil
+	default:
+		return nil, domain.ErrInvalidDataType
+	}
+}
+
+// ParseUserDataBody - parse and validate raw data and meta of record with given type
+func ParseUserDataBody(dataType string, data json.RawMessage, meta string) (domain.AddUserStoredDataBody, error) {
+	body, err := NewUserDataBody(dataType)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := json.Marshal(struct {
+		Data json.RawMessage `json:"data"`
+		Meta string          `json:"meta"`
+	}{
+		Data: data,
+		Meta: meta,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(raw, body); err != nil || !body.Valid() {
+		return nil, domain.ErrInvalidBody
+	}
+
+	return body, nil
+}
+
+// ParseData - parse and validate data of create or update item
+func (item *SyncPushItem) ParseData() (domain.AddUserStoredDataBody, error) {
+	return ParseUserDataBody(item.DataType, item.Data, item.Meta)
+}
+
+// SyncAckBody - cursor of change feed device has fully applied
+type SyncAckBody struct {
+	Cursor int64 `json:"cursor"`
+}
+
+func (b *SyncAckBody) Valid() bool {
+	return b.Cursor >= 0
+}
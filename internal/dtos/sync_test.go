@@ -0,0 +1,143 @@
+package dtos
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+)
+
+const (
+	testUUID1 = "0b7e2f3c-5f0a-4c8e-9d7a-2f1e6b3a4c51"
+	testUUID2 = "1c8f3a4d-6a1b-4d9f-8e8b-3a2f7c4b5d62"
+	testUUID3 = "2d9a4b5e-7b2c-4ea0-9f9c-4b3a8d5c6e73"
+)
+
+func TestSyncPushBody_Valid(t *testing.T) {
+	testCases := []struct {
+		name  string
+		body  SyncPushBody
+		valid bool
+	}{
+		{
+			// batch is applied in one transaction, so its size is limited only by size of request body
+			name:  "large batch",
+			body:  largeSyncPushBody(1200),
+			valid: true,
+		},
+		{
+			name: "valid",
+			body: SyncPushBody{
+				Items: []SyncPushItem{
+					{UUID: testUUID1, Operation: domain.SyncOperationCreate, DataType: domain.TextDataType},
+					{UUID: testUUID2, Operation: domain.SyncOperationUpdate, Version: 3, DataType: domain.TextDataType},
+					{UUID: testUUID3, Operation: domain.SyncOperationDelete, Version: 2},
+				},
+			},
+			valid: true,
+		},
+		{
+			name:  "no valid (empty)",
+			body:  SyncPushBody{},
+			valid: false,
+		},
+		{
+			name: "no valid (operation)",
+			body: SyncPushBody{
+				Items: []SyncPushItem{{UUID: testUUID1, Operation: "upsert"}},
+			},
+			valid: false,
+		},
+		{
+			name: "no valid (record changed twice)",
+			body: SyncPushBody{
+				Items: []SyncPushItem{
+					{UUID: testUUID1, Operation: domain.SyncOperationUpdate, Version: 1},
+					{UUID: testUUID1, Operation: domain.SyncOperationDelete},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "no valid (update without version)",
+			body: SyncPushBody{
+				Items: []SyncPushItem{{UUID: testUUID1, Operation: domain.SyncOperationUpdate}},
+			},
+			valid: false,
+		},
+		{
+			name: "no valid (delete without version)",
+			body: SyncPushBody{
+				Items: []SyncPushItem{{UUID: testUUID1, Operation: domain.SyncOperationDelete}},
+			},
+			valid: false,
+		},
+		{
+			name: "no valid (delete without uuid)",
+			body: SyncPushBody{
+				Items: []SyncPushItem{{Operation: domain.SyncOperationDelete}},
+			},
+			valid: false,
+		},
+		{
+			name: "no valid (uuid)",
+			body: SyncPushBody{
+				Items: []SyncPushItem{{UUID: "not-uuid", Operation: domain.SyncOperationCreate}},
+			},
+			valid: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			valid := testCase.body.Valid()
+			assert.Equal(t, testCase.valid, valid)
+		})
+	}
+}
+
+func largeSyncPushBody(size int) SyncPushBody {
+	body := SyncPushBody{Items: make([]SyncPushItem, 0, size)}
+	for idx := 1; idx <= size; idx++ {
+		body.Items = append(body.Items, SyncPushItem{
+			UUID:      fmt.Sprintf("00000000-0000-4000-8000-%012d", idx),
+			Operation: domain.SyncOperationDelete,
+			Version:   1,
+		})
+	}
+
+	return body
+}
+
+func TestSyncPushItem_ParseData(t *testing.T) {
+	testCases := []struct {
+		name string
+		item SyncPushItem
+		err  error
+	}{
+		{
+			name: "valid",
+			item: SyncPushItem{DataType: domain.TextDataType, Data: json.RawMessage(`{"text":"text"}`)},
+		},
+		{
+			name: "unknown data type",
+			item: SyncPushItem{DataType: "unknown", Data: json.RawMessage(`{"text":"text"}`)},
+			err:  domain.ErrInvalidDataType,
+		},
+		{
+			name: "invalid data",
+			item: SyncPushItem{DataType: domain.TextDataType, Data: json.RawMessage(`{"text":""}`)},
+			err:  domain.ErrInvalidBody,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := testCase.item.ParseData()
+			assert.ErrorIs(t, err, testCase.err)
+		})
+	}
+}
@@ -3,12 +3,13 @@ package dtos
 import "github.com/MowlCoder/goph-keeper/internal/domain"
 
 type AddNewTextBody struct {
+	RecordUUID
 	Data domain.TextData `json:"data"`
 	Meta string          `json:"meta"`
 }
 
 func (b *AddNewTextBody) Valid() bool {
-	if b.Data.Text == "" {
+	if !b.validUUID() || b.Data.Text == "" {
 		return false
 	}
 
@@ -3,6 +3,9 @@ package dtos
 import (
 	"regexp"
 	"unicode"
+	"unicode/utf8"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
 )
 
 var (
@@ -36,13 +39,28 @@ func validatePassword(password string) bool {
 	return hasNumber && hasUpper && hasLower && hasSpecial
 }
 
+// DeviceInfo - device user logs in from, it is registered with returned token. DeviceID is device client
+// logged in from before, it is reused instead of registering new one
+type DeviceInfo struct {
+	DeviceID       int    `json:"device_id,omitempty"`
+	DeviceName     string `json:"device_name,omitempty"`
+	DevicePlatform string `json:"device_platform,omitempty"`
+}
+
+func (d *DeviceInfo) validDevice() bool {
+	return d.DeviceID >= 0 &&
+		utf8.RuneCountInString(d.DeviceName) <= domain.DeviceNameMaxLength &&
+		utf8.RuneCountInString(d.DevicePlatform) <= domain.DevicePlatformMaxLength
+}
+
 type RegisterBody struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
+	DeviceInfo
 }
 
 func (b *RegisterBody) Validate() bool {
-	if b.Email == "" || b.Password == "" {
+	if b.Email == "" || b.Password == "" || !b.validDevice() {
 		return false
 	}
 
@@ -64,10 +82,11 @@ type RegisterResponse struct {
 type AuthorizeBody struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
+	DeviceInfo
 }
 
 func (b *AuthorizeBody) Validate() bool {
-	if b.Email == "" || b.Password == "" {
+	if b.Email == "" || b.Password == "" || !b.validDevice() {
 		return false
 	}
 
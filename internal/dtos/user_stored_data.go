@@ -0,0 +1,22 @@
+package dtos
+
+import (
+	"encoding/json"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+)
+
+// UpdateUserDataBody - Version is required expected current version of record, it can be passed in If-Match
+// header instead
+type UpdateUserDataBody struct {
+	Data    json.RawMessage `json:"data" swaggertype:"object"`
+	Meta    string          `json:"meta"`
+	Version int             `json:"version,omitempty"`
+}
+
+// DataVersionConflictResponse - returned when record was changed after client read it, Current is server copy
+type DataVersionConflictResponse struct {
+	Code    int                    `json:"code"`
+	Error   string                 `json:"error"`
+	Current *domain.UserStoredData `json:"current"`
+}
@@ -1,9 +1,12 @@
 package dtos
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
 )
 
 func TestRegisterBody_Validate(t *testing.T) {
@@ -92,6 +95,24 @@ func TestAuthorizeBody_Validate(t *testing.T) {
 			},
 			valid: false,
 		},
+		{
+			name: "with device",
+			body: AuthorizeBody{
+				Email:      "email@email.com",
+				Password:   "Password",
+				DeviceInfo: DeviceInfo{DeviceName: "laptop", DevicePlatform: "linux/amd64"},
+			},
+			valid: true,
+		},
+		{
+			name: "too long device name",
+			body: AuthorizeBody{
+				Email:      "email@email.com",
+				Password:   "Password",
+				DeviceInfo: DeviceInfo{DeviceName: strings.Repeat("a", domain.DeviceNameMaxLength+1)},
+			},
+			valid: false,
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -0,0 +1,103 @@
+package events
+
+import (
+	"context"
+	"sync"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+)
+
+// subscriberBuffer - client only needs to know that something changed, so events which do not fit into
+// buffer of slow subscriber are dropped
+const subscriberBuffer = 8
+
+type subscriptionKey struct {
+	userID       int
+	collectionID int
+}
+
+// keyOf - changes of personal vault are delivered to its owner, changes of collection to every subscriber
+// of that collection
+func keyOf(userID int, collectionID int) subscriptionKey {
+	if collectionID != 0 {
+		return subscriptionKey{collectionID: collectionID}
+	}
+
+	return subscriptionKey{userID: userID}
+}
+
+// Broker - in-process pub/sub of record changes, delivers events to clients connected to this server instance
+type Broker struct {
+	mu          sync.Mutex
+	subscribers map[subscriptionKey]map[chan domain.DataChangeEvent]struct{}
+	closed      bool
+}
+
+func NewBroker() *Broker {
+	return &Broker{
+		subscribers: make(map[subscriptionKey]map[chan domain.DataChangeEvent]struct{}),
+	}
+}
+
+// Publish - deliver event to subscribers of changed vault without blocking on slow ones
+func (b *Broker) Publish(ctx context.Context, event domain.DataChangeEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subscribers[keyOf(event.UserID, event.CollectionID)] {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// Subscribe - get events of personal vault of user (collectionID = 0) or of organization collection.
+// Returned function must be called when subscriber leaves, channel is closed by it or by Close
+func (b *Broker) Subscribe(userID int, collectionID int) (<-chan domain.DataChangeEvent, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ch := make(chan domain.DataChangeEvent, subscriberBuffer)
+	if b.closed {
+		close(ch)
+		return ch, func() {}
+	}
+
+	key := keyOf(userID, collectionID)
+	if b.subscribers[key] == nil {
+		b.subscribers[key] = make(map[chan domain.DataChangeEvent]struct{})
+	}
+	b.subscribers[key][ch] = struct{}{}
+
+	unsubscribe := func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		if _, ok := b.subscribers[key][ch]; !ok {
+			return
+		}
+
+		delete(b.subscribers[key], ch)
+		if len(b.subscribers[key]) == 0 {
+			delete(b.subscribers, key)
+		}
+		close(ch)
+	}
+
+	return ch, unsubscribe
+}
+
+// Close - close channels of all subscribers, so long-lived connections finish on server shutdown
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for key, channels := range b.subscribers {
+		for ch := range channels {
+			close(ch)
+		}
+		delete(b.subscribers, key)
+	}
+}
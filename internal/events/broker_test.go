@@ -0,0 +1,83 @@
+package events
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+)
+
+func TestBroker_Publish(t *testing.T) {
+	testCases := []struct {
+		name         string
+		userID       int
+		collectionID int
+		event        domain.DataChangeEvent
+		delivered    bool
+	}{
+		{
+			name:      "personal vault change is delivered to owner",
+			userID:    1,
+			event:     domain.DataChangeEvent{UserID: 1},
+			delivered: true,
+		},
+		{
+			name:   "personal vault change is not delivered to other user",
+			userID: 2,
+			event:  domain.DataChangeEvent{UserID: 1},
+		},
+		{
+			name:         "collection change is delivered to any collection subscriber",
+			userID:       2,
+			collectionID: 5,
+			event:        domain.DataChangeEvent{UserID: 1, CollectionID: 5},
+			delivered:    true,
+		},
+		{
+			name:   "collection change is not delivered to personal vault subscriber",
+			userID: 1,
+			event:  domain.DataChangeEvent{UserID: 1, CollectionID: 5},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			broker := NewBroker()
+			events, unsubscribe := broker.Subscribe(testCase.userID, testCase.collectionID)
+			defer unsubscribe()
+
+			broker.Publish(context.Background(), testCase.event)
+
+			assert.Equal(t, testCase.delivered, len(events) == 1)
+		})
+	}
+}
+
+func TestBroker_SlowSubscriber(t *testing.T) {
+	broker := NewBroker()
+	events, unsubscribe := broker.Subscribe(1, 0)
+	defer unsubscribe()
+
+	for i := 0; i < subscriberBuffer*2; i++ {
+		broker.Publish(context.Background(), domain.DataChangeEvent{UserID: 1})
+	}
+
+	assert.Equal(t, subscriberBuffer, len(events))
+}
+
+func TestBroker_Close(t *testing.T) {
+	broker := NewBroker()
+	events, unsubscribe := broker.Subscribe(1, 0)
+
+	broker.Close()
+	unsubscribe()
+
+	_, ok := <-events
+	assert.False(t, ok)
+
+	afterClose, _ := broker.Subscribe(1, 0)
+	_, ok = <-afterClose
+	assert.False(t, ok)
+}
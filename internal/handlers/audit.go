@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+	"github.com/MowlCoder/goph-keeper/internal/handlers/httperrors"
+	"github.com/MowlCoder/goph-keeper/internal/utils/usercontext"
+	"github.com/MowlCoder/goph-keeper/pkg/httputils"
+)
+
+type auditRecorder interface {
+	Record(ctx context.Context, event domain.AuditEvent)
+}
+
+type auditService interface {
+	GetUserEvents(ctx context.Context, userID int, filters *domain.StorageFilters) (*domain.PaginatedResult, error)
+}
+
+type AuditHandler struct {
+	service auditService
+}
+
+func NewAuditHandler(service auditService) *AuditHandler {
+	return &AuditHandler{
+		service: service,
+	}
+}
+
+// GetMy godoc
+// @Summary Get security history of current user, newest first
+// @Produce json
+// @Tags audit
+// @Security Bearer
+// @Param page query int false "Page, 1 by default"
+// @Param count query int false "Events per page, 50 by default"
+// @Success 200 {object} domain.PaginatedResult
+// @Failure 401
+// @Failure 500 {object} httputils.HTTPError
+// @Router /api/v1/audit [get]
+func (h *AuditHandler) GetMy(w http.ResponseWriter, r *http.Request) {
+	userID, err := usercontext.GetUserIDFromContext(r.Context())
+	if err != nil {
+		httperrors.Handle(w, domain.ErrNotAuth)
+		return
+	}
+
+	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil || page <= 0 {
+		page = 1
+	}
+	count, err := strconv.Atoi(r.URL.Query().Get("count"))
+	if err != nil || count <= 0 || count > 300 {
+		count = 50
+	}
+
+	paginatedResult, err := h.service.GetUserEvents(r.Context(), userID, &domain.StorageFilters{
+		IsPaginated:    true,
+		IsSortedByDate: true,
+		Pagination: domain.PaginationFilters{
+			Page:  page,
+			Count: count,
+		},
+		SortDate: domain.SortDateFilters{
+			IsASC: false,
+		},
+	})
+	if err != nil {
+		httperrors.Handle(w, err)
+		return
+	}
+
+	httputils.SendJSONResponse(w, http.StatusOK, paginatedResult)
+}
+
+// newAuditEvent - fill event with client info from request
+func newAuditEvent(r *http.Request, eventType string, userID int, recordID int) domain.AuditEvent {
+	clientIP, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		clientIP = r.RemoteAddr
+	}
+
+	return domain.AuditEvent{
+		UserID:    userID,
+		Type:      eventType,
+		RecordID:  recordID,
+		ClientIP:  clientIP,
+		UserAgent: r.UserAgent(),
+	}
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+	mock_handlers "github.com/MowlCoder/goph-keeper/internal/handlers/mocks"
+	"github.com/MowlCoder/goph-keeper/internal/utils/usercontext"
+)
+
+type auditTestSuite struct {
+	suite.Suite
+
+	service *mock_handlers.MockauditService
+
+	handler *AuditHandler
+}
+
+func (suite *auditTestSuite) SetupSuite() {
+}
+
+func (suite *auditTestSuite) TearDownSuite() {
+}
+
+func (suite *auditTestSuite) SetupTest() {
+	ctrl := gomock.NewController(suite.T())
+
+	suite.service = mock_handlers.NewMockauditService(ctrl)
+
+	suite.handler = NewAuditHandler(suite.service)
+}
+
+func (suite *auditTestSuite) TearDownTest() {
+}
+
+func TestAuditSuite(t *testing.T) {
+	suite.Run(t, new(auditTestSuite))
+}
+
+func (suite *auditTestSuite) TestGetMy() {
+	testCases := []struct {
+		name       string
+		statusCode int
+		query      string
+		prepare    func() int
+	}{
+		{
+			name:       "valid",
+			statusCode: http.StatusOK,
+			query:      "?page=2&count=10",
+			prepare: func() int {
+				userID := 1
+
+				suite.service.
+					EXPECT().
+					GetUserEvents(gomock.Any(), userID, &domain.StorageFilters{
+						IsPaginated:    true,
+						IsSortedByDate: true,
+						Pagination:     domain.PaginationFilters{Page: 2, Count: 10},
+					}).
+					Return(&domain.PaginatedResult{}, nil)
+
+				return userID
+			},
+		},
+		{
+			name:       "default pagination",
+			statusCode: http.StatusOK,
+			query:      "?page=abc&count=1000",
+			prepare: func() int {
+				userID := 1
+
+				suite.service.
+					EXPECT().
+					GetUserEvents(gomock.Any(), userID, &domain.StorageFilters{
+						IsPaginated:    true,
+						IsSortedByDate: true,
+						Pagination:     domain.PaginationFilters{Page: 1, Count: 50},
+					}).
+					Return(&domain.PaginatedResult{}, nil)
+
+				return userID
+			},
+		},
+		{
+			name:       "internal error",
+			statusCode: http.StatusInternalServerError,
+			prepare: func() int {
+				userID := 1
+
+				suite.service.
+					EXPECT().
+					GetUserEvents(gomock.Any(), userID, gomock.Any()).
+					Return(nil, domain.ErrInternal)
+
+				return userID
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.name, func() {
+			userID := testCase.prepare()
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/audit"+testCase.query, nil)
+			r = r.WithContext(usercontext.SetUserIDToContext(r.Context(), userID))
+			w := httptest.NewRecorder()
+
+			suite.handler.GetMy(w, r)
+			res := w.Result()
+			defer res.Body.Close()
+
+			suite.Equal(testCase.statusCode, res.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+	"github.com/MowlCoder/goph-keeper/internal/handlers/httperrors"
+	"github.com/MowlCoder/goph-keeper/internal/utils/usercontext"
+	"github.com/MowlCoder/goph-keeper/pkg/httputils"
+)
+
+type deviceService interface {
+	GetUserDevices(ctx context.Context, userID int, currentDeviceID int) ([]domain.Device, error)
+	Revoke(ctx context.Context, userID int, deviceID int) error
+}
+
+type DeviceHandler struct {
+	service deviceService
+	audit   auditRecorder
+}
+
+func NewDeviceHandler(service deviceService, audit auditRecorder) *DeviceHandler {
+	return &DeviceHandler{
+		service: service,
+		audit:   audit,
+	}
+}
+
+// GetMy godoc
+// @Summary Get devices current user is logged in from, device of request is marked as current
+// @Produce json
+// @Tags devices
+// @Security Bearer
+// @Success 200 {array} domain.Device
+// @Failure 401
+// @Failure 500 {object} httputils.HTTPError
+// @Router /api/v1/devices [get]
+func (h *DeviceHandler) GetMy(w http.ResponseWriter, r *http.Request) {
+	userID, err := usercontext.GetUserIDFromContext(r.Context())
+	if err != nil {
+		httperrors.Handle(w, domain.ErrNotAuth)
+		return
+	}
+
+	deviceID, err := usercontext.GetDeviceIDFromContext(r.Context())
+	if err != nil {
+		httperrors.Handle(w, domain.ErrNotAuth)
+		return
+	}
+
+	devices, err := h.service.GetUserDevices(r.Context(), userID, deviceID)
+	if err != nil {
+		httperrors.Handle(w, err)
+		return
+	}
+
+	httputils.SendJSONResponse(w, http.StatusOK, devices)
+}
+
+// Revoke godoc
+// @Summary Revoke device, its token is rejected on next request
+// @Produce json
+// @Tags devices
+// @Security Bearer
+// @Param id path string true "Device ID"
+// @Success 204
+// @Failure 401
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Router /api/v1/devices/{id} [delete]
+func (h *DeviceHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	userID, err := usercontext.GetUserIDFromContext(r.Context())
+	if err != nil {
+		httperrors.Handle(w, domain.ErrNotAuth)
+		return
+	}
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		httperrors.Handle(w, domain.ErrDeviceNotFound)
+		return
+	}
+
+	if err := h.service.Revoke(r.Context(), userID, id); err != nil {
+		httperrors.Handle(w, err)
+		return
+	}
+
+	h.audit.Record(r.Context(), newAuditEvent(r, domain.AuditEventDeviceRevoke, userID, id))
+
+	httputils.SendStatusCode(w, http.StatusNoContent)
+}
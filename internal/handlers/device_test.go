@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+	mock_handlers "github.com/MowlCoder/goph-keeper/internal/handlers/mocks"
+	"github.com/MowlCoder/goph-keeper/internal/utils/usercontext"
+)
+
+type deviceTestSuite struct {
+	suite.Suite
+
+	service *mock_handlers.MockdeviceService
+	audit   *mock_handlers.MockauditRecorder
+
+	handler *DeviceHandler
+}
+
+func (suite *deviceTestSuite) SetupSuite() {
+}
+
+func (suite *deviceTestSuite) TearDownSuite() {
+}
+
+func (suite *deviceTestSuite) SetupTest() {
+	ctrl := gomock.NewController(suite.T())
+
+	suite.service = mock_handlers.NewMockdeviceService(ctrl)
+	suite.audit = mock_handlers.NewMockauditRecorder(ctrl)
+	suite.audit.EXPECT().Record(gomock.Any(), gomock.Any()).AnyTimes()
+
+	suite.handler = NewDeviceHandler(suite.service, suite.audit)
+}
+
+func (suite *deviceTestSuite) TearDownTest() {
+}
+
+func TestDeviceSuite(t *testing.T) {
+	suite.Run(t, new(deviceTestSuite))
+}
+
+func (suite *deviceTestSuite) TestGetMy() {
+	testCases := []struct {
+		name       string
+		statusCode int
+		prepare    func() int
+	}{
+		{
+			name:       "valid",
+			statusCode: http.StatusOK,
+			prepare: func() int {
+				userID := 1
+
+				suite.service.
+					EXPECT().
+					GetUserDevices(gomock.Any(), userID, 2).
+					Return([]domain.Device{{ID: 2, Current: true}}, nil)
+
+				return userID
+			},
+		},
+		{
+			name:       "internal error",
+			statusCode: http.StatusInternalServerError,
+			prepare: func() int {
+				userID := 1
+
+				suite.service.
+					EXPECT().
+					GetUserDevices(gomock.Any(), userID, 2).
+					Return(nil, domain.ErrInternal)
+
+				return userID
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.name, func() {
+			userID := testCase.prepare()
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/devices", nil)
+			ctx := usercontext.SetUserIDToContext(r.Context(), userID)
+			r = r.WithContext(usercontext.SetDeviceIDToContext(ctx, 2))
+			w := httptest.NewRecorder()
+
+			suite.handler.GetMy(w, r)
+			res := w.Result()
+			defer res.Body.Close()
+
+			suite.Equal(testCase.statusCode, res.StatusCode)
+		})
+	}
+}
+
+func (suite *deviceTestSuite) TestRevoke() {
+	testCases := []struct {
+		name       string
+		statusCode int
+		prepare    func() (int, string)
+	}{
+		{
+			name:       "valid",
+			statusCode: http.StatusNoContent,
+			prepare: func() (int, string) {
+				userID := 1
+
+				suite.service.
+					EXPECT().
+					Revoke(gomock.Any(), userID, 5).
+					Return(nil)
+
+				return userID, "5"
+			},
+		},
+		{
+			name:       "invalid id",
+			statusCode: http.StatusNotFound,
+			prepare: func() (int, string) {
+				return 1, "test"
+			},
+		},
+		{
+			name:       "device not found",
+			statusCode: http.StatusNotFound,
+			prepare: func() (int, string) {
+				userID := 1
+
+				suite.service.
+					EXPECT().
+					Revoke(gomock.Any(), userID, 5).
+					Return(domain.ErrDeviceNotFound)
+
+				return userID, "5"
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.name, func() {
+			userID, id := testCase.prepare()
+			r := httptest.NewRequest(http.MethodDelete, "/api/v1/devices/"+id, nil)
+			r = r.WithContext(usercontext.SetUserIDToContext(r.Context(), userID))
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", id)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			suite.handler.Revoke(w, r)
+			res := w.Result()
+			defer res.Body.Close()
+
+			suite.Equal(testCase.statusCode, res.StatusCode)
+		})
+	}
+}
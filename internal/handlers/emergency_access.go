@@ -0,0 +1,266 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+	"github.com/MowlCoder/goph-keeper/internal/dtos"
+	"github.com/MowlCoder/goph-keeper/internal/handlers/httperrors"
+	"github.com/MowlCoder/goph-keeper/internal/utils/usercontext"
+	"github.com/MowlCoder/goph-keeper/pkg/httputils"
+	jsonutil "github.com/MowlCoder/goph-keeper/pkg/jsonutils"
+)
+
+type emergencyAccessService interface {
+	Invite(ctx context.Context, grantorID int, granteeEmail string, waitDays int) (*domain.EmergencyAccess, error)
+	Confirm(ctx context.Context, granteeID int, id int) error
+	Initiate(ctx context.Context, granteeID int, id int) error
+	Approve(ctx context.Context, grantorID int, id int) error
+	Reject(ctx context.Context, grantorID int, id int) error
+	Revoke(ctx context.Context, userID int, id int) error
+	GetGranted(ctx context.Context, grantorID int) ([]domain.EmergencyAccess, error)
+	GetTrusted(ctx context.Context, granteeID int) ([]domain.EmergencyAccess, error)
+	GetVault(ctx context.Context, granteeID int, id int) ([]domain.UserStoredData, error)
+}
+
+type EmergencyAccessHandler struct {
+	service emergencyAccessService
+	audit   auditRecorder
+}
+
+func NewEmergencyAccessHandler(service emergencyAccessService, audit auditRecorder) *EmergencyAccessHandler {
+	return &EmergencyAccessHandler{
+		service: service,
+		audit:   audit,
+	}
+}
+
+// Invite godoc
+// @Summary Designate user as trusted emergency contact
+// @Accept json
+// @Produce json
+// @Tags emergency
+// @Security Bearer
+// @Param dto body dtos.InviteEmergencyContactBody true "body"
+// @Success 201 {object} domain.EmergencyAccess
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 401
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 409 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Router /api/v1/emergency [post]
+func (h *EmergencyAccessHandler) Invite(w http.ResponseWriter, r *http.Request) {
+	userID, err := usercontext.GetUserIDFromContext(r.Context())
+	if err != nil {
+		httperrors.Handle(w, domain.ErrNotAuth)
+		return
+	}
+
+	var body dtos.InviteEmergencyContactBody
+	if statusCode, err := jsonutil.Unmarshal(w, r, &body); err != nil {
+		httputils.SendJSONErrorResponse(w, statusCode, err.Error(), statusCode)
+		return
+	}
+
+	if !body.Valid() {
+		httperrors.Handle(w, domain.ErrInvalidBody)
+		return
+	}
+
+	access, err := h.service.Invite(r.Context(), userID, body.Email, body.WaitDays)
+	if err != nil {
+		httperrors.Handle(w, err)
+		return
+	}
+
+	httputils.SendJSONResponse(w, http.StatusCreated, access)
+}
+
+// GetGranted godoc
+// @Summary Get emergency accesses granted by current user
+// @Produce json
+// @Tags emergency
+// @Security Bearer
+// @Success 200 {array} domain.EmergencyAccess
+// @Failure 401
+// @Failure 500 {object} httputils.HTTPError
+// @Router /api/v1/emergency/granted [get]
+func (h *EmergencyAccessHandler) GetGranted(w http.ResponseWriter, r *http.Request) {
+	userID, err := usercontext.GetUserIDFromContext(r.Context())
+	if err != nil {
+		httperrors.Handle(w, domain.ErrNotAuth)
+		return
+	}
+
+	accesses, err := h.service.GetGranted(r.Context(), userID)
+	if err != nil {
+		httperrors.Handle(w, err)
+		return
+	}
+
+	httputils.SendJSONResponse(w, http.StatusOK, accesses)
+}
+
+// GetTrusted godoc
+// @Summary Get emergency accesses where current user is trusted contact
+// @Produce json
+// @Tags emergency
+// @Security Bearer
+// @Success 200 {array} domain.EmergencyAccess
+// @Failure 401
+// @Failure 500 {object} httputils.HTTPError
+// @Router /api/v1/emergency/trusted [get]
+func (h *EmergencyAccessHandler) GetTrusted(w http.ResponseWriter, r *http.Request) {
+	userID, err := usercontext.GetUserIDFromContext(r.Context())
+	if err != nil {
+		httperrors.Handle(w, domain.ErrNotAuth)
+		return
+	}
+
+	accesses, err := h.service.GetTrusted(r.Context(), userID)
+	if err != nil {
+		httperrors.Handle(w, err)
+		return
+	}
+
+	httputils.SendJSONResponse(w, http.StatusOK, accesses)
+}
+
+// Confirm godoc
+// @Summary Confirm being trusted contact (grantee)
+// @Tags emergency
+// @Security Bearer
+// @Param id path string true "Emergency Access ID"
+// @Success 204
+// @Failure 401
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 409 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Router /api/v1/emergency/{id}/confirm [post]
+func (h *EmergencyAccessHandler) Confirm(w http.ResponseWriter, r *http.Request) {
+	h.handleAction(w, r, h.service.Confirm)
+}
+
+// Initiate godoc
+// @Summary Request access to grantor vault, starts waiting period (grantee)
+// @Tags emergency
+// @Security Bearer
+// @Param id path string true "Emergency Access ID"
+// @Success 204
+// @Failure 401
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 409 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Router /api/v1/emergency/{id}/initiate [post]
+func (h *EmergencyAccessHandler) Initiate(w http.ResponseWriter, r *http.Request) {
+	h.handleAction(w, r, h.service.Initiate)
+}
+
+// Approve godoc
+// @Summary Approve recovery before waiting period is over (grantor)
+// @Tags emergency
+// @Security Bearer
+// @Param id path string true "Emergency Access ID"
+// @Success 204
+// @Failure 401
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 409 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Router /api/v1/emergency/{id}/approve [post]
+func (h *EmergencyAccessHandler) Approve(w http.ResponseWriter, r *http.Request) {
+	h.handleAction(w, r, h.service.Approve)
+}
+
+// Reject godoc
+// @Summary Reject recovery (grantor)
+// @Tags emergency
+// @Security Bearer
+// @Param id path string true "Emergency Access ID"
+// @Success 204
+// @Failure 401
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 409 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Router /api/v1/emergency/{id}/reject [post]
+func (h *EmergencyAccessHandler) Reject(w http.ResponseWriter, r *http.Request) {
+	h.handleAction(w, r, h.service.Reject)
+}
+
+// Delete godoc
+// @Summary Revoke emergency access (available for grantor and grantee)
+// @Tags emergency
+// @Security Bearer
+// @Param id path string true "Emergency Access ID"
+// @Success 204
+// @Failure 401
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Router /api/v1/emergency/{id} [delete]
+func (h *EmergencyAccessHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	h.handleAction(w, r, h.service.Revoke)
+}
+
+// GetVault godoc
+// @Summary Get grantor vault with approved emergency access (grantee)
+// @Produce json
+// @Tags emergency
+// @Security Bearer
+// @Param id path string true "Emergency Access ID"
+// @Success 200 {array} domain.UserStoredData
+// @Failure 401
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 409 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Router /api/v1/emergency/{id}/vault [get]
+func (h *EmergencyAccessHandler) GetVault(w http.ResponseWriter, r *http.Request) {
+	userID, err := usercontext.GetUserIDFromContext(r.Context())
+	if err != nil {
+		httperrors.Handle(w, domain.ErrNotAuth)
+		return
+	}
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		httperrors.Handle(w, domain.ErrEmergencyAccessNotFound)
+		return
+	}
+
+	dataSet, err := h.service.GetVault(r.Context(), userID, id)
+	if err != nil {
+		httperrors.Handle(w, err)
+		return
+	}
+
+	h.audit.Record(r.Context(), newAuditEvent(r, domain.AuditEventEmergencyVault, userID, id))
+
+	httputils.SendJSONResponse(w, http.StatusOK, dataSet)
+}
+
+func (h *EmergencyAccessHandler) handleAction(
+	w http.ResponseWriter,
+	r *http.Request,
+	action func(ctx context.Context, userID int, id int) error,
+) {
+	userID, err := usercontext.GetUserIDFromContext(r.Context())
+	if err != nil {
+		httperrors.Handle(w, domain.ErrNotAuth)
+		return
+	}
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		httperrors.Handle(w, domain.ErrEmergencyAccessNotFound)
+		return
+	}
+
+	if err := action(r.Context(), userID, id); err != nil {
+		httperrors.Handle(w, err)
+		return
+	}
+
+	httputils.SendStatusCode(w, http.StatusNoContent)
+}
@@ -0,0 +1,244 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+	"github.com/MowlCoder/goph-keeper/internal/dtos"
+	mock_handlers "github.com/MowlCoder/goph-keeper/internal/handlers/mocks"
+	"github.com/MowlCoder/goph-keeper/internal/utils/usercontext"
+)
+
+type emergencyAccessTestSuite struct {
+	suite.Suite
+
+	service *mock_handlers.MockemergencyAccessService
+	audit   *mock_handlers.MockauditRecorder
+
+	handler *EmergencyAccessHandler
+}
+
+func (suite *emergencyAccessTestSuite) SetupSuite() {
+}
+
+func (suite *emergencyAccessTestSuite) TearDownSuite() {
+}
+
+func (suite *emergencyAccessTestSuite) SetupTest() {
+	ctrl := gomock.NewController(suite.T())
+
+	suite.service = mock_handlers.NewMockemergencyAccessService(ctrl)
+	suite.audit = mock_handlers.NewMockauditRecorder(ctrl)
+	suite.audit.EXPECT().Record(gomock.Any(), gomock.Any()).AnyTimes()
+
+	suite.handler = NewEmergencyAccessHandler(suite.service, suite.audit)
+}
+
+func (suite *emergencyAccessTestSuite) TearDownTest() {
+}
+
+func TestEmergencyAccessSuite(t *testing.T) {
+	suite.Run(t, new(emergencyAccessTestSuite))
+}
+
+func (suite *emergencyAccessTestSuite) TestInvite() {
+	testCases := []struct {
+		name       string
+		statusCode int
+		prepare    func() (int, []byte)
+	}{
+		{
+			name:       "valid",
+			statusCode: http.StatusCreated,
+			prepare: func() (int, []byte) {
+				userID := 1
+				body := dtos.InviteEmergencyContactBody{
+					Email:    "test@gmail.com",
+					WaitDays: 7,
+				}
+				b, _ := json.Marshal(body)
+
+				suite.service.
+					EXPECT().
+					Invite(gomock.Any(), userID, body.Email, body.WaitDays).
+					Return(&domain.EmergencyAccess{ID: 1}, nil)
+
+				return userID, b
+			},
+		},
+		{
+			name:       "invalid body",
+			statusCode: http.StatusBadRequest,
+			prepare: func() (int, []byte) {
+				body := dtos.InviteEmergencyContactBody{
+					Email:    "test@gmail.com",
+					WaitDays: 0,
+				}
+				b, _ := json.Marshal(body)
+
+				return 1, b
+			},
+		},
+		{
+			name:       "already exists",
+			statusCode: http.StatusConflict,
+			prepare: func() (int, []byte) {
+				userID := 1
+				body := dtos.InviteEmergencyContactBody{
+					Email:    "test@gmail.com",
+					WaitDays: 7,
+				}
+				b, _ := json.Marshal(body)
+
+				suite.service.
+					EXPECT().
+					Invite(gomock.Any(), userID, body.Email, body.WaitDays).
+					Return(nil, domain.ErrEmergencyAccessAlreadyExists)
+
+				return userID, b
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.name, func() {
+			userID, body := testCase.prepare()
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/emergency", bytes.NewReader(body))
+			r.Header.Set("Content-Type", "application/json")
+			r = r.WithContext(usercontext.SetUserIDToContext(r.Context(), userID))
+			w := httptest.NewRecorder()
+
+			suite.handler.Invite(w, r)
+			res := w.Result()
+			defer res.Body.Close()
+
+			suite.Equal(testCase.statusCode, res.StatusCode)
+		})
+	}
+}
+
+func (suite *emergencyAccessTestSuite) TestInitiate() {
+	testCases := []struct {
+		name       string
+		statusCode int
+		prepare    func() (int, string)
+	}{
+		{
+			name:       "valid",
+			statusCode: http.StatusNoContent,
+			prepare: func() (int, string) {
+				userID := 2
+
+				suite.service.
+					EXPECT().
+					Initiate(gomock.Any(), userID, 1).
+					Return(nil)
+
+				return userID, "1"
+			},
+		},
+		{
+			name:       "invalid status",
+			statusCode: http.StatusConflict,
+			prepare: func() (int, string) {
+				userID := 2
+
+				suite.service.
+					EXPECT().
+					Initiate(gomock.Any(), userID, 1).
+					Return(domain.ErrEmergencyAccessInvalidStatus)
+
+				return userID, "1"
+			},
+		},
+		{
+			name:       "invalid id",
+			statusCode: http.StatusNotFound,
+			prepare: func() (int, string) {
+				return 2, "abc"
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.name, func() {
+			userID, id := testCase.prepare()
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/emergency/"+id+"/initiate", nil)
+			r = r.WithContext(usercontext.SetUserIDToContext(r.Context(), userID))
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", id)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			suite.handler.Initiate(w, r)
+			res := w.Result()
+			defer res.Body.Close()
+
+			suite.Equal(testCase.statusCode, res.StatusCode)
+		})
+	}
+}
+
+func (suite *emergencyAccessTestSuite) TestGetVault() {
+	testCases := []struct {
+		name       string
+		statusCode int
+		prepare    func() (int, string)
+	}{
+		{
+			name:       "valid",
+			statusCode: http.StatusOK,
+			prepare: func() (int, string) {
+				userID := 2
+
+				suite.service.
+					EXPECT().
+					GetVault(gomock.Any(), userID, 1).
+					Return([]domain.UserStoredData{}, nil)
+
+				return userID, "1"
+			},
+		},
+		{
+			name:       "not approved",
+			statusCode: http.StatusConflict,
+			prepare: func() (int, string) {
+				userID := 2
+
+				suite.service.
+					EXPECT().
+					GetVault(gomock.Any(), userID, 1).
+					Return(nil, domain.ErrEmergencyAccessInvalidStatus)
+
+				return userID, "1"
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.name, func() {
+			userID, id := testCase.prepare()
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/emergency/"+id+"/vault", nil)
+			r = r.WithContext(usercontext.SetUserIDToContext(r.Context(), userID))
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", id)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			suite.handler.GetVault(w, r)
+			res := w.Result()
+			defer res.Body.Close()
+
+			suite.Equal(testCase.statusCode, res.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+	"github.com/MowlCoder/goph-keeper/internal/handlers/httperrors"
+	"github.com/MowlCoder/goph-keeper/internal/utils/usercontext"
+)
+
+// eventsHeartbeatInterval - comment sent to idle stream, so proxies do not close connection
+const eventsHeartbeatInterval = 30 * time.Second
+
+type eventsService interface {
+	SubscribeChanges(ctx context.Context, userID int, collectionID int) (<-chan domain.DataChangeEvent, func(), error)
+}
+
+// EventsHandler - stream is answered with 200 before first event, so access log can not tell why it ended,
+// failures after that are logged by handler itself
+type EventsHandler struct {
+	service eventsService
+	logger  *slog.Logger
+}
+
+func NewEventsHandler(service eventsService, logger *slog.Logger) *EventsHandler {
+	return &EventsHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Stream godoc
+// @Summary Stream of server-sent events, "change" event is sent every time records of vault are changed
+// @Description Event data is JSON with user_id and collection_id of changed vault. Client is expected to sync
+// @Description after event, stream does not contain changes themselves
+// @Produce text/event-stream
+// @Tags sync
+// @Security Bearer
+// @Param collection_id query int false "Organization collection ID, personal vault if omitted"
+// @Success 200 {object} domain.DataChangeEvent
+// @Failure 401
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Router /api/v1/events [get]
+func (h *EventsHandler) Stream(w http.ResponseWriter, r *http.Request) {
+	userID, err := usercontext.GetUserIDFromContext(r.Context())
+	if err != nil {
+		httperrors.Handle(w, domain.ErrNotAuth)
+		return
+	}
+
+	collectionID, err := parseCollectionID(r)
+	if err != nil {
+		httperrors.Handle(w, err)
+		return
+	}
+
+	events, unsubscribe, err := h.service.SubscribeChanges(r.Context(), userID, collectionID)
+	if err != nil {
+		httperrors.Handle(w, err)
+		return
+	}
+	defer unsubscribe()
+
+	controller := http.NewResponseController(w)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	if err := writeEvent(w, controller, ": connected\n\n"); err != nil {
+		h.logStreamClosed(r.Context(), err)
+		return
+	}
+
+	heartbeat := time.NewTicker(eventsHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			if err := writeEvent(w, controller, ": ping\n\n"); err != nil {
+				h.logStreamClosed(r.Context(), err)
+				return
+			}
+		case event, ok := <-events:
+			// channel is closed on server shutdown
+			if !ok {
+				return
+			}
+
+			data, err := json.Marshal(event)
+			if err != nil {
+				h.logger.ErrorContext(r.Context(), "failed to encode change event", "error", err.Error())
+				return
+			}
+
+			if err := writeEvent(w, controller, fmt.Sprintf("event: change\ndata: %s\n\n", data)); err != nil {
+				h.logStreamClosed(r.Context(), err)
+				return
+			}
+		}
+	}
+}
+
+// logStreamClosed - stream usually fails to write because client went away, so it is not an error of server
+func (h *EventsHandler) logStreamClosed(ctx context.Context, err error) {
+	h.logger.DebugContext(ctx, "event stream closed on write", "error", err.Error())
+}
+
+func writeEvent(w http.ResponseWriter, controller *http.ResponseController, event string) error {
+	if _, err := fmt.Fprint(w, event); err != nil {
+		return err
+	}
+
+	return controller.Flush()
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+	mock_handlers "github.com/MowlCoder/goph-keeper/internal/handlers/mocks"
+	"github.com/MowlCoder/goph-keeper/internal/utils/usercontext"
+)
+
+type eventsTestSuite struct {
+	suite.Suite
+
+	service *mock_handlers.MockeventsService
+
+	handler *EventsHandler
+}
+
+func (suite *eventsTestSuite) SetupSuite() {
+}
+
+func (suite *eventsTestSuite) TearDownSuite() {
+}
+
+func (suite *eventsTestSuite) SetupTest() {
+	ctrl := gomock.NewController(suite.T())
+
+	suite.service = mock_handlers.NewMockeventsService(ctrl)
+
+	suite.handler = NewEventsHandler(suite.service, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func (suite *eventsTestSuite) TearDownTest() {
+}
+
+func TestEventsSuite(t *testing.T) {
+	suite.Run(t, new(eventsTestSuite))
+}
+
+func (suite *eventsTestSuite) TestStream() {
+	testCases := []struct {
+		name       string
+		statusCode int
+		query      string
+		body       string
+		prepare    func() int
+	}{
+		{
+			name:       "change events are streamed until channel is closed",
+			statusCode: http.StatusOK,
+			body:       ": connected\n\nevent: change\ndata: {\"user_id\":1,\"collection_id\":0}\n\n",
+			prepare: func() int {
+				userID := 1
+				events := make(chan domain.DataChangeEvent, 1)
+				events <- domain.DataChangeEvent{UserID: userID}
+				close(events)
+
+				suite.service.
+					EXPECT().
+					SubscribeChanges(gomock.Any(), userID, 0).
+					Return(events, func() {}, nil)
+
+				return userID
+			},
+		},
+		{
+			name:       "invalid collection id",
+			statusCode: http.StatusNotFound,
+			query:      "?collection_id=abc",
+			prepare: func() int {
+				return 1
+			},
+		},
+		{
+			name:       "no access to collection",
+			statusCode: http.StatusNotFound,
+			query:      "?collection_id=5",
+			prepare: func() int {
+				userID := 1
+
+				suite.service.
+					EXPECT().
+					SubscribeChanges(gomock.Any(), userID, 5).
+					Return(nil, nil, domain.ErrCollectionNotFound)
+
+				return userID
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.name, func() {
+			userID := testCase.prepare()
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/events"+testCase.query, nil)
+			r = r.WithContext(usercontext.SetUserIDToContext(r.Context(), userID))
+			w := httptest.NewRecorder()
+
+			suite.handler.Stream(w, r)
+			res := w.Result()
+			defer res.Body.Close()
+
+			suite.Equal(testCase.statusCode, res.StatusCode)
+			if testCase.body != "" {
+				body, _ := io.ReadAll(res.Body)
+				suite.Equal(testCase.body, string(body))
+				suite.Equal("text/event-stream", res.Header.Get("Content-Type"))
+			}
+		})
+	}
+}
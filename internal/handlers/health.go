@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+	"github.com/MowlCoder/goph-keeper/internal/dtos"
+	"github.com/MowlCoder/goph-keeper/pkg/httputils"
+)
+
+const readinessCheckTimeout = 2 * time.Second
+
+type readinessChecker interface {
+	CheckReadiness(ctx context.Context) error
+}
+
+type HealthHandler struct {
+	checker readinessChecker
+	logger  *slog.Logger
+
+	buildVersion string
+	buildDate    string
+
+	shuttingDown atomic.Bool
+}
+
+func NewHealthHandler(checker readinessChecker, buildVersion string, buildDate string, logger *slog.Logger) *HealthHandler {
+	return &HealthHandler{
+		checker:      checker,
+		logger:       logger,
+		buildVersion: buildVersion,
+		buildDate:    buildDate,
+	}
+}
+
+// SetShuttingDown - make readiness probe fail, so load balancer stops sending new requests before server shutdown
+func (h *HealthHandler) SetShuttingDown() {
+	h.shuttingDown.Store(true)
+}
+
+// Liveness godoc
+// @Summary Liveness probe, responds while process is able to serve http
+// @Produce json
+// @Tags health
+// @Success 200 {object} dtos.HealthStatusResponse
+// @Router /healthz [get]
+func (h *HealthHandler) Liveness(w http.ResponseWriter, r *http.Request) {
+	httputils.SendJSONResponse(w, http.StatusOK, dtos.HealthStatusResponse{Status: "ok"})
+}
+
+// Readiness godoc
+// @Summary Readiness probe, checks database connection and migrations
+// @Produce json
+// @Tags health
+// @Success 200 {object} dtos.HealthStatusResponse
+// @Failure 503 {object} dtos.HealthStatusResponse
+// @Router /readyz [get]
+func (h *HealthHandler) Readiness(w http.ResponseWriter, r *http.Request) {
+	if h.shuttingDown.Load() {
+		h.sendNotReady(w, domain.ErrShuttingDown)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), readinessCheckTimeout)
+	defer cancel()
+
+	if err := h.checker.CheckReadiness(ctx); err != nil {
+		// probe callers get only reason, cause is left for operators
+		h.logger.WarnContext(r.Context(), "readiness check failed", "error", err.Error())
+		h.sendNotReady(w, err)
+		return
+	}
+
+	httputils.SendJSONResponse(w, http.StatusOK, dtos.HealthStatusResponse{Status: "ready"})
+}
+
+// Version godoc
+// @Summary Get version of server binary
+// @Produce json
+// @Tags health
+// @Success 200 {object} dtos.VersionResponse
+// @Router /version [get]
+func (h *HealthHandler) Version(w http.ResponseWriter, r *http.Request) {
+	httputils.SendJSONResponse(w, http.StatusOK, dtos.VersionResponse{
+		Version:   h.buildVersion,
+		BuildDate: h.buildDate,
+	})
+}
+
+// sendNotReady - respond with reason without leaking driver errors to probe callers
+func (h *HealthHandler) sendNotReady(w http.ResponseWriter, err error) {
+	reason := domain.ErrDatabaseUnavailable.Error()
+
+	switch {
+	case errors.Is(err, domain.ErrShuttingDown):
+		reason = domain.ErrShuttingDown.Error()
+	case errors.Is(err, domain.ErrDatabaseSchemaOutdated):
+		reason = domain.ErrDatabaseSchemaOutdated.Error()
+	}
+
+	httputils.SendJSONResponse(w, http.StatusServiceUnavailable, dtos.HealthStatusResponse{
+		Status: "not ready",
+		Reason: reason,
+	})
+}
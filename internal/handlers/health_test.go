@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+	"github.com/MowlCoder/goph-keeper/internal/dtos"
+	mock_handlers "github.com/MowlCoder/goph-keeper/internal/handlers/mocks"
+)
+
+type healthTestSuite struct {
+	suite.Suite
+
+	checker *mock_handlers.MockreadinessChecker
+	logs    *bytes.Buffer
+
+	handler *HealthHandler
+}
+
+func (suite *healthTestSuite) SetupSuite() {
+}
+
+func (suite *healthTestSuite) TearDownSuite() {
+}
+
+func (suite *healthTestSuite) SetupTest() {
+	ctrl := gomock.NewController(suite.T())
+
+	suite.checker = mock_handlers.NewMockreadinessChecker(ctrl)
+	suite.logs = &bytes.Buffer{}
+
+	suite.handler = NewHealthHandler(suite.checker, "v1.2.3", "2024-02-20", slog.New(slog.NewTextHandler(suite.logs, nil)))
+}
+
+func (suite *healthTestSuite) TearDownTest() {
+}
+
+func TestHealthSuite(t *testing.T) {
+	suite.Run(t, new(healthTestSuite))
+}
+
+func (suite *healthTestSuite) TestLiveness() {
+	r := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	w := httptest.NewRecorder()
+
+	suite.handler.Liveness(w, r)
+	res := w.Result()
+	defer res.Body.Close()
+
+	suite.Equal(http.StatusOK, res.StatusCode)
+}
+
+func (suite *healthTestSuite) TestReadiness() {
+	testCases := []struct {
+		name       string
+		statusCode int
+		reason     string
+		logged     string
+		prepare    func()
+	}{
+		{
+			name:       "ready",
+			statusCode: http.StatusOK,
+			prepare: func() {
+				suite.checker.
+					EXPECT().
+					CheckReadiness(gomock.Any()).
+					Return(nil)
+			},
+		},
+		{
+			name:       "database unavailable",
+			statusCode: http.StatusServiceUnavailable,
+			reason:     domain.ErrDatabaseUnavailable.Error(),
+			logged:     "connection refused",
+			prepare: func() {
+				suite.checker.
+					EXPECT().
+					CheckReadiness(gomock.Any()).
+					Return(errors.New("dial tcp 10.0.0.1:5432: connection refused"))
+			},
+		},
+		{
+			name:       "migrations are not applied",
+			statusCode: http.StatusServiceUnavailable,
+			reason:     domain.ErrDatabaseSchemaOutdated.Error(),
+			prepare: func() {
+				suite.checker.
+					EXPECT().
+					CheckReadiness(gomock.Any()).
+					Return(domain.ErrDatabaseSchemaOutdated)
+			},
+		},
+		{
+			name:       "shutting down",
+			statusCode: http.StatusServiceUnavailable,
+			reason:     domain.ErrShuttingDown.Error(),
+			prepare: func() {
+				suite.handler.SetShuttingDown()
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.name, func() {
+			testCase.prepare()
+			r := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+			w := httptest.NewRecorder()
+
+			suite.handler.Readiness(w, r)
+			res := w.Result()
+			defer res.Body.Close()
+
+			var body dtos.HealthStatusResponse
+			suite.NoError(json.NewDecoder(res.Body).Decode(&body))
+
+			suite.Equal(testCase.statusCode, res.StatusCode)
+			suite.Equal(testCase.reason, body.Reason)
+			suite.Contains(suite.logs.String(), testCase.logged)
+		})
+	}
+}
+
+func (suite *healthTestSuite) TestVersion() {
+	r := httptest.NewRequest(http.MethodGet, "/version", nil)
+	w := httptest.NewRecorder()
+
+	suite.handler.Version(w, r)
+	res := w.Result()
+	defer res.Body.Close()
+
+	var body dtos.VersionResponse
+	suite.NoError(json.NewDecoder(res.Body).Decode(&body))
+
+	suite.Equal(http.StatusOK, res.StatusCode)
+	suite.Equal("v1.2.3", body.Version)
+	suite.Equal("2024-02-20", body.BuildDate)
+}
@@ -37,6 +37,102 @@ var errorToErrorInfo = map[error]errorInfo{
 		statusCode: http.StatusNotFound,
 		errorCode:  6,
 	},
+	domain.ErrShareNotFound: {
+		statusCode: http.StatusNotFound,
+		errorCode:  7,
+	},
+	domain.ErrShareWithYourself: {
+		statusCode: http.StatusBadRequest,
+		errorCode:  8,
+	},
+	domain.ErrNotEnoughPermissions: {
+		statusCode: http.StatusForbidden,
+		errorCode:  9,
+	},
+	domain.ErrInvalidSharePermission: {
+		statusCode: http.StatusBadRequest,
+		errorCode:  10,
+	},
+	domain.ErrOrganizationNotFound: {
+		statusCode: http.StatusNotFound,
+		errorCode:  11,
+	},
+	domain.ErrOrganizationInviteNotFound: {
+		statusCode: http.StatusNotFound,
+		errorCode:  12,
+	},
+	domain.ErrAlreadyOrganizationMember: {
+		statusCode: http.StatusConflict,
+		errorCode:  13,
+	},
+	domain.ErrInvalidOrganizationRole: {
+		statusCode: http.StatusBadRequest,
+		errorCode:  14,
+	},
+	domain.ErrCollectionNotFound: {
+		statusCode: http.StatusNotFound,
+		errorCode:  15,
+	},
+	domain.ErrEmergencyAccessNotFound: {
+		statusCode: http.StatusNotFound,
+		errorCode:  16,
+	},
+	domain.ErrEmergencyAccessAlreadyExists: {
+		statusCode: http.StatusConflict,
+		errorCode:  17,
+	},
+	domain.ErrEmergencyAccessInvalidStatus: {
+		statusCode: http.StatusConflict,
+		errorCode:  18,
+	},
+	domain.ErrEmergencyAccessToYourself: {
+		statusCode: http.StatusBadRequest,
+		errorCode:  19,
+	},
+	domain.ErrInvalidEmergencyWaitDays: {
+		statusCode: http.StatusBadRequest,
+		errorCode:  20,
+	},
+	domain.ErrInvalidSyncCursor: {
+		statusCode: http.StatusBadRequest,
+		errorCode:  21,
+	},
+	domain.ErrDataVersionConflict: {
+		statusCode: http.StatusConflict,
+		errorCode:  22,
+	},
+	domain.ErrInvalidDataVersion: {
+		statusCode: http.StatusBadRequest,
+		errorCode:  23,
+	},
+	domain.ErrInvalidRecordRef: {
+		statusCode: http.StatusBadRequest,
+		errorCode:  24,
+	},
+	domain.ErrAmbiguousRecordRef: {
+		statusCode: http.StatusBadRequest,
+		errorCode:  25,
+	},
+	domain.ErrRecordUUIDTaken: {
+		statusCode: http.StatusConflict,
+		errorCode:  26,
+	},
+	domain.ErrDeviceNotFound: {
+		statusCode: http.StatusNotFound,
+		errorCode:  27,
+	},
+	domain.ErrSyncCursorExpired: {
+		statusCode: http.StatusGone,
+		errorCode:  28,
+	},
+	domain.ErrAmbiguousRecordID: {
+		statusCode: http.StatusBadRequest,
+		errorCode:  29,
+	},
+	domain.ErrDataVersionRequired: {
+		statusCode: http.StatusPreconditionRequired,
+		errorCode:  30,
+	},
 	domain.ErrNotAuth: {
 		statusCode: http.StatusUnauthorized,
 		errorCode:  401,
@@ -47,13 +143,31 @@ var errorToErrorInfo = map[error]errorInfo{
 	},
 }
 
+// Code - error code sent to client for given error
+func Code(err error) int {
+	if info, ok := errorToErrorInfo[err]; ok {
+		return info.errorCode
+	}
+
+	return errorToErrorInfo[domain.ErrInternal].errorCode
+}
+
+// errorRecorder - response writer which keeps root cause of internal error for logging
+type errorRecorder interface {
+	SetError(err error)
+}
+
+// Handle - send error response. Unknown errors are hidden from client behind internal error and
+// passed to response writer if it is able to record them, so root cause can be logged with request id.
 func Handle(w http.ResponseWriter, err error) {
-	var info errorInfo
+	info, ok := errorToErrorInfo[err]
+	if !ok || info.statusCode == http.StatusInternalServerError {
+		if recorder, ok := w.(errorRecorder); ok {
+			recorder.SetError(err)
+		}
 
-	if i, ok := errorToErrorInfo[err]; ok {
-		info = i
-	} else {
 		info = errorToErrorInfo[domain.ErrInternal]
+		err = domain.ErrInternal
 	}
 
 	httputils.SendJSONErrorResponse(w, info.statusCode, err.Error(), info.errorCode)
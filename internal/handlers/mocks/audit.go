@@ -0,0 +1,90 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/handlers/audit.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/handlers/audit.go -destination=./internal/handlers/mocks/audit.go
+//
+// Package mock_handlers is a generated GoMock package.
+package mock_handlers
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/MowlCoder/goph-keeper/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockauditRecorder is a mock of auditRecorder interface.
+type MockauditRecorder struct {
+	ctrl     *gomock.Controller
+	recorder *MockauditRecorderMockRecorder
+}
+
+// MockauditRecorderMockRecorder is the mock recorder for MockauditRecorder.
+type MockauditRecorderMockRecorder struct {
+	mock *MockauditRecorder
+}
+
+// NewMockauditRecorder creates a new mock instance.
+func NewMockauditRecorder(ctrl *gomock.Controller) *MockauditRecorder {
+	mock := &MockauditRecorder{ctrl: ctrl}
+	mock.recorder = &MockauditRecorderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockauditRecorder) EXPECT() *MockauditRecorderMockRecorder {
+	return m.recorder
+}
+
+// Record mocks base method.
+func (m *MockauditRecorder) Record(ctx context.Context, event domain.AuditEvent) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Record", ctx, event)
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockauditRecorderMockRecorder) Record(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockauditRecorder)(nil).Record), ctx, event)
+}
+
+// MockauditService is a mock of auditService interface.
+type MockauditService struct {
+	ctrl     *gomock.Controller
+	recorder *MockauditServiceMockRecorder
+}
+
+// MockauditServiceMockRecorder is the mock recorder for MockauditService.
+type MockauditServiceMockRecorder struct {
+	mock *MockauditService
+}
+
+// NewMockauditService creates a new mock instance.
+func NewMockauditService(ctrl *gomock.Controller) *MockauditService {
+	mock := &MockauditService{ctrl: ctrl}
+	mock.recorder = &MockauditServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockauditService) EXPECT() *MockauditServiceMockRecorder {
+	return m.recorder
+}
+
+// GetUserEvents mocks base method.
+func (m *MockauditService) GetUserEvents(ctx context.Context, userID int, filters *domain.StorageFilters) (*domain.PaginatedResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserEvents", ctx, userID, filters)
+	ret0, _ := ret[0].(*domain.PaginatedResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserEvents indicates an expected call of GetUserEvents.
+func (mr *MockauditServiceMockRecorder) GetUserEvents(ctx, userID, filters any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserEvents", reflect.TypeOf((*MockauditService)(nil).GetUserEvents), ctx, userID, filters)
+}
@@ -0,0 +1,170 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/handlers/emergency_access.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/handlers/emergency_access.go -destination=./internal/handlers/mocks/emergency_access.go
+//
+// Package mock_handlers is a generated GoMock package.
+package mock_handlers
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/MowlCoder/goph-keeper/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockemergencyAccessService is a mock of emergencyAccessService interface.
+type MockemergencyAccessService struct {
+	ctrl     *gomock.Controller
+	recorder *MockemergencyAccessServiceMockRecorder
+}
+
+// MockemergencyAccessServiceMockRecorder is the mock recorder for MockemergencyAccessService.
+type MockemergencyAccessServiceMockRecorder struct {
+	mock *MockemergencyAccessService
+}
+
+// NewMockemergencyAccessService creates a new mock instance.
+func NewMockemergencyAccessService(ctrl *gomock.Controller) *MockemergencyAccessService {
+	mock := &MockemergencyAccessService{ctrl: ctrl}
+	mock.recorder = &MockemergencyAccessServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockemergencyAccessService) EXPECT() *MockemergencyAccessServiceMockRecorder {
+	return m.recorder
+}
+
+// Approve mocks base method.
+func (m *MockemergencyAccessService) Approve(ctx context.Context, grantorID, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Approve", ctx, grantorID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Approve indicates an expected call of Approve.
+func (mr *MockemergencyAccessServiceMockRecorder) Approve(ctx, grantorID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Approve", reflect.TypeOf((*MockemergencyAccessService)(nil).Approve), ctx, grantorID, id)
+}
+
+// Confirm mocks base method.
+func (m *MockemergencyAccessService) Confirm(ctx context.Context, granteeID, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Confirm", ctx, granteeID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Confirm indicates an expected call of Confirm.
+func (mr *MockemergencyAccessServiceMockRecorder) Confirm(ctx, granteeID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Confirm", reflect.TypeOf((*MockemergencyAccessService)(nil).Confirm), ctx, granteeID, id)
+}
+
+// GetGranted mocks base method.
+func (m *MockemergencyAccessService) GetGranted(ctx context.Context, grantorID int) ([]domain.EmergencyAccess, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGranted", ctx, grantorID)
+	ret0, _ := ret[0].([]domain.EmergencyAccess)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGranted indicates an expected call of GetGranted.
+func (mr *MockemergencyAccessServiceMockRecorder) GetGranted(ctx, grantorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGranted", reflect.TypeOf((*MockemergencyAccessService)(nil).GetGranted), ctx, grantorID)
+}
+
+// GetTrusted mocks base method.
+func (m *MockemergencyAccessService) GetTrusted(ctx context.Context, granteeID int) ([]domain.EmergencyAccess, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrusted", ctx, granteeID)
+	ret0, _ := ret[0].([]domain.EmergencyAccess)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrusted indicates an expected call of GetTrusted.
+func (mr *MockemergencyAccessServiceMockRecorder) GetTrusted(ctx, granteeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrusted", reflect.TypeOf((*MockemergencyAccessService)(nil).GetTrusted), ctx, granteeID)
+}
+
+// GetVault mocks base method.
+func (m *MockemergencyAccessService) GetVault(ctx context.Context, granteeID, id int) ([]domain.UserStoredData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVault", ctx, granteeID, id)
+	ret0, _ := ret[0].([]domain.UserStoredData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVault indicates an expected call of GetVault.
+func (mr *MockemergencyAccessServiceMockRecorder) GetVault(ctx, granteeID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVault", reflect.TypeOf((*MockemergencyAccessService)(nil).GetVault), ctx, granteeID, id)
+}
+
+// Initiate mocks base method.
+func (m *MockemergencyAccessService) Initiate(ctx context.Context, granteeID, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Initiate", ctx, granteeID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Initiate indicates an expected call of Initiate.
+func (mr *MockemergencyAccessServiceMockRecorder) Initiate(ctx, granteeID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Initiate", reflect.TypeOf((*MockemergencyAccessService)(nil).Initiate), ctx, granteeID, id)
+}
+
+// Invite mocks base method.
+func (m *MockemergencyAccessService) Invite(ctx context.Context, grantorID int, granteeEmail string, waitDays int) (*domain.EmergencyAccess, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Invite", ctx, grantorID, granteeEmail, waitDays)
+	ret0, _ := ret[0].(*domain.EmergencyAccess)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Invite indicates an expected call of Invite.
+func (mr *MockemergencyAccessServiceMockRecorder) Invite(ctx, grantorID, granteeEmail, waitDays any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invite", reflect.TypeOf((*MockemergencyAccessService)(nil).Invite), ctx, grantorID, granteeEmail, waitDays)
+}
+
+// Reject mocks base method.
+func (m *MockemergencyAccessService) Reject(ctx context.Context, grantorID, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reject", ctx, grantorID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reject indicates an expected call of Reject.
+func (mr *MockemergencyAccessServiceMockRecorder) Reject(ctx, grantorID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reject", reflect.TypeOf((*MockemergencyAccessService)(nil).Reject), ctx, grantorID, id)
+}
+
+// Revoke mocks base method.
+func (m *MockemergencyAccessService) Revoke(ctx context.Context, userID, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockemergencyAccessServiceMockRecorder) Revoke(ctx, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockemergencyAccessService)(nil).Revoke), ctx, userID, id)
+}
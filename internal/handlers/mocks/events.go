@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/handlers/events.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/handlers/events.go -destination=./internal/handlers/mocks/events.go
+//
+// Package mock_handlers is a generated GoMock package.
+package mock_handlers
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/MowlCoder/goph-keeper/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockeventsService is a mock of eventsService interface.
+type MockeventsService struct {
+	ctrl     *gomock.Controller
+	recorder *MockeventsServiceMockRecorder
+}
+
+// MockeventsServiceMockRecorder is the mock recorder for MockeventsService.
+type MockeventsServiceMockRecorder struct {
+	mock *MockeventsService
+}
+
+// NewMockeventsService creates a new mock instance.
+func NewMockeventsService(ctrl *gomock.Controller) *MockeventsService {
+	mock := &MockeventsService{ctrl: ctrl}
+	mock.recorder = &MockeventsServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockeventsService) EXPECT() *MockeventsServiceMockRecorder {
+	return m.recorder
+}
+
+// SubscribeChanges mocks base method.
+func (m *MockeventsService) SubscribeChanges(ctx context.Context, userID, collectionID int) (<-chan domain.DataChangeEvent, func(), error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeChanges", ctx, userID, collectionID)
+	ret0, _ := ret[0].(<-chan domain.DataChangeEvent)
+	ret1, _ := ret[1].(func())
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SubscribeChanges indicates an expected call of SubscribeChanges.
+func (mr *MockeventsServiceMockRecorder) SubscribeChanges(ctx, userID, collectionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeChanges", reflect.TypeOf((*MockeventsService)(nil).SubscribeChanges), ctx, userID, collectionID)
+}
@@ -0,0 +1,53 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/handlers/health.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/handlers/health.go -destination=./internal/handlers/mocks/health.go
+//
+// Package mock_handlers is a generated GoMock package.
+package mock_handlers
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockreadinessChecker is a mock of readinessChecker interface.
+type MockreadinessChecker struct {
+	ctrl     *gomock.Controller
+	recorder *MockreadinessCheckerMockRecorder
+}
+
+// MockreadinessCheckerMockRecorder is the mock recorder for MockreadinessChecker.
+type MockreadinessCheckerMockRecorder struct {
+	mock *MockreadinessChecker
+}
+
+// NewMockreadinessChecker creates a new mock instance.
+func NewMockreadinessChecker(ctrl *gomock.Controller) *MockreadinessChecker {
+	mock := &MockreadinessChecker{ctrl: ctrl}
+	mock.recorder = &MockreadinessCheckerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockreadinessChecker) EXPECT() *MockreadinessCheckerMockRecorder {
+	return m.recorder
+}
+
+// CheckReadiness mocks base method.
+func (m *MockreadinessChecker) CheckReadiness(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckReadiness", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckReadiness indicates an expected call of CheckReadiness.
+func (mr *MockreadinessCheckerMockRecorder) CheckReadiness(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckReadiness", reflect.TypeOf((*MockreadinessChecker)(nil).CheckReadiness), ctx)
+}
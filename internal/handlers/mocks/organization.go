@@ -0,0 +1,144 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/handlers/organization.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/handlers/organization.go -destination=./internal/handlers/mocks/organization.go
+//
+// Package mock_handlers is a generated GoMock package.
+package mock_handlers
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/MowlCoder/goph-keeper/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockorganizationService is a mock of organizationService interface.
+type MockorganizationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockorganizationServiceMockRecorder
+}
+
+// MockorganizationServiceMockRecorder is the mock recorder for MockorganizationService.
+type MockorganizationServiceMockRecorder struct {
+	mock *MockorganizationService
+}
+
+// NewMockorganizationService creates a new mock instance.
+func NewMockorganizationService(ctrl *gomock.Controller) *MockorganizationService {
+	mock := &MockorganizationService{ctrl: ctrl}
+	mock.recorder = &MockorganizationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockorganizationService) EXPECT() *MockorganizationServiceMockRecorder {
+	return m.recorder
+}
+
+// Accept mocks base method.
+func (m *MockorganizationService) Accept(ctx context.Context, userID, organizationID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Accept", ctx, userID, organizationID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Accept indicates an expected call of Accept.
+func (mr *MockorganizationServiceMockRecorder) Accept(ctx, userID, organizationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Accept", reflect.TypeOf((*MockorganizationService)(nil).Accept), ctx, userID, organizationID)
+}
+
+// Create mocks base method.
+func (m *MockorganizationService) Create(ctx context.Context, userID int, name string) (*domain.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, userID, name)
+	ret0, _ := ret[0].(*domain.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockorganizationServiceMockRecorder) Create(ctx, userID, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockorganizationService)(nil).Create), ctx, userID, name)
+}
+
+// CreateCollection mocks base method.
+func (m *MockorganizationService) CreateCollection(ctx context.Context, userID, organizationID int, name string) (*domain.Collection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCollection", ctx, userID, organizationID, name)
+	ret0, _ := ret[0].(*domain.Collection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCollection indicates an expected call of CreateCollection.
+func (mr *MockorganizationServiceMockRecorder) CreateCollection(ctx, userID, organizationID, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCollection", reflect.TypeOf((*MockorganizationService)(nil).CreateCollection), ctx, userID, organizationID, name)
+}
+
+// GetCollections mocks base method.
+func (m *MockorganizationService) GetCollections(ctx context.Context, userID, organizationID int) ([]domain.Collection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCollections", ctx, userID, organizationID)
+	ret0, _ := ret[0].([]domain.Collection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCollections indicates an expected call of GetCollections.
+func (mr *MockorganizationServiceMockRecorder) GetCollections(ctx, userID, organizationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCollections", reflect.TypeOf((*MockorganizationService)(nil).GetCollections), ctx, userID, organizationID)
+}
+
+// GetMembers mocks base method.
+func (m *MockorganizationService) GetMembers(ctx context.Context, userID, organizationID int) ([]domain.OrganizationMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembers", ctx, userID, organizationID)
+	ret0, _ := ret[0].([]domain.OrganizationMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembers indicates an expected call of GetMembers.
+func (mr *MockorganizationServiceMockRecorder) GetMembers(ctx, userID, organizationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembers", reflect.TypeOf((*MockorganizationService)(nil).GetMembers), ctx, userID, organizationID)
+}
+
+// GetUserOrganizations mocks base method.
+func (m *MockorganizationService) GetUserOrganizations(ctx context.Context, userID int) ([]domain.OrganizationMembership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserOrganizations", ctx, userID)
+	ret0, _ := ret[0].([]domain.OrganizationMembership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserOrganizations indicates an expected call of GetUserOrganizations.
+func (mr *MockorganizationServiceMockRecorder) GetUserOrganizations(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserOrganizations", reflect.TypeOf((*MockorganizationService)(nil).GetUserOrganizations), ctx, userID)
+}
+
+// Invite mocks base method.
+func (m *MockorganizationService) Invite(ctx context.Context, userID, organizationID int, email, role string) (*domain.OrganizationMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Invite", ctx, userID, organizationID, email, role)
+	ret0, _ := ret[0].(*domain.OrganizationMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Invite indicates an expected call of Invite.
+func (mr *MockorganizationServiceMockRecorder) Invite(ctx, userID, organizationID, email, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invite", reflect.TypeOf((*MockorganizationService)(nil).Invite), ctx, userID, organizationID, email, role)
+}
@@ -0,0 +1,99 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/handlers/share.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/handlers/share.go -destination=./internal/handlers/mocks/share.go
+//
+// Package mock_handlers is a generated GoMock package.
+package mock_handlers
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/MowlCoder/goph-keeper/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockshareService is a mock of shareService interface.
+type MockshareService struct {
+	ctrl     *gomock.Controller
+	recorder *MockshareServiceMockRecorder
+}
+
+// MockshareServiceMockRecorder is the mock recorder for MockshareService.
+type MockshareServiceMockRecorder struct {
+	mock *MockshareService
+}
+
+// NewMockshareService creates a new mock instance.
+func NewMockshareService(ctrl *gomock.Controller) *MockshareService {
+	mock := &MockshareService{ctrl: ctrl}
+	mock.recorder = &MockshareServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockshareService) EXPECT() *MockshareServiceMockRecorder {
+	return m.recorder
+}
+
+// GetSharedByUser mocks base method.
+func (m *MockshareService) GetSharedByUser(ctx context.Context, userID int) ([]domain.Share, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSharedByUser", ctx, userID)
+	ret0, _ := ret[0].([]domain.Share)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSharedByUser indicates an expected call of GetSharedByUser.
+func (mr *MockshareServiceMockRecorder) GetSharedByUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSharedByUser", reflect.TypeOf((*MockshareService)(nil).GetSharedByUser), ctx, userID)
+}
+
+// GetSharedWithUser mocks base method.
+func (m *MockshareService) GetSharedWithUser(ctx context.Context, userID int) ([]domain.SharedUserStoredData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSharedWithUser", ctx, userID)
+	ret0, _ := ret[0].([]domain.SharedUserStoredData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSharedWithUser indicates an expected call of GetSharedWithUser.
+func (mr *MockshareServiceMockRecorder) GetSharedWithUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSharedWithUser", reflect.TypeOf((*MockshareService)(nil).GetSharedWithUser), ctx, userID)
+}
+
+// Share mocks base method.
+func (m *MockshareService) Share(ctx context.Context, ownerID, dataID int, recipientEmail, permission string) (*domain.Share, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Share", ctx, ownerID, dataID, recipientEmail, permission)
+	ret0, _ := ret[0].(*domain.Share)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Share indicates an expected call of Share.
+func (mr *MockshareServiceMockRecorder) Share(ctx, ownerID, dataID, recipientEmail, permission any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Share", reflect.TypeOf((*MockshareService)(nil).Share), ctx, ownerID, dataID, recipientEmail, permission)
+}
+
+// Unshare mocks base method.
+func (m *MockshareService) Unshare(ctx context.Context, userID, shareID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unshare", ctx, userID, shareID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unshare indicates an expected call of Unshare.
+func (mr *MockshareServiceMockRecorder) Unshare(ctx, userID, shareID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unshare", reflect.TypeOf((*MockshareService)(nil).Unshare), ctx, userID, shareID)
+}
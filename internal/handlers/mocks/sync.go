@@ -0,0 +1,107 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/handlers/sync.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/handlers/sync.go -destination=./internal/handlers/mocks/sync.go
+//
+// Package mock_handlers is a generated GoMock package.
+package mock_handlers
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/MowlCoder/goph-keeper/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MocksyncService is a mock of syncService interface.
+type MocksyncService struct {
+	ctrl     *gomock.Controller
+	recorder *MocksyncServiceMockRecorder
+}
+
+// MocksyncServiceMockRecorder is the mock recorder for MocksyncService.
+type MocksyncServiceMockRecorder struct {
+	mock *MocksyncService
+}
+
+// NewMocksyncService creates a new mock instance.
+func NewMocksyncService(ctrl *gomock.Controller) *MocksyncService {
+	mock := &MocksyncService{ctrl: ctrl}
+	mock.recorder = &MocksyncServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocksyncService) EXPECT() *MocksyncServiceMockRecorder {
+	return m.recorder
+}
+
+// GetChanges mocks base method.
+func (m *MocksyncService) GetChanges(ctx context.Context, userID, collectionID int, since int64, limit int) (*domain.ChangeFeed, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChanges", ctx, userID, collectionID, since, limit)
+	ret0, _ := ret[0].(*domain.ChangeFeed)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChanges indicates an expected call of GetChanges.
+func (mr *MocksyncServiceMockRecorder) GetChanges(ctx, userID, collectionID, since, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChanges", reflect.TypeOf((*MocksyncService)(nil).GetChanges), ctx, userID, collectionID, since, limit)
+}
+
+// Push mocks base method.
+func (m *MocksyncService) Push(ctx context.Context, userID int, items []domain.SyncPushItem) (*domain.SyncPushResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Push", ctx, userID, items)
+	ret0, _ := ret[0].(*domain.SyncPushResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Push indicates an expected call of Push.
+func (mr *MocksyncServiceMockRecorder) Push(ctx, userID, items any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Push", reflect.TypeOf((*MocksyncService)(nil).Push), ctx, userID, items)
+}
+
+// MocksyncAcknowledger is a mock of syncAcknowledger interface.
+type MocksyncAcknowledger struct {
+	ctrl     *gomock.Controller
+	recorder *MocksyncAcknowledgerMockRecorder
+}
+
+// MocksyncAcknowledgerMockRecorder is the mock recorder for MocksyncAcknowledger.
+type MocksyncAcknowledgerMockRecorder struct {
+	mock *MocksyncAcknowledger
+}
+
+// NewMocksyncAcknowledger creates a new mock instance.
+func NewMocksyncAcknowledger(ctrl *gomock.Controller) *MocksyncAcknowledger {
+	mock := &MocksyncAcknowledger{ctrl: ctrl}
+	mock.recorder = &MocksyncAcknowledgerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocksyncAcknowledger) EXPECT() *MocksyncAcknowledgerMockRecorder {
+	return m.recorder
+}
+
+// AcknowledgeSync mocks base method.
+func (m *MocksyncAcknowledger) AcknowledgeSync(ctx context.Context, userID, deviceID int, cursor int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcknowledgeSync", ctx, userID, deviceID, cursor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AcknowledgeSync indicates an expected call of AcknowledgeSync.
+func (mr *MocksyncAcknowledgerMockRecorder) AcknowledgeSync(ctx, userID, deviceID, cursor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcknowledgeSync", reflect.TypeOf((*MocksyncAcknowledger)(nil).AcknowledgeSync), ctx, userID, deviceID, cursor)
+}
@@ -5,6 +5,7 @@
 //
 //	mockgen -source=./internal/handlers/user.go -destination=./internal/handlers/mocks/user.go
 //
+
 // Package mock_handlers is a generated GoMock package.
 package mock_handlers
 
@@ -93,16 +94,89 @@ func (m *MocktokenGenerator) EXPECT() *MocktokenGeneratorMockRecorder {
 }
 
 // Generate mocks base method.
-func (m *MocktokenGenerator) Generate(ctx context.Context, user domain.User) (string, error) {
+func (m *MocktokenGenerator) Generate(ctx context.Context, user domain.User, deviceID int) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Generate", ctx, user)
+	ret := m.ctrl.Call(m, "Generate", ctx, user, deviceID)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Generate indicates an expected call of Generate.
-func (mr *MocktokenGeneratorMockRecorder) Generate(ctx, user any) *gomock.Call {
+func (mr *MocktokenGeneratorMockRecorder) Generate(ctx, user, deviceID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Generate", reflect.TypeOf((*MocktokenGenerator)(nil).Generate), ctx, user, deviceID)
+}
+
+// MockdeviceRegistrar is a mock of deviceRegistrar interface.
+type MockdeviceRegistrar struct {
+	ctrl     *gomock.Controller
+	recorder *MockdeviceRegistrarMockRecorder
+}
+
+// MockdeviceRegistrarMockRecorder is the mock recorder for MockdeviceRegistrar.
+type MockdeviceRegistrarMockRecorder struct {
+	mock *MockdeviceRegistrar
+}
+
+// NewMockdeviceRegistrar creates a new mock instance.
+func NewMockdeviceRegistrar(ctrl *gomock.Controller) *MockdeviceRegistrar {
+	mock := &MockdeviceRegistrar{ctrl: ctrl}
+	mock.recorder = &MockdeviceRegistrarMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockdeviceRegistrar) EXPECT() *MockdeviceRegistrarMockRecorder {
+	return m.recorder
+}
+
+// Register mocks base method.
+func (m *MockdeviceRegistrar) Register(ctx context.Context, userID, deviceID int, name, platform string) (*domain.Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Register", ctx, userID, deviceID, name, platform)
+	ret0, _ := ret[0].(*domain.Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Register indicates an expected call of Register.
+func (mr *MockdeviceRegistrarMockRecorder) Register(ctx, userID, deviceID, name, platform any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockdeviceRegistrar)(nil).Register), ctx, userID, deviceID, name, platform)
+}
+
+// MockauthFailureCounter is a mock of authFailureCounter interface.
+type MockauthFailureCounter struct {
+	ctrl     *gomock.Controller
+	recorder *MockauthFailureCounterMockRecorder
+}
+
+// MockauthFailureCounterMockRecorder is the mock recorder for MockauthFailureCounter.
+type MockauthFailureCounterMockRecorder struct {
+	mock *MockauthFailureCounter
+}
+
+// NewMockauthFailureCounter creates a new mock instance.
+func NewMockauthFailureCounter(ctrl *gomock.Controller) *MockauthFailureCounter {
+	mock := &MockauthFailureCounter{ctrl: ctrl}
+	mock.recorder = &MockauthFailureCounterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockauthFailureCounter) EXPECT() *MockauthFailureCounterMockRecorder {
+	return m.recorder
+}
+
+// IncAuthFailure mocks base method.
+func (m *MockauthFailureCounter) IncAuthFailure(reason string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "IncAuthFailure", reason)
+}
+
+// IncAuthFailure indicates an expected call of IncAuthFailure.
+func (mr *MockauthFailureCounterMockRecorder) IncAuthFailure(reason any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Generate", reflect.TypeOf((*MocktokenGenerator)(nil).Generate), ctx, user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncAuthFailure", reflect.TypeOf((*MockauthFailureCounter)(nil).IncAuthFailure), reason)
 }
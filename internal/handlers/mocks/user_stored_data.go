@@ -5,6 +5,7 @@
 //
 //	mockgen -source=./internal/handlers/user_stored_data.go -destination=./internal/handlers/mocks/user_stored_data.go
 //
+
 // Package mock_handlers is a generated GoMock package.
 package mock_handlers
 
@@ -40,62 +41,63 @@ func (m *MockuserStoredDataService) EXPECT() *MockuserStoredDataServiceMockRecor
 }
 
 // Add mocks base method.
-func (m *MockuserStoredDataService) Add(ctx context.Context, userID int, dataType string, data any, meta string) (*domain.UserStoredData, error) {
+func (m *MockuserStoredDataService) Add(ctx context.Context, userID, collectionID int, recordUUID, dataType string, data any, meta string) (*domain.UserStoredData, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Add", ctx, userID, dataType, data, meta)
+	ret := m.ctrl.Call(m, "Add", ctx, userID, collectionID, recordUUID, dataType, data, meta)
 	ret0, _ := ret[0].(*domain.UserStoredData)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Add indicates an expected call of Add.
-func (mr *MockuserStoredDataServiceMockRecorder) Add(ctx, userID, dataType, data, meta any) *gomock.Call {
+func (mr *MockuserStoredDataServiceMockRecorder) Add(ctx, userID, collectionID, recordUUID, dataType, data, meta any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockuserStoredDataService)(nil).Add), ctx, userID, dataType, data, meta)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockuserStoredDataService)(nil).Add), ctx, userID, collectionID, recordUUID, dataType, data, meta)
 }
 
 // DeleteBatch mocks base method.
-func (m *MockuserStoredDataService) DeleteBatch(ctx context.Context, userID int, ids []int) error {
+func (m *MockuserStoredDataService) DeleteBatch(ctx context.Context, userID, collectionID int, ids []int) ([]int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteBatch", ctx, userID, ids)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "DeleteBatch", ctx, userID, collectionID, ids)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // DeleteBatch indicates an expected call of DeleteBatch.
-func (mr *MockuserStoredDataServiceMockRecorder) DeleteBatch(ctx, userID, ids any) *gomock.Call {
+func (mr *MockuserStoredDataServiceMockRecorder) DeleteBatch(ctx, userID, collectionID, ids any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBatch", reflect.TypeOf((*MockuserStoredDataService)(nil).DeleteBatch), ctx, userID, ids)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBatch", reflect.TypeOf((*MockuserStoredDataService)(nil).DeleteBatch), ctx, userID, collectionID, ids)
 }
 
 // GetAllUserData mocks base method.
-func (m *MockuserStoredDataService) GetAllUserData(ctx context.Context, userID int) ([]domain.UserStoredData, error) {
+func (m *MockuserStoredDataService) GetAllUserData(ctx context.Context, userID, collectionID int) ([]domain.UserStoredData, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllUserData", ctx, userID)
+	ret := m.ctrl.Call(m, "GetAllUserData", ctx, userID, collectionID)
 	ret0, _ := ret[0].([]domain.UserStoredData)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAllUserData indicates an expected call of GetAllUserData.
-func (mr *MockuserStoredDataServiceMockRecorder) GetAllUserData(ctx, userID any) *gomock.Call {
+func (mr *MockuserStoredDataServiceMockRecorder) GetAllUserData(ctx, userID, collectionID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllUserData", reflect.TypeOf((*MockuserStoredDataService)(nil).GetAllUserData), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllUserData", reflect.TypeOf((*MockuserStoredDataService)(nil).GetAllUserData), ctx, userID, collectionID)
 }
 
 // GetUserData mocks base method.
-func (m *MockuserStoredDataService) GetUserData(ctx context.Context, userID int, dataType string, filters *domain.StorageFilters) (*domain.PaginatedResult, error) {
+func (m *MockuserStoredDataService) GetUserData(ctx context.Context, userID, collectionID int, dataType string, filters *domain.StorageFilters) (*domain.PaginatedResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserData", ctx, userID, dataType, filters)
+	ret := m.ctrl.Call(m, "GetUserData", ctx, userID, collectionID, dataType, filters)
 	ret0, _ := ret[0].(*domain.PaginatedResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetUserData indicates an expected call of GetUserData.
-func (mr *MockuserStoredDataServiceMockRecorder) GetUserData(ctx, userID, dataType, filters any) *gomock.Call {
+func (mr *MockuserStoredDataServiceMockRecorder) GetUserData(ctx, userID, collectionID, dataType, filters any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserData", reflect.TypeOf((*MockuserStoredDataService)(nil).GetUserData), ctx, userID, dataType, filters)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserData", reflect.TypeOf((*MockuserStoredDataService)(nil).GetUserData), ctx, userID, collectionID, dataType, filters)
 }
 
 // GetUserDataByID mocks base method.
@@ -113,17 +115,32 @@ func (mr *MockuserStoredDataServiceMockRecorder) GetUserDataByID(ctx, userID, id
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserDataByID", reflect.TypeOf((*MockuserStoredDataService)(nil).GetUserDataByID), ctx, userID, id)
 }
 
+// ResolveDataRef mocks base method.
+func (m *MockuserStoredDataService) ResolveDataRef(ctx context.Context, userID int, ref string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveDataRef", ctx, userID, ref)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveDataRef indicates an expected call of ResolveDataRef.
+func (mr *MockuserStoredDataServiceMockRecorder) ResolveDataRef(ctx, userID, ref any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveDataRef", reflect.TypeOf((*MockuserStoredDataService)(nil).ResolveDataRef), ctx, userID, ref)
+}
+
 // UpdateUserData mocks base method.
-func (m *MockuserStoredDataService) UpdateUserData(ctx context.Context, userID, dataID int, data any, meta string) (*domain.UserStoredData, error) {
+func (m *MockuserStoredDataService) UpdateUserData(ctx context.Context, userID, dataID, expectedVersion int, data any, meta string) (*domain.UserStoredData, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateUserData", ctx, userID, dataID, data, meta)
+	ret := m.ctrl.Call(m, "UpdateUserData", ctx, userID, dataID, expectedVersion, data, meta)
 	ret0, _ := ret[0].(*domain.UserStoredData)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateUserData indicates an expected call of UpdateUserData.
-func (mr *MockuserStoredDataServiceMockRecorder) UpdateUserData(ctx, userID, dataID, data, meta any) *gomock.Call {
+func (mr *MockuserStoredDataServiceMockRecorder) UpdateUserData(ctx, userID, dataID, expectedVersion, data, meta any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserData", reflect.TypeOf((*MockuserStoredDataService)(nil).UpdateUserData), ctx, userID, dataID, data, meta)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserData", reflect.TypeOf((*MockuserStoredDataService)(nil).UpdateUserData), ctx, userID, dataID, expectedVersion, data, meta)
 }
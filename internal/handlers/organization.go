@@ -0,0 +1,295 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+	"github.com/MowlCoder/goph-keeper/internal/dtos"
+	"github.com/MowlCoder/goph-keeper/internal/handlers/httperrors"
+	"github.com/MowlCoder/goph-keeper/internal/utils/usercontext"
+	"github.com/MowlCoder/goph-keeper/pkg/httputils"
+	jsonutil "github.com/MowlCoder/goph-keeper/pkg/jsonutils"
+)
+
+type organizationService interface {
+	Create(ctx context.Context, userID int, name string) (*domain.Organization, error)
+	GetUserOrganizations(ctx context.Context, userID int) ([]domain.OrganizationMembership, error)
+	Invite(ctx context.Context, userID int, organizationID int, email string, role string) (*domain.OrganizationMember, error)
+	Accept(ctx context.Context, userID int, organizationID int) error
+	GetMembers(ctx context.Context, userID int, organizationID int) ([]domain.OrganizationMember, error)
+	CreateCollection(ctx context.Context, userID int, organizationID int, name string) (*domain.Collection, error)
+	GetCollections(ctx context.Context, userID int, organizationID int) ([]domain.Collection, error)
+}
+
+type OrganizationHandler struct {
+	service organizationService
+}
+
+func NewOrganizationHandler(service organizationService) *OrganizationHandler {
+	return &OrganizationHandler{
+		service: service,
+	}
+}
+
+// Create godoc
+// @Summary Create organization, creator becomes its owner
+// @Accept json
+// @Produce json
+// @Tags organization
+// @Security Bearer
+// @Param dto body dtos.CreateOrganizationBody true "body"
+// @Success 201 {object} domain.Organization
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 401
+// @Failure 500 {object} httputils.HTTPError
+// @Router /api/v1/organization [post]
+func (h *OrganizationHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID, err := usercontext.GetUserIDFromContext(r.Context())
+	if err != nil {
+		httperrors.Handle(w, domain.ErrNotAuth)
+		return
+	}
+
+	var body dtos.CreateOrganizationBody
+	if statusCode, err := jsonutil.Unmarshal(w, r, &body); err != nil {
+		httputils.SendJSONErrorResponse(w, statusCode, err.Error(), statusCode)
+		return
+	}
+
+	if !body.Valid() {
+		httperrors.Handle(w, domain.ErrInvalidBody)
+		return
+	}
+
+	organization, err := h.service.Create(r.Context(), userID, body.Name)
+	if err != nil {
+		httperrors.Handle(w, err)
+		return
+	}
+
+	httputils.SendJSONResponse(w, http.StatusCreated, organization)
+}
+
+// GetMy godoc
+// @Summary Get organizations of current user including pending invites
+// @Produce json
+// @Tags organization
+// @Security Bearer
+// @Success 200 {array} domain.OrganizationMembership
+// @Failure 401
+// @Failure 500 {object} httputils.HTTPError
+// @Router /api/v1/organization [get]
+func (h *OrganizationHandler) GetMy(w http.ResponseWriter, r *http.Request) {
+	userID, err := usercontext.GetUserIDFromContext(r.Context())
+	if err != nil {
+		httperrors.Handle(w, domain.ErrNotAuth)
+		return
+	}
+
+	memberships, err := h.service.GetUserOrganizations(r.Context(), userID)
+	if err != nil {
+		httperrors.Handle(w, err)
+		return
+	}
+
+	httputils.SendJSONResponse(w, http.StatusOK, memberships)
+}
+
+// Invite godoc
+// @Summary Invite user to organization (available for owner and admin)
+// @Accept json
+// @Produce json
+// @Tags organization
+// @Security Bearer
+// @Param id path string true "Organization ID"
+// @Param dto body dtos.InviteOrganizationMemberBody true "body"
+// @Success 201 {object} domain.OrganizationMember
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 401
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 409 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Router /api/v1/organization/{id}/invite [post]
+func (h *OrganizationHandler) Invite(w http.ResponseWriter, r *http.Request) {
+	userID, err := usercontext.GetUserIDFromContext(r.Context())
+	if err != nil {
+		httperrors.Handle(w, domain.ErrNotAuth)
+		return
+	}
+
+	organizationID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		httperrors.Handle(w, domain.ErrOrganizationNotFound)
+		return
+	}
+
+	var body dtos.InviteOrganizationMemberBody
+	if statusCode, err := jsonutil.Unmarshal(w, r, &body); err != nil {
+		httputils.SendJSONErrorResponse(w, statusCode, err.Error(), statusCode)
+		return
+	}
+
+	if !body.Valid() {
+		httperrors.Handle(w, domain.ErrInvalidBody)
+		return
+	}
+
+	member, err := h.service.Invite(r.Context(), userID, organizationID, body.Email, body.Role)
+	if err != nil {
+		httperrors.Handle(w, err)
+		return
+	}
+
+	httputils.SendJSONResponse(w, http.StatusCreated, member)
+}
+
+// Accept godoc
+// @Summary Accept invite to organization
+// @Produce json
+// @Tags organization
+// @Security Bearer
+// @Param id path string true "Organization ID"
+// @Success 204
+// @Failure 401
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Router /api/v1/organization/{id}/accept [post]
+func (h *OrganizationHandler) Accept(w http.ResponseWriter, r *http.Request) {
+	userID, err := usercontext.GetUserIDFromContext(r.Context())
+	if err != nil {
+		httperrors.Handle(w, domain.ErrNotAuth)
+		return
+	}
+
+	organizationID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		httperrors.Handle(w, domain.ErrOrganizationInviteNotFound)
+		return
+	}
+
+	if err := h.service.Accept(r.Context(), userID, organizationID); err != nil {
+		httperrors.Handle(w, err)
+		return
+	}
+
+	httputils.SendStatusCode(w, http.StatusNoContent)
+}
+
+// GetMembers godoc
+// @Summary Get organization members
+// @Produce json
+// @Tags organization
+// @Security Bearer
+// @Param id path string true "Organization ID"
+// @Success 200 {array} domain.OrganizationMember
+// @Failure 401
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Router /api/v1/organization/{id}/members [get]
+func (h *OrganizationHandler) GetMembers(w http.ResponseWriter, r *http.Request) {
+	userID, err := usercontext.GetUserIDFromContext(r.Context())
+	if err != nil {
+		httperrors.Handle(w, domain.ErrNotAuth)
+		return
+	}
+
+	organizationID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		httperrors.Handle(w, domain.ErrOrganizationNotFound)
+		return
+	}
+
+	members, err := h.service.GetMembers(r.Context(), userID, organizationID)
+	if err != nil {
+		httperrors.Handle(w, err)
+		return
+	}
+
+	httputils.SendJSONResponse(w, http.StatusOK, members)
+}
+
+// CreateCollection godoc
+// @Summary Create collection in organization (available for owner and admin)
+// @Accept json
+// @Produce json
+// @Tags organization
+// @Security Bearer
+// @Param id path string true "Organization ID"
+// @Param dto body dtos.CreateCollectionBody true "body"
+// @Success 201 {object} domain.Collection
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 401
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Router /api/v1/organization/{id}/collection [post]
+func (h *OrganizationHandler) CreateCollection(w http.ResponseWriter, r *http.Request) {
+	userID, err := usercontext.GetUserIDFromContext(r.Context())
+	if err != nil {
+		httperrors.Handle(w, domain.ErrNotAuth)
+		return
+	}
+
+	organizationID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		httperrors.Handle(w, domain.ErrOrganizationNotFound)
+		return
+	}
+
+	var body dtos.CreateCollectionBody
+	if statusCode, err := jsonutil.Unmarshal(w, r, &body); err != nil {
+		httputils.SendJSONErrorResponse(w, statusCode, err.Error(), statusCode)
+		return
+	}
+
+	if !body.Valid() {
+		httperrors.Handle(w, domain.ErrInvalidBody)
+		return
+	}
+
+	collection, err := h.service.CreateCollection(r.Context(), userID, organizationID, body.Name)
+	if err != nil {
+		httperrors.Handle(w, err)
+		return
+	}
+
+	httputils.SendJSONResponse(w, http.StatusCreated, collection)
+}
+
+// GetCollections godoc
+// @Summary Get organization collections
+// @Produce json
+// @Tags organization
+// @Security Bearer
+// @Param id path string true "Organization ID"
+// @Success 200 {array} domain.Collection
+// @Failure 401
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Router /api/v1/organization/{id}/collection [get]
+func (h *OrganizationHandler) GetCollections(w http.ResponseWriter, r *http.Request) {
+	userID, err := usercontext.GetUserIDFromContext(r.Context())
+	if err != nil {
+		httperrors.Handle(w, domain.ErrNotAuth)
+		return
+	}
+
+	organizationID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		httperrors.Handle(w, domain.ErrOrganizationNotFound)
+		return
+	}
+
+	collections, err := h.service.GetCollections(r.Context(), userID, organizationID)
+	if err != nil {
+		httperrors.Handle(w, err)
+		return
+	}
+
+	httputils.SendJSONResponse(w, http.StatusOK, collections)
+}
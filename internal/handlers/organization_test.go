@@ -0,0 +1,317 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+	"github.com/MowlCoder/goph-keeper/internal/dtos"
+	mock_handlers "github.com/MowlCoder/goph-keeper/internal/handlers/mocks"
+	"github.com/MowlCoder/goph-keeper/internal/utils/usercontext"
+)
+
+type organizationTestSuite struct {
+	suite.Suite
+
+	service *mock_handlers.MockorganizationService
+
+	handler *OrganizationHandler
+}
+
+func (suite *organizationTestSuite) SetupSuite() {
+}
+
+func (suite *organizationTestSuite) TearDownSuite() {
+}
+
+func (suite *organizationTestSuite) SetupTest() {
+	ctrl := gomock.NewController(suite.T())
+
+	suite.service = mock_handlers.NewMockorganizationService(ctrl)
+
+	suite.handler = NewOrganizationHandler(suite.service)
+}
+
+func (suite *organizationTestSuite) TearDownTest() {
+}
+
+func TestOrganizationSuite(t *testing.T) {
+	suite.Run(t, new(organizationTestSuite))
+}
+
+func (suite *organizationTestSuite) TestCreate() {
+	testCases := []struct {
+		name       string
+		statusCode int
+		prepare    func() (int, []byte)
+	}{
+		{
+			name:       "valid",
+			statusCode: http.StatusCreated,
+			prepare: func() (int, []byte) {
+				userID := 1
+				body := dtos.CreateOrganizationBody{
+					Name: "Family",
+				}
+				b, _ := json.Marshal(body)
+
+				suite.service.
+					EXPECT().
+					Create(gomock.Any(), userID, body.Name).
+					Return(&domain.Organization{ID: 1, Name: body.Name}, nil)
+
+				return userID, b
+			},
+		},
+		{
+			name:       "invalid body",
+			statusCode: http.StatusBadRequest,
+			prepare: func() (int, []byte) {
+				userID := 1
+				body := dtos.CreateOrganizationBody{}
+				b, _ := json.Marshal(body)
+
+				return userID, b
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.name, func() {
+			userID, body := testCase.prepare()
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/organization", bytes.NewReader(body))
+			r.Header.Set("Content-Type", "application/json")
+			r = r.WithContext(usercontext.SetUserIDToContext(r.Context(), userID))
+			w := httptest.NewRecorder()
+
+			suite.handler.Create(w, r)
+			res := w.Result()
+			defer res.Body.Close()
+
+			suite.Equal(testCase.statusCode, res.StatusCode)
+		})
+	}
+}
+
+func (suite *organizationTestSuite) TestInvite() {
+	testCases := []struct {
+		name       string
+		statusCode int
+		prepare    func() (int, string, []byte)
+	}{
+		{
+			name:       "valid",
+			statusCode: http.StatusCreated,
+			prepare: func() (int, string, []byte) {
+				userID := 1
+				body := dtos.InviteOrganizationMemberBody{
+					Email: "test@gmail.com",
+					Role:  domain.OrganizationMemberRole,
+				}
+				b, _ := json.Marshal(body)
+
+				suite.service.
+					EXPECT().
+					Invite(gomock.Any(), userID, 5, body.Email, body.Role).
+					Return(&domain.OrganizationMember{ID: 1}, nil)
+
+				return userID, "5", b
+			},
+		},
+		{
+			name:       "invalid role",
+			statusCode: http.StatusBadRequest,
+			prepare: func() (int, string, []byte) {
+				body := dtos.InviteOrganizationMemberBody{
+					Email: "test@gmail.com",
+					Role:  domain.OrganizationOwnerRole,
+				}
+				b, _ := json.Marshal(body)
+
+				return 1, "5", b
+			},
+		},
+		{
+			name:       "invalid organization id",
+			statusCode: http.StatusNotFound,
+			prepare: func() (int, string, []byte) {
+				return 1, "abc", []byte("{}")
+			},
+		},
+		{
+			name:       "not enough permissions",
+			statusCode: http.StatusForbidden,
+			prepare: func() (int, string, []byte) {
+				userID := 1
+				body := dtos.InviteOrganizationMemberBody{
+					Email: "test@gmail.com",
+					Role:  domain.OrganizationAdminRole,
+				}
+				b, _ := json.Marshal(body)
+
+				suite.service.
+					EXPECT().
+					Invite(gomock.Any(), userID, 5, body.Email, body.Role).
+					Return(nil, domain.ErrNotEnoughPermissions)
+
+				return userID, "5", b
+			},
+		},
+		{
+			name:       "already member",
+			statusCode: http.StatusConflict,
+			prepare: func() (int, string, []byte) {
+				userID := 1
+				body := dtos.InviteOrganizationMemberBody{
+					Email: "test@gmail.com",
+					Role:  domain.OrganizationReadOnlyRole,
+				}
+				b, _ := json.Marshal(body)
+
+				suite.service.
+					EXPECT().
+					Invite(gomock.Any(), userID, 5, body.Email, body.Role).
+					Return(nil, domain.ErrAlreadyOrganizationMember)
+
+				return userID, "5", b
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.name, func() {
+			userID, id, body := testCase.prepare()
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/organization/"+id+"/invite", bytes.NewReader(body))
+			r.Header.Set("Content-Type", "application/json")
+			r = r.WithContext(usercontext.SetUserIDToContext(r.Context(), userID))
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", id)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			suite.handler.Invite(w, r)
+			res := w.Result()
+			defer res.Body.Close()
+
+			suite.Equal(testCase.statusCode, res.StatusCode)
+		})
+	}
+}
+
+func (suite *organizationTestSuite) TestAccept() {
+	testCases := []struct {
+		name       string
+		statusCode int
+		prepare    func() (int, string)
+	}{
+		{
+			name:       "valid",
+			statusCode: http.StatusNoContent,
+			prepare: func() (int, string) {
+				userID := 1
+
+				suite.service.
+					EXPECT().
+					Accept(gomock.Any(), userID, 5).
+					Return(nil)
+
+				return userID, "5"
+			},
+		},
+		{
+			name:       "invite not found",
+			statusCode: http.StatusNotFound,
+			prepare: func() (int, string) {
+				userID := 1
+
+				suite.service.
+					EXPECT().
+					Accept(gomock.Any(), userID, 5).
+					Return(domain.ErrOrganizationInviteNotFound)
+
+				return userID, "5"
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.name, func() {
+			userID, id := testCase.prepare()
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/organization/"+id+"/accept", nil)
+			r = r.WithContext(usercontext.SetUserIDToContext(r.Context(), userID))
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", id)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			suite.handler.Accept(w, r)
+			res := w.Result()
+			defer res.Body.Close()
+
+			suite.Equal(testCase.statusCode, res.StatusCode)
+		})
+	}
+}
+
+func (suite *organizationTestSuite) TestGetCollections() {
+	testCases := []struct {
+		name       string
+		statusCode int
+		prepare    func() (int, string)
+	}{
+		{
+			name:       "valid",
+			statusCode: http.StatusOK,
+			prepare: func() (int, string) {
+				userID := 1
+
+				suite.service.
+					EXPECT().
+					GetCollections(gomock.Any(), userID, 5).
+					Return([]domain.Collection{}, nil)
+
+				return userID, "5"
+			},
+		},
+		{
+			name:       "organization not found",
+			statusCode: http.StatusNotFound,
+			prepare: func() (int, string) {
+				userID := 1
+
+				suite.service.
+					EXPECT().
+					GetCollections(gomock.Any(), userID, 5).
+					Return(nil, domain.ErrOrganizationNotFound)
+
+				return userID, "5"
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.name, func() {
+			userID, id := testCase.prepare()
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/organization/"+id+"/collection", nil)
+			r = r.WithContext(usercontext.SetUserIDToContext(r.Context(), userID))
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", id)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			suite.handler.GetCollections(w, r)
+			res := w.Result()
+			defer res.Body.Close()
+
+			suite.Equal(testCase.statusCode, res.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+	"github.com/MowlCoder/goph-keeper/internal/dtos"
+	"github.com/MowlCoder/goph-keeper/internal/handlers/httperrors"
+	"github.com/MowlCoder/goph-keeper/internal/utils/usercontext"
+	"github.com/MowlCoder/goph-keeper/pkg/httputils"
+	jsonutil "github.com/MowlCoder/goph-keeper/pkg/jsonutils"
+)
+
+type shareService interface {
+	Share(ctx context.Context, ownerID int, dataID int, recipientEmail string, permission string) (*domain.Share, error)
+	Unshare(ctx context.Context, userID int, shareID int) error
+	GetSharedByUser(ctx context.Context, userID int) ([]domain.Share, error)
+	GetSharedWithUser(ctx context.Context, userID int) ([]domain.SharedUserStoredData, error)
+}
+
+type ShareHandler struct {
+	service shareService
+	audit   auditRecorder
+}
+
+func NewShareHandler(service shareService, audit auditRecorder) *ShareHandler {
+	return &ShareHandler{
+		service: service,
+		audit:   audit,
+	}
+}
+
+// Create godoc
+// @Summary Share user data with another user
+// @Accept json
+// @Produce json
+// @Tags share
+// @Security Bearer
+// @Param dto body dtos.CreateShareBody true "body"
+// @Success 201 {object} domain.Share
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 401
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Router /api/v1/share [post]
+func (h *ShareHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID, err := usercontext.GetUserIDFromContext(r.Context())
+	if err != nil {
+		httperrors.Handle(w, domain.ErrNotAuth)
+		return
+	}
+
+	var body dtos.CreateShareBody
+	if statusCode, err := jsonutil.Unmarshal(w, r, &body); err != nil {
+		httputils.SendJSONErrorResponse(w, statusCode, err.Error(), statusCode)
+		return
+	}
+
+	if !body.Valid() {
+		httperrors.Handle(w, domain.ErrInvalidBody)
+		return
+	}
+
+	share, err := h.service.Share(r.Context(), userID, body.DataID, body.Email, body.Permission)
+	if err != nil {
+		httperrors.Handle(w, err)
+		return
+	}
+
+	h.audit.Record(r.Context(), newAuditEvent(r, domain.AuditEventShareCreate, userID, share.DataID))
+
+	httputils.SendJSONResponse(w, http.StatusCreated, share)
+}
+
+// GetSharedWithMe godoc
+// @Summary Get data shared with current user
+// @Produce json
+// @Tags share
+// @Security Bearer
+// @Success 200 {array} domain.SharedUserStoredData
+// @Failure 401
+// @Failure 500 {object} httputils.HTTPError
+// @Router /api/v1/share/with-me [get]
+func (h *ShareHandler) GetSharedWithMe(w http.ResponseWriter, r *http.Request) {
+	userID, err := usercontext.GetUserIDFromContext(r.Context())
+	if err != nil {
+		httperrors.Handle(w, domain.ErrNotAuth)
+		return
+	}
+
+	dataSet, err := h.service.GetSharedWithUser(r.Context(), userID)
+	if err != nil {
+		httperrors.Handle(w, err)
+		return
+	}
+
+	httputils.SendJSONResponse(w, http.StatusOK, dataSet)
+}
+
+// GetSharedByMe godoc
+// @Summary Get shares created by current user
+// @Produce json
+// @Tags share
+// @Security Bearer
+// @Success 200 {array} domain.Share
+// @Failure 401
+// @Failure 500 {object} httputils.HTTPError
+// @Router /api/v1/share/by-me [get]
+func (h *ShareHandler) GetSharedByMe(w http.ResponseWriter, r *http.Request) {
+	userID, err := usercontext.GetUserIDFromContext(r.Context())
+	if err != nil {
+		httperrors.Handle(w, domain.ErrNotAuth)
+		return
+	}
+
+	shares, err := h.service.GetSharedByUser(r.Context(), userID)
+	if err != nil {
+		httperrors.Handle(w, err)
+		return
+	}
+
+	httputils.SendJSONResponse(w, http.StatusOK, shares)
+}
+
+// Delete godoc
+// @Summary Revoke share (available for owner and recipient)
+// @Produce json
+// @Tags share
+// @Security Bearer
+// @Param id path string true "Share ID"
+// @Success 204
+// @Failure 401
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Router /api/v1/share/{id} [delete]
+func (h *ShareHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	userID, err := usercontext.GetUserIDFromContext(r.Context())
+	if err != nil {
+		httperrors.Handle(w, domain.ErrNotAuth)
+		return
+	}
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		httperrors.Handle(w, domain.ErrShareNotFound)
+		return
+	}
+
+	if err := h.service.Unshare(r.Context(), userID, id); err != nil {
+		httperrors.Handle(w, err)
+		return
+	}
+
+	h.audit.Record(r.Context(), newAuditEvent(r, domain.AuditEventShareDelete, userID, id))
+
+	httputils.SendStatusCode(w, http.StatusNoContent)
+}
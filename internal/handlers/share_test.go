@@ -0,0 +1,317 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+	"github.com/MowlCoder/goph-keeper/internal/dtos"
+	mock_handlers "github.com/MowlCoder/goph-keeper/internal/handlers/mocks"
+	"github.com/MowlCoder/goph-keeper/internal/utils/usercontext"
+)
+
+type shareTestSuite struct {
+	suite.Suite
+
+	service *mock_handlers.MockshareService
+	audit   *mock_handlers.MockauditRecorder
+
+	handler *ShareHandler
+}
+
+func (suite *shareTestSuite) SetupSuite() {
+}
+
+func (suite *shareTestSuite) TearDownSuite() {
+}
+
+func (suite *shareTestSuite) SetupTest() {
+	ctrl := gomock.NewController(suite.T())
+
+	suite.service = mock_handlers.NewMockshareService(ctrl)
+	suite.audit = mock_handlers.NewMockauditRecorder(ctrl)
+	suite.audit.EXPECT().Record(gomock.Any(), gomock.Any()).AnyTimes()
+
+	suite.handler = NewShareHandler(suite.service, suite.audit)
+}
+
+func (suite *shareTestSuite) TearDownTest() {
+}
+
+func TestShareSuite(t *testing.T) {
+	suite.Run(t, new(shareTestSuite))
+}
+
+func (suite *shareTestSuite) TestCreate() {
+	testCases := []struct {
+		name       string
+		statusCode int
+		prepare    func() (int, []byte)
+	}{
+		{
+			name:       "valid",
+			statusCode: http.StatusCreated,
+			prepare: func() (int, []byte) {
+				userID := 1
+				body := dtos.CreateShareBody{
+					DataID:     10,
+					Email:      "test@gmail.com",
+					Permission: domain.ShareReadPermission,
+				}
+				b, _ := json.Marshal(body)
+
+				suite.service.
+					EXPECT().
+					Share(gomock.Any(), userID, body.DataID, body.Email, body.Permission).
+					Return(&domain.Share{ID: 1}, nil)
+
+				return userID, b
+			},
+		},
+		{
+			name:       "invalid body",
+			statusCode: http.StatusBadRequest,
+			prepare: func() (int, []byte) {
+				userID := 1
+				body := dtos.CreateShareBody{
+					DataID:     10,
+					Email:      "test@gmail.com",
+					Permission: "admin",
+				}
+				b, _ := json.Marshal(body)
+
+				return userID, b
+			},
+		},
+		{
+			name:       "data not found",
+			statusCode: http.StatusNotFound,
+			prepare: func() (int, []byte) {
+				userID := 1
+				body := dtos.CreateShareBody{
+					DataID:     10,
+					Email:      "test@gmail.com",
+					Permission: domain.ShareWritePermission,
+				}
+				b, _ := json.Marshal(body)
+
+				suite.service.
+					EXPECT().
+					Share(gomock.Any(), userID, body.DataID, body.Email, body.Permission).
+					Return(nil, domain.ErrUserStoredDataNotFound)
+
+				return userID, b
+			},
+		},
+		{
+			name:       "share with yourself",
+			statusCode: http.StatusBadRequest,
+			prepare: func() (int, []byte) {
+				userID := 1
+				body := dtos.CreateShareBody{
+					DataID:     10,
+					Email:      "test@gmail.com",
+					Permission: domain.ShareWritePermission,
+				}
+				b, _ := json.Marshal(body)
+
+				suite.service.
+					EXPECT().
+					Share(gomock.Any(), userID, body.DataID, body.Email, body.Permission).
+					Return(nil, domain.ErrShareWithYourself)
+
+				return userID, b
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.name, func() {
+			userID, body := testCase.prepare()
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/share", bytes.NewReader(body))
+			r.Header.Set("Content-Type", "application/json")
+			r = r.WithContext(usercontext.SetUserIDToContext(r.Context(), userID))
+			w := httptest.NewRecorder()
+
+			suite.handler.Create(w, r)
+			res := w.Result()
+			defer res.Body.Close()
+
+			suite.Equal(testCase.statusCode, res.StatusCode)
+		})
+	}
+}
+
+func (suite *shareTestSuite) TestGetSharedWithMe() {
+	testCases := []struct {
+		name       string
+		statusCode int
+		prepare    func() int
+	}{
+		{
+			name:       "valid",
+			statusCode: http.StatusOK,
+			prepare: func() int {
+				userID := 1
+
+				suite.service.
+					EXPECT().
+					GetSharedWithUser(gomock.Any(), userID).
+					Return([]domain.SharedUserStoredData{}, nil)
+
+				return userID
+			},
+		},
+		{
+			name:       "internal error",
+			statusCode: http.StatusInternalServerError,
+			prepare: func() int {
+				userID := 1
+
+				suite.service.
+					EXPECT().
+					GetSharedWithUser(gomock.Any(), userID).
+					Return(nil, domain.ErrInternal)
+
+				return userID
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.name, func() {
+			userID := testCase.prepare()
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/share/with-me", nil)
+			r = r.WithContext(usercontext.SetUserIDToContext(r.Context(), userID))
+			w := httptest.NewRecorder()
+
+			suite.handler.GetSharedWithMe(w, r)
+			res := w.Result()
+			defer res.Body.Close()
+
+			suite.Equal(testCase.statusCode, res.StatusCode)
+		})
+	}
+}
+
+func (suite *shareTestSuite) TestGetSharedByMe() {
+	testCases := []struct {
+		name       string
+		statusCode int
+		prepare    func() int
+	}{
+		{
+			name:       "valid",
+			statusCode: http.StatusOK,
+			prepare: func() int {
+				userID := 1
+
+				suite.service.
+					EXPECT().
+					GetSharedByUser(gomock.Any(), userID).
+					Return([]domain.Share{}, nil)
+
+				return userID
+			},
+		},
+		{
+			name:       "internal error",
+			statusCode: http.StatusInternalServerError,
+			prepare: func() int {
+				userID := 1
+
+				suite.service.
+					EXPECT().
+					GetSharedByUser(gomock.Any(), userID).
+					Return(nil, domain.ErrInternal)
+
+				return userID
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.name, func() {
+			userID := testCase.prepare()
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/share/by-me", nil)
+			r = r.WithContext(usercontext.SetUserIDToContext(r.Context(), userID))
+			w := httptest.NewRecorder()
+
+			suite.handler.GetSharedByMe(w, r)
+			res := w.Result()
+			defer res.Body.Close()
+
+			suite.Equal(testCase.statusCode, res.StatusCode)
+		})
+	}
+}
+
+func (suite *shareTestSuite) TestDelete() {
+	testCases := []struct {
+		name       string
+		statusCode int
+		prepare    func() (int, string)
+	}{
+		{
+			name:       "valid",
+			statusCode: http.StatusNoContent,
+			prepare: func() (int, string) {
+				userID := 1
+
+				suite.service.
+					EXPECT().
+					Unshare(gomock.Any(), userID, 5).
+					Return(nil)
+
+				return userID, "5"
+			},
+		},
+		{
+			name:       "invalid id",
+			statusCode: http.StatusNotFound,
+			prepare: func() (int, string) {
+				return 1, "test"
+			},
+		},
+		{
+			name:       "share not found",
+			statusCode: http.StatusNotFound,
+			prepare: func() (int, string) {
+				userID := 1
+
+				suite.service.
+					EXPECT().
+					Unshare(gomock.Any(), userID, 5).
+					Return(domain.ErrShareNotFound)
+
+				return userID, "5"
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.name, func() {
+			userID, id := testCase.prepare()
+			r := httptest.NewRequest(http.MethodDelete, "/api/v1/share/"+id, nil)
+			r = r.WithContext(usercontext.SetUserIDToContext(r.Context(), userID))
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", id)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			suite.handler.Delete(w, r)
+			res := w.Result()
+			defer res.Body.Close()
+
+			suite.Equal(testCase.statusCode, res.StatusCode)
+		})
+	}
+}
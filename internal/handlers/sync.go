@@ -0,0 +1,214 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+	"github.com/MowlCoder/goph-keeper/internal/dtos"
+	"github.com/MowlCoder/goph-keeper/internal/handlers/httperrors"
+	"github.com/MowlCoder/goph-keeper/internal/utils/usercontext"
+	"github.com/MowlCoder/goph-keeper/pkg/httputils"
+	jsonutil "github.com/MowlCoder/goph-keeper/pkg/jsonutils"
+)
+
+type syncService interface {
+	GetChanges(ctx context.Context, userID int, collectionID int, since int64, limit int) (*domain.ChangeFeed, error)
+	Push(ctx context.Context, userID int, items []domain.SyncPushItem) (*domain.SyncPushResponse, error)
+}
+
+type syncAcknowledger interface {
+	AcknowledgeSync(ctx context.Context, userID int, deviceID int, cursor int64) error
+}
+
+type SyncHandler struct {
+	service syncService
+	devices syncAcknowledger
+	audit   auditRecorder
+}
+
+func NewSyncHandler(service syncService, devices syncAcknowledger, audit auditRecorder) *SyncHandler {
+	return &SyncHandler{
+		service: service,
+		devices: devices,
+		audit:   audit,
+	}
+}
+
+// GetChanges godoc
+// @Summary Get records changed after cursor, deleted records are returned with deleted flag
+// @Produce json
+// @Tags sync
+// @Security Bearer
+// @Param since query int false "Cursor returned by previous call, 0 by default"
+// @Param limit query int false "Changes per page, 500 by default"
+// @Param collection_id query int false "Organization collection ID, personal vault if omitted"
+// @Success 200 {object} domain.ChangeFeed
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 401
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 410 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Router /api/v1/sync/changes [get]
+func (h *SyncHandler) GetChanges(w http.ResponseWriter, r *http.Request) {
+	userID, err := usercontext.GetUserIDFromContext(r.Context())
+	if err != nil {
+		httperrors.Handle(w, domain.ErrNotAuth)
+		return
+	}
+
+	collectionID, err := parseCollectionID(r)
+	if err != nil {
+		httperrors.Handle(w, err)
+		return
+	}
+
+	var since int64
+	if rawSince := r.URL.Query().Get("since"); rawSince != "" {
+		since, err = strconv.ParseInt(rawSince, 10, 64)
+		if err != nil || since < 0 {
+			httperrors.Handle(w, domain.ErrInvalidSyncCursor)
+			return
+		}
+	}
+
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil {
+		limit = domain.SyncChangesDefaultLimit
+	}
+
+	feed, err := h.service.GetChanges(r.Context(), userID, collectionID, since, limit)
+	if err != nil {
+		httperrors.Handle(w, err)
+		return
+	}
+
+	httputils.SendJSONResponse(w, http.StatusOK, feed)
+}
+
+// syncOperationAuditEvents - audit event recorded for every applied item of pushed batch
+var syncOperationAuditEvents = map[string]string{
+	domain.SyncOperationCreate: domain.AuditEventDataCreate,
+	domain.SyncOperationUpdate: domain.AuditEventDataUpdate,
+	domain.SyncOperationDelete: domain.AuditEventDataDelete,
+}
+
+// Push godoc
+// @Summary Apply batch of personal vault creates, updates and deletes in one transaction
+// @Description Batch is applied only as a whole. If any item conflicts with server version or is not found,
+// @Description nothing is changed and 409 is returned with status of every item
+// @Accept json
+// @Produce json
+// @Tags sync
+// @Security Bearer
+// @Param dto body dtos.SyncPushBody true "body"
+// @Success 200 {object} domain.SyncPushResponse
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 401
+// @Failure 409 {object} domain.SyncPushResponse
+// @Failure 500 {object} httputils.HTTPError
+// @Router /api/v1/sync/push [post]
+func (h *SyncHandler) Push(w http.ResponseWriter, r *http.Request) {
+	userID, err := usercontext.GetUserIDFromContext(r.Context())
+	if err != nil {
+		httperrors.Handle(w, domain.ErrNotAuth)
+		return
+	}
+
+	var body dtos.SyncPushBody
+	if statusCode, err := jsonutil.Unmarshal(w, r, &body); err != nil {
+		httputils.SendJSONErrorResponse(w, statusCode, err.Error(), statusCode)
+		return
+	}
+
+	if !body.Valid() {
+		httperrors.Handle(w, domain.ErrInvalidBody)
+		return
+	}
+
+	items := make([]domain.SyncPushItem, 0, len(body.Items))
+	for _, bodyItem := range body.Items {
+		item := domain.SyncPushItem{
+			UUID:      bodyItem.UUID,
+			Operation: bodyItem.Operation,
+			Version:   bodyItem.Version,
+			DataType:  bodyItem.DataType,
+		}
+
+		if bodyItem.Operation != domain.SyncOperationDelete {
+			dataBody, err := bodyItem.ParseData()
+			if err != nil {
+				httperrors.Handle(w, err)
+				return
+			}
+
+			item.Data = dataBody.GetData()
+			item.Meta = dataBody.GetMeta()
+		}
+
+		items = append(items, item)
+	}
+
+	response, err := h.service.Push(r.Context(), userID, items)
+	if err != nil {
+		httperrors.Handle(w, err)
+		return
+	}
+
+	if !response.Applied {
+		httputils.SendJSONResponse(w, http.StatusConflict, response)
+		return
+	}
+
+	for idx, result := range response.Results {
+		h.audit.Record(r.Context(), newAuditEvent(r, syncOperationAuditEvents[items[idx].Operation], userID, result.ID))
+	}
+
+	httputils.SendJSONResponse(w, http.StatusOK, response)
+}
+
+// Ack godoc
+// @Summary Acknowledge that personal vault changes up to cursor are applied on device
+// @Description Deletions acknowledged by every device of user are collected, device which cursor
+// @Description is older than collected deletions gets 410 on changes request and must sync from the beginning
+// @Accept json
+// @Tags sync
+// @Security Bearer
+// @Param dto body dtos.SyncAckBody true "body"
+// @Success 204
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 401
+// @Failure 500 {object} httputils.HTTPError
+// @Router /api/v1/sync/ack [post]
+func (h *SyncHandler) Ack(w http.ResponseWriter, r *http.Request) {
+	userID, err := usercontext.GetUserIDFromContext(r.Context())
+	if err != nil {
+		httperrors.Handle(w, domain.ErrNotAuth)
+		return
+	}
+
+	deviceID, err := usercontext.GetDeviceIDFromContext(r.Context())
+	if err != nil {
+		httperrors.Handle(w, domain.ErrNotAuth)
+		return
+	}
+
+	var body dtos.SyncAckBody
+	if statusCode, err := jsonutil.Unmarshal(w, r, &body); err != nil {
+		httputils.SendJSONErrorResponse(w, statusCode, err.Error(), statusCode)
+		return
+	}
+
+	if !body.Valid() {
+		httperrors.Handle(w, domain.ErrInvalidSyncCursor)
+		return
+	}
+
+	if err := h.devices.AcknowledgeSync(r.Context(), userID, deviceID, body.Cursor); err != nil {
+		httperrors.Handle(w, err)
+		return
+	}
+
+	httputils.SendStatusCode(w, http.StatusNoContent)
+}
@@ -0,0 +1,331 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+	mock_handlers "github.com/MowlCoder/goph-keeper/internal/handlers/mocks"
+	"github.com/MowlCoder/goph-keeper/internal/utils/usercontext"
+)
+
+type syncTestSuite struct {
+	suite.Suite
+
+	service *mock_handlers.MocksyncService
+	devices *mock_handlers.MocksyncAcknowledger
+	audit   *mock_handlers.MockauditRecorder
+
+	handler *SyncHandler
+}
+
+func (suite *syncTestSuite) SetupSuite() {
+}
+
+func (suite *syncTestSuite) TearDownSuite() {
+}
+
+func (suite *syncTestSuite) SetupTest() {
+	ctrl := gomock.NewController(suite.T())
+
+	suite.service = mock_handlers.NewMocksyncService(ctrl)
+	suite.devices = mock_handlers.NewMocksyncAcknowledger(ctrl)
+	suite.audit = mock_handlers.NewMockauditRecorder(ctrl)
+	suite.audit.EXPECT().Record(gomock.Any(), gomock.Any()).AnyTimes()
+
+	suite.handler = NewSyncHandler(suite.service, suite.devices, suite.audit)
+}
+
+func (suite *syncTestSuite) TearDownTest() {
+}
+
+func TestSyncSuite(t *testing.T) {
+	suite.Run(t, new(syncTestSuite))
+}
+
+func (suite *syncTestSuite) TestGetChanges() {
+	testCases := []struct {
+		name       string
+		statusCode int
+		prepare    func() (int, string)
+	}{
+		{
+			name:       "valid",
+			statusCode: http.StatusOK,
+			prepare: func() (int, string) {
+				userID := 1
+
+				suite.service.
+					EXPECT().
+					GetChanges(gomock.Any(), userID, 0, int64(15), 100).
+					Return(&domain.ChangeFeed{Cursor: 15}, nil)
+
+				return userID, "?since=15&limit=100"
+			},
+		},
+		{
+			name:       "without cursor",
+			statusCode: http.StatusOK,
+			prepare: func() (int, string) {
+				userID := 1
+
+				suite.service.
+					EXPECT().
+					GetChanges(gomock.Any(), userID, 0, int64(0), domain.SyncChangesDefaultLimit).
+					Return(&domain.ChangeFeed{}, nil)
+
+				return userID, ""
+			},
+		},
+		{
+			name:       "collection",
+			statusCode: http.StatusNotFound,
+			prepare: func() (int, string) {
+				userID := 1
+
+				suite.service.
+					EXPECT().
+					GetChanges(gomock.Any(), userID, 3, int64(0), domain.SyncChangesDefaultLimit).
+					Return(nil, domain.ErrCollectionNotFound)
+
+				return userID, "?collection_id=3"
+			},
+		},
+		{
+			name:       "collected cursor",
+			statusCode: http.StatusGone,
+			prepare: func() (int, string) {
+				userID := 1
+
+				suite.service.
+					EXPECT().
+					GetChanges(gomock.Any(), userID, 0, int64(15), domain.SyncChangesDefaultLimit).
+					Return(nil, domain.ErrSyncCursorExpired)
+
+				return userID, "?since=15"
+			},
+		},
+		{
+			name:       "invalid cursor",
+			statusCode: http.StatusBadRequest,
+			prepare: func() (int, string) {
+				return 1, "?since=-1"
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.name, func() {
+			userID, query := testCase.prepare()
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/sync/changes"+query, nil)
+			r = r.WithContext(usercontext.SetUserIDToContext(r.Context(), userID))
+			w := httptest.NewRecorder()
+
+			suite.handler.GetChanges(w, r)
+			res := w.Result()
+			defer res.Body.Close()
+
+			suite.Equal(testCase.statusCode, res.StatusCode)
+		})
+	}
+}
+
+func (suite *syncTestSuite) TestPush() {
+	newRecordUUID := "5f0c7a1e-3b2d-4c8f-9a6e-1d2b3c4d5e6f"
+	deletedRecordUUID := "6a1d8b2f-4c3e-4d9a-8b7f-2e3c4d5e6f70"
+
+	testCases := []struct {
+		name       string
+		statusCode int
+		prepare    func() (int, []byte)
+	}{
+		{
+			name:       "applied",
+			statusCode: http.StatusOK,
+			prepare: func() (int, []byte) {
+				userID := 1
+
+				suite.service.
+					EXPECT().
+					Push(gomock.Any(), userID, []domain.SyncPushItem{
+						{
+							UUID:      newRecordUUID,
+							Operation: domain.SyncOperationCreate,
+							DataType:  domain.TextDataType,
+							Data:      domain.TextData{Text: "text"},
+							Meta:      "meta",
+						},
+						{
+							UUID:      deletedRecordUUID,
+							Operation: domain.SyncOperationDelete,
+							Version:   2,
+						},
+					}).
+					Return(&domain.SyncPushResponse{
+						Applied: true,
+						Results: []domain.SyncPushResult{
+							{UUID: newRecordUUID, Status: domain.SyncPushStatusApplied, ID: 10, Version: 1},
+							{UUID: deletedRecordUUID, Status: domain.SyncPushStatusApplied, ID: 5},
+						},
+					}, nil)
+
+				body, _ := json.Marshal(map[string]interface{}{
+					"items": []map[string]interface{}{
+						{
+							"uuid":      newRecordUUID,
+							"operation": domain.SyncOperationCreate,
+							"data_type": domain.TextDataType,
+							"data":      map[string]string{"text": "text"},
+							"meta":      "meta",
+						},
+						{
+							"uuid":      deletedRecordUUID,
+							"operation": domain.SyncOperationDelete,
+							"version":   2,
+						},
+					},
+				})
+
+				return userID, body
+			},
+		},
+		{
+			name:       "conflict",
+			statusCode: http.StatusConflict,
+			prepare: func() (int, []byte) {
+				userID := 1
+
+				suite.service.
+					EXPECT().
+					Push(gomock.Any(), userID, gomock.Any()).
+					Return(&domain.SyncPushResponse{
+						Results: []domain.SyncPushResult{
+							{UUID: deletedRecordUUID, Status: domain.SyncPushStatusConflict},
+						},
+					}, nil)
+
+				body, _ := json.Marshal(map[string]interface{}{
+					"items": []map[string]interface{}{
+						{
+							"uuid":      deletedRecordUUID,
+							"operation": domain.SyncOperationUpdate,
+							"version":   2,
+							"data_type": domain.TextDataType,
+							"data":      map[string]string{"text": "text"},
+						},
+					},
+				})
+
+				return userID, body
+			},
+		},
+		{
+			name:       "invalid data",
+			statusCode: http.StatusBadRequest,
+			prepare: func() (int, []byte) {
+				body, _ := json.Marshal(map[string]interface{}{
+					"items": []map[string]interface{}{
+						{
+							"uuid":      newRecordUUID,
+							"operation": domain.SyncOperationCreate,
+							"data_type": domain.TextDataType,
+							"data":      map[string]string{},
+						},
+					},
+				})
+
+				return 1, body
+			},
+		},
+		{
+			name:       "empty batch",
+			statusCode: http.StatusBadRequest,
+			prepare: func() (int, []byte) {
+				return 1, []byte(`{"items":[]}`)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.name, func() {
+			userID, body := testCase.prepare()
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/sync/push", bytes.NewBuffer(body))
+			r.Header.Set("Content-Type", "application/json")
+			r = r.WithContext(usercontext.SetUserIDToContext(r.Context(), userID))
+			w := httptest.NewRecorder()
+
+			suite.handler.Push(w, r)
+			res := w.Result()
+			defer res.Body.Close()
+
+			suite.Equal(testCase.statusCode, res.StatusCode)
+		})
+	}
+}
+
+func (suite *syncTestSuite) TestAck() {
+	testCases := []struct {
+		name       string
+		statusCode int
+		prepare    func() (int, []byte)
+	}{
+		{
+			name:       "valid",
+			statusCode: http.StatusNoContent,
+			prepare: func() (int, []byte) {
+				userID := 1
+
+				suite.devices.
+					EXPECT().
+					AcknowledgeSync(gomock.Any(), userID, 2, int64(15)).
+					Return(nil)
+
+				return userID, []byte(`{"cursor":15}`)
+			},
+		},
+		{
+			name:       "revoked device",
+			statusCode: http.StatusNotFound,
+			prepare: func() (int, []byte) {
+				userID := 1
+
+				suite.devices.
+					EXPECT().
+					AcknowledgeSync(gomock.Any(), userID, 2, int64(15)).
+					Return(domain.ErrDeviceNotFound)
+
+				return userID, []byte(`{"cursor":15}`)
+			},
+		},
+		{
+			name:       "invalid cursor",
+			statusCode: http.StatusBadRequest,
+			prepare: func() (int, []byte) {
+				return 1, []byte(`{"cursor":-1}`)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.name, func() {
+			userID, body := testCase.prepare()
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/sync/ack", bytes.NewBuffer(body))
+			r.Header.Set("Content-Type", "application/json")
+			ctx := usercontext.SetUserIDToContext(r.Context(), userID)
+			r = r.WithContext(usercontext.SetDeviceIDToContext(ctx, 2))
+			w := httptest.NewRecorder()
+
+			suite.handler.Ack(w, r)
+			res := w.Result()
+			defer res.Body.Close()
+
+			suite.Equal(testCase.statusCode, res.StatusCode)
+		})
+	}
+}
@@ -2,12 +2,15 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"net/http"
 
 	"github.com/MowlCoder/goph-keeper/internal/dtos"
 
 	"github.com/MowlCoder/goph-keeper/internal/domain"
 	"github.com/MowlCoder/goph-keeper/internal/handlers/httperrors"
+	"github.com/MowlCoder/goph-keeper/internal/metrics"
+	"github.com/MowlCoder/goph-keeper/internal/utils/usercontext"
 	"github.com/MowlCoder/goph-keeper/pkg/httputils"
 	jsonutil "github.com/MowlCoder/goph-keeper/pkg/jsonutils"
 )
@@ -18,21 +21,38 @@ type userService interface {
 }
 
 type tokenGenerator interface {
-	Generate(ctx context.Context, user domain.User) (string, error)
+	Generate(ctx context.Context, user domain.User, deviceID int) (string, error)
+}
+
+type deviceRegistrar interface {
+	Register(ctx context.Context, userID int, deviceID int, name string, platform string) (*domain.Device, error)
+}
+
+type authFailureCounter interface {
+	IncAuthFailure(reason string)
 }
 
 type UserHandler struct {
 	userService    userService
 	tokenGenerator tokenGenerator
+	devices        deviceRegistrar
+	audit          auditRecorder
+	authFailures   authFailureCounter
 }
 
 func NewUserHandler(
 	userService userService,
 	tokenGenerator tokenGenerator,
+	devices deviceRegistrar,
+	audit auditRecorder,
+	authFailures authFailureCounter,
 ) *UserHandler {
 	return &UserHandler{
 		userService:    userService,
 		tokenGenerator: tokenGenerator,
+		devices:        devices,
+		audit:          audit,
+		authFailures:   authFailures,
 	}
 }
 
@@ -65,12 +85,14 @@ func (h *UserHandler) Register(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	token, err := h.tokenGenerator.Generate(r.Context(), *user)
+	token, err := h.issueDeviceToken(r.Context(), *user, body.DeviceInfo)
 	if err != nil {
 		httperrors.Handle(w, err)
 		return
 	}
 
+	h.audit.Record(r.Context(), newAuditEvent(r, domain.AuditEventRegister, user.ID, 0))
+
 	httputils.SendJSONResponse(w, http.StatusCreated, dtos.RegisterResponse{
 		Token: token,
 	})
@@ -100,17 +122,71 @@ func (h *UserHandler) Authorize(w http.ResponseWriter, r *http.Request) {
 
 	user, err := h.userService.Authorize(r.Context(), body.Email, body.Password)
 	if err != nil {
+		if errors.Is(err, domain.ErrWrongCredentials) || errors.Is(err, domain.ErrUserNotFound) {
+			event := newAuditEvent(r, domain.AuditEventLoginFailed, 0, 0)
+			event.Email = body.Email
+			h.audit.Record(r.Context(), event)
+			h.authFailures.IncAuthFailure(metrics.AuthFailureWrongCredentials)
+		}
+
 		httperrors.Handle(w, err)
 		return
 	}
 
-	token, err := h.tokenGenerator.Generate(r.Context(), *user)
+	token, err := h.issueDeviceToken(r.Context(), *user, body.DeviceInfo)
 	if err != nil {
 		httperrors.Handle(w, err)
 		return
 	}
 
+	h.audit.Record(r.Context(), newAuditEvent(r, domain.AuditEventLogin, user.ID, 0))
+
 	httputils.SendJSONResponse(w, http.StatusOK, dtos.AuthorizeResponse{
 		Token: token,
 	})
 }
+
+// Refresh godoc
+// @Summary Issue new token for device of current token, so client stays authorized without entering password
+// @Produce json
+// @Tags users
+// @Security Bearer
+// @Success 200 {object} dtos.AuthorizeResponse
+// @Failure 401
+// @Failure 500 {object} httputils.HTTPError
+// @Router /api/v1/user/refresh [post]
+func (h *UserHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	userID, err := usercontext.GetUserIDFromContext(r.Context())
+	if err != nil {
+		httperrors.Handle(w, domain.ErrNotAuth)
+		return
+	}
+
+	deviceID, err := usercontext.GetDeviceIDFromContext(r.Context())
+	if err != nil {
+		httperrors.Handle(w, domain.ErrNotAuth)
+		return
+	}
+
+	token, err := h.tokenGenerator.Generate(r.Context(), domain.User{ID: userID}, deviceID)
+	if err != nil {
+		httperrors.Handle(w, err)
+		return
+	}
+
+	h.audit.Record(r.Context(), newAuditEvent(r, domain.AuditEventTokenRefresh, userID, 0))
+
+	httputils.SendJSONResponse(w, http.StatusOK, dtos.AuthorizeResponse{
+		Token: token,
+	})
+}
+
+// issueDeviceToken - register device user has logged in from and bind token to it, so device can be revoked
+func (h *UserHandler) issueDeviceToken(ctx context.Context, user domain.User, info dtos.DeviceInfo) (string, error) {
+	device, err := h.devices.Register(ctx, user.ID, info.DeviceID, info.DeviceName, info.DevicePlatform)
+	if err != nil {
+		return "", err
+	}
+
+	return h.tokenGenerator.Generate(ctx, user, device.ID)
+}
@@ -2,8 +2,10 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 
@@ -16,21 +18,24 @@ import (
 )
 
 type userStoredDataService interface {
-	GetAllUserData(ctx context.Context, userID int) ([]domain.UserStoredData, error)
-	Add(ctx context.Context, userID int, dataType string, data interface{}, meta string) (*domain.UserStoredData, error)
+	GetAllUserData(ctx context.Context, userID int, collectionID int) ([]domain.UserStoredData, error)
+	Add(ctx context.Context, userID int, collectionID int, recordUUID string, dataType string, data interface{}, meta string) (*domain.UserStoredData, error)
+	ResolveDataRef(ctx context.Context, userID int, ref string) (int, error)
 	GetUserDataByID(ctx context.Context, userID int, id int) (*domain.UserStoredData, error)
-	GetUserData(ctx context.Context, userID int, dataType string, filters *domain.StorageFilters) (*domain.PaginatedResult, error)
-	UpdateUserData(ctx context.Context, userID int, dataID int, data interface{}, meta string) (*domain.UserStoredData, error)
-	DeleteBatch(ctx context.Context, userID int, ids []int) error
+	GetUserData(ctx context.Context, userID int, collectionID int, dataType string, filters *domain.StorageFilters) (*domain.PaginatedResult, error)
+	UpdateUserData(ctx context.Context, userID int, dataID int, expectedVersion int, data interface{}, meta string) (*domain.UserStoredData, error)
+	DeleteBatch(ctx context.Context, userID int, collectionID int, ids []int) ([]int, error)
 }
 
 type UserStoredDataHandler struct {
 	service userStoredDataService
+	audit   auditRecorder
 }
 
-func NewUserStoredDataHandler(service userStoredDataService) *UserStoredDataHandler {
+func NewUserStoredDataHandler(service userStoredDataService, audit auditRecorder) *UserStoredDataHandler {
 	return &UserStoredDataHandler{
 		service: service,
+		audit:   audit,
 	}
 }
 
@@ -39,6 +44,7 @@ func NewUserStoredDataHandler(service userStoredDataService) *UserStoredDataHand
 // @Produce json
 // @Tags data
 // @Security Bearer
+// @Param collection_id query int false "Organization collection ID, personal vault if omitted"
 // @Success 200 {array} domain.UserStoredData
 // @Failure 400 {object} httputils.HTTPError
 // @Failure 401
@@ -51,12 +57,20 @@ func (h *UserStoredDataHandler) GetUserAll(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	dataSet, err := h.service.GetAllUserData(r.Context(), userID)
+	collectionID, err := parseCollectionID(r)
 	if err != nil {
 		httperrors.Handle(w, err)
 		return
 	}
 
+	dataSet, err := h.service.GetAllUserData(r.Context(), userID, collectionID)
+	if err != nil {
+		httperrors.Handle(w, err)
+		return
+	}
+
+	h.audit.Record(r.Context(), newAuditEvent(r, domain.AuditEventVaultExport, userID, 0))
+
 	httputils.SendJSONResponse(w, http.StatusOK, dataSet)
 }
 
@@ -66,6 +80,7 @@ func (h *UserStoredDataHandler) GetUserAll(w http.ResponseWriter, r *http.Reques
 // @Tags data
 // @Security Bearer
 // @Param type path string true "Data Type"
+// @Param collection_id query int false "Organization collection ID, personal vault if omitted"
 // @Success 200 {object} domain.UserStoredData
 // @Failure 400 {object} httputils.HTTPError
 // @Failure 401
@@ -79,6 +94,12 @@ func (h *UserStoredDataHandler) GetOfType(w http.ResponseWriter, r *http.Request
 	}
 	dataType := chi.URLParam(r, "type")
 
+	collectionID, err := parseCollectionID(r)
+	if err != nil {
+		httperrors.Handle(w, err)
+		return
+	}
+
 	page, err := strconv.Atoi(r.URL.Query().Get("page"))
 	if err != nil || page <= 0 {
 		page = 1
@@ -88,7 +109,7 @@ func (h *UserStoredDataHandler) GetOfType(w http.ResponseWriter, r *http.Request
 		count = 50
 	}
 
-	paginatedResult, err := h.service.GetUserData(r.Context(), userID, dataType, &domain.StorageFilters{
+	paginatedResult, err := h.service.GetUserData(r.Context(), userID, collectionID, dataType, &domain.StorageFilters{
 		IsPaginated:    true,
 		IsSortedByDate: true,
 		Pagination: domain.PaginationFilters{
@@ -114,6 +135,7 @@ func (h *UserStoredDataHandler) GetOfType(w http.ResponseWriter, r *http.Request
 // @Tags data
 // @Security Bearer
 // @Param type path string true "Data Type"
+// @Param collection_id query int false "Organization collection ID, personal vault if omitted"
 // @Param dto body dtos.AddNewCardBody true "body"
 // @Success 200 {object} domain.UserStoredData
 // @Failure 400 {object} httputils.HTTPError
@@ -127,6 +149,12 @@ func (h *UserStoredDataHandler) Add(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	collectionID, err := parseCollectionID(r)
+	if err != nil {
+		httperrors.Handle(w, err)
+		return
+	}
+
 	dataType := chi.URLParam(r, "type")
 	dataBody, err := h.parseUserDataBody(w, r, dataType)
 	if err != nil {
@@ -139,26 +167,69 @@ func (h *UserStoredDataHandler) Add(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	data, err := h.service.Add(r.Context(), userID, dataType, dataBody.GetData(), dataBody.GetMeta())
+	data, err := h.service.Add(r.Context(), userID, collectionID, dataBody.GetUUID(), dataType, dataBody.GetData(), dataBody.GetMeta())
 	if err != nil {
 		httperrors.Handle(w, err)
 		return
 	}
 
+	h.audit.Record(r.Context(), newAuditEvent(r, domain.AuditEventDataCreate, userID, data.ID))
+
 	httputils.SendJSONResponse(w, http.StatusCreated, data)
 }
 
+// GetOne godoc
+// @Summary Get one record with given id, uuid or uuid prefix (own, shared or from organization collection)
+// @Produce json
+// @Tags data
+// @Security Bearer
+// @Param id path string true "UUID, UUID prefix or Data Record ID (id:<id>)"
+// @Success 200 {object} domain.UserStoredData
+// @Failure 401
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Router /api/v1/data/record/{id} [get]
+func (h *UserStoredDataHandler) GetOne(w http.ResponseWriter, r *http.Request) {
+	userID, err := usercontext.GetUserIDFromContext(r.Context())
+	if err != nil {
+		httperrors.Handle(w, domain.ErrNotAuth)
+		return
+	}
+
+	id, err := h.service.ResolveDataRef(r.Context(), userID, chi.URLParam(r, "id"))
+	if err != nil {
+		httperrors.Handle(w, err)
+		return
+	}
+
+	data, err := h.service.GetUserDataByID(r.Context(), userID, id)
+	if err != nil {
+		httperrors.Handle(w, err)
+		return
+	}
+
+	data.CryptedData = nil
+
+	w.Header().Set("ETag", formatETag(data.Version))
+	httputils.SendJSONResponse(w, http.StatusOK, data)
+}
+
 // UpdateOne godoc
-// @Summary Update one record with given id
+// @Summary Update one record with given id, uuid or uuid prefix
+// @Description Expected current version of record is required, it is passed in If-Match header (ETag from
+// @Description GetOne) or in body. If record was changed since, 409 is returned with current server copy
 // @Accept json
 // @Produce json
 // @Tags data
 // @Security Bearer
-// @Param id path string true "Data Record ID"
-// @Param dto body dtos.AddNewCardBody true "body"
+// @Param id path string true "UUID, UUID prefix or Data Record ID (id:<id>)"
+// @Param If-Match header string false "ETag of record version client has read, required if body has no version"
+// @Param dto body dtos.UpdateUserDataBody true "body"
 // @Success 200 {object} domain.UserStoredData
 // @Failure 400 {object} httputils.HTTPError
 // @Failure 401
+// @Failure 409 {object} dtos.DataVersionConflictResponse
+// @Failure 428 {object} httputils.HTTPError
 // @Failure 500 {object} httputils.HTTPError
 // @Router /api/v1/data/update/{id} [put]
 func (h *UserStoredDataHandler) UpdateOne(w http.ResponseWriter, r *http.Request) {
@@ -168,9 +239,9 @@ func (h *UserStoredDataHandler) UpdateOne(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	id, err := h.service.ResolveDataRef(r.Context(), userID, chi.URLParam(r, "id"))
 	if err != nil {
-		httperrors.Handle(w, domain.ErrUserStoredDataNotFound)
+		httperrors.Handle(w, err)
 		return
 	}
 
@@ -180,32 +251,65 @@ func (h *UserStoredDataHandler) UpdateOne(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	dataBody, err := h.parseUserDataBody(w, r, oldData.DataType)
+	var body dtos.UpdateUserDataBody
+	if statusCode, err := jsonutil.Unmarshal(w, r, &body); err != nil {
+		httputils.SendJSONErrorResponse(w, statusCode, err.Error(), statusCode)
+		return
+	}
+
+	dataBody, err := dtos.ParseUserDataBody(oldData.DataType, body.Data, body.Meta)
 	if err != nil {
 		httperrors.Handle(w, err)
 		return
 	}
 
-	if !dataBody.Valid() {
-		httperrors.Handle(w, domain.ErrInvalidBody)
+	version, err := expectedVersion(r, body.Version)
+	if err != nil {
+		httperrors.Handle(w, err)
 		return
 	}
 
-	updatedUserData, err := h.service.UpdateUserData(r.Context(), userID, id, dataBody.GetData(), dataBody.GetMeta())
+	updatedUserData, err := h.service.UpdateUserData(r.Context(), userID, id, version, dataBody.GetData(), dataBody.GetMeta())
+	if errors.Is(err, domain.ErrDataVersionConflict) {
+		h.sendVersionConflict(w, r, userID, id)
+		return
+	}
 	if err != nil {
 		httperrors.Handle(w, err)
 		return
 	}
 
+	h.audit.Record(r.Context(), newAuditEvent(r, domain.AuditEventDataUpdate, userID, id))
+
+	w.Header().Set("ETag", formatETag(updatedUserData.Version))
 	httputils.SendJSONResponse(w, http.StatusOK, updatedUserData)
 }
 
+// sendVersionConflict - respond with current server copy, so client can merge without one more request
+func (h *UserStoredDataHandler) sendVersionConflict(w http.ResponseWriter, r *http.Request, userID int, id int) {
+	current, err := h.service.GetUserDataByID(r.Context(), userID, id)
+	if err != nil {
+		httperrors.Handle(w, err)
+		return
+	}
+
+	current.CryptedData = nil
+
+	w.Header().Set("ETag", formatETag(current.Version))
+	httputils.SendJSONResponse(w, http.StatusConflict, dtos.DataVersionConflictResponse{
+		Code:    httperrors.Code(domain.ErrDataVersionConflict),
+		Error:   domain.ErrDataVersionConflict.Error(),
+		Current: current,
+	})
+}
+
 // DeleteBatch godoc
 // @Summary Save user data
 // @Accept json
 // @Produce json
 // @Tags data
 // @Security Bearer
+// @Param collection_id query int false "Organization collection ID, personal vault if omitted"
 // @Param dto body dtos.DeleteBatchBody true "body"
 // @Success 204
 // @Failure 400 {object} httputils.HTTPError
@@ -219,6 +323,12 @@ func (h *UserStoredDataHandler) DeleteBatch(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	collectionID, err := parseCollectionID(r)
+	if err != nil {
+		httperrors.Handle(w, err)
+		return
+	}
+
 	var body dtos.DeleteBatchBody
 	if statusCode, err := jsonutil.Unmarshal(w, r, &body); err != nil {
 		httputils.SendJSONErrorResponse(w, statusCode, err.Error(), statusCode)
@@ -230,46 +340,80 @@ func (h *UserStoredDataHandler) DeleteBatch(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	err = h.service.DeleteBatch(r.Context(), userID, body.IDs)
+	deleted, err := h.service.DeleteBatch(r.Context(), userID, collectionID, body.IDs)
 	if err != nil {
 		httperrors.Handle(w, err)
 		return
 	}
 
+	for _, id := range deleted {
+		h.audit.Record(r.Context(), newAuditEvent(r, domain.AuditEventDataDelete, userID, id))
+	}
+
 	httputils.SendStatusCode(w, http.StatusNoContent)
 }
 
 func (h *UserStoredDataHandler) parseUserDataBody(w http.ResponseWriter, r *http.Request, dataType string) (domain.AddUserStoredDataBody, error) {
-	switch dataType {
-	case domain.LogPassDataType:
-		var body dtos.AddNewLogPassBody
-		if _, err := jsonutil.Unmarshal(w, r, &body); err != nil {
-			return nil, err
-		}
+	body, err := dtos.NewUserDataBody(dataType)
+	if err != nil {
+		return nil, err
+	}
 
-		return &body, nil
-	case domain.CardDataType:
-		var body dtos.AddNewCardBody
-		if _, err := jsonutil.Unmarshal(w, r, &body); err != nil {
-			return nil, err
-		}
+	if _, err := jsonutil.Unmarshal(w, r, body); err != nil {
+		return nil, err
+	}
 
-		return &body, nil
-	case domain.TextDataType:
-		var body dtos.AddNewTextBody
-		if _, err := jsonutil.Unmarshal(w, r, &body); err != nil {
-			return nil, err
-		}
+	return body, nil
+}
+
+// expectedVersion - version of record client has read, taken from If-Match header or body. It is required,
+// so record is never overwritten by client, which has not seen its current version
+func expectedVersion(r *http.Request, bodyVersion int) (int, error) {
+	if bodyVersion < 0 {
+		return 0, domain.ErrInvalidDataVersion
+	}
 
-		return &body, nil
-	case domain.FileDataType:
-		var body dtos.AddNewFileBody
-		if _, err := jsonutil.Unmarshal(w, r, &body); err != nil {
-			return nil, err
+	ifMatch := strings.TrimSpace(r.Header.Get("If-Match"))
+	if ifMatch == "" || ifMatch == "*" {
+		if bodyVersion == 0 {
+			return 0, domain.ErrDataVersionRequired
 		}
 
-		return &body, nil
-	default:
-		return nil, domain.ErrInvalidDataType
+		return bodyVersion, nil
+	}
+
+	ifMatch = strings.TrimPrefix(ifMatch, "W/")
+	if len(ifMatch) < 2 || ifMatch[0] != '"' || ifMatch[len(ifMatch)-1] != '"' {
+		return 0, domain.ErrInvalidDataVersion
+	}
+
+	version, err := strconv.Atoi(ifMatch[1 : len(ifMatch)-1])
+	if err != nil || version <= 0 {
+		return 0, domain.ErrInvalidDataVersion
+	}
+
+	if bodyVersion != 0 && bodyVersion != version {
+		return 0, domain.ErrInvalidDataVersion
+	}
+
+	return version, nil
+}
+
+func formatETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// parseCollectionID - returns collection id from query, 0 means personal vault
+func parseCollectionID(r *http.Request) (int, error) {
+	rawCollectionID := r.URL.Query().Get("collection_id")
+	if rawCollectionID == "" {
+		return 0, nil
 	}
+
+	collectionID, err := strconv.Atoi(rawCollectionID)
+	if err != nil || collectionID <= 0 {
+		return 0, domain.ErrCollectionNotFound
+	}
+
+	return collectionID, nil
 }
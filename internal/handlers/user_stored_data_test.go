@@ -22,6 +22,7 @@ type userStoredDataTestSuite struct {
 	suite.Suite
 
 	service *mock_handlers.MockuserStoredDataService
+	audit   *mock_handlers.MockauditRecorder
 
 	handler *UserStoredDataHandler
 }
@@ -36,8 +37,10 @@ func (suite *userStoredDataTestSuite) SetupTest() {
 	ctrl := gomock.NewController(suite.T())
 
 	suite.service = mock_handlers.NewMockuserStoredDataService(ctrl)
+	suite.audit = mock_handlers.NewMockauditRecorder(ctrl)
+	suite.audit.EXPECT().Record(gomock.Any(), gomock.Any()).AnyTimes()
 
-	suite.handler = NewUserStoredDataHandler(suite.service)
+	suite.handler = NewUserStoredDataHandler(suite.service, suite.audit)
 }
 
 func (suite *userStoredDataTestSuite) TearDownTest() {
@@ -61,7 +64,7 @@ func (suite *userStoredDataTestSuite) TestGetUserAll() {
 
 				suite.service.
 					EXPECT().
-					GetAllUserData(gomock.Any(), userID).
+					GetAllUserData(gomock.Any(), userID, 0).
 					Return([]domain.UserStoredData{}, nil)
 
 				return userID
@@ -75,7 +78,7 @@ func (suite *userStoredDataTestSuite) TestGetUserAll() {
 
 				suite.service.
 					EXPECT().
-					GetAllUserData(gomock.Any(), userID).
+					GetAllUserData(gomock.Any(), userID, 0).
 					Return(nil, domain.ErrInternal)
 
 				return userID
@@ -114,7 +117,7 @@ func (suite *userStoredDataTestSuite) TestGetOfType() {
 
 				suite.service.
 					EXPECT().
-					GetUserData(gomock.Any(), userID, dataType, &domain.StorageFilters{
+					GetUserData(gomock.Any(), userID, 0, dataType, &domain.StorageFilters{
 						IsPaginated:    true,
 						IsSortedByDate: true,
 						Pagination: domain.PaginationFilters{
@@ -139,7 +142,7 @@ func (suite *userStoredDataTestSuite) TestGetOfType() {
 
 				suite.service.
 					EXPECT().
-					GetUserData(gomock.Any(), userID, dataType, &domain.StorageFilters{
+					GetUserData(gomock.Any(), userID, 0, dataType, &domain.StorageFilters{
 						IsPaginated:    true,
 						IsSortedByDate: true,
 						Pagination: domain.PaginationFilters{
@@ -164,7 +167,7 @@ func (suite *userStoredDataTestSuite) TestGetOfType() {
 
 				suite.service.
 					EXPECT().
-					GetUserData(gomock.Any(), userID, dataType, &domain.StorageFilters{
+					GetUserData(gomock.Any(), userID, 0, dataType, &domain.StorageFilters{
 						IsPaginated:    true,
 						IsSortedByDate: true,
 						Pagination: domain.PaginationFilters{
@@ -223,12 +226,58 @@ func (suite *userStoredDataTestSuite) TestAdd() {
 
 				suite.service.
 					EXPECT().
-					Add(gomock.Any(), userID, dataType, body.Data, body.Meta).
+					Add(gomock.Any(), userID, 0, "", dataType, body.Data, body.Meta).
 					Return(&domain.UserStoredData{}, nil)
 
 				return userID, b, dataType
 			},
 		},
+		{
+			name:       "valid with client uuid",
+			statusCode: http.StatusCreated,
+			prepare: func() (int, []byte, string) {
+				userID := 1
+				body := dtos.AddNewTextBody{
+					RecordUUID: dtos.RecordUUID{UUID: "5f0c7a1e-3b2d-4c8f-9a6e-1d2b3c4d5e6f"},
+					Data: domain.TextData{
+						Text: "text",
+					},
+					Meta: "meta",
+				}
+				b, _ := json.Marshal(body)
+				dataType := domain.TextDataType
+
+				suite.service.
+					EXPECT().
+					Add(gomock.Any(), userID, 0, body.UUID, dataType, body.Data, body.Meta).
+					Return(&domain.UserStoredData{}, nil)
+
+				return userID, b, dataType
+			},
+		},
+		{
+			name:       "uuid already taken",
+			statusCode: http.StatusConflict,
+			prepare: func() (int, []byte, string) {
+				userID := 1
+				body := dtos.AddNewTextBody{
+					RecordUUID: dtos.RecordUUID{UUID: "5f0c7a1e-3b2d-4c8f-9a6e-1d2b3c4d5e6f"},
+					Data: domain.TextData{
+						Text: "text",
+					},
+					Meta: "meta",
+				}
+				b, _ := json.Marshal(body)
+				dataType := domain.TextDataType
+
+				suite.service.
+					EXPECT().
+					Add(gomock.Any(), userID, 0, body.UUID, dataType, body.Data, body.Meta).
+					Return(nil, domain.ErrRecordUUIDTaken)
+
+				return userID, b, dataType
+			},
+		},
 		{
 			name:       "invalid body",
 			statusCode: http.StatusBadRequest,
@@ -279,7 +328,7 @@ func (suite *userStoredDataTestSuite) TestAdd() {
 
 				suite.service.
 					EXPECT().
-					Add(gomock.Any(), userID, dataType, body.Data, body.Meta).
+					Add(gomock.Any(), userID, 0, "", dataType, body.Data, body.Meta).
 					Return(nil, domain.ErrInternal)
 
 				return userID, b, dataType
@@ -311,11 +360,13 @@ func (suite *userStoredDataTestSuite) TestUpdateOne() {
 	testCases := []struct {
 		name       string
 		statusCode int
+		ifMatch    string
 		prepare    func() (int, []byte, string)
 	}{
 		{
 			name:       "valid",
 			statusCode: http.StatusOK,
+			ifMatch:    `"2"`,
 			prepare: func() (int, []byte, string) {
 				id := "1"
 				userID := 1
@@ -327,6 +378,11 @@ func (suite *userStoredDataTestSuite) TestUpdateOne() {
 				}
 				b, _ := json.Marshal(body)
 
+				suite.service.
+					EXPECT().
+					ResolveDataRef(gomock.Any(), userID, "1").
+					Return(1, nil)
+
 				suite.service.
 					EXPECT().
 					GetUserDataByID(gomock.Any(), userID, 1).
@@ -334,15 +390,42 @@ func (suite *userStoredDataTestSuite) TestUpdateOne() {
 
 				suite.service.
 					EXPECT().
-					UpdateUserData(gomock.Any(), userID, 1, body.Data, body.Meta).
+					UpdateUserData(gomock.Any(), userID, 1, 2, body.Data, body.Meta).
 					Return(&domain.UserStoredData{}, nil)
 
 				return userID, b, id
 			},
 		},
+		{
+			name:       "version is required",
+			statusCode: http.StatusPreconditionRequired,
+			prepare: func() (int, []byte, string) {
+				id := "1"
+				userID := 1
+				body := dtos.AddNewTextBody{
+					Data: domain.TextData{
+						Text: "text",
+					},
+					Meta: "meta",
+				}
+				b, _ := json.Marshal(body)
+
+				suite.service.
+					EXPECT().
+					ResolveDataRef(gomock.Any(), userID, "1").
+					Return(1, nil)
+
+				suite.service.
+					EXPECT().
+					GetUserDataByID(gomock.Any(), userID, 1).
+					Return(&domain.UserStoredData{DataType: domain.TextDataType}, nil)
+
+				return userID, b, id
+			},
+		},
 		{
 			name:       "invalid id",
-			statusCode: http.StatusNotFound,
+			statusCode: http.StatusBadRequest,
 			prepare: func() (int, []byte, string) {
 				id := "test"
 				userID := 1
@@ -354,6 +437,11 @@ func (suite *userStoredDataTestSuite) TestUpdateOne() {
 				}
 				b, _ := json.Marshal(body)
 
+				suite.service.
+					EXPECT().
+					ResolveDataRef(gomock.Any(), userID, id).
+					Return(0, domain.ErrInvalidRecordRef)
+
 				return userID, b, id
 			},
 		},
@@ -371,6 +459,11 @@ func (suite *userStoredDataTestSuite) TestUpdateOne() {
 				}
 				b, _ := json.Marshal(body)
 
+				suite.service.
+					EXPECT().
+					ResolveDataRef(gomock.Any(), userID, "1").
+					Return(1, nil)
+
 				suite.service.
 					EXPECT().
 					GetUserDataByID(gomock.Any(), userID, 1).
@@ -393,6 +486,11 @@ func (suite *userStoredDataTestSuite) TestUpdateOne() {
 				}
 				b, _ := json.Marshal(body)
 
+				suite.service.
+					EXPECT().
+					ResolveDataRef(gomock.Any(), userID, "1").
+					Return(1, nil)
+
 				suite.service.
 					EXPECT().
 					GetUserDataByID(gomock.Any(), userID, 1).
@@ -401,9 +499,105 @@ func (suite *userStoredDataTestSuite) TestUpdateOne() {
 				return userID, b, id
 			},
 		},
+		{
+			name:       "version from if-match",
+			statusCode: http.StatusOK,
+			ifMatch:    `"3"`,
+			prepare: func() (int, []byte, string) {
+				userID := 1
+				b := []byte(`{"data":{"text":"text"},"meta":"meta"}`)
+
+				suite.service.
+					EXPECT().
+					ResolveDataRef(gomock.Any(), userID, "1").
+					Return(1, nil)
+
+				suite.service.
+					EXPECT().
+					GetUserDataByID(gomock.Any(), userID, 1).
+					Return(&domain.UserStoredData{DataType: domain.TextDataType}, nil)
+
+				suite.service.
+					EXPECT().
+					UpdateUserData(gomock.Any(), userID, 1, 3, domain.TextData{Text: "text"}, "meta").
+					Return(&domain.UserStoredData{Version: 4}, nil)
+
+				return userID, b, "1"
+			},
+		},
+		{
+			name:       "stale version",
+			statusCode: http.StatusConflict,
+			prepare: func() (int, []byte, string) {
+				userID := 1
+				b := []byte(`{"data":{"text":"text"},"meta":"meta","version":2}`)
+
+				gomock.InOrder(
+					suite.service.
+						EXPECT().
+						ResolveDataRef(gomock.Any(), userID, "1").
+						Return(1, nil),
+					suite.service.
+						EXPECT().
+						GetUserDataByID(gomock.Any(), userID, 1).
+						Return(&domain.UserStoredData{DataType: domain.TextDataType, Version: 3}, nil),
+					suite.service.
+						EXPECT().
+						UpdateUserData(gomock.Any(), userID, 1, 2, domain.TextData{Text: "text"}, "meta").
+						Return(nil, domain.ErrDataVersionConflict),
+					suite.service.
+						EXPECT().
+						GetUserDataByID(gomock.Any(), userID, 1).
+						Return(&domain.UserStoredData{DataType: domain.TextDataType, Version: 3}, nil),
+				)
+
+				return userID, b, "1"
+			},
+		},
+		{
+			name:       "malformed if-match",
+			statusCode: http.StatusBadRequest,
+			ifMatch:    "3",
+			prepare: func() (int, []byte, string) {
+				userID := 1
+
+				suite.service.
+					EXPECT().
+					ResolveDataRef(gomock.Any(), userID, "1").
+					Return(1, nil)
+
+				suite.service.
+					EXPECT().
+					GetUserDataByID(gomock.Any(), userID, 1).
+					Return(&domain.UserStoredData{DataType: domain.TextDataType}, nil)
+
+				return userID, []byte(`{"data":{"text":"text"},"meta":"meta"}`), "1"
+			},
+		},
+		{
+			name:       "if-match differs from body version",
+			statusCode: http.StatusBadRequest,
+			ifMatch:    `W/"3"`,
+			prepare: func() (int, []byte, string) {
+				userID := 1
+
+				suite.service.
+					EXPECT().
+					ResolveDataRef(gomock.Any(), userID, "1").
+					Return(1, nil)
+
+				suite.service.
+					EXPECT().
+					GetUserDataByID(gomock.Any(), userID, 1).
+					Return(&domain.UserStoredData{DataType: domain.TextDataType}, nil)
+
+				return userID, []byte(`{"data":{"text":"text"},"meta":"meta","version":2}`), "1"
+			},
+		},
 		{
 			name:       "internal error",
 			statusCode: http.StatusInternalServerError,
+			ifMatch:    `"2"`,
 			prepare: func() (int, []byte, string) {
 				id := "1"
 				userID := 1
@@ -415,6 +609,11 @@ func (suite *userStoredDataTestSuite) TestUpdateOne() {
 				}
 				b, _ := json.Marshal(body)
 
+				suite.service.
+					EXPECT().
+					ResolveDataRef(gomock.Any(), userID, "1").
+					Return(1, nil)
+
 				suite.service.
 					EXPECT().
 					GetUserDataByID(gomock.Any(), userID, 1).
@@ -422,7 +621,7 @@ func (suite *userStoredDataTestSuite) TestUpdateOne() {
 
 				suite.service.
 					EXPECT().
-					UpdateUserData(gomock.Any(), userID, 1, body.Data, body.Meta).
+					UpdateUserData(gomock.Any(), userID, 1, 2, body.Data, body.Meta).
 					Return(nil, domain.ErrInternal)
 
 				return userID, b, id
@@ -435,6 +634,9 @@ func (suite *userStoredDataTestSuite) TestUpdateOne() {
 			userID, body, id := testCase.prepare()
 			r := httptest.NewRequest(http.MethodPut, "/api/v1/data/update"+id, bytes.NewReader(body))
 			r.Header.Set("Content-Type", "application/json")
+			if testCase.ifMatch != "" {
+				r.Header.Set("If-Match", testCase.ifMatch)
+			}
 			r = r.WithContext(usercontext.SetUserIDToContext(r.Context(), userID))
 			rctx := chi.NewRouteContext()
 			rctx.URLParams.Add("id", id)
@@ -446,6 +648,12 @@ func (suite *userStoredDataTestSuite) TestUpdateOne() {
 			defer res.Body.Close()
 
 			suite.Equal(testCase.statusCode, res.StatusCode)
+			if res.StatusCode == http.StatusConflict {
+				var conflict dtos.DataVersionConflictResponse
+				suite.NoError(json.NewDecoder(res.Body).Decode(&conflict))
+				suite.Equal(3, conflict.Current.Version)
+				suite.Equal(`"3"`, res.Header.Get("ETag"))
+			}
 		})
 	}
 }
@@ -468,8 +676,8 @@ func (suite *userStoredDataTestSuite) TestDeleteBatch() {
 
 				suite.service.
 					EXPECT().
-					DeleteBatch(gomock.Any(), userID, body.IDs).
-					Return(nil)
+					DeleteBatch(gomock.Any(), userID, 0, body.IDs).
+					Return([]int{1, 2}, nil)
 
 				return userID, b
 			},
@@ -499,8 +707,8 @@ func (suite *userStoredDataTestSuite) TestDeleteBatch() {
 
 				suite.service.
 					EXPECT().
-					DeleteBatch(gomock.Any(), userID, body.IDs).
-					Return(domain.ErrInternal)
+					DeleteBatch(gomock.Any(), userID, 0, body.IDs).
+					Return(nil, domain.ErrInternal)
 
 				return userID, b
 			},
@@ -523,3 +731,92 @@ func (suite *userStoredDataTestSuite) TestDeleteBatch() {
 		})
 	}
 }
+
+func (suite *userStoredDataTestSuite) TestGetOne() {
+	testCases := []struct {
+		name       string
+		statusCode int
+		prepare    func() (int, string)
+	}{
+		{
+			name:       "valid",
+			statusCode: http.StatusOK,
+			prepare: func() (int, string) {
+				userID := 1
+
+				suite.service.
+					EXPECT().
+					ResolveDataRef(gomock.Any(), userID, "1").
+					Return(1, nil)
+
+				suite.service.
+					EXPECT().
+					GetUserDataByID(gomock.Any(), userID, 1).
+					Return(&domain.UserStoredData{ID: 1, DataType: domain.TextDataType, Data: domain.TextData{Text: "text"}}, nil)
+
+				return userID, "1"
+			},
+		},
+		{
+			name:       "invalid id",
+			statusCode: http.StatusBadRequest,
+			prepare: func() (int, string) {
+				suite.service.
+					EXPECT().
+					ResolveDataRef(gomock.Any(), 1, "abc").
+					Return(0, domain.ErrInvalidRecordRef)
+
+				return 1, "abc"
+			},
+		},
+		{
+			name:       "ambiguous uuid prefix",
+			statusCode: http.StatusBadRequest,
+			prepare: func() (int, string) {
+				suite.service.
+					EXPECT().
+					ResolveDataRef(gomock.Any(), 1, "5f0c").
+					Return(0, domain.ErrAmbiguousRecordRef)
+
+				return 1, "5f0c"
+			},
+		},
+		{
+			name:       "not enough permissions",
+			statusCode: http.StatusNotFound,
+			prepare: func() (int, string) {
+				userID := 1
+
+				suite.service.
+					EXPECT().
+					ResolveDataRef(gomock.Any(), userID, "1").
+					Return(1, nil)
+
+				suite.service.
+					EXPECT().
+					GetUserDataByID(gomock.Any(), userID, 1).
+					Return(nil, domain.ErrCollectionNotFound)
+
+				return userID, "1"
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.name, func() {
+			userID, id := testCase.prepare()
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/data/record/"+id, nil)
+			r = r.WithContext(usercontext.SetUserIDToContext(r.Context(), userID))
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", id)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			suite.handler.GetOne(w, r)
+			res := w.Result()
+			defer res.Body.Close()
+
+			suite.Equal(testCase.statusCode, res.StatusCode)
+		})
+	}
+}
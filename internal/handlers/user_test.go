@@ -13,6 +13,8 @@ import (
 	"github.com/MowlCoder/goph-keeper/internal/domain"
 	"github.com/MowlCoder/goph-keeper/internal/dtos"
 	mock_handlers "github.com/MowlCoder/goph-keeper/internal/handlers/mocks"
+	"github.com/MowlCoder/goph-keeper/internal/metrics"
+	"github.com/MowlCoder/goph-keeper/internal/utils/usercontext"
 )
 
 type userTestSuite struct {
@@ -20,6 +22,9 @@ type userTestSuite struct {
 
 	service        *mock_handlers.MockuserService
 	tokenGenerator *mock_handlers.MocktokenGenerator
+	devices        *mock_handlers.MockdeviceRegistrar
+	audit          *mock_handlers.MockauditRecorder
+	authFailures   *mock_handlers.MockauthFailureCounter
 
 	handler *UserHandler
 }
@@ -35,8 +40,11 @@ func (suite *userTestSuite) SetupTest() {
 
 	suite.service = mock_handlers.NewMockuserService(ctrl)
 	suite.tokenGenerator = mock_handlers.NewMocktokenGenerator(ctrl)
+	suite.devices = mock_handlers.NewMockdeviceRegistrar(ctrl)
+	suite.audit = mock_handlers.NewMockauditRecorder(ctrl)
+	suite.authFailures = mock_handlers.NewMockauthFailureCounter(ctrl)
 
-	suite.handler = NewUserHandler(suite.service, suite.tokenGenerator)
+	suite.handler = NewUserHandler(suite.service, suite.tokenGenerator, suite.devices, suite.audit, suite.authFailures)
 }
 
 func (suite *userTestSuite) TearDownTest() {
@@ -59,6 +67,11 @@ func (suite *userTestSuite) TestAuthorize() {
 				body := dtos.AuthorizeBody{
 					Email:    "test@gmail.com",
 					Password: "test123",
+					DeviceInfo: dtos.DeviceInfo{
+						DeviceID:       5,
+						DeviceName:     "laptop",
+						DevicePlatform: "linux/amd64",
+					},
 				}
 				b, _ := json.Marshal(body)
 
@@ -67,11 +80,24 @@ func (suite *userTestSuite) TestAuthorize() {
 					Authorize(gomock.Any(), body.Email, body.Password).
 					Return(&domain.User{ID: 1}, nil)
 
+				suite.devices.
+					EXPECT().
+					Register(gomock.Any(), 1, body.DeviceID, body.DeviceName, body.DevicePlatform).
+					Return(&domain.Device{ID: 5, UserID: 1}, nil)
+
 				suite.tokenGenerator.
 					EXPECT().
-					Generate(gomock.Any(), domain.User{ID: 1}).
+					Generate(gomock.Any(), domain.User{ID: 1}, 5).
 					Return("token", nil)
 
+				suite.audit.
+					EXPECT().
+					Record(gomock.Any(), domain.AuditEvent{
+						UserID:   1,
+						Type:     domain.AuditEventLogin,
+						ClientIP: "192.0.2.1",
+					})
+
 				return b
 			},
 		},
@@ -103,6 +129,48 @@ func (suite *userTestSuite) TestAuthorize() {
 					Authorize(gomock.Any(), body.Email, body.Password).
 					Return(nil, domain.ErrUserNotFound)
 
+				suite.audit.
+					EXPECT().
+					Record(gomock.Any(), domain.AuditEvent{
+						Type:     domain.AuditEventLoginFailed,
+						ClientIP: "192.0.2.1",
+						Email:    body.Email,
+					})
+
+				suite.authFailures.
+					EXPECT().
+					IncAuthFailure(metrics.AuthFailureWrongCredentials)
+
+				return b
+			},
+		},
+		{
+			name:       "wrong password",
+			statusCode: http.StatusBadRequest,
+			prepare: func() []byte {
+				body := dtos.AuthorizeBody{
+					Email:    "test@gmail.com",
+					Password: "test123",
+				}
+				b, _ := json.Marshal(body)
+
+				suite.service.
+					EXPECT().
+					Authorize(gomock.Any(), body.Email, body.Password).
+					Return(nil, domain.ErrWrongCredentials)
+
+				suite.audit.
+					EXPECT().
+					Record(gomock.Any(), domain.AuditEvent{
+						Type:     domain.AuditEventLoginFailed,
+						ClientIP: "192.0.2.1",
+						Email:    body.Email,
+					})
+
+				suite.authFailures.
+					EXPECT().
+					IncAuthFailure(metrics.AuthFailureWrongCredentials)
+
 				return b
 			},
 		},
@@ -121,9 +189,14 @@ func (suite *userTestSuite) TestAuthorize() {
 					Authorize(gomock.Any(), body.Email, body.Password).
 					Return(&domain.User{ID: 1}, nil)
 
+				suite.devices.
+					EXPECT().
+					Register(gomock.Any(), 1, body.DeviceID, body.DeviceName, body.DevicePlatform).
+					Return(&domain.Device{ID: 5, UserID: 1}, nil)
+
 				suite.tokenGenerator.
 					EXPECT().
-					Generate(gomock.Any(), domain.User{ID: 1}).
+					Generate(gomock.Any(), domain.User{ID: 1}, 5).
 					Return("", domain.ErrInternal)
 
 				return b
@@ -169,11 +242,24 @@ func (suite *userTestSuite) TestRegister() {
 					Create(gomock.Any(), body.Email, body.Password).
 					Return(&domain.User{ID: 1}, nil)
 
+				suite.devices.
+					EXPECT().
+					Register(gomock.Any(), 1, body.DeviceID, body.DeviceName, body.DevicePlatform).
+					Return(&domain.Device{ID: 5, UserID: 1}, nil)
+
 				suite.tokenGenerator.
 					EXPECT().
-					Generate(gomock.Any(), domain.User{ID: 1}).
+					Generate(gomock.Any(), domain.User{ID: 1}, 5).
 					Return("token", nil)
 
+				suite.audit.
+					EXPECT().
+					Record(gomock.Any(), domain.AuditEvent{
+						UserID:   1,
+						Type:     domain.AuditEventRegister,
+						ClientIP: "192.0.2.1",
+					})
+
 				return b
 			},
 		},
@@ -223,14 +309,42 @@ func (suite *userTestSuite) TestRegister() {
 					Create(gomock.Any(), body.Email, body.Password).
 					Return(&domain.User{ID: 1}, nil)
 
+				suite.devices.
+					EXPECT().
+					Register(gomock.Any(), 1, body.DeviceID, body.DeviceName, body.DevicePlatform).
+					Return(&domain.Device{ID: 5, UserID: 1}, nil)
+
 				suite.tokenGenerator.
 					EXPECT().
-					Generate(gomock.Any(), domain.User{ID: 1}).
+					Generate(gomock.Any(), domain.User{ID: 1}, 5).
 					Return("", domain.ErrInternal)
 
 				return b
 			},
 		},
+		{
+			name:       "error when register device",
+			statusCode: http.StatusInternalServerError,
+			prepare: func() []byte {
+				body := dtos.RegisterBody{
+					Email:    "test@gmail.com",
+					Password: "Test123!",
+				}
+				b, _ := json.Marshal(body)
+
+				suite.service.
+					EXPECT().
+					Create(gomock.Any(), body.Email, body.Password).
+					Return(&domain.User{ID: 1}, nil)
+
+				suite.devices.
+					EXPECT().
+					Register(gomock.Any(), 1, 0, "", "").
+					Return(nil, domain.ErrInternal)
+
+				return b
+			},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -249,3 +363,68 @@ func (suite *userTestSuite) TestRegister() {
 		})
 	}
 }
+
+func (suite *userTestSuite) TestRefresh() {
+	testCases := []struct {
+		name       string
+		statusCode int
+		deviceID   int
+		prepare    func()
+	}{
+		{
+			name:       "valid",
+			statusCode: http.StatusOK,
+			deviceID:   5,
+			prepare: func() {
+				suite.tokenGenerator.
+					EXPECT().
+					Generate(gomock.Any(), domain.User{ID: 1}, 5).
+					Return("token", nil)
+
+				suite.audit.
+					EXPECT().
+					Record(gomock.Any(), domain.AuditEvent{
+						UserID:   1,
+						Type:     domain.AuditEventTokenRefresh,
+						ClientIP: "192.0.2.1",
+					})
+			},
+		},
+		{
+			name:       "token without device",
+			statusCode: http.StatusUnauthorized,
+			prepare:    func() {},
+		},
+		{
+			name:       "error when generate token",
+			statusCode: http.StatusInternalServerError,
+			deviceID:   5,
+			prepare: func() {
+				suite.tokenGenerator.
+					EXPECT().
+					Generate(gomock.Any(), domain.User{ID: 1}, 5).
+					Return("", domain.ErrInternal)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.name, func() {
+			testCase.prepare()
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/user/refresh", nil)
+			ctx := usercontext.SetUserIDToContext(r.Context(), 1)
+			if testCase.deviceID != 0 {
+				ctx = usercontext.SetDeviceIDToContext(ctx, testCase.deviceID)
+			}
+			r = r.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			suite.handler.Refresh(w, r)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			suite.Equal(testCase.statusCode, res.StatusCode)
+		})
+	}
+}
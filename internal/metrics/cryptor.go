@@ -0,0 +1,42 @@
+package metrics
+
+import "time"
+
+type bytesCryptor interface {
+	EncryptBytes(raw []byte) ([]byte, error)
+	DecryptBytes(crypted []byte) ([]byte, error)
+}
+
+// InstrumentedCryptor - cryptor decorator which measures encryption and decryption timings
+type InstrumentedCryptor struct {
+	cryptor bytesCryptor
+	metrics *Metrics
+}
+
+// NewInstrumentedCryptor - constructor for InstrumentedCryptor struct
+func NewInstrumentedCryptor(cryptor bytesCryptor, metrics *Metrics) *InstrumentedCryptor {
+	return &InstrumentedCryptor{
+		cryptor: cryptor,
+		metrics: metrics,
+	}
+}
+
+// EncryptBytes - encrypt given byte array and return encrypted byte array
+func (c *InstrumentedCryptor) EncryptBytes(raw []byte) ([]byte, error) {
+	start := time.Now()
+	defer func() {
+		c.metrics.ObserveCrypto(CryptoEncrypt, time.Since(start))
+	}()
+
+	return c.cryptor.EncryptBytes(raw)
+}
+
+// DecryptBytes - decrypt given byte array and return decrypted byte array
+func (c *InstrumentedCryptor) DecryptBytes(crypted []byte) ([]byte, error) {
+	start := time.Now()
+	defer func() {
+		c.metrics.ObserveCrypto(CryptoDecrypt, time.Since(start))
+	}()
+
+	return c.cryptor.DecryptBytes(crypted)
+}
@@ -0,0 +1,110 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "gophkeeper"
+
+// Auth failure reasons
+const (
+	AuthFailureMissingToken     = "missing_token"
+	AuthFailureInvalidToken     = "invalid_token"
+	AuthFailureWrongCredentials = "wrong_credentials"
+	AuthFailureRevokedDevice    = "revoked_device"
+)
+
+// Crypto operations
+const (
+	CryptoEncrypt = "encrypt"
+	CryptoDecrypt = "decrypt"
+)
+
+// Metrics - struct responsible for collecting server metrics. Labels are limited to route patterns,
+// methods, statuses and other fixed sets of values, user identifying data must never be used as label.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests   *prometheus.CounterVec
+	httpDuration   *prometheus.HistogramVec
+	authFailures   *prometheus.CounterVec
+	cryptoDuration *prometheus.HistogramVec
+}
+
+// New - constructor for Metrics struct, Go runtime and process metrics are registered too
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "Count of handled HTTP requests.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Latency of handled HTTP requests.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+		authFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "auth_failures_total",
+			Help:      "Count of rejected logins and requests with missing or invalid token.",
+		}, []string{"reason"}),
+		cryptoDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "crypto_duration_seconds",
+			Help:      "Duration of user data encryption and decryption.",
+			Buckets:   []float64{.00001, .00005, .0001, .0005, .001, .005, .01, .05},
+		}, []string{"operation"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpDuration,
+		m.authFailures,
+		m.cryptoDuration,
+	)
+
+	return m
+}
+
+// Register - register additional collectors
+func (m *Metrics) Register(cs ...prometheus.Collector) error {
+	for _, c := range cs {
+		if err := m.registry.Register(c); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Handler - http handler exposing metrics in prometheus format
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// ObserveHTTPRequest - count request and its latency, route must be pattern (e.g. /api/v1/data/{type}), not path
+func (m *Metrics) ObserveHTTPRequest(method string, route string, statusCode int, duration time.Duration) {
+	m.httpRequests.WithLabelValues(method, route, strconv.Itoa(statusCode)).Inc()
+	m.httpDuration.WithLabelValues(method, route).Observe(duration.Seconds())
+}
+
+// IncAuthFailure - count failed authentication with given reason
+func (m *Metrics) IncAuthFailure(reason string) {
+	m.authFailures.WithLabelValues(reason).Inc()
+}
+
+// ObserveCrypto - save duration of encryption or decryption
+func (m *Metrics) ObserveCrypto(operation string, duration time.Duration) {
+	m.cryptoDuration.WithLabelValues(operation).Observe(duration.Seconds())
+}
@@ -0,0 +1,173 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dto "github.com/prometheus/client_model/go"
+)
+
+func findFamily(t *testing.T, m *Metrics, name string) *dto.MetricFamily {
+	t.Helper()
+
+	families, err := m.registry.Gather()
+	require.NoError(t, err)
+
+	for _, family := range families {
+		if family.GetName() == name {
+			return family
+		}
+	}
+
+	return nil
+}
+
+func labels(metric *dto.Metric) map[string]string {
+	result := make(map[string]string)
+	for _, pair := range metric.GetLabel() {
+		result[pair.GetName()] = pair.GetValue()
+	}
+
+	return result
+}
+
+func TestMetrics_ObserveHTTPRequest(t *testing.T) {
+	m := New()
+
+	m.ObserveHTTPRequest(http.MethodGet, "/api/v1/data/record/{id}", http.StatusOK, 10*time.Millisecond)
+	m.ObserveHTTPRequest(http.MethodGet, "/api/v1/data/record/{id}", http.StatusOK, 20*time.Millisecond)
+
+	family := findFamily(t, m, "gophkeeper_http_requests_total")
+	require.NotNil(t, family)
+	require.Len(t, family.GetMetric(), 1)
+	assert.Equal(t, 2.0, family.GetMetric()[0].GetCounter().GetValue())
+	assert.Equal(t, map[string]string{
+		"method": http.MethodGet,
+		"route":  "/api/v1/data/record/{id}",
+		"status": "200",
+	}, labels(family.GetMetric()[0]))
+
+	family = findFamily(t, m, "gophkeeper_http_request_duration_seconds")
+	require.NotNil(t, family)
+	assert.Equal(t, uint64(2), family.GetMetric()[0].GetHistogram().GetSampleCount())
+}
+
+func TestMetrics_IncAuthFailure(t *testing.T) {
+	m := New()
+
+	m.IncAuthFailure(AuthFailureInvalidToken)
+	m.IncAuthFailure(AuthFailureInvalidToken)
+	m.IncAuthFailure(AuthFailureWrongCredentials)
+
+	family := findFamily(t, m, "gophkeeper_auth_failures_total")
+	require.NotNil(t, family)
+
+	values := make(map[string]float64)
+	for _, metric := range family.GetMetric() {
+		values[labels(metric)["reason"]] = metric.GetCounter().GetValue()
+	}
+
+	assert.Equal(t, map[string]float64{
+		AuthFailureInvalidToken:     2,
+		AuthFailureWrongCredentials: 1,
+	}, values)
+}
+
+type cryptorStub struct{}
+
+func (c cryptorStub) EncryptBytes(raw []byte) ([]byte, error) {
+	return raw, nil
+}
+
+func (c cryptorStub) DecryptBytes(crypted []byte) ([]byte, error) {
+	return nil, errors.New("invalid data")
+}
+
+func TestInstrumentedCryptor(t *testing.T) {
+	m := New()
+	c := NewInstrumentedCryptor(cryptorStub{}, m)
+
+	b, err := c.EncryptBytes([]byte("data"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("data"), b)
+
+	_, err = c.DecryptBytes([]byte("data"))
+	assert.Error(t, err)
+
+	family := findFamily(t, m, "gophkeeper_crypto_duration_seconds")
+	require.NotNil(t, family)
+
+	counts := make(map[string]uint64)
+	for _, metric := range family.GetMetric() {
+		counts[labels(metric)["operation"]] = metric.GetHistogram().GetSampleCount()
+	}
+
+	assert.Equal(t, map[string]uint64{CryptoEncrypt: 1, CryptoDecrypt: 1}, counts)
+}
+
+type recordsCounterStub struct {
+	counts map[string]int
+	err    error
+}
+
+func (c recordsCounterStub) CountByDataType(ctx context.Context) (map[string]int, error) {
+	return c.counts, c.err
+}
+
+func TestStoredRecordsCollector(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	t.Run("valid", func(t *testing.T) {
+		m := New()
+		require.NoError(t, m.Register(NewStoredRecordsCollector(
+			recordsCounterStub{counts: map[string]int{"card": 3, "text": 1}},
+			time.Second,
+			logger,
+		)))
+
+		family := findFamily(t, m, "gophkeeper_stored_records")
+		require.NotNil(t, family)
+
+		values := make(map[string]float64)
+		for _, metric := range family.GetMetric() {
+			values[labels(metric)["data_type"]] = metric.GetGauge().GetValue()
+		}
+
+		assert.Equal(t, map[string]float64{"card": 3, "text": 1}, values)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		m := New()
+		require.NoError(t, m.Register(NewStoredRecordsCollector(
+			recordsCounterStub{err: errors.New("connection refused")},
+			time.Second,
+			logger,
+		)))
+
+		assert.Nil(t, findFamily(t, m, "gophkeeper_stored_records"))
+	})
+}
+
+func TestMetrics_Handler(t *testing.T) {
+	m := New()
+	m.IncAuthFailure(AuthFailureMissingToken)
+
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	res := w.Result()
+	defer res.Body.Close()
+	body, _ := io.ReadAll(res.Body)
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, string(body), `gophkeeper_auth_failures_total{reason="missing_token"} 1`)
+}
@@ -0,0 +1,56 @@
+package metrics
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type recordsCounter interface {
+	CountByDataType(ctx context.Context) (map[string]int, error)
+}
+
+// StoredRecordsCollector - exports number of stored records per data type, counted at scrape time
+type StoredRecordsCollector struct {
+	counter recordsCounter
+	timeout time.Duration
+	logger  *slog.Logger
+
+	records *prometheus.Desc
+}
+
+// NewStoredRecordsCollector - constructor for StoredRecordsCollector struct
+func NewStoredRecordsCollector(counter recordsCounter, timeout time.Duration, logger *slog.Logger) *StoredRecordsCollector {
+	return &StoredRecordsCollector{
+		counter: counter,
+		timeout: timeout,
+		logger:  logger,
+		records: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "stored_records"),
+			"Number of stored records per data type.",
+			[]string{"data_type"},
+			nil,
+		),
+	}
+}
+
+func (c *StoredRecordsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.records
+}
+
+func (c *StoredRecordsCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	counts, err := c.counter.CountByDataType(ctx)
+	if err != nil {
+		c.logger.Error("failed to count stored records", "error", err.Error())
+		return
+	}
+
+	for dataType, count := range counts {
+		ch <- prometheus.MustNewConstMetric(c.records, prometheus.GaugeValue, float64(count), dataType)
+	}
+}
@@ -1,11 +1,15 @@
 package middleware
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
 
 	"github.com/MowlCoder/goph-keeper/internal/domain"
+	"github.com/MowlCoder/goph-keeper/internal/handlers/httperrors"
+	"github.com/MowlCoder/goph-keeper/internal/metrics"
 	"github.com/MowlCoder/goph-keeper/internal/utils/usercontext"
 	"github.com/MowlCoder/goph-keeper/pkg/httputils"
 )
@@ -14,15 +18,28 @@ type tokenParser interface {
 	Parse(token string) (*domain.TokenClaim, error)
 }
 
+// deviceChecker - checks that device token was issued for is not revoked
+type deviceChecker interface {
+	Touch(ctx context.Context, userID int, deviceID int) error
+}
+
+type authFailureCounter interface {
+	IncAuthFailure(reason string)
+}
+
 // AuthMiddleware - struct responsible for validation user session
 type AuthMiddleware struct {
-	tokenParser tokenParser
+	tokenParser  tokenParser
+	devices      deviceChecker
+	authFailures authFailureCounter
 }
 
 // NewAuthMiddleware - constructor for AuthMiddleware struct
-func NewAuthMiddleware(tokenParser tokenParser) *AuthMiddleware {
+func NewAuthMiddleware(tokenParser tokenParser, devices deviceChecker, authFailures authFailureCounter) *AuthMiddleware {
 	return &AuthMiddleware{
-		tokenParser: tokenParser,
+		tokenParser:  tokenParser,
+		devices:      devices,
+		authFailures: authFailures,
 	}
 }
 
@@ -31,17 +48,32 @@ func (m *AuthMiddleware) Middleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		token, err := getTokenFromHeader(r.Header)
 		if err != nil {
+			m.authFailures.IncAuthFailure(metrics.AuthFailureMissingToken)
 			httputils.SendStatusCode(w, http.StatusUnauthorized)
 			return
 		}
 
+		// tokens issued before devices were introduced are not bound to any device
 		claim, err := m.tokenParser.Parse(token)
-		if err != nil {
+		if err != nil || claim.DeviceID == 0 {
+			m.authFailures.IncAuthFailure(metrics.AuthFailureInvalidToken)
 			httputils.SendStatusCode(w, http.StatusUnauthorized)
 			return
 		}
 
+		if err := m.devices.Touch(r.Context(), claim.ID, claim.DeviceID); err != nil {
+			if errors.Is(err, domain.ErrDeviceNotFound) {
+				m.authFailures.IncAuthFailure(metrics.AuthFailureRevokedDevice)
+				httputils.SendStatusCode(w, http.StatusUnauthorized)
+				return
+			}
+
+			httperrors.Handle(w, err)
+			return
+		}
+
 		ctx := usercontext.SetUserIDToContext(r.Context(), claim.ID)
+		ctx = usercontext.SetDeviceIDToContext(ctx, claim.DeviceID)
 
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
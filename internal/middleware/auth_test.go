@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+	"github.com/MowlCoder/goph-keeper/internal/metrics"
+	"github.com/MowlCoder/goph-keeper/internal/utils/usercontext"
+)
+
+type tokenParserStub struct {
+	claims map[string]*domain.TokenClaim
+}
+
+func (p *tokenParserStub) Parse(token string) (*domain.TokenClaim, error) {
+	claim, ok := p.claims[token]
+	if !ok {
+		return nil, errors.New("invalid token")
+	}
+
+	return claim, nil
+}
+
+type deviceCheckerStub struct {
+	revoked map[int]struct{}
+}
+
+func (c *deviceCheckerStub) Touch(ctx context.Context, userID int, deviceID int) error {
+	if _, ok := c.revoked[deviceID]; ok {
+		return domain.ErrDeviceNotFound
+	}
+
+	return nil
+}
+
+type authFailureCounterStub struct {
+	reasons []string
+}
+
+func (c *authFailureCounterStub) IncAuthFailure(reason string) {
+	c.reasons = append(c.reasons, reason)
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	parser := &tokenParserStub{
+		claims: map[string]*domain.TokenClaim{
+			"valid":   {ID: 1, DeviceID: 2},
+			"revoked": {ID: 1, DeviceID: 3},
+			"legacy":  {ID: 1},
+		},
+	}
+	devices := &deviceCheckerStub{revoked: map[int]struct{}{3: {}}}
+
+	testCases := []struct {
+		name          string
+		authorization string
+		statusCode    int
+		failure       string
+	}{
+		{
+			name:          "valid",
+			authorization: "Bearer valid",
+			statusCode:    http.StatusOK,
+		},
+		{
+			name:          "missing token",
+			authorization: "",
+			statusCode:    http.StatusUnauthorized,
+			failure:       metrics.AuthFailureMissingToken,
+		},
+		{
+			name:          "invalid token",
+			authorization: "Bearer invalid",
+			statusCode:    http.StatusUnauthorized,
+			failure:       metrics.AuthFailureInvalidToken,
+		},
+		{
+			name:          "token without device",
+			authorization: "Bearer legacy",
+			statusCode:    http.StatusUnauthorized,
+			failure:       metrics.AuthFailureInvalidToken,
+		},
+		{
+			name:          "revoked device",
+			authorization: "Bearer revoked",
+			statusCode:    http.StatusUnauthorized,
+			failure:       metrics.AuthFailureRevokedDevice,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			failures := &authFailureCounterStub{}
+
+			handler := NewAuthMiddleware(parser, devices, failures).Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				userID, _ := usercontext.GetUserIDFromContext(r.Context())
+				deviceID, _ := usercontext.GetDeviceIDFromContext(r.Context())
+
+				assert.Equal(t, 1, userID)
+				assert.Equal(t, 2, deviceID)
+			}))
+
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/data", nil)
+			if testCase.authorization != "" {
+				r.Header.Set("Authorization", testCase.authorization)
+			}
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, r)
+
+			assert.Equal(t, testCase.statusCode, w.Code)
+			if testCase.failure == "" {
+				assert.Empty(t, failures.reasons)
+			} else {
+				assert.Equal(t, []string{testCase.failure}, failures.reasons)
+			}
+		})
+	}
+}
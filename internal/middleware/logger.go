@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// LoggerMiddleware - struct responsible for logging requests and root causes of internal errors
+type LoggerMiddleware struct {
+	logger *slog.Logger
+}
+
+// NewLoggerMiddleware - constructor for LoggerMiddleware struct
+func NewLoggerMiddleware(logger *slog.Logger) *LoggerMiddleware {
+	return &LoggerMiddleware{
+		logger: logger,
+	}
+}
+
+// Middleware - log every request after it is handled, recover from panics. Query string and headers
+// are not logged because they can contain secrets.
+func (m *LoggerMiddleware) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rw := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+		defer func() {
+			if p := recover(); p != nil {
+				rw.SetError(fmt.Errorf("panic: %v", p))
+				rw.WriteHeader(http.StatusInternalServerError)
+			}
+
+			attrs := []any{
+				"method", r.Method,
+				"path", r.URL.Path,
+				"status", rw.statusCode,
+				"bytes", rw.bytes,
+				"duration", time.Since(start),
+				"remote_addr", r.RemoteAddr,
+			}
+
+			if rw.err != nil {
+				m.logger.ErrorContext(r.Context(), "request failed", append(attrs, "error", rw.err.Error())...)
+				return
+			}
+
+			m.logger.InfoContext(r.Context(), "request handled", attrs...)
+		}()
+
+		next.ServeHTTP(rw, r)
+	})
+}
+
+// responseWriter - captures status code, written bytes and root cause of internal error reported by handler
+type responseWriter struct {
+	http.ResponseWriter
+
+	statusCode  int
+	bytes       int
+	wroteHeader bool
+	err         error
+}
+
+func (w *responseWriter) WriteHeader(statusCode int) {
+	if w.wroteHeader {
+		return
+	}
+
+	w.statusCode = statusCode
+	w.wroteHeader = true
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += n
+
+	return n, err
+}
+
+// SetError - save root cause of internal error, used by httperrors
+func (w *responseWriter) SetError(err error) {
+	w.err = err
+}
+
+func (w *responseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,160 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+	"github.com/MowlCoder/goph-keeper/internal/handlers/httperrors"
+	"github.com/MowlCoder/goph-keeper/internal/utils/logger"
+	"github.com/MowlCoder/goph-keeper/internal/utils/requestid"
+)
+
+func TestLoggerMiddleware(t *testing.T) {
+	testCases := []struct {
+		name          string
+		handler       http.HandlerFunc
+		statusCode    int
+		level         string
+		loggedError   string
+		hiddenFromLog string
+	}{
+		{
+			name: "success",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusCreated)
+			},
+			statusCode: http.StatusCreated,
+			level:      "INFO",
+		},
+		{
+			name: "mapped error is not logged as failure",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				httperrors.Handle(w, domain.ErrUserStoredDataNotFound)
+			},
+			statusCode: http.StatusNotFound,
+			level:      "INFO",
+		},
+		{
+			name: "root cause of internal error",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				httperrors.Handle(w, errors.New("connection refused"))
+			},
+			statusCode:  http.StatusInternalServerError,
+			level:       "ERROR",
+			loggedError: "connection refused",
+		},
+		{
+			name: "panic",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				panic("boom")
+			},
+			statusCode:  http.StatusInternalServerError,
+			level:       "ERROR",
+			loggedError: "panic: boom",
+		},
+		{
+			name: "query string is not logged",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			},
+			statusCode:    http.StatusOK,
+			level:         "INFO",
+			hiddenFromLog: "secret-value",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			handler := NewRequestIDMiddleware().Middleware(
+				NewLoggerMiddleware(logger.New(buf, "info")).Middleware(testCase.handler),
+			)
+
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/data?token=secret-value", nil)
+			r.Header.Set(RequestIDHeader, "req-1")
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, r)
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, testCase.statusCode, res.StatusCode)
+			assert.Equal(t, "req-1", res.Header.Get(RequestIDHeader))
+
+			lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+			var record map[string]any
+			require.NoError(t, json.Unmarshal(lines[len(lines)-1], &record))
+
+			assert.Equal(t, testCase.level, record["level"])
+			assert.Equal(t, "req-1", record["request_id"])
+			assert.EqualValues(t, testCase.statusCode, record["status"])
+
+			if testCase.loggedError != "" {
+				assert.Equal(t, testCase.loggedError, record["error"])
+
+				body, _ := io.ReadAll(res.Body)
+				assert.NotContains(t, string(body), testCase.loggedError)
+			}
+
+			if testCase.hiddenFromLog != "" {
+				assert.NotContains(t, buf.String(), testCase.hiddenFromLog)
+			}
+		})
+	}
+}
+
+func TestRequestIDMiddleware(t *testing.T) {
+	testCases := []struct {
+		name     string
+		header   string
+		expected string
+	}{
+		{
+			name:     "from header",
+			header:   "abc-123",
+			expected: "abc-123",
+		},
+		{
+			name:   "generated when missing",
+			header: "",
+		},
+		{
+			name:   "generated when invalid",
+			header: "abc\n123",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var fromContext string
+			handler := NewRequestIDMiddleware().Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fromContext, _ = requestid.GetRequestIDFromContext(r.Context())
+			}))
+
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.Header.Set(RequestIDHeader, testCase.header)
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, r)
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.NotEmpty(t, fromContext)
+			assert.Equal(t, fromContext, res.Header.Get(RequestIDHeader))
+			if testCase.expected != "" {
+				assert.Equal(t, testCase.expected, fromContext)
+			} else {
+				assert.Len(t, fromContext, 32)
+			}
+		})
+	}
+}
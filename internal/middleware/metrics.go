@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// unmatchedRoute - label for requests which did not match any route, path is not used to keep labels bounded
+const unmatchedRoute = "unmatched"
+
+type httpRequestObserver interface {
+	ObserveHTTPRequest(method string, route string, statusCode int, duration time.Duration)
+}
+
+// MetricsMiddleware - struct responsible for measuring requests per route pattern
+type MetricsMiddleware struct {
+	observer httpRequestObserver
+}
+
+// NewMetricsMiddleware - constructor for MetricsMiddleware struct
+func NewMetricsMiddleware(observer httpRequestObserver) *MetricsMiddleware {
+	return &MetricsMiddleware{
+		observer: observer,
+	}
+}
+
+// Middleware - count requests and their latency by chi route pattern, so ids in path do not produce new series
+func (m *MetricsMiddleware) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rw := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+		next.ServeHTTP(rw, r)
+
+		route := unmatchedRoute
+		if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+			route = rctx.RoutePattern()
+		}
+
+		m.observer.ObserveHTTPRequest(r.Method, route, rw.statusCode, time.Since(start))
+	})
+}
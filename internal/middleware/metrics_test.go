@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+type observedRequest struct {
+	method     string
+	route      string
+	statusCode int
+}
+
+type httpRequestObserverStub struct {
+	requests []observedRequest
+}
+
+func (o *httpRequestObserverStub) ObserveHTTPRequest(method string, route string, statusCode int, duration time.Duration) {
+	o.requests = append(o.requests, observedRequest{method: method, route: route, statusCode: statusCode})
+}
+
+func TestMetricsMiddleware(t *testing.T) {
+	testCases := []struct {
+		name     string
+		method   string
+		path     string
+		expected observedRequest
+	}{
+		{
+			name:     "route pattern is used instead of path",
+			method:   http.MethodGet,
+			path:     "/api/v1/data/record/42",
+			expected: observedRequest{method: http.MethodGet, route: "/api/v1/data/record/{id}", statusCode: http.StatusOK},
+		},
+		{
+			name:     "status code",
+			method:   http.MethodDelete,
+			path:     "/api/v1/data/record/42",
+			expected: observedRequest{method: http.MethodDelete, route: "/api/v1/data/record/{id}", statusCode: http.StatusNoContent},
+		},
+		{
+			name:     "unmatched route",
+			method:   http.MethodGet,
+			path:     "/some/user@gmail.com",
+			expected: observedRequest{method: http.MethodGet, route: unmatchedRoute, statusCode: http.StatusNotFound},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			observer := &httpRequestObserverStub{}
+
+			router := chi.NewRouter()
+			router.Use(NewMetricsMiddleware(observer).Middleware)
+			router.Route("/api/v1/data", func(dataRouter chi.Router) {
+				dataRouter.Get("/record/{id}", func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusOK)
+				})
+				dataRouter.Delete("/record/{id}", func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusNoContent)
+				})
+			})
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(testCase.method, testCase.path, nil))
+
+			assert.Equal(t, []observedRequest{testCase.expected}, observer.requests)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"regexp"
+
+	"github.com/MowlCoder/goph-keeper/internal/utils/requestid"
+)
+
+// RequestIDHeader - header used to pass request id from client and return it in response
+const RequestIDHeader = "X-Request-ID"
+
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// RequestIDMiddleware - struct responsible for assigning id to every request
+type RequestIDMiddleware struct {
+}
+
+// NewRequestIDMiddleware - constructor for RequestIDMiddleware struct
+func NewRequestIDMiddleware() *RequestIDMiddleware {
+	return &RequestIDMiddleware{}
+}
+
+// Middleware - take request id from header if it is valid or generate new one, save it in request context
+// and response header
+func (m *RequestIDMiddleware) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if !validRequestID.MatchString(id) {
+			id = generateRequestID()
+		}
+
+		w.Header().Set(RequestIDHeader, id)
+		ctx := requestid.SetRequestIDToContext(r.Context(), id)
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func generateRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// untracedPaths - probes are called every few seconds and would only add noise to traces, event stream
+// lasts as long as client is connected and would produce span of hours
+var untracedPaths = map[string]struct{}{
+	"/healthz":       {},
+	"/readyz":        {},
+	"/api/v1/events": {},
+}
+
+// TracingMiddleware - struct responsible for starting server span for every request
+type TracingMiddleware struct {
+}
+
+// NewTracingMiddleware - constructor for TracingMiddleware struct
+func NewTracingMiddleware() *TracingMiddleware {
+	return &TracingMiddleware{}
+}
+
+// Middleware - start span continuing trace context from request headers. Span is renamed to chi route
+// pattern when request is routed, so ids in path do not produce separate span names
+func (m *TracingMiddleware) Middleware(next http.Handler) http.Handler {
+	routed := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r)
+
+		route := unmatchedRoute
+		if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+			route = rctx.RoutePattern()
+		}
+
+		span := trace.SpanFromContext(r.Context())
+		span.SetName(r.Method + " " + route)
+		span.SetAttributes(semconv.HTTPRoute(route))
+	})
+
+	return otelhttp.NewHandler(
+		routed,
+		"http.request",
+		otelhttp.WithFilter(func(r *http.Request) bool {
+			_, untraced := untracedPaths[r.URL.Path]
+			return !untraced
+		}),
+	)
+}
@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+
+	"github.com/MowlCoder/goph-keeper/internal/tracing"
+	"github.com/MowlCoder/goph-keeper/internal/tracing/tracingtest"
+)
+
+func TestTracingMiddleware(t *testing.T) {
+	newRouter := func() http.Handler {
+		router := chi.NewRouter()
+		router.Use(NewTracingMiddleware().Middleware)
+		router.Get("/api/v1/data/record/{id}", func(w http.ResponseWriter, r *http.Request) {
+			_, span := tracing.Start(r.Context(), "UserStoredDataService.GetUserDataByID")
+			span.End()
+		})
+		router.Get("/healthz", func(w http.ResponseWriter, r *http.Request) {})
+
+		return router
+	}
+
+	t.Run("span is named by route pattern", func(t *testing.T) {
+		exporter := tracingtest.Setup(t)
+
+		r := httptest.NewRequest(http.MethodGet, "/api/v1/data/record/42", nil)
+		newRouter().ServeHTTP(httptest.NewRecorder(), r)
+
+		assert.Equal(t, []string{"UserStoredDataService.GetUserDataByID", "GET /api/v1/data/record/{id}"}, tracingtest.SpanNames(exporter))
+	})
+
+	t.Run("trace is continued from client headers", func(t *testing.T) {
+		exporter := tracingtest.Setup(t)
+
+		clientCtx, clientSpan := tracing.Start(context.Background(), "BaseSyncer.Sync")
+		r := httptest.NewRequest(http.MethodGet, "/api/v1/data/record/42", nil)
+		otel.GetTextMapPropagator().Inject(clientCtx, propagation.HeaderCarrier(r.Header))
+
+		newRouter().ServeHTTP(httptest.NewRecorder(), r)
+		clientSpan.End()
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 3)
+		for _, span := range spans {
+			assert.Equal(t, clientSpan.SpanContext().TraceID(), span.SpanContext.TraceID())
+		}
+		assert.Equal(t, clientSpan.SpanContext().SpanID(), spans[1].Parent.SpanID())
+	})
+
+	t.Run("probes are not traced", func(t *testing.T) {
+		exporter := tracingtest.Setup(t)
+
+		r := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+		newRouter().ServeHTTP(httptest.NewRecorder(), r)
+
+		assert.Empty(t, exporter.GetSpans())
+	})
+}
@@ -0,0 +1,238 @@
+package presenter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+)
+
+// Format - how results of commands are printed
+type Format string
+
+const (
+	// FormatTable - aligned columns with headers for people
+	FormatTable Format = "table"
+	// FormatPlain - tab separated values without headers and titles for shell tools
+	FormatPlain Format = "plain"
+	// FormatJSON - one json document per result for programs
+	FormatJSON Format = "json"
+)
+
+// ParseFormat - get output format by its name
+func ParseFormat(name string) (Format, error) {
+	switch format := Format(name); format {
+	case FormatTable, FormatPlain, FormatJSON:
+		return format, nil
+	default:
+		return "", domain.ErrInvalidOutputFormat
+	}
+}
+
+// Presenter - renders results of all commands in one output format
+type Presenter struct {
+	format Format
+	out    io.Writer
+}
+
+// NewPresenter - constructor for Presenter struct
+func NewPresenter(format Format, out io.Writer) *Presenter {
+	return &Presenter{
+		format: format,
+		out:    out,
+	}
+}
+
+// Format - output format of presenter
+func (p *Presenter) Format() Format {
+	return p.format
+}
+
+// Present - print result in output format of presenter
+func (p *Presenter) Present(result *Result) error {
+	if result == nil {
+		return nil
+	}
+
+	switch p.format {
+	case FormatJSON:
+		return p.presentJSON(result)
+	case FormatPlain:
+		return p.presentPlain(result)
+	default:
+		return p.presentTable(result)
+	}
+}
+
+// PresentError - print error of command, in json format error is json document too
+func (p *Presenter) PresentError(out io.Writer, err error) error {
+	if p.format == FormatJSON {
+		encoder := json.NewEncoder(out)
+		encoder.SetEscapeHTML(false)
+
+		return encoder.Encode(map[string]string{"error": err.Error()})
+	}
+
+	_, writeErr := fmt.Fprintln(out, "error:", err)
+	return writeErr
+}
+
+func (p *Presenter) presentJSON(result *Result) error {
+	var document any
+
+	switch result.kind {
+	case kindMessage:
+		document = map[string]string{"message": result.Message}
+	case kindValue:
+		document = result.item(result.Rows[0])
+	case kindRecord:
+		document = result.item(result.Rows[0])
+	case kindList:
+		items := make([]map[string]any, 0, len(result.Rows))
+		for _, row := range result.Rows {
+			items = append(items, result.item(row))
+		}
+
+		list := map[string]any{"items": items}
+		if result.Page != nil {
+			list["page"] = result.Page
+		}
+		document = list
+	}
+
+	// output is read by programs, not embedded in html, so usage like <uuid> is kept as is
+	encoder := json.NewEncoder(p.out)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(document)
+}
+
+func (p *Presenter) presentPlain(result *Result) error {
+	writer := newErrWriter(p.out)
+
+	switch result.kind {
+	case kindMessage:
+		writer.println(result.Message)
+	case kindValue:
+		writer.println(formatValue(result.Rows[0][0]))
+	case kindRecord:
+		for idx, column := range result.Columns {
+			writer.println(column + "\t" + formatValue(result.Rows[0][idx]))
+		}
+	case kindList:
+		for _, row := range result.Rows {
+			values := make([]string, 0, len(row))
+			for _, value := range row {
+				values = append(values, formatValue(value))
+			}
+			writer.println(strings.Join(values, "\t"))
+		}
+	}
+
+	return writer.err
+}
+
+func (p *Presenter) presentTable(result *Result) error {
+	writer := newErrWriter(p.out)
+
+	if result.kind == kindMessage {
+		writer.println(result.Message)
+		return writer.err
+	}
+
+	if result.kind == kindValue {
+		writer.println(formatValue(result.Rows[0][0]))
+		return writer.err
+	}
+
+	writer.println(fmt.Sprintf("================== %s ==================", result.Title))
+
+	table := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	if result.kind == kindRecord {
+		for idx, column := range result.Columns {
+			fmt.Fprintf(table, "%s:\t%s\n", columnTitle(column), formatValue(result.Rows[0][idx]))
+		}
+	} else {
+		headers := make([]string, 0, len(result.Columns))
+		for _, column := range result.Columns {
+			headers = append(headers, columnTitle(column))
+		}
+		fmt.Fprintln(table, strings.Join(headers, "\t"))
+
+		for _, row := range result.Rows {
+			values := make([]string, 0, len(row))
+			for _, value := range row {
+				values = append(values, formatValue(value))
+			}
+			fmt.Fprintln(table, strings.Join(values, "\t"))
+		}
+	}
+	if err := table.Flush(); err != nil {
+		return err
+	}
+
+	if result.Page != nil {
+		writer.println(fmt.Sprintf("================== (%d/%d) ==================", result.Page.Current, result.Page.Count))
+	}
+
+	if result.Message != "" {
+		writer.println(result.Message)
+	}
+
+	return writer.err
+}
+
+func columnTitle(column string) string {
+	return strings.ToUpper(strings.ReplaceAll(column, "_", " "))
+}
+
+func formatValue(value any) string {
+	switch typed := value.(type) {
+	case nil:
+		return ""
+	case time.Time:
+		if typed.IsZero() {
+			return ""
+		}
+
+		return typed.Local().Format("2006-01-02 15:04:05")
+	case *time.Time:
+		if typed == nil {
+			return ""
+		}
+
+		return formatValue(*typed)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// errWriter - remembers first write error, so rendering code is not interrupted by checks
+type errWriter struct {
+	out io.Writer
+	err error
+}
+
+func newErrWriter(out io.Writer) *errWriter {
+	return &errWriter{out: out}
+}
+
+func (w *errWriter) Write(data []byte) (int, error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+
+	n, err := w.out.Write(data)
+	w.err = err
+
+	return n, err
+}
+
+func (w *errWriter) println(line string) {
+	fmt.Fprintln(w, line)
+}
@@ -0,0 +1,113 @@
+package presenter
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+)
+
+func TestParseFormat(t *testing.T) {
+	format, err := ParseFormat("json")
+	require.NoError(t, err)
+	assert.Equal(t, FormatJSON, format)
+
+	_, err = ParseFormat("yaml")
+	assert.ErrorIs(t, err, domain.ErrInvalidOutputFormat)
+}
+
+func TestPresenter_Present(t *testing.T) {
+	list := NewList("Log Pass", "id", "login", "version").
+		AddRow("826c4770", "alice", 2).
+		WithPage(1, 3)
+
+	testCases := []struct {
+		name     string
+		format   Format
+		result   *Result
+		expected string
+	}{
+		{
+			name:     "message in table",
+			format:   FormatTable,
+			result:   NewMessage("Successfully saved %s", "card"),
+			expected: "Successfully saved card\n",
+		},
+		{
+			name:     "message in json",
+			format:   FormatJSON,
+			result:   NewMessage("Successfully saved %s", "card"),
+			expected: "{\n  \"message\": \"Successfully saved card\"\n}\n",
+		},
+		{
+			name:   "list in table",
+			format: FormatTable,
+			result: list,
+			expected: "================== Log Pass ==================\n" +
+				"ID        LOGIN  VERSION\n" +
+				"826c4770  alice  2\n" +
+				"================== (1/3) ==================\n",
+		},
+		{
+			name:     "list in plain",
+			format:   FormatPlain,
+			result:   list,
+			expected: "826c4770\talice\t2\n",
+		},
+		{
+			name:   "list in json",
+			format: FormatJSON,
+			result: list,
+			expected: "{\n  \"items\": [\n    {\n      \"id\": \"826c4770\",\n      \"login\": \"alice\",\n      \"version\": 2\n    }\n  ],\n" +
+				"  \"page\": {\n    \"current\": 1,\n    \"count\": 3\n  }\n}\n",
+		},
+		{
+			name:     "record in plain",
+			format:   FormatPlain,
+			result:   NewRecord("Log Pass", []string{"id", "login"}, []any{"826c4770", "alice"}),
+			expected: "id\t826c4770\nlogin\talice\n",
+		},
+		{
+			name:     "value in table",
+			format:   FormatTable,
+			result:   NewValue("password", "s3cret"),
+			expected: "s3cret\n",
+		},
+		{
+			name:     "value in json",
+			format:   FormatJSON,
+			result:   NewValue("password", "s3cret"),
+			expected: "{\n  \"password\": \"s3cret\"\n}\n",
+		},
+		{
+			name:     "no result",
+			format:   FormatJSON,
+			result:   nil,
+			expected: "",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+
+			err := NewPresenter(testCase.format, out).Present(testCase.result)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, out.String())
+		})
+	}
+}
+
+func TestPresenter_PresentError(t *testing.T) {
+	out := &bytes.Buffer{}
+	require.NoError(t, NewPresenter(FormatJSON, &bytes.Buffer{}).PresentError(out, errors.New("not found")))
+	assert.Equal(t, "{\"error\":\"not found\"}\n", out.String())
+
+	out.Reset()
+	require.NoError(t, NewPresenter(FormatPlain, &bytes.Buffer{}).PresentError(out, errors.New("not found")))
+	assert.Equal(t, "error: not found\n", out.String())
+}
@@ -0,0 +1,101 @@
+package presenter
+
+import "fmt"
+
+type resultKind int
+
+const (
+	kindMessage resultKind = iota
+	kindList
+	kindRecord
+	kindValue
+)
+
+// Page - position of list in paginated result
+type Page struct {
+	Current int `json:"current"`
+	Count   int `json:"count"`
+}
+
+// Result - structured result of command, which is rendered by Presenter in chosen output format.
+// Columns are keys of values in json output and headers in table output
+type Result struct {
+	kind resultKind
+
+	Message string
+	Title   string
+	Columns []string
+	Rows    [][]any
+	Page    *Page
+}
+
+// NewMessage - result of command, which only reports what is done
+func NewMessage(format string, args ...any) *Result {
+	return &Result{
+		kind:    kindMessage,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+// NewList - result with list of items, every item has value for each column
+func NewList(title string, columns ...string) *Result {
+	return &Result{
+		kind:    kindList,
+		Title:   title,
+		Columns: columns,
+		Rows:    make([][]any, 0),
+	}
+}
+
+// NewRecord - result with single item, its values are shown field by field
+func NewRecord(title string, columns []string, values []any) *Result {
+	return &Result{
+		kind:    kindRecord,
+		Title:   title,
+		Columns: columns,
+		Rows:    [][]any{values},
+	}
+}
+
+// NewValue - result with value of single field, it is printed as is in text formats, so it can be used
+// by scripts
+func NewValue(name string, value any) *Result {
+	return &Result{
+		kind:    kindValue,
+		Columns: []string{name},
+		Rows:    [][]any{{value}},
+	}
+}
+
+// AddRow - add item to list, values are in order of columns
+func (r *Result) AddRow(values ...any) *Result {
+	r.Rows = append(r.Rows, values)
+	return r
+}
+
+// WithPage - mark list as page of paginated result
+func (r *Result) WithPage(current int, count int) *Result {
+	r.Page = &Page{
+		Current: current,
+		Count:   count,
+	}
+
+	return r
+}
+
+// WithMessage - add note shown after list or record, e.g. hint what to do next
+func (r *Result) WithMessage(format string, args ...any) *Result {
+	r.Message = fmt.Sprintf(format, args...)
+	return r
+}
+
+func (r *Result) item(row []any) map[string]any {
+	item := make(map[string]any, len(r.Columns))
+	for idx, column := range r.Columns {
+		if idx < len(row) {
+			item[column] = row[idx]
+		}
+	}
+
+	return item
+}
@@ -0,0 +1,289 @@
+package profile
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/gofrs/flock"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+	"github.com/MowlCoder/goph-keeper/internal/storage/file"
+)
+
+const (
+	registryFileName     = "profiles.json"
+	registryLockFileName = "profiles.lock"
+	profilesDirName      = "profiles"
+
+	registryFilePerm = 0600
+	profileDirPerm   = 0700
+)
+
+// legacyDataFiles - files kept in root of data directory before profiles, they are moved to default profile
+var legacyDataFiles = []string{
+	"vault.db",
+	"session.json",
+	"user_stored_data.json",
+	"user_stored_data.json.migrated",
+}
+
+var profileNamePattern = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+type registryState struct {
+	Active   string           `json:"active"`
+	Profiles []domain.Profile `json:"profiles"`
+}
+
+func (s *registryState) find(name string) int {
+	for idx := range s.Profiles {
+		if s.Profiles[idx].Name == name {
+			return idx
+		}
+	}
+
+	return -1
+}
+
+// Registry - list of profiles kept in root of data directory. Several clients with different profiles can
+// work at the same time, so registry file is reread and changed under file lock
+type Registry struct {
+	rootDir string
+	lock    *flock.Flock
+}
+
+// NewRegistry - constructor for Registry struct. On first start default profile is created and data
+// of previous versions is moved into its directory
+func NewRegistry(rootDir string) (*Registry, error) {
+	registry := &Registry{
+		rootDir: rootDir,
+		lock:    flock.New(filepath.Join(rootDir, registryLockFileName)),
+	}
+
+	err := registry.update(func(state *registryState) error {
+		if state.find(domain.DefaultProfileName) != -1 {
+			return nil
+		}
+
+		if err := registry.moveLegacyData(); err != nil {
+			return err
+		}
+
+		state.Profiles = append(state.Profiles, domain.Profile{Name: domain.DefaultProfileName})
+		if state.Active == "" {
+			state.Active = domain.DefaultProfileName
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return registry, nil
+}
+
+// DataDir - directory with vault and session of profile
+func (r *Registry) DataDir(name string) string {
+	return filepath.Join(r.rootDir, profilesDirName, name)
+}
+
+func (r *Registry) GetAll() ([]domain.Profile, error) {
+	state, err := r.read()
+	if err != nil {
+		return nil, err
+	}
+
+	for idx := range state.Profiles {
+		state.Profiles[idx].Active = state.Profiles[idx].Name == state.Active
+	}
+
+	return state.Profiles, nil
+}
+
+// Open - get profile client works with, empty name means active profile. Profile without server is bound to
+// server given for this run or to default server. Server profile is bound to wins over default server, only
+// server given for this run replaces it, binding of profile is not changed then
+func (r *Registry) Open(name string, defaultServerAddr string, runServerAddr string) (*domain.Profile, error) {
+	var profile domain.Profile
+
+	err := r.update(func(state *registryState) error {
+		if name == "" {
+			name = state.Active
+		}
+
+		idx := state.find(name)
+		if idx == -1 {
+			return domain.ErrProfileNotFound
+		}
+
+		if state.Profiles[idx].ServerAddr == "" {
+			state.Profiles[idx].ServerAddr = runServerAddr
+			if runServerAddr == "" {
+				state.Profiles[idx].ServerAddr = defaultServerAddr
+			}
+		}
+
+		profile = state.Profiles[idx]
+		profile.Active = name == state.Active
+		if runServerAddr != "" {
+			profile.ServerAddr = runServerAddr
+		}
+
+		return os.MkdirAll(r.DataDir(name), profileDirPerm)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &profile, nil
+}
+
+// Create - add profile and its data directory, server address can be empty, then profile is bound
+// to server it is opened with first time
+func (r *Registry) Create(name string, serverAddr string) (*domain.Profile, error) {
+	if !profileNamePattern.MatchString(name) || len(name) > domain.ProfileNameMaxLength {
+		return nil, domain.ErrInvalidProfileName
+	}
+
+	profile := domain.Profile{
+		Name:       name,
+		ServerAddr: serverAddr,
+	}
+
+	err := r.update(func(state *registryState) error {
+		if state.find(name) != -1 {
+			return domain.ErrProfileAlreadyExists
+		}
+
+		if err := os.MkdirAll(r.DataDir(name), profileDirPerm); err != nil {
+			return err
+		}
+
+		state.Profiles = append(state.Profiles, profile)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &profile, nil
+}
+
+// SetActive - make profile opened by default on next start
+func (r *Registry) SetActive(name string) error {
+	return r.update(func(state *registryState) error {
+		if state.find(name) == -1 {
+			return domain.ErrProfileNotFound
+		}
+
+		state.Active = name
+		return nil
+	})
+}
+
+// Delete - remove profile with its vault and session. Profile opened by any client, this one included,
+// is locked and can not be deleted
+func (r *Registry) Delete(name string) error {
+	if name == domain.DefaultProfileName {
+		return domain.ErrDefaultProfileDelete
+	}
+
+	return r.update(func(state *registryState) error {
+		idx := state.find(name)
+		if idx == -1 {
+			return domain.ErrProfileNotFound
+		}
+
+		dataDirLock, err := file.LockDir(r.DataDir(name))
+		if errors.Is(err, domain.ErrVaultInUse) {
+			return domain.ErrProfileInUse
+		}
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		if dataDirLock != nil {
+			defer dataDirLock.Unlock()
+		}
+
+		if err := os.RemoveAll(r.DataDir(name)); err != nil {
+			return err
+		}
+
+		state.Profiles = append(state.Profiles[:idx], state.Profiles[idx+1:]...)
+		if state.Active == name {
+			state.Active = domain.DefaultProfileName
+		}
+
+		return nil
+	})
+}
+
+func (r *Registry) read() (*registryState, error) {
+	if err := r.lock.RLock(); err != nil {
+		return nil, err
+	}
+	defer r.lock.Unlock()
+
+	return r.load()
+}
+
+// update - change registry, state is reread under lock, so changes of other clients are not lost
+func (r *Registry) update(change func(state *registryState) error) error {
+	if err := r.lock.Lock(); err != nil {
+		return err
+	}
+	defer r.lock.Unlock()
+
+	state, err := r.load()
+	if err != nil {
+		return err
+	}
+
+	if err := change(state); err != nil {
+		return err
+	}
+
+	content, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	return file.WriteFileAtomic(filepath.Join(r.rootDir, registryFileName), content, registryFilePerm)
+}
+
+func (r *Registry) load() (*registryState, error) {
+	state := &registryState{}
+
+	content, err := os.ReadFile(filepath.Join(r.rootDir, registryFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(content, state); err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+func (r *Registry) moveLegacyData() error {
+	dataDir := r.DataDir(domain.DefaultProfileName)
+	if err := os.MkdirAll(dataDir, profileDirPerm); err != nil {
+		return err
+	}
+
+	for _, name := range legacyDataFiles {
+		err := os.Rename(filepath.Join(r.rootDir, name), filepath.Join(dataDir, name))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package profile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+	"github.com/MowlCoder/goph-keeper/internal/storage/file"
+)
+
+func TestNewRegistry_MovesLegacyData(t *testing.T) {
+	rootDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(rootDir, "vault.db"), []byte("vault"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(rootDir, "session.json"), []byte("session"), 0600))
+
+	registry, err := NewRegistry(rootDir)
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(registry.DataDir(domain.DefaultProfileName), "vault.db"))
+	require.NoError(t, err)
+	assert.Equal(t, "vault", string(content))
+	assert.NoFileExists(t, filepath.Join(rootDir, "session.json"))
+
+	profiles, err := registry.GetAll()
+	require.NoError(t, err)
+	require.Len(t, profiles, 1)
+	assert.True(t, profiles[0].Active)
+
+	// default profile already exists, so registry is not changed on next start
+	_, err = NewRegistry(rootDir)
+	require.NoError(t, err)
+	profiles, err = registry.GetAll()
+	require.NoError(t, err)
+	assert.Len(t, profiles, 1)
+}
+
+func TestRegistry_Create(t *testing.T) {
+	registry, err := NewRegistry(t.TempDir())
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name        string
+		profileName string
+		err         error
+	}{
+		{
+			name:        "valid",
+			profileName: "work",
+		},
+		{
+			name:        "already exists",
+			profileName: domain.DefaultProfileName,
+			err:         domain.ErrProfileAlreadyExists,
+		},
+		{
+			name:        "path in name",
+			profileName: "../work",
+			err:         domain.ErrInvalidProfileName,
+		},
+		{
+			name:        "empty name",
+			profileName: "",
+			err:         domain.ErrInvalidProfileName,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := registry.Create(testCase.profileName, "localhost:8080")
+			assert.ErrorIs(t, err, testCase.err)
+		})
+	}
+
+	assert.DirExists(t, registry.DataDir("work"))
+}
+
+func TestRegistry_Open(t *testing.T) {
+	registry, err := NewRegistry(t.TempDir())
+	require.NoError(t, err)
+
+	_, err = registry.Create("work", "work.example.com")
+	require.NoError(t, err)
+	require.NoError(t, registry.SetActive("work"))
+
+	profile, err := registry.Open("", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, "work", profile.Name)
+	assert.Equal(t, "work.example.com", profile.ServerAddr)
+
+	// default server does not replace server profile is bound to
+	profile, err = registry.Open("work", "personal.example.com", "")
+	require.NoError(t, err)
+	assert.Equal(t, "work.example.com", profile.ServerAddr)
+
+	// server given for this run does, but profile stays bound to its server
+	profile, err = registry.Open("work", "personal.example.com", "localhost:4000")
+	require.NoError(t, err)
+	assert.Equal(t, "localhost:4000", profile.ServerAddr)
+
+	profile, err = registry.Open("work", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, "work.example.com", profile.ServerAddr)
+
+	// profile without server is bound to default server the first time it is opened
+	profile, err = registry.Open(domain.DefaultProfileName, "personal.example.com", "")
+	require.NoError(t, err)
+	assert.Equal(t, "personal.example.com", profile.ServerAddr)
+
+	profile, err = registry.Open(domain.DefaultProfileName, "work.example.com", "")
+	require.NoError(t, err)
+	assert.Equal(t, "personal.example.com", profile.ServerAddr)
+
+	_, err = registry.Open("home", "", "")
+	assert.ErrorIs(t, err, domain.ErrProfileNotFound)
+}
+
+func TestRegistry_OpenBindsToRunServer(t *testing.T) {
+	registry, err := NewRegistry(t.TempDir())
+	require.NoError(t, err)
+
+	profile, err := registry.Open(domain.DefaultProfileName, "default.example.com", "run.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "run.example.com", profile.ServerAddr)
+
+	profile, err = registry.Open(domain.DefaultProfileName, "default.example.com", "")
+	require.NoError(t, err)
+	assert.Equal(t, "run.example.com", profile.ServerAddr)
+}
+
+func TestRegistry_Delete(t *testing.T) {
+	registry, err := NewRegistry(t.TempDir())
+	require.NoError(t, err)
+
+	_, err = registry.Create("work", "")
+	require.NoError(t, err)
+	require.NoError(t, registry.SetActive("work"))
+
+	lock, err := file.LockDir(registry.DataDir("work"))
+	require.NoError(t, err)
+	assert.ErrorIs(t, registry.Delete("work"), domain.ErrProfileInUse)
+	require.NoError(t, lock.Unlock())
+
+	require.NoError(t, registry.Delete("work"))
+	assert.NoDirExists(t, registry.DataDir("work"))
+
+	profiles, err := registry.GetAll()
+	require.NoError(t, err)
+	require.Len(t, profiles, 1)
+	assert.True(t, profiles[0].Active)
+
+	assert.ErrorIs(t, registry.Delete(domain.DefaultProfileName), domain.ErrDefaultProfileDelete)
+	assert.ErrorIs(t, registry.Delete("work"), domain.ErrProfileNotFound)
+}
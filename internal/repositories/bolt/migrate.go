@@ -0,0 +1,94 @@
+package bolt
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/google/uuid"
+	"go.etcd.io/bbolt"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+)
+
+// migratedFileSuffix - json store is kept with this suffix after migration, so it can be restored by hand
+const migratedFileSuffix = ".migrated"
+
+// MigrateFromJSONFile - move records from json file used by previous versions of client into database.
+// Records are imported in one transaction, records already present in database are skipped, so migration
+// interrupted before file is renamed can be repeated. Returns number of imported records
+func (repo *UserStoredDataRepository) MigrateFromJSONFile(ctx context.Context, path string) (int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, nil
+		}
+
+		return 0, err
+	}
+
+	dataSet := make([]domain.UserStoredData, 0)
+	err = json.NewDecoder(file).Decode(&dataSet)
+	file.Close()
+	if err != nil && !errors.Is(err, io.EOF) {
+		return 0, err
+	}
+
+	if err := assignMissingUUIDs(dataSet); err != nil {
+		return 0, err
+	}
+	sortByCreation(dataSet)
+
+	imported := 0
+	err = repo.update(func(tx *bbolt.Tx, index *vaultIndex) error {
+		for idx := range dataSet {
+			if tx.Bucket(recordsBucket).Get(repo.recordKey(dataSet[idx].UUID)) != nil {
+				continue
+			}
+
+			if err := repo.putRecord(tx, index, nil, &dataSet[idx]); err != nil {
+				return err
+			}
+			imported++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return imported, os.Rename(path, path+migratedFileSuffix)
+}
+
+// assignMissingUUIDs - give uuids to records saved before records had them. Synced records get uuid derived
+// from server id, as server does, local records become local records without id. Uuid of local record is
+// derived from its place and content in file, so repeated migration of the same file skips it as imported
+func assignMissingUUIDs(dataSet []domain.UserStoredData) error {
+	for idx, data := range dataSet {
+		if data.UUID != "" {
+			continue
+		}
+
+		if data.ID > 0 {
+			dataSet[idx].UUID = domain.LegacyRecordUUID(data.ID)
+			continue
+		}
+
+		content, err := json.Marshal(data)
+		if err != nil {
+			return err
+		}
+
+		sum := md5.Sum(append([]byte("local_user_stored_data:"+strconv.Itoa(idx)+":"), content...))
+		dataSet[idx].UUID = uuid.UUID(sum).String()
+		dataSet[idx].ID = 0
+		dataSet[idx].Version = -1
+	}
+
+	return nil
+}
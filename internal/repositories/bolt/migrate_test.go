@@ -0,0 +1,140 @@
+package bolt
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+)
+
+// legacyJSONStore - user_stored_data.json as it was written by previous versions of client: synced records
+// from before uuids, a local record without uuid and a record with uuid
+const legacyJSONStore = `[
+	{"id":4,"user_id":1,"data_type":"text","crypted_data":"dGV4dA==","meta":"synced","version":2,"created_at":"2024-01-10T10:00:00Z","updated_at":"2024-01-11T10:00:00Z"},
+	{"id":0,"user_id":0,"data_type":"text","crypted_data":"bG9jYWw=","meta":"local","version":0,"created_at":"2024-01-12T10:00:00Z","updated_at":"2024-01-12T10:00:00Z"},
+	{"id":7,"uuid":"0a1b2c3d-0000-4000-8000-000000000001","user_id":1,"data_type":"card","crypted_data":"Y2FyZA==","meta":"","version":1,"created_at":"2024-01-13T10:00:00Z","updated_at":"2024-01-13T10:00:00Z"}
+]`
+
+// failingSealer - sealer failing on sealing after given number of successful seals, negative number never fails
+type failingSealer struct {
+	vaultSealer
+	sealsLeft int
+}
+
+func (s *failingSealer) Seal(plaintext []byte, additionalData []byte) ([]byte, error) {
+	if s.sealsLeft == 0 {
+		return nil, errors.New("seal failed")
+	}
+	s.sealsLeft--
+
+	return s.vaultSealer.Seal(plaintext, additionalData)
+}
+
+func writeLegacyStore(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "user_stored_data.json")
+	require.NoError(t, os.WriteFile(path, []byte(legacyJSONStore), 0600))
+
+	return path
+}
+
+func TestUserStoredDataRepository_MigrateFromJSONFile(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+	path := writeLegacyStore(t)
+
+	imported, err := repo.MigrateFromJSONFile(ctx, path)
+	require.NoError(t, err)
+	assert.Equal(t, 3, imported)
+
+	assert.NoFileExists(t, path)
+	assert.FileExists(t, path+migratedFileSuffix)
+
+	synced, err := repo.GetByID(ctx, 4)
+	require.NoError(t, err)
+	assert.Equal(t, domain.LegacyRecordUUID(4), synced.UUID)
+	assert.Equal(t, []byte("text"), synced.CryptedData)
+	assert.Equal(t, 2, synced.Version)
+
+	withUUID, err := repo.GetByID(ctx, 7)
+	require.NoError(t, err)
+	assert.Equal(t, testUUID1, withUUID.UUID)
+
+	texts, err := repo.GetWithType(ctx, domain.TextDataType, &domain.StorageFilters{})
+	require.NoError(t, err)
+	require.Len(t, texts, 2)
+
+	local := texts[1]
+	assert.Equal(t, "local", local.Meta)
+	assert.NotEmpty(t, local.UUID)
+	assert.True(t, local.IsLocal())
+
+	count, err := repo.CountUserDataOfType(ctx, domain.CardDataType)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestUserStoredDataRepository_MigrateFromJSONFile_NoFile(t *testing.T) {
+	repo := newTestRepository(t)
+
+	imported, err := repo.MigrateFromJSONFile(context.Background(), filepath.Join(t.TempDir(), "missing.json"))
+	require.NoError(t, err)
+	assert.Equal(t, 0, imported)
+}
+
+func TestUserStoredDataRepository_MigrateFromJSONFile_Repeated(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+	path := writeLegacyStore(t)
+
+	imported, err := repo.MigrateFromJSONFile(ctx, path)
+	require.NoError(t, err)
+	assert.Equal(t, 3, imported)
+
+	// file left in place, e.g. client was stopped before it was renamed
+	require.NoError(t, os.Rename(path+migratedFileSuffix, path))
+
+	imported, err = repo.MigrateFromJSONFile(ctx, path)
+	require.NoError(t, err)
+	assert.Equal(t, 0, imported)
+
+	count, err := repo.CountUserDataOfType(ctx, domain.TextDataType)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
+
+func TestUserStoredDataRepository_MigrateFromJSONFile_Failed(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	sealer := &failingSealer{vaultSealer: newTestSealer(t), sealsLeft: -1}
+	path := writeLegacyStore(t)
+
+	repo, err := NewUserStoredDataRepository(db, sealer)
+	require.NoError(t, err)
+
+	// the second record fails, the first one must not stay in vault
+	sealer.sealsLeft = 1
+	_, err = repo.MigrateFromJSONFile(ctx, path)
+	require.Error(t, err)
+
+	assert.FileExists(t, path)
+	_, err = repo.GetByID(ctx, 4)
+	assert.ErrorIs(t, err, domain.ErrUserStoredDataNotFound)
+
+	count, err := repo.CountUserDataOfType(ctx, domain.TextDataType)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	sealer.sealsLeft = -1
+	imported, err := repo.MigrateFromJSONFile(ctx, path)
+	require.NoError(t, err)
+	assert.Equal(t, 3, imported)
+	assert.NoFileExists(t, path)
+}
@@ -0,0 +1,745 @@
+package bolt
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"go.etcd.io/bbolt"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+)
+
+var (
+	// recordsBucket - sealed records by blinded uuid
+	recordsBucket = []byte("records")
+
+	// metaBucket - keeps sealed header, which tells that vault is encrypted and checks vault key, sealed
+	// index of records and version of index, index of older version is built again on start
+	metaBucket      = []byte("meta")
+	headerKey       = []byte("header")
+	headerContent   = []byte("goph-keeper vault")
+	indexKey        = []byte("index")
+	indexVersionKey = []byte("index_version")
+	indexVersion    = []byte("3")
+
+	// legacyIndexBuckets - indexes kept by previous versions in bucket per data type and uuid prefix, which
+	// told how many records of each type vault has, they are removed when index is built again
+	legacyIndexBuckets = [][]byte{[]byte("records_by_type"), []byte("records_by_id"), []byte("records_by_prefix")}
+)
+
+type vaultSealer interface {
+	Seal(plaintext []byte, additionalData []byte) ([]byte, error)
+	Open(sealed []byte, additionalData []byte) ([]byte, error)
+	Blind(value []byte) []byte
+}
+
+// UserStoredDataRepository - local store in embedded database, every change is made in its own transaction,
+// so crash in the middle of write does not corrupt vault, all changes of one sync share one transaction.
+// Records and their index are sealed under vault key and keys of records are blinded, so database file
+// tells only how many records vault has
+type UserStoredDataRepository struct {
+	db     *bbolt.DB
+	sealer vaultSealer
+}
+
+func NewUserStoredDataRepository(db *bbolt.DB, sealer vaultSealer) (*UserStoredDataRepository, error) {
+	repo := &UserStoredDataRepository{
+		db:     db,
+		sealer: sealer,
+	}
+
+	if err := db.Update(repo.init); err != nil {
+		return nil, err
+	}
+
+	return repo, nil
+}
+
+// init - check vault key against sealed header and build index again, when it is of older version.
+// Vault without header was saved by previous version in plain form, its records are sealed again in the
+// same transaction
+func (repo *UserStoredDataRepository) init(tx *bbolt.Tx) error {
+	if meta := tx.Bucket(metaBucket); meta != nil {
+		if _, err := repo.sealer.Open(meta.Get(headerKey), headerKey); err != nil {
+			return err
+		}
+
+		if bytes.Equal(meta.Get(indexVersionKey), indexVersion) {
+			return nil
+		}
+
+		return repo.rebuildIndex(tx)
+	}
+
+	plainRecords, err := readPlainRecords(tx)
+	if err != nil {
+		return err
+	}
+
+	if err := deleteBuckets(tx, append(legacyIndexBuckets, recordsBucket)...); err != nil {
+		return err
+	}
+	if _, err := tx.CreateBucket(recordsBucket); err != nil {
+		return err
+	}
+
+	header, err := repo.sealer.Seal(headerContent, headerKey)
+	if err != nil {
+		return err
+	}
+
+	meta, err := tx.CreateBucket(metaBucket)
+	if err != nil {
+		return err
+	}
+
+	if err := meta.Put(headerKey, header); err != nil {
+		return err
+	}
+
+	index := newVaultIndex()
+	sortByCreation(plainRecords)
+	for idx := range plainRecords {
+		if err := repo.putRecord(tx, index, nil, &plainRecords[idx]); err != nil {
+			return err
+		}
+	}
+
+	if err := repo.saveIndex(tx, index); err != nil {
+		return err
+	}
+
+	return meta.Put(indexVersionKey, indexVersion)
+}
+
+// rebuildIndex - index every record again, records get sequence numbers in order of creation
+func (repo *UserStoredDataRepository) rebuildIndex(tx *bbolt.Tx) error {
+	dataSet, err := repo.allRecords(tx)
+	if err != nil {
+		return err
+	}
+
+	if err := deleteBuckets(tx, legacyIndexBuckets...); err != nil {
+		return err
+	}
+
+	index := newVaultIndex()
+	sortByCreation(dataSet)
+	for idx := range dataSet {
+		index.put(&dataSet[idx], 0)
+	}
+
+	if err := repo.saveIndex(tx, index); err != nil {
+		return err
+	}
+
+	return tx.Bucket(metaBucket).Put(indexVersionKey, indexVersion)
+}
+
+func deleteBuckets(tx *bbolt.Tx, names ...[]byte) error {
+	for _, name := range names {
+		if err := tx.DeleteBucket(name); err != nil && !errors.Is(err, bbolt.ErrBucketNotFound) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func sortByCreation(dataSet []domain.UserStoredData) {
+	sort.SliceStable(dataSet, func(i, j int) bool {
+		return dataSet[i].CreatedAt.Before(dataSet[j].CreatedAt)
+	})
+}
+
+func readPlainRecords(tx *bbolt.Tx) ([]domain.UserStoredData, error) {
+	dataSet := make([]domain.UserStoredData, 0)
+
+	bucket := tx.Bucket(recordsBucket)
+	if bucket == nil {
+		return dataSet, nil
+	}
+
+	err := bucket.ForEach(func(key, value []byte) error {
+		var data domain.UserStoredData
+		if err := json.Unmarshal(value, &data); err != nil {
+			return err
+		}
+
+		dataSet = append(dataSet, data)
+		return nil
+	})
+
+	return dataSet, err
+}
+
+func (repo *UserStoredDataRepository) GetByUUID(ctx context.Context, recordUUID string) (*domain.UserStoredData, error) {
+	var data *domain.UserStoredData
+
+	err := repo.db.View(func(tx *bbolt.Tx) error {
+		var err error
+		data, err = repo.getRecord(tx, recordUUID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// GetByID - get synced record by its server id
+func (repo *UserStoredDataRepository) GetByID(ctx context.Context, id int) (*domain.UserStoredData, error) {
+	var data *domain.UserStoredData
+
+	err := repo.view(func(tx *bbolt.Tx, index *vaultIndex) error {
+		recordUUID, ok := index.IDs[id]
+		if !ok {
+			return domain.ErrUserStoredDataNotFound
+		}
+
+		var err error
+		data, err = repo.getRecord(tx, recordUUID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// FindByRef - get record by normalized uuid or uuid prefix, prefix must match only one record.
+// Prefix is searched among uuids in index, only found record is decrypted
+func (repo *UserStoredDataRepository) FindByRef(ctx context.Context, ref string) (*domain.UserStoredData, error) {
+	if len(ref) < domain.RecordRefMinLength {
+		return nil, domain.ErrInvalidRecordRef
+	}
+
+	var found *domain.UserStoredData
+
+	err := repo.view(func(tx *bbolt.Tx, index *vaultIndex) error {
+		uuids := index.uuidsWithPrefix(ref, 2)
+
+		switch len(uuids) {
+		case 0:
+			return domain.ErrUserStoredDataNotFound
+		case 1:
+			var err error
+			found, err = repo.getRecord(tx, uuids[0])
+			return err
+		default:
+			return domain.ErrAmbiguousRecordRef
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return found, nil
+}
+
+func (repo *UserStoredDataRepository) GetAll(ctx context.Context) ([]domain.UserStoredData, error) {
+	var dataSet []domain.UserStoredData
+
+	err := repo.db.View(func(tx *bbolt.Tx) error {
+		var err error
+		dataSet, err = repo.allRecords(tx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dataSet, nil
+}
+
+// GetWithType - get records of type in order of creation. Records are ordered and paginated by
+// sequence numbers in index, so only records of requested page are decrypted
+func (repo *UserStoredDataRepository) GetWithType(ctx context.Context, dataType string, filters *domain.StorageFilters) ([]domain.UserStoredData, error) {
+	dataSet := make([]domain.UserStoredData, 0)
+
+	err := repo.view(func(tx *bbolt.Tx, index *vaultIndex) error {
+		entries := make([]typeIndexEntry, 0, len(index.Types[dataType]))
+		for recordUUID, order := range index.Types[dataType] {
+			entries = append(entries, typeIndexEntry{uuid: recordUUID, order: order})
+		}
+
+		sort.Slice(entries, func(i, j int) bool {
+			if filters.IsSortedByDate && !filters.SortDate.IsASC {
+				return entries[i].order > entries[j].order
+			}
+
+			return entries[i].order < entries[j].order
+		})
+
+		if filters.IsPaginated {
+			startFrom := (filters.Pagination.Page - 1) * filters.Pagination.Count
+			endAt := startFrom + filters.Pagination.Count
+
+			if startFrom >= len(entries) {
+				return nil
+			}
+
+			if endAt > len(entries) {
+				endAt = len(entries)
+			}
+
+			entries = entries[startFrom:endAt]
+		}
+
+		for _, entry := range entries {
+			data, err := repo.getRecord(tx, entry.uuid)
+			if err != nil {
+				return err
+			}
+
+			dataSet = append(dataSet, *data)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dataSet, nil
+}
+
+// typeIndexEntry - uuid of record with its sequence number
+type typeIndexEntry struct {
+	uuid  string
+	order uint64
+}
+
+func (repo *UserStoredDataRepository) CountUserDataOfType(ctx context.Context, dataType string) (int, error) {
+	count := 0
+
+	err := repo.view(func(tx *bbolt.Tx, index *vaultIndex) error {
+		count = len(index.Types[dataType])
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (repo *UserStoredDataRepository) AddData(ctx context.Context, recordUUID string, dataType string, data []byte, meta string) error {
+	return repo.update(func(tx *bbolt.Tx, index *vaultIndex) error {
+		return repo.addRecord(tx, index, domain.UserStoredData{
+			UUID:        recordUUID,
+			DataType:    dataType,
+			CryptedData: data,
+			Meta:        meta,
+			Version:     -1,
+		})
+	})
+}
+
+func (repo *UserStoredDataRepository) UpdateByUUID(ctx context.Context, recordUUID string, data []byte, meta string) (*domain.UserStoredData, error) {
+	var updatedData domain.UserStoredData
+
+	err := repo.update(func(tx *bbolt.Tx, index *vaultIndex) error {
+		current, err := repo.getRecord(tx, recordUUID)
+		if err != nil {
+			return notFound(err)
+		}
+
+		updatedData = *current
+		updatedData.CryptedData = data
+		updatedData.Meta = meta
+		updatedData.UpdatedAt = time.Now().UTC()
+
+		return repo.putRecord(tx, index, current, &updatedData)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &updatedData, nil
+}
+
+func (repo *UserStoredDataRepository) DeleteByUUID(ctx context.Context, recordUUID string) error {
+	return repo.update(func(tx *bbolt.Tx, index *vaultIndex) error {
+		current, err := repo.getRecord(tx, recordUUID)
+		if err != nil {
+			return notFound(err)
+		}
+
+		return repo.deleteRecord(tx, index, current)
+	})
+}
+
+func (repo *UserStoredDataRepository) DeleteBatch(ctx context.Context, uuids []string) error {
+	return repo.update(func(tx *bbolt.Tx, index *vaultIndex) error {
+		return repo.deleteBatch(tx, index, uuids)
+	})
+}
+
+// SyncUpdate - remember server id and version of record after it was synced
+func (repo *UserStoredDataRepository) SyncUpdate(ctx context.Context, recordUUID string, id int, version int) error {
+	return repo.update(func(tx *bbolt.Tx, index *vaultIndex) error {
+		return repo.syncUpdate(tx, index, recordUUID, id, version, nil)
+	})
+}
+
+// SaveSyncBase - remember current state of every synced record as base for merging future conflicts.
+// Must be called only after successful sync, when all synced records are the same as on server
+func (repo *UserStoredDataRepository) SaveSyncBase(ctx context.Context) error {
+	return repo.update(repo.saveSyncBase)
+}
+
+// ApplySync - apply all changes of sync in one transaction. Sync base is saved after records of server are
+// changed, conflicted copies are added after it, so they stay local and are pushed on next sync
+func (repo *UserStoredDataRepository) ApplySync(ctx context.Context, changes domain.LocalSyncChanges) error {
+	return repo.update(func(tx *bbolt.Tx, index *vaultIndex) error {
+		for _, result := range changes.Synced {
+			if err := repo.syncUpdate(tx, index, result.UUID, result.ID, result.Version, nil); err != nil {
+				return err
+			}
+		}
+
+		if err := repo.deleteBatch(tx, index, changes.Deleted); err != nil {
+			return err
+		}
+
+		for idx := range changes.Updated {
+			data := &changes.Updated[idx]
+			if err := repo.syncUpdate(tx, index, data.UUID, data.ID, data.Version, data); err != nil {
+				return err
+			}
+		}
+
+		for _, data := range changes.Added {
+			if err := repo.addRecord(tx, index, data); err != nil {
+				return err
+			}
+		}
+
+		if err := repo.saveSyncBase(tx, index); err != nil {
+			return err
+		}
+
+		for _, data := range changes.Copies {
+			data.ID = 0
+			data.Version = -1
+			if err := repo.addRecord(tx, index, data); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// addRecord - save new record, uuid must not be taken by other record
+func (repo *UserStoredDataRepository) addRecord(tx *bbolt.Tx, index *vaultIndex, data domain.UserStoredData) error {
+	if tx.Bucket(recordsBucket).Get(repo.recordKey(data.UUID)) != nil {
+		return domain.ErrRecordUUIDTaken
+	}
+
+	now := time.Now().UTC()
+	data.CreatedAt = now
+	data.UpdatedAt = now
+
+	return repo.putRecord(tx, index, nil, &data)
+}
+
+// deleteBatch - delete records with given uuids, records which are already missing are skipped
+func (repo *UserStoredDataRepository) deleteBatch(tx *bbolt.Tx, index *vaultIndex, uuids []string) error {
+	for _, recordUUID := range uuids {
+		current, err := repo.getRecord(tx, recordUUID)
+		if errors.Is(err, domain.ErrUserStoredDataNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := repo.deleteRecord(tx, index, current); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// syncUpdate - set server id and version of record, data and meta are replaced too if changed record is given
+func (repo *UserStoredDataRepository) syncUpdate(
+	tx *bbolt.Tx,
+	index *vaultIndex,
+	recordUUID string,
+	id int,
+	version int,
+	changed *domain.UserStoredData,
+) error {
+	current, err := repo.getRecord(tx, recordUUID)
+	if err != nil {
+		return notFound(err)
+	}
+
+	updatedData := *current
+	updatedData.ID = id
+	updatedData.Version = version
+	if changed != nil {
+		updatedData.CryptedData = changed.CryptedData
+		updatedData.Meta = changed.Meta
+		updatedData.UpdatedAt = time.Now().UTC()
+	}
+
+	return repo.putRecord(tx, index, current, &updatedData)
+}
+
+func (repo *UserStoredDataRepository) saveSyncBase(tx *bbolt.Tx, index *vaultIndex) error {
+	// bucket can not be changed while it is iterated, so records are collected first
+	dataSet, err := repo.allRecords(tx)
+	if err != nil {
+		return err
+	}
+
+	for idx := range dataSet {
+		if dataSet[idx].IsLocal() {
+			continue
+		}
+
+		dataSet[idx].Base = &domain.SyncBase{
+			CryptedData: dataSet[idx].CryptedData,
+			Meta:        dataSet[idx].Meta,
+		}
+
+		if err := repo.putRecord(tx, index, &dataSet[idx], &dataSet[idx]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// view - read vault with its index in one transaction
+func (repo *UserStoredDataRepository) view(fn func(tx *bbolt.Tx, index *vaultIndex) error) error {
+	return repo.db.View(func(tx *bbolt.Tx) error {
+		index, err := repo.loadIndex(tx)
+		if err != nil {
+			return err
+		}
+
+		return fn(tx, index)
+	})
+}
+
+// update - change vault in one transaction, index is sealed again after change
+func (repo *UserStoredDataRepository) update(fn func(tx *bbolt.Tx, index *vaultIndex) error) error {
+	return repo.db.Update(func(tx *bbolt.Tx) error {
+		index, err := repo.loadIndex(tx)
+		if err != nil {
+			return err
+		}
+
+		if err := fn(tx, index); err != nil {
+			return err
+		}
+
+		return repo.saveIndex(tx, index)
+	})
+}
+
+func (repo *UserStoredDataRepository) loadIndex(tx *bbolt.Tx) (*vaultIndex, error) {
+	index := newVaultIndex()
+
+	sealed := tx.Bucket(metaBucket).Get(indexKey)
+	if sealed == nil {
+		return index, nil
+	}
+
+	plain, err := repo.sealer.Open(sealed, indexKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(plain, index); err != nil {
+		return nil, err
+	}
+
+	return index, nil
+}
+
+func (repo *UserStoredDataRepository) saveIndex(tx *bbolt.Tx, index *vaultIndex) error {
+	plain, err := json.Marshal(index)
+	if err != nil {
+		return err
+	}
+
+	sealed, err := repo.sealer.Seal(plain, indexKey)
+	if err != nil {
+		return err
+	}
+
+	return tx.Bucket(metaBucket).Put(indexKey, sealed)
+}
+
+func (repo *UserStoredDataRepository) getRecord(tx *bbolt.Tx, recordUUID string) (*domain.UserStoredData, error) {
+	key := repo.recordKey(recordUUID)
+
+	value := tx.Bucket(recordsBucket).Get(key)
+	if value == nil {
+		return nil, domain.ErrUserStoredDataNotFound
+	}
+
+	return repo.openRecord(key, value)
+}
+
+func (repo *UserStoredDataRepository) allRecords(tx *bbolt.Tx) ([]domain.UserStoredData, error) {
+	dataSet := make([]domain.UserStoredData, 0)
+
+	err := tx.Bucket(recordsBucket).ForEach(func(key, value []byte) error {
+		data, err := repo.openRecord(key, value)
+		if err != nil {
+			return err
+		}
+
+		dataSet = append(dataSet, *data)
+		return nil
+	})
+
+	return dataSet, err
+}
+
+// openRecord - record is sealed with its key as additional data, so sealed records can not be swapped
+func (repo *UserStoredDataRepository) openRecord(key []byte, value []byte) (*domain.UserStoredData, error) {
+	plain, err := repo.sealer.Open(value, key)
+	if err != nil {
+		return nil, err
+	}
+
+	var data domain.UserStoredData
+	if err := json.Unmarshal(plain, &data); err != nil {
+		return nil, err
+	}
+
+	return &data, nil
+}
+
+// putRecord - save record and move its index entries, previous is nil for new record. Changed record
+// keeps its sequence number
+func (repo *UserStoredDataRepository) putRecord(tx *bbolt.Tx, index *vaultIndex, previous *domain.UserStoredData, data *domain.UserStoredData) error {
+	key := repo.recordKey(data.UUID)
+
+	var order uint64
+	if previous != nil {
+		order = index.remove(previous)
+	}
+
+	plain, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	value, err := repo.sealer.Seal(plain, key)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Bucket(recordsBucket).Put(key, value); err != nil {
+		return err
+	}
+
+	index.put(data, order)
+	return nil
+}
+
+func (repo *UserStoredDataRepository) deleteRecord(tx *bbolt.Tx, index *vaultIndex, data *domain.UserStoredData) error {
+	index.remove(data)
+
+	return tx.Bucket(recordsBucket).Delete(repo.recordKey(data.UUID))
+}
+
+func (repo *UserStoredDataRepository) recordKey(recordUUID string) []byte {
+	return repo.sealer.Blind([]byte("uuid:" + recordUUID))
+}
+
+// vaultIndex - index of records sealed as single value, so vault file tells neither how many records of
+// each type it has nor which of them are synced
+type vaultIndex struct {
+	// Sequence - last sequence number given to record, records of type are ordered by it
+	Sequence uint64 `json:"sequence"`
+	// Types - sequence numbers of records by data type and uuid
+	Types map[string]map[string]uint64 `json:"types"`
+	// IDs - uuids of synced records by server id
+	IDs map[int]string `json:"ids"`
+}
+
+func newVaultIndex() *vaultIndex {
+	return &vaultIndex{
+		Types: map[string]map[string]uint64{},
+		IDs:   map[int]string{},
+	}
+}
+
+// put - add index entries of record, record without sequence number (0) gets the next one
+func (index *vaultIndex) put(data *domain.UserStoredData, order uint64) {
+	if order == 0 {
+		index.Sequence++
+		order = index.Sequence
+	}
+
+	if index.Types[data.DataType] == nil {
+		index.Types[data.DataType] = map[string]uint64{}
+	}
+	index.Types[data.DataType][data.UUID] = order
+
+	if data.ID > 0 {
+		index.IDs[data.ID] = data.UUID
+	}
+}
+
+// remove - remove index entries of record and return its sequence number
+func (index *vaultIndex) remove(data *domain.UserStoredData) uint64 {
+	order := index.Types[data.DataType][data.UUID]
+
+	delete(index.Types[data.DataType], data.UUID)
+	if len(index.Types[data.DataType]) == 0 {
+		delete(index.Types, data.DataType)
+	}
+
+	if data.ID > 0 && index.IDs[data.ID] == data.UUID {
+		delete(index.IDs, data.ID)
+	}
+
+	return order
+}
+
+// uuidsWithPrefix - uuids of at most limit records, which start with prefix
+func (index *vaultIndex) uuidsWithPrefix(prefix string, limit int) []string {
+	uuids := make([]string, 0, limit)
+
+	for _, records := range index.Types {
+		for recordUUID := range records {
+			if !strings.HasPrefix(recordUUID, prefix) {
+				continue
+			}
+
+			uuids = append(uuids, recordUUID)
+			if len(uuids) == limit {
+				return uuids
+			}
+		}
+	}
+
+	return uuids
+}
+
+// notFound - keep not found error of record, which is expected by callers of changing methods
+func notFound(err error) error {
+	if errors.Is(err, domain.ErrUserStoredDataNotFound) {
+		return domain.ErrNotFound
+	}
+
+	return err
+}
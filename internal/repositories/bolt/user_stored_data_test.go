@@ -0,0 +1,389 @@
+package bolt
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/bbolt"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+	boltStorage "github.com/MowlCoder/goph-keeper/internal/storage/bolt"
+	"github.com/MowlCoder/goph-keeper/internal/utils/cryptor"
+)
+
+const (
+	testUUID1 = "0a1b2c3d-0000-4000-8000-000000000001"
+	testUUID2 = "0a1b2c3d-0000-4000-8000-000000000002"
+	testUUID3 = "7f000000-0000-4000-8000-000000000003"
+)
+
+func newTestDB(t *testing.T) *bbolt.DB {
+	t.Helper()
+
+	db, err := boltStorage.InitBoltStorage(filepath.Join(t.TempDir(), "vault.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
+
+func newTestSealer(t *testing.T) *cryptor.Sealer {
+	t.Helper()
+
+	sealer, err := cryptor.NewSealer("test-vault-key")
+	require.NoError(t, err)
+
+	return sealer
+}
+
+// countingSealer - sealer counting opened values
+type countingSealer struct {
+	vaultSealer
+	opened int
+}
+
+func (s *countingSealer) Open(sealed []byte, additionalData []byte) ([]byte, error) {
+	s.opened++
+	return s.vaultSealer.Open(sealed, additionalData)
+}
+
+func newTestRepository(t *testing.T) *UserStoredDataRepository {
+	t.Helper()
+
+	repo, err := NewUserStoredDataRepository(newTestDB(t), newTestSealer(t))
+	require.NoError(t, err)
+
+	return repo
+}
+
+func TestUserStoredDataRepository_CRUD(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+
+	require.NoError(t, repo.AddData(ctx, testUUID1, domain.TextDataType, []byte("text"), "note"))
+	assert.ErrorIs(t, repo.AddData(ctx, testUUID1, domain.TextDataType, []byte("text"), "note"), domain.ErrRecordUUIDTaken)
+
+	data, err := repo.GetByUUID(ctx, testUUID1)
+	require.NoError(t, err)
+	assert.Equal(t, testUUID1, data.UUID)
+	assert.Equal(t, domain.TextDataType, data.DataType)
+	assert.Equal(t, []byte("text"), data.CryptedData)
+	assert.Equal(t, "note", data.Meta)
+	assert.True(t, data.IsLocal())
+
+	updated, err := repo.UpdateByUUID(ctx, testUUID1, []byte("new text"), "new note")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("new text"), updated.CryptedData)
+	assert.Equal(t, data.CreatedAt, updated.CreatedAt)
+
+	data, err = repo.GetByUUID(ctx, testUUID1)
+	require.NoError(t, err)
+	assert.Equal(t, "new note", data.Meta)
+
+	require.NoError(t, repo.DeleteByUUID(ctx, testUUID1))
+
+	_, err = repo.GetByUUID(ctx, testUUID1)
+	assert.ErrorIs(t, err, domain.ErrUserStoredDataNotFound)
+
+	_, err = repo.UpdateByUUID(ctx, testUUID1, []byte("text"), "")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.ErrorIs(t, repo.DeleteByUUID(ctx, testUUID1), domain.ErrNotFound)
+	assert.ErrorIs(t, repo.SyncUpdate(ctx, testUUID1, 1, 1), domain.ErrNotFound)
+}
+
+func TestUserStoredDataRepository_TypeIndex(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+
+	require.NoError(t, repo.AddData(ctx, testUUID1, domain.TextDataType, []byte("first"), ""))
+	require.NoError(t, repo.AddData(ctx, testUUID2, domain.CardDataType, []byte("card"), ""))
+	require.NoError(t, repo.AddData(ctx, testUUID3, domain.TextDataType, []byte("second"), ""))
+
+	count, err := repo.CountUserDataOfType(ctx, domain.TextDataType)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	texts, err := repo.GetWithType(ctx, domain.TextDataType, &domain.StorageFilters{})
+	require.NoError(t, err)
+	require.Len(t, texts, 2)
+	assert.Equal(t, testUUID1, texts[0].UUID)
+	assert.Equal(t, testUUID3, texts[1].UUID)
+
+	newestFirst := &domain.StorageFilters{IsSortedByDate: true}
+	texts, err = repo.GetWithType(ctx, domain.TextDataType, newestFirst)
+	require.NoError(t, err)
+	require.Len(t, texts, 2)
+	assert.Equal(t, testUUID3, texts[0].UUID)
+
+	secondPage := &domain.StorageFilters{IsPaginated: true}
+	secondPage.Pagination.Page = 2
+	secondPage.Pagination.Count = 1
+	texts, err = repo.GetWithType(ctx, domain.TextDataType, secondPage)
+	require.NoError(t, err)
+	require.Len(t, texts, 1)
+	assert.Equal(t, testUUID3, texts[0].UUID)
+
+	secondPage.Pagination.Page = 3
+	texts, err = repo.GetWithType(ctx, domain.TextDataType, secondPage)
+	require.NoError(t, err)
+	assert.Empty(t, texts)
+
+	// changed record stays in index of its type, deleted one leaves it
+	_, err = repo.UpdateByUUID(ctx, testUUID1, []byte("changed"), "")
+	require.NoError(t, err)
+	require.NoError(t, repo.DeleteByUUID(ctx, testUUID3))
+
+	texts, err = repo.GetWithType(ctx, domain.TextDataType, &domain.StorageFilters{})
+	require.NoError(t, err)
+	require.Len(t, texts, 1)
+	assert.Equal(t, []byte("changed"), texts[0].CryptedData)
+
+	count, err = repo.CountUserDataOfType(ctx, domain.TextDataType)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	texts, err = repo.GetWithType(ctx, "unknown", &domain.StorageFilters{})
+	require.NoError(t, err)
+	assert.Empty(t, texts)
+}
+
+func TestUserStoredDataRepository_IDIndex(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+
+	require.NoError(t, repo.AddData(ctx, testUUID1, domain.TextDataType, []byte("text"), ""))
+
+	_, err := repo.GetByID(ctx, 5)
+	assert.ErrorIs(t, err, domain.ErrUserStoredDataNotFound)
+
+	require.NoError(t, repo.SyncUpdate(ctx, testUUID1, 5, 1))
+
+	data, err := repo.GetByID(ctx, 5)
+	require.NoError(t, err)
+	assert.Equal(t, testUUID1, data.UUID)
+	assert.Equal(t, 1, data.Version)
+	assert.False(t, data.IsLocal())
+
+	require.NoError(t, repo.DeleteBatch(ctx, []string{testUUID1, testUUID2}))
+
+	_, err = repo.GetByID(ctx, 5)
+	assert.ErrorIs(t, err, domain.ErrUserStoredDataNotFound)
+}
+
+func TestUserStoredDataRepository_FindByRef(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+
+	require.NoError(t, repo.AddData(ctx, testUUID1, domain.TextDataType, []byte("text"), ""))
+	require.NoError(t, repo.AddData(ctx, testUUID2, domain.TextDataType, []byte("text"), ""))
+	require.NoError(t, repo.AddData(ctx, testUUID3, domain.CardDataType, []byte("card"), ""))
+
+	testCases := []struct {
+		name     string
+		ref      string
+		expected string
+		err      error
+	}{
+		{name: "full uuid", ref: testUUID2, expected: testUUID2},
+		{name: "unique prefix", ref: "7f00", expected: testUUID3},
+		{name: "longer unique prefix", ref: "0a1b2c3d-0000-4000-8000-000000000001", expected: testUUID1},
+		{name: "ambiguous prefix", ref: "0a1b2c3d", err: domain.ErrAmbiguousRecordRef},
+		{name: "unknown prefix", ref: "ffff", err: domain.ErrUserStoredDataNotFound},
+		{name: "too short prefix", ref: "0a1", err: domain.ErrInvalidRecordRef},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			data, err := repo.FindByRef(ctx, testCase.ref)
+			assert.ErrorIs(t, err, testCase.err)
+			if testCase.err == nil {
+				assert.Equal(t, testCase.expected, data.UUID)
+			}
+		})
+	}
+}
+
+func TestUserStoredDataRepository_SaveSyncBase(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+
+	require.NoError(t, repo.AddData(ctx, testUUID1, domain.TextDataType, []byte("synced"), "meta"))
+	require.NoError(t, repo.AddData(ctx, testUUID2, domain.TextDataType, []byte("local"), ""))
+	require.NoError(t, repo.SyncUpdate(ctx, testUUID1, 1, 1))
+
+	require.NoError(t, repo.SaveSyncBase(ctx))
+
+	synced, err := repo.GetByUUID(ctx, testUUID1)
+	require.NoError(t, err)
+	require.NotNil(t, synced.Base)
+	assert.Equal(t, []byte("synced"), synced.Base.CryptedData)
+	assert.Equal(t, "meta", synced.Base.Meta)
+
+	local, err := repo.GetByUUID(ctx, testUUID2)
+	require.NoError(t, err)
+	assert.Nil(t, local.Base)
+}
+
+func TestUserStoredDataRepository_ApplySync(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+
+	require.NoError(t, repo.AddData(ctx, testUUID1, domain.TextDataType, []byte("pushed"), ""))
+	require.NoError(t, repo.AddData(ctx, testUUID2, domain.TextDataType, []byte("deleted"), ""))
+	require.NoError(t, repo.SyncUpdate(ctx, testUUID2, 2, 1))
+
+	changes := domain.LocalSyncChanges{
+		Synced:  []domain.SyncPushResult{{UUID: testUUID1, ID: 1, Version: 1}},
+		Deleted: []string{testUUID2},
+		Added: []domain.UserStoredData{
+			{UUID: testUUID3, ID: 3, Version: 2, DataType: domain.CardDataType, CryptedData: []byte("card")},
+		},
+		Copies: []domain.UserStoredData{
+			{UUID: testUUID2, DataType: domain.TextDataType, CryptedData: []byte("copy"), Meta: "copy"},
+		},
+	}
+
+	// copy takes uuid of record deleted in the same sync, so failed copy must roll back the whole sync
+	failed := changes
+	failed.Copies = []domain.UserStoredData{{UUID: testUUID1, DataType: domain.TextDataType}}
+	assert.ErrorIs(t, repo.ApplySync(ctx, failed), domain.ErrRecordUUIDTaken)
+
+	pushed, err := repo.GetByUUID(ctx, testUUID1)
+	require.NoError(t, err)
+	assert.True(t, pushed.IsLocal())
+	_, err = repo.GetByUUID(ctx, testUUID2)
+	require.NoError(t, err)
+	_, err = repo.GetByUUID(ctx, testUUID3)
+	assert.ErrorIs(t, err, domain.ErrUserStoredDataNotFound)
+
+	require.NoError(t, repo.ApplySync(ctx, changes))
+
+	pushed, err = repo.GetByUUID(ctx, testUUID1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, pushed.Version)
+	require.NotNil(t, pushed.Base)
+
+	added, err := repo.GetByID(ctx, 3)
+	require.NoError(t, err)
+	assert.Equal(t, testUUID3, added.UUID)
+	assert.Equal(t, []byte("card"), added.CryptedData)
+	require.NotNil(t, added.Base)
+
+	copied, err := repo.GetByUUID(ctx, testUUID2)
+	require.NoError(t, err)
+	assert.True(t, copied.IsLocal())
+	assert.Nil(t, copied.Base)
+	assert.Equal(t, []byte("copy"), copied.CryptedData)
+}
+
+func TestUserStoredDataRepository_DecryptsOnlyFoundRecords(t *testing.T) {
+	ctx := context.Background()
+	sealer := &countingSealer{vaultSealer: newTestSealer(t)}
+
+	repo, err := NewUserStoredDataRepository(newTestDB(t), sealer)
+	require.NoError(t, err)
+
+	for idx := 0; idx < 50; idx++ {
+		recordUUID := fmt.Sprintf("%08x-0000-4000-8000-000000000000", idx<<16)
+		require.NoError(t, repo.AddData(ctx, recordUUID, domain.TextDataType, []byte("text"), strconv.Itoa(idx)))
+	}
+
+	// index is opened with found records
+	sealer.opened = 0
+	data, err := repo.FindByRef(ctx, "0005")
+	require.NoError(t, err)
+	assert.Equal(t, "5", data.Meta)
+	assert.Equal(t, 2, sealer.opened)
+
+	page := &domain.StorageFilters{IsPaginated: true, IsSortedByDate: true}
+	page.Pagination.Page = 2
+	page.Pagination.Count = 10
+
+	sealer.opened = 0
+	dataSet, err := repo.GetWithType(ctx, domain.TextDataType, page)
+	require.NoError(t, err)
+	require.Len(t, dataSet, 10)
+	assert.Equal(t, "39", dataSet[0].Meta)
+	assert.Equal(t, "30", dataSet[9].Meta)
+	assert.Equal(t, 11, sealer.opened)
+}
+
+func TestUserStoredDataRepository_RebuildsIndexes(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	sealer := newTestSealer(t)
+
+	repo, err := NewUserStoredDataRepository(db, sealer)
+	require.NoError(t, err)
+
+	require.NoError(t, repo.AddData(ctx, testUUID3, domain.TextDataType, []byte("first"), ""))
+	require.NoError(t, repo.AddData(ctx, testUUID1, domain.TextDataType, []byte("second"), ""))
+	require.NoError(t, repo.SyncUpdate(ctx, testUUID1, 5, 1))
+
+	// vault written by version keeping indexes in buckets per data type
+	require.NoError(t, db.Update(func(tx *bbolt.Tx) error {
+		if _, err := tx.CreateBucket(legacyIndexBuckets[0]); err != nil {
+			return err
+		}
+		if err := tx.Bucket(metaBucket).Delete(indexKey); err != nil {
+			return err
+		}
+
+		return tx.Bucket(metaBucket).Put(indexVersionKey, []byte("2"))
+	}))
+
+	repo, err = NewUserStoredDataRepository(db, sealer)
+	require.NoError(t, err)
+
+	require.NoError(t, db.View(func(tx *bbolt.Tx) error {
+		assert.Nil(t, tx.Bucket(legacyIndexBuckets[0]))
+		return nil
+	}))
+
+	data, err := repo.FindByRef(ctx, "0a1b")
+	require.NoError(t, err)
+	assert.Equal(t, testUUID1, data.UUID)
+
+	data, err = repo.GetByID(ctx, 5)
+	require.NoError(t, err)
+	assert.Equal(t, testUUID1, data.UUID)
+
+	texts, err := repo.GetWithType(ctx, domain.TextDataType, &domain.StorageFilters{})
+	require.NoError(t, err)
+	require.Len(t, texts, 2)
+	assert.Equal(t, testUUID3, texts[0].UUID)
+	assert.Equal(t, testUUID1, texts[1].UUID)
+}
+
+func TestUserStoredDataRepository_OnlySealedValues(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+
+	repo, err := NewUserStoredDataRepository(db, newTestSealer(t))
+	require.NoError(t, err)
+
+	require.NoError(t, repo.AddData(ctx, testUUID1, domain.TextDataType, []byte("text"), ""))
+	require.NoError(t, repo.AddData(ctx, testUUID2, domain.CardDataType, []byte("card"), ""))
+	require.NoError(t, repo.SyncUpdate(ctx, testUUID1, 5, 1))
+
+	// neither count of records of each type nor synced records can be seen without vault key
+	require.NoError(t, db.View(func(tx *bbolt.Tx) error {
+		buckets := make([]string, 0)
+		err := tx.ForEach(func(name []byte, bucket *bbolt.Bucket) error {
+			buckets = append(buckets, string(name))
+
+			return bucket.ForEach(func(key, value []byte) error {
+				assert.NotNil(t, value, "nested bucket %x in %s", key, name)
+				return nil
+			})
+		})
+		assert.ElementsMatch(t, []string{string(metaBucket), string(recordsBucket)}, buckets)
+
+		return err
+	}))
+}
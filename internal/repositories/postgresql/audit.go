@@ -0,0 +1,78 @@
+package postgresql
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+)
+
+type AuditRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewAuditRepository(pool *pgxpool.Pool) *AuditRepository {
+	return &AuditRepository{
+		pool: pool,
+	}
+}
+
+func (repo *AuditRepository) Create(ctx context.Context, event domain.AuditEvent) error {
+	query := `
+		INSERT INTO audit_events (user_id, event_type, record_id, client_ip, user_agent)
+		VALUES (NULLIF($1, 0), $2, NULLIF($3, 0), $4, $5)
+	`
+
+	_, err := repo.pool.Exec(
+		ctx,
+		query,
+		event.UserID, event.Type, event.RecordID, event.ClientIP, event.UserAgent,
+	)
+
+	return err
+}
+
+func (repo *AuditRepository) GetUserEvents(ctx context.Context, userID int, filters *domain.StorageFilters) ([]domain.AuditEvent, error) {
+	baseQuery := `
+		SELECT id, user_id, event_type, COALESCE(record_id, 0), client_ip, user_agent, created_at FROM audit_events
+		WHERE user_id = $1
+	`
+
+	rows, err := repo.pool.Query(ctx, filters.BuildSQL(baseQuery), userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make([]domain.AuditEvent, 0)
+	for rows.Next() {
+		var event domain.AuditEvent
+
+		if err := rows.Scan(
+			&event.ID,
+			&event.UserID,
+			&event.Type,
+			&event.RecordID,
+			&event.ClientIP,
+			&event.UserAgent,
+			&event.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+func (repo *AuditRepository) CountUserEvents(ctx context.Context, userID int) (int, error) {
+	var count int
+	err := repo.pool.QueryRow(ctx, `SELECT COUNT(id) FROM audit_events WHERE user_id = $1`, userID).Scan(&count)
+	if err != nil {
+		return -1, err
+	}
+
+	return count, nil
+}
@@ -0,0 +1,135 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+)
+
+type DeviceRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewDeviceRepository(pool *pgxpool.Pool) *DeviceRepository {
+	return &DeviceRepository{
+		pool: pool,
+	}
+}
+
+const deviceSelect = `
+	SELECT id, user_id, name, platform, sync_cursor, created_at, last_seen_at
+	FROM devices
+`
+
+func (repo *DeviceRepository) Create(ctx context.Context, userID int, name string, platform string) (*domain.Device, error) {
+	query := `
+		INSERT INTO devices (user_id, name, platform)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at, last_seen_at
+	`
+
+	device := domain.Device{
+		UserID:   userID,
+		Name:     name,
+		Platform: platform,
+	}
+
+	err := repo.pool.QueryRow(ctx, query, userID, name, platform).Scan(&device.ID, &device.CreatedAt, &device.LastSeenAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &device, nil
+}
+
+func (repo *DeviceRepository) GetByID(ctx context.Context, id int) (*domain.Device, error) {
+	device, err := scanDevice(repo.pool.QueryRow(ctx, deviceSelect+`WHERE id = $1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrDeviceNotFound
+		}
+
+		return nil, err
+	}
+
+	return device, nil
+}
+
+func (repo *DeviceRepository) GetByUser(ctx context.Context, userID int) ([]domain.Device, error) {
+	rows, err := repo.pool.Query(ctx, deviceSelect+`WHERE user_id = $1 ORDER BY last_seen_at DESC`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	devices := make([]domain.Device, 0)
+	for rows.Next() {
+		device, err := scanDevice(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		devices = append(devices, *device)
+	}
+
+	return devices, rows.Err()
+}
+
+func (repo *DeviceRepository) UpdateLastSeen(ctx context.Context, id int) error {
+	_, err := repo.pool.Exec(ctx, `UPDATE devices SET last_seen_at = NOW() WHERE id = $1`, id)
+	return err
+}
+
+// UpdateSyncCursor - cursor is set as is, not only moved forward, because client which lost its local
+// store starts again from the beginning
+func (repo *DeviceRepository) UpdateSyncCursor(ctx context.Context, userID int, id int, cursor int64) error {
+	result, err := repo.pool.Exec(
+		ctx,
+		`UPDATE devices SET sync_cursor = $1, last_seen_at = NOW() WHERE id = $2 AND user_id = $3`,
+		cursor, id, userID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrDeviceNotFound
+	}
+
+	return nil
+}
+
+func (repo *DeviceRepository) Delete(ctx context.Context, userID int, id int) error {
+	result, err := repo.pool.Exec(ctx, `DELETE FROM devices WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrDeviceNotFound
+	}
+
+	return nil
+}
+
+func scanDevice(row pgx.Row) (*domain.Device, error) {
+	var device domain.Device
+
+	if err := row.Scan(
+		&device.ID,
+		&device.UserID,
+		&device.Name,
+		&device.Platform,
+		&device.SyncCursor,
+		&device.CreatedAt,
+		&device.LastSeenAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &device, nil
+}
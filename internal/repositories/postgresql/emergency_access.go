@@ -0,0 +1,178 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+	"github.com/MowlCoder/goph-keeper/internal/storage/postgresql"
+)
+
+const emergencyAccessSelect = `
+	SELECT a.id, a.grantor_id, g.email, a.grantee_id, t.email, a.status, a.wait_days, a.recovery_initiated_at, a.created_at
+	FROM emergency_access a
+	JOIN users g ON g.id = a.grantor_id
+	JOIN users t ON t.id = a.grantee_id
+`
+
+type EmergencyAccessRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewEmergencyAccessRepository(pool *pgxpool.Pool) *EmergencyAccessRepository {
+	return &EmergencyAccessRepository{
+		pool: pool,
+	}
+}
+
+func (repo *EmergencyAccessRepository) Create(ctx context.Context, grantorID int, granteeID int, waitDays int) (*domain.EmergencyAccess, error) {
+	query := `
+		INSERT INTO emergency_access (grantor_id, grantee_id, status, wait_days)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+
+	access := domain.EmergencyAccess{
+		GrantorID: grantorID,
+		GranteeID: granteeID,
+		Status:    domain.EmergencyAccessInvited,
+		WaitDays:  waitDays,
+	}
+
+	err := repo.pool.QueryRow(
+		ctx,
+		query,
+		grantorID, granteeID, domain.EmergencyAccessInvited, waitDays,
+	).Scan(&access.ID, &access.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+
+		if errors.As(err, &pgErr) && pgErr.Code == postgresql.PgUniqueIndexErrorCode {
+			return nil, domain.ErrEmergencyAccessAlreadyExists
+		}
+
+		return nil, err
+	}
+
+	return &access, nil
+}
+
+func (repo *EmergencyAccessRepository) GetByID(ctx context.Context, id int) (*domain.EmergencyAccess, error) {
+	access, err := scanEmergencyAccess(repo.pool.QueryRow(ctx, emergencyAccessSelect+`WHERE a.id = $1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrEmergencyAccessNotFound
+		}
+
+		return nil, err
+	}
+
+	return access, nil
+}
+
+func (repo *EmergencyAccessRepository) GetByGrantor(ctx context.Context, grantorID int) ([]domain.EmergencyAccess, error) {
+	return repo.getMany(ctx, emergencyAccessSelect+`WHERE a.grantor_id = $1 ORDER BY a.created_at`, grantorID)
+}
+
+func (repo *EmergencyAccessRepository) GetByGrantee(ctx context.Context, granteeID int) ([]domain.EmergencyAccess, error) {
+	return repo.getMany(ctx, emergencyAccessSelect+`WHERE a.grantee_id = $1 ORDER BY a.created_at`, granteeID)
+}
+
+// UpdateStatus - move access to new status only if it is still in expected one, so concurrent
+// actions (e.g. reject from grantor and approve from worker) can not both succeed
+func (repo *EmergencyAccessRepository) UpdateStatus(ctx context.Context, id int, fromStatus string, toStatus string) error {
+	query := `
+		UPDATE emergency_access
+		SET status = $1,
+			recovery_initiated_at = CASE WHEN $4::BOOLEAN THEN NOW() ELSE recovery_initiated_at END,
+			updated_at = NOW()
+		WHERE id = $2 AND status = $3
+	`
+
+	isRecoveryInitiated := toStatus == domain.EmergencyAccessRecoveryInitiated
+
+	result, err := repo.pool.Exec(ctx, query, toStatus, id, fromStatus, isRecoveryInitiated)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrEmergencyAccessInvalidStatus
+	}
+
+	return nil
+}
+
+// ApproveExpired - approve all recoveries which waiting period is over, database time is used
+// because recovery_initiated_at is also set by database
+func (repo *EmergencyAccessRepository) ApproveExpired(ctx context.Context) (int64, error) {
+	query := `
+		UPDATE emergency_access
+		SET status = $1, updated_at = NOW()
+		WHERE status = $2 AND recovery_initiated_at + wait_days * INTERVAL '1 day' <= NOW()
+	`
+
+	result, err := repo.pool.Exec(ctx, query, domain.EmergencyAccessApproved, domain.EmergencyAccessRecoveryInitiated)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected(), nil
+}
+
+func (repo *EmergencyAccessRepository) DeleteByID(ctx context.Context, id int) error {
+	result, err := repo.pool.Exec(ctx, `DELETE FROM emergency_access WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrEmergencyAccessNotFound
+	}
+
+	return nil
+}
+
+func (repo *EmergencyAccessRepository) getMany(ctx context.Context, query string, args ...any) ([]domain.EmergencyAccess, error) {
+	rows, err := repo.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	accesses := make([]domain.EmergencyAccess, 0)
+	for rows.Next() {
+		access, err := scanEmergencyAccess(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		accesses = append(accesses, *access)
+	}
+
+	return accesses, rows.Err()
+}
+
+func scanEmergencyAccess(row pgx.Row) (*domain.EmergencyAccess, error) {
+	var access domain.EmergencyAccess
+
+	if err := row.Scan(
+		&access.ID,
+		&access.GrantorID,
+		&access.GrantorEmail,
+		&access.GranteeID,
+		&access.GranteeEmail,
+		&access.Status,
+		&access.WaitDays,
+		&access.RecoveryInitiatedAt,
+		&access.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &access, nil
+}
@@ -0,0 +1,285 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+	"github.com/MowlCoder/goph-keeper/internal/storage/postgresql"
+)
+
+type OrganizationRepository struct {
+	pool   *pgxpool.Pool
+	logger *slog.Logger
+}
+
+func NewOrganizationRepository(pool *pgxpool.Pool, logger *slog.Logger) *OrganizationRepository {
+	return &OrganizationRepository{
+		pool:   pool,
+		logger: logger,
+	}
+}
+
+func (repo *OrganizationRepository) Create(ctx context.Context, ownerID int, name string) (*domain.Organization, error) {
+	tx, err := repo.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rollback(ctx, tx, repo.logger)
+
+	organization := domain.Organization{
+		Name: name,
+	}
+
+	err = tx.QueryRow(
+		ctx,
+		`INSERT INTO organizations (name) VALUES ($1) RETURNING id, created_at`,
+		name,
+	).Scan(&organization.ID, &organization.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`INSERT INTO organization_members (organization_id, user_id, role, status) VALUES ($1, $2, $3, $4)`,
+		organization.ID, ownerID, domain.OrganizationOwnerRole, domain.OrganizationMemberAccepted,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return &organization, nil
+}
+
+func (repo *OrganizationRepository) GetUserOrganizations(ctx context.Context, userID int) ([]domain.OrganizationMembership, error) {
+	query := `
+		SELECT o.id, o.name, o.created_at, m.role, m.status
+		FROM organization_members m
+		JOIN organizations o ON o.id = m.organization_id
+		WHERE m.user_id = $1
+		ORDER BY o.created_at
+	`
+
+	rows, err := repo.pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	memberships := make([]domain.OrganizationMembership, 0)
+	for rows.Next() {
+		var membership domain.OrganizationMembership
+		if err := rows.Scan(
+			&membership.Organization.ID,
+			&membership.Organization.Name,
+			&membership.Organization.CreatedAt,
+			&membership.Role,
+			&membership.Status,
+		); err != nil {
+			return nil, err
+		}
+
+		memberships = append(memberships, membership)
+	}
+
+	return memberships, rows.Err()
+}
+
+func (repo *OrganizationRepository) GetMember(ctx context.Context, organizationID int, userID int) (*domain.OrganizationMember, error) {
+	query := `
+		SELECT m.id, m.organization_id, m.user_id, u.email, m.role, m.status, m.created_at
+		FROM organization_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.organization_id = $1 AND m.user_id = $2
+	`
+
+	member, err := scanOrganizationMember(repo.pool.QueryRow(ctx, query, organizationID, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrOrganizationNotFound
+		}
+
+		return nil, err
+	}
+
+	return member, nil
+}
+
+func (repo *OrganizationRepository) GetCollectionMember(ctx context.Context, collectionID int, userID int) (*domain.OrganizationMember, error) {
+	query := `
+		SELECT m.id, m.organization_id, m.user_id, u.email, m.role, m.status, m.created_at
+		FROM collections c
+		JOIN organization_members m ON m.organization_id = c.organization_id
+		JOIN users u ON u.id = m.user_id
+		WHERE c.id = $1 AND m.user_id = $2
+	`
+
+	member, err := scanOrganizationMember(repo.pool.QueryRow(ctx, query, collectionID, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrCollectionNotFound
+		}
+
+		return nil, err
+	}
+
+	return member, nil
+}
+
+func (repo *OrganizationRepository) GetMembers(ctx context.Context, organizationID int) ([]domain.OrganizationMember, error) {
+	query := `
+		SELECT m.id, m.organization_id, m.user_id, u.email, m.role, m.status, m.created_at
+		FROM organization_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.organization_id = $1
+		ORDER BY m.created_at
+	`
+
+	rows, err := repo.pool.Query(ctx, query, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	members := make([]domain.OrganizationMember, 0)
+	for rows.Next() {
+		member, err := scanOrganizationMember(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		members = append(members, *member)
+	}
+
+	return members, rows.Err()
+}
+
+func (repo *OrganizationRepository) AddMember(ctx context.Context, organizationID int, userID int, role string, status string) (*domain.OrganizationMember, error) {
+	query := `
+		INSERT INTO organization_members (organization_id, user_id, role, status)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+
+	member := domain.OrganizationMember{
+		OrganizationID: organizationID,
+		UserID:         userID,
+		Role:           role,
+		Status:         status,
+	}
+
+	err := repo.pool.QueryRow(
+		ctx,
+		query,
+		organizationID, userID, role, status,
+	).Scan(&member.ID, &member.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+
+		if errors.As(err, &pgErr) && pgErr.Code == postgresql.PgUniqueIndexErrorCode {
+			return nil, domain.ErrAlreadyOrganizationMember
+		}
+
+		return nil, err
+	}
+
+	return &member, nil
+}
+
+func (repo *OrganizationRepository) AcceptInvite(ctx context.Context, organizationID int, userID int) error {
+	query := `
+		UPDATE organization_members
+		SET status = $1
+		WHERE organization_id = $2 AND user_id = $3 AND status = $4
+	`
+
+	result, err := repo.pool.Exec(
+		ctx,
+		query,
+		domain.OrganizationMemberAccepted, organizationID, userID, domain.OrganizationMemberInvited,
+	)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrOrganizationInviteNotFound
+	}
+
+	return nil
+}
+
+func (repo *OrganizationRepository) CreateCollection(ctx context.Context, organizationID int, name string) (*domain.Collection, error) {
+	query := `
+		INSERT INTO collections (organization_id, name)
+		VALUES ($1, $2)
+		RETURNING id, created_at
+	`
+
+	collection := domain.Collection{
+		OrganizationID: organizationID,
+		Name:           name,
+	}
+
+	err := repo.pool.QueryRow(ctx, query, organizationID, name).Scan(&collection.ID, &collection.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &collection, nil
+}
+
+func (repo *OrganizationRepository) GetCollections(ctx context.Context, organizationID int) ([]domain.Collection, error) {
+	query := `
+		SELECT id, organization_id, name, created_at
+		FROM collections
+		WHERE organization_id = $1
+		ORDER BY created_at
+	`
+
+	rows, err := repo.pool.Query(ctx, query, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	collections := make([]domain.Collection, 0)
+	for rows.Next() {
+		var collection domain.Collection
+		if err := rows.Scan(&collection.ID, &collection.OrganizationID, &collection.Name, &collection.CreatedAt); err != nil {
+			return nil, err
+		}
+
+		collections = append(collections, collection)
+	}
+
+	return collections, rows.Err()
+}
+
+func scanOrganizationMember(row pgx.Row) (*domain.OrganizationMember, error) {
+	var member domain.OrganizationMember
+
+	if err := row.Scan(
+		&member.ID,
+		&member.OrganizationID,
+		&member.UserID,
+		&member.Email,
+		&member.Role,
+		&member.Status,
+		&member.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &member, nil
+}
@@ -0,0 +1,209 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+)
+
+type ShareRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewShareRepository(pool *pgxpool.Pool) *ShareRepository {
+	return &ShareRepository{
+		pool: pool,
+	}
+}
+
+func (repo *ShareRepository) Create(ctx context.Context, dataID int, ownerID int, recipientID int, permission string) (*domain.Share, error) {
+	query := `
+		INSERT INTO user_stored_data_shares (data_id, owner_id, recipient_id, permission)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (data_id, recipient_id) DO UPDATE SET permission = EXCLUDED.permission
+		RETURNING id, created_at, (SELECT d.uuid FROM user_stored_data d WHERE d.id = user_stored_data_shares.data_id)
+	`
+
+	share := domain.Share{
+		DataID:      dataID,
+		OwnerID:     ownerID,
+		RecipientID: recipientID,
+		Permission:  permission,
+	}
+
+	err := repo.pool.QueryRow(
+		ctx,
+		query,
+		dataID, ownerID, recipientID, permission,
+	).Scan(&share.ID, &share.CreatedAt, &share.DataUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &share, nil
+}
+
+func (repo *ShareRepository) GetByID(ctx context.Context, id int) (*domain.Share, error) {
+	query := `
+		SELECT s.id, s.data_id, d.uuid, s.owner_id, o.email, s.recipient_id, r.email, s.permission, s.created_at
+		FROM user_stored_data_shares s
+		JOIN users o ON o.id = s.owner_id
+		JOIN users r ON r.id = s.recipient_id
+		JOIN user_stored_data d ON d.id = s.data_id
+		WHERE s.id = $1
+	`
+
+	share, err := scanShare(repo.pool.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrShareNotFound
+		}
+
+		return nil, err
+	}
+
+	return share, nil
+}
+
+func (repo *ShareRepository) GetByDataAndRecipient(ctx context.Context, dataID int, recipientID int) (*domain.Share, error) {
+	query := `
+		SELECT s.id, s.data_id, d.uuid, s.owner_id, o.email, s.recipient_id, r.email, s.permission, s.created_at
+		FROM user_stored_data_shares s
+		JOIN users o ON o.id = s.owner_id
+		JOIN users r ON r.id = s.recipient_id
+		JOIN user_stored_data d ON d.id = s.data_id
+		WHERE s.data_id = $1 AND s.recipient_id = $2
+	`
+
+	share, err := scanShare(repo.pool.QueryRow(ctx, query, dataID, recipientID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrShareNotFound
+		}
+
+		return nil, err
+	}
+
+	return share, nil
+}
+
+func (repo *ShareRepository) GetSharedByUser(ctx context.Context, ownerID int) ([]domain.Share, error) {
+	query := `
+		SELECT s.id, s.data_id, d.uuid, s.owner_id, o.email, s.recipient_id, r.email, s.permission, s.created_at
+		FROM user_stored_data_shares s
+		JOIN users o ON o.id = s.owner_id
+		JOIN users r ON r.id = s.recipient_id
+		JOIN user_stored_data d ON d.id = s.data_id
+		WHERE s.owner_id = $1
+		ORDER BY s.created_at DESC
+	`
+
+	rows, err := repo.pool.Query(ctx, query, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	shares := make([]domain.Share, 0)
+	for rows.Next() {
+		share, err := scanShare(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		shares = append(shares, *share)
+	}
+
+	return shares, rows.Err()
+}
+
+func (repo *ShareRepository) GetSharedWithUser(ctx context.Context, recipientID int) ([]domain.SharedUserStoredData, error) {
+	query := `
+		SELECT s.id, s.data_id, d.uuid, s.owner_id, o.email, s.recipient_id, r.email, s.permission, s.created_at,
+			d.id, d.uuid, d.user_id, d.data_type, d.data, d.meta, d.version, d.created_at
+		FROM user_stored_data_shares s
+		JOIN users o ON o.id = s.owner_id
+		JOIN users r ON r.id = s.recipient_id
+		JOIN user_stored_data d ON d.id = s.data_id
+		WHERE s.recipient_id = $1
+		ORDER BY s.created_at DESC
+	`
+
+	rows, err := repo.pool.Query(ctx, query, recipientID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	dataSet := make([]domain.SharedUserStoredData, 0)
+	for rows.Next() {
+		var shared domain.SharedUserStoredData
+		if err := rows.Scan(
+			&shared.Share.ID,
+			&shared.Share.DataID,
+			&shared.Share.DataUUID,
+			&shared.Share.OwnerID,
+			&shared.Share.OwnerEmail,
+			&shared.Share.RecipientID,
+			&shared.Share.RecipientEmail,
+			&shared.Share.Permission,
+			&shared.Share.CreatedAt,
+			&shared.Data.ID,
+			&shared.Data.UUID,
+			&shared.Data.UserID,
+			&shared.Data.DataType,
+			&shared.Data.CryptedData,
+			&shared.Data.Meta,
+			&shared.Data.Version,
+			&shared.Data.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+
+		dataSet = append(dataSet, shared)
+	}
+
+	return dataSet, rows.Err()
+}
+
+func (repo *ShareRepository) DeleteByID(ctx context.Context, id int) error {
+	query := `
+		DELETE FROM user_stored_data_shares
+		WHERE id = $1
+	`
+
+	result, err := repo.pool.Exec(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrShareNotFound
+	}
+
+	return nil
+}
+
+func scanShare(row pgx.Row) (*domain.Share, error) {
+	var share domain.Share
+
+	if err := row.Scan(
+		&share.ID,
+		&share.DataID,
+		&share.DataUUID,
+		&share.OwnerID,
+		&share.OwnerEmail,
+		&share.RecipientID,
+		&share.RecipientEmail,
+		&share.Permission,
+		&share.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &share, nil
+}
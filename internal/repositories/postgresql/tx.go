@@ -0,0 +1,17 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// rollback - roll back transaction, which was not committed. Caller already returns error, which made it
+// stop before commit, so failure of rollback is only logged
+func rollback(ctx context.Context, tx pgx.Tx, logger *slog.Logger) {
+	if err := tx.Rollback(context.WithoutCancel(ctx)); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+		logger.ErrorContext(ctx, "failed to roll back transaction", "error", err.Error())
+	}
+}
@@ -2,48 +2,90 @@ package postgresql
 
 import (
 	"context"
+	"errors"
+	"log/slog"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/MowlCoder/goph-keeper/internal/domain"
+	"github.com/MowlCoder/goph-keeper/internal/storage/postgresql"
+	"github.com/MowlCoder/goph-keeper/internal/tracing"
 )
 
 type UserStoredDataRepository struct {
-	pool *pgxpool.Pool
+	pool   *pgxpool.Pool
+	logger *slog.Logger
 }
 
-func NewUserStoredDataRepository(pool *pgxpool.Pool) *UserStoredDataRepository {
+func NewUserStoredDataRepository(pool *pgxpool.Pool, logger *slog.Logger) *UserStoredDataRepository {
 	return &UserStoredDataRepository{
-		pool: pool,
+		pool:   pool,
+		logger: logger,
 	}
 }
 
 func (repo *UserStoredDataRepository) GetByID(ctx context.Context, id int) (*domain.UserStoredData, error) {
+	ctx, span := tracing.Start(ctx, "UserStoredDataRepository.GetByID")
+	defer span.End()
+
 	query := `
-		SELECT id, user_id, data_type, data, meta, version, created_at FROM user_stored_data
-		WHERE id = $1 
+		SELECT id, uuid, user_id, COALESCE(collection_id, 0), data_type, data, meta, version, created_at, updated_at FROM user_stored_data
+		WHERE id = $1
 	`
 
-	var userData domain.UserStoredData
-	if err := repo.pool.QueryRow(ctx, query, id).Scan(
-		&userData.ID,
-		&userData.UserID,
-		&userData.DataType,
-		&userData.CryptedData,
-		&userData.Meta,
-		&userData.Version,
-		&userData.CreatedAt,
-	); err != nil {
+	return scanUserStoredData(repo.pool.QueryRow(ctx, query, id))
+}
+
+func (repo *UserStoredDataRepository) GetByUUID(ctx context.Context, recordUUID string) (*domain.UserStoredData, error) {
+	ctx, span := tracing.Start(ctx, "UserStoredDataRepository.GetByUUID")
+	defer span.End()
+
+	query := `
+		SELECT id, uuid, user_id, COALESCE(collection_id, 0), data_type, data, meta, version, created_at, updated_at FROM user_stored_data
+		WHERE uuid = $1
+	`
+
+	return scanUserStoredData(repo.pool.QueryRow(ctx, query, recordUUID))
+}
+
+// FindIDsByUUIDPrefix - ids of at most limit records, which user can access as owner, share recipient or
+// organization member and whose uuid starts with prefix
+func (repo *UserStoredDataRepository) FindIDsByUUIDPrefix(ctx context.Context, userID int, prefix string, limit int) ([]int, error) {
+	ctx, span := tracing.Start(ctx, "UserStoredDataRepository.FindIDsByUUIDPrefix")
+	defer span.End()
+
+	query := `
+		SELECT d.id FROM user_stored_data d
+		WHERE d.uuid::text LIKE $2 || '%' AND (
+			(d.user_id = $1 AND d.collection_id IS NULL)
+			OR d.id IN (SELECT s.data_id FROM user_stored_data_shares s WHERE s.recipient_id = $1)
+			OR d.collection_id IN (
+				SELECT c.id FROM collections c
+				JOIN organization_members m ON m.organization_id = c.organization_id
+				WHERE m.user_id = $1 AND m.status = $4
+			)
+		)
+		ORDER BY d.id
+		LIMIT $3
+	`
+
+	rows, err := repo.pool.Query(ctx, query, userID, prefix, limit, domain.OrganizationMemberAccepted)
+	if err != nil {
 		return nil, err
 	}
 
-	return &userData, nil
+	return scanIDRows(rows)
 }
 
 func (repo *UserStoredDataRepository) GetUserAllData(ctx context.Context, userID int) ([]domain.UserStoredData, error) {
+	ctx, span := tracing.Start(ctx, "UserStoredDataRepository.GetUserAllData")
+	defer span.End()
+
 	query := `
-		SELECT id, user_id, data_type, data, meta, version, created_at FROM user_stored_data
-		WHERE user_id = $1
+		SELECT id, uuid, user_id, COALESCE(collection_id, 0), data_type, data, meta, version, created_at, updated_at FROM user_stored_data
+		WHERE user_id = $1 AND collection_id IS NULL
 	`
 
 	rows, err := repo.pool.Query(ctx, query, userID)
@@ -51,23 +93,33 @@ func (repo *UserStoredDataRepository) GetUserAllData(ctx context.Context, userID
 		return nil, err
 	}
 
-	dataSet := make([]domain.UserStoredData, 0)
-	for rows.Next() {
-		var data domain.UserStoredData
-		if err := rows.Scan(&data.ID, &data.UserID, &data.DataType, &data.CryptedData, &data.Meta, &data.Version, &data.CreatedAt); err != nil {
-			return nil, err
-		}
+	return scanUserStoredDataRows(rows)
+}
 
-		dataSet = append(dataSet, data)
+func (repo *UserStoredDataRepository) GetCollectionAllData(ctx context.Context, collectionID int) ([]domain.UserStoredData, error) {
+	ctx, span := tracing.Start(ctx, "UserStoredDataRepository.GetCollectionAllData")
+	defer span.End()
+
+	query := `
+		SELECT id, uuid, user_id, COALESCE(collection_id, 0), data_type, data, meta, version, created_at, updated_at FROM user_stored_data
+		WHERE collection_id = $1
+	`
+
+	rows, err := repo.pool.Query(ctx, query, collectionID)
+	if err != nil {
+		return nil, err
 	}
 
-	return dataSet, nil
+	return scanUserStoredDataRows(rows)
 }
 
 func (repo *UserStoredDataRepository) GetWithType(ctx context.Context, userID int, dataType string, filters *domain.StorageFilters) ([]domain.UserStoredData, error) {
+	ctx, span := tracing.Start(ctx, "UserStoredDataRepository.GetWithType")
+	defer span.End()
+
 	baseQuery := `
-		SELECT id, user_id, data_type, data, meta, version, created_at FROM user_stored_data
-		WHERE user_id = $1 AND data_type = $2
+		SELECT id, uuid, user_id, COALESCE(collection_id, 0), data_type, data, meta, version, created_at, updated_at FROM user_stored_data
+		WHERE user_id = $1 AND collection_id IS NULL AND data_type = $2
 	`
 
 	rows, err := repo.pool.Query(ctx, filters.BuildSQL(baseQuery), userID, dataType)
@@ -75,24 +127,34 @@ func (repo *UserStoredDataRepository) GetWithType(ctx context.Context, userID in
 		return nil, err
 	}
 
-	dataSet := make([]domain.UserStoredData, 0)
-	for rows.Next() {
-		var data domain.UserStoredData
-		if err := rows.Scan(&data.ID, &data.UserID, &data.DataType, &data.CryptedData, &data.Meta, &data.Version, &data.CreatedAt); err != nil {
-			return nil, err
-		}
+	return scanUserStoredDataRows(rows)
+}
 
-		dataSet = append(dataSet, data)
+func (repo *UserStoredDataRepository) GetCollectionDataWithType(ctx context.Context, collectionID int, dataType string, filters *domain.StorageFilters) ([]domain.UserStoredData, error) {
+	ctx, span := tracing.Start(ctx, "UserStoredDataRepository.GetCollectionDataWithType")
+	defer span.End()
+
+	baseQuery := `
+		SELECT id, uuid, user_id, COALESCE(collection_id, 0), data_type, data, meta, version, created_at, updated_at FROM user_stored_data
+		WHERE collection_id = $1 AND data_type = $2
+	`
+
+	rows, err := repo.pool.Query(ctx, filters.BuildSQL(baseQuery), collectionID, dataType)
+	if err != nil {
+		return nil, err
 	}
 
-	return dataSet, nil
+	return scanUserStoredDataRows(rows)
 }
 
 func (repo *UserStoredDataRepository) CountUserDataOfType(ctx context.Context, userID int, dataType string) (int, error) {
+	ctx, span := tracing.Start(ctx, "UserStoredDataRepository.CountUserDataOfType")
+	defer span.End()
+
 	query := `
 		SELECT COUNT(id)
 		FROM user_stored_data
-		WHERE user_id = $1 AND data_type = $2
+		WHERE user_id = $1 AND collection_id IS NULL AND data_type = $2
 	`
 
 	var count int
@@ -104,10 +166,32 @@ func (repo *UserStoredDataRepository) CountUserDataOfType(ctx context.Context, u
 	return count, nil
 }
 
-func (repo *UserStoredDataRepository) AddData(ctx context.Context, userID int, dataType string, data []byte, meta string) (int64, error) {
+func (repo *UserStoredDataRepository) CountCollectionDataOfType(ctx context.Context, collectionID int, dataType string) (int, error) {
+	ctx, span := tracing.Start(ctx, "UserStoredDataRepository.CountCollectionDataOfType")
+	defer span.End()
+
+	query := `
+		SELECT COUNT(id)
+		FROM user_stored_data
+		WHERE collection_id = $1 AND data_type = $2
+	`
+
+	var count int
+	err := repo.pool.QueryRow(ctx, query, collectionID, dataType).Scan(&count)
+	if err != nil {
+		return -1, err
+	}
+
+	return count, nil
+}
+
+func (repo *UserStoredDataRepository) AddData(ctx context.Context, userID int, recordUUID string, dataType string, data []byte, meta string) (int64, error) {
+	ctx, span := tracing.Start(ctx, "UserStoredDataRepository.AddData")
+	defer span.End()
+
 	query := `
-		INSERT INTO user_stored_data (user_id, data_type, data, meta)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO user_stored_data (uuid, user_id, data_type, data, meta)
+		VALUES ($1, $2, $3, $4, $5)
 		RETURNING id
 	`
 	var insertedID int64
@@ -115,43 +199,105 @@ func (repo *UserStoredDataRepository) AddData(ctx context.Context, userID int, d
 	err := repo.pool.QueryRow(
 		ctx,
 		query,
-		userID, dataType, data, meta,
+		recordUUID, userID, dataType, data, meta,
 	).Scan(&insertedID)
 	if err != nil {
-		return 0, err
+		return 0, uuidTakenError(err)
 	}
 
 	return insertedID, nil
 }
 
-func (repo *UserStoredDataRepository) UpdateUserData(ctx context.Context, userID int, dataID int, data interface{}, meta string) (*domain.UserStoredData, error) {
+func (repo *UserStoredDataRepository) AddCollectionData(ctx context.Context, userID int, collectionID int, recordUUID string, dataType string, data []byte, meta string) (int64, error) {
+	ctx, span := tracing.Start(ctx, "UserStoredDataRepository.AddCollectionData")
+	defer span.End()
+
+	query := `
+		INSERT INTO user_stored_data (uuid, user_id, collection_id, data_type, data, meta)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id
+	`
+	var insertedID int64
+
+	err := repo.pool.QueryRow(
+		ctx,
+		query,
+		recordUUID, userID, collectionID, dataType, data, meta,
+	).Scan(&insertedID)
+	if err != nil {
+		return 0, uuidTakenError(err)
+	}
+
+	return insertedID, nil
+}
+
+func uuidTakenError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == postgresql.PgUniqueIndexErrorCode {
+		return domain.ErrRecordUUIDTaken
+	}
+
+	return err
+}
+
+// UpdateUserData - update personal record, record is updated only if it still has expected version
+func (repo *UserStoredDataRepository) UpdateUserData(ctx context.Context, userID int, dataID int, expectedVersion int, data interface{}, meta string) (*domain.UserStoredData, error) {
+	ctx, span := tracing.Start(ctx, "UserStoredDataRepository.UpdateUserData")
+	defer span.End()
+
 	query := `
 		UPDATE user_stored_data
 		SET data = $1, meta = $2, version = version + 1
-		WHERE id = $3 AND user_id = $4
-		RETURNING id, user_id, data_type, data, meta, version, created_at
+		WHERE id = $3 AND user_id = $4 AND collection_id IS NULL AND version = $5
+		RETURNING id, uuid, user_id, COALESCE(collection_id, 0), data_type, data, meta, version, created_at, updated_at
 	`
 
-	var userData domain.UserStoredData
-	if err := repo.pool.QueryRow(ctx, query, data, meta, dataID, userID).Scan(
-		&userData.ID,
-		&userData.UserID,
-		&userData.DataType,
-		&userData.CryptedData,
-		&userData.Meta,
-		&userData.Version,
-		&userData.CreatedAt,
-	); err != nil {
-		return nil, err
+	updated, err := scanUserStoredData(repo.pool.QueryRow(ctx, query, data, meta, dataID, userID, expectedVersion))
+	return updated, repo.versionConflictError(ctx, err, dataID, expectedVersion)
+}
+
+// UpdateCollectionData - update collection record, record is updated only if it still has expected version
+func (repo *UserStoredDataRepository) UpdateCollectionData(ctx context.Context, collectionID int, dataID int, expectedVersion int, data interface{}, meta string) (*domain.UserStoredData, error) {
+	ctx, span := tracing.Start(ctx, "UserStoredDataRepository.UpdateCollectionData")
+	defer span.End()
+
+	query := `
+		UPDATE user_stored_data
+		SET data = $1, meta = $2, version = version + 1
+		WHERE id = $3 AND collection_id = $4 AND version = $5
+		RETURNING id, uuid, user_id, COALESCE(collection_id, 0), data_type, data, meta, version, created_at, updated_at
+	`
+
+	updated, err := scanUserStoredData(repo.pool.QueryRow(ctx, query, data, meta, dataID, collectionID, expectedVersion))
+	return updated, repo.versionConflictError(ctx, err, dataID, expectedVersion)
+}
+
+// versionConflictError - conditional update touching no rows means either that record is gone or that
+// it has other version now, only the latter is conflict
+func (repo *UserStoredDataRepository) versionConflictError(ctx context.Context, err error, dataID int, expectedVersion int) error {
+	if !errors.Is(err, domain.ErrUserStoredDataNotFound) {
+		return err
 	}
 
-	return &userData, nil
+	var exists bool
+	if err := repo.pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM user_stored_data WHERE id = $1)`, dataID).Scan(&exists); err != nil {
+		return err
+	}
+
+	if exists {
+		return domain.ErrDataVersionConflict
+	}
+
+	return domain.ErrUserStoredDataNotFound
 }
 
 func (repo *UserStoredDataRepository) DeleteByID(ctx context.Context, userID int, id int) error {
+	ctx, span := tracing.Start(ctx, "UserStoredDataRepository.DeleteByID")
+	defer span.End()
+
 	query := `
 		DELETE FROM user_stored_data
-		WHERE id = $1 AND user_id = $2
+		WHERE id = $1 AND user_id = $2 AND collection_id IS NULL
 	`
 
 	result, err := repo.pool.Exec(ctx, query, id, userID)
@@ -166,16 +312,407 @@ func (repo *UserStoredDataRepository) DeleteByID(ctx context.Context, userID int
 	return nil
 }
 
-func (repo *UserStoredDataRepository) DeleteBatch(ctx context.Context, userID int, id []int) error {
+// DeleteBatch - delete personal records of user with given ids and return ids of records which were deleted
+func (repo *UserStoredDataRepository) DeleteBatch(ctx context.Context, userID int, id []int) ([]int, error) {
+	ctx, span := tracing.Start(ctx, "UserStoredDataRepository.DeleteBatch")
+	defer span.End()
+
 	query := `
 		DELETE FROM user_stored_data
-		WHERE user_id = $1 AND id = ANY($2)
+		WHERE user_id = $1 AND collection_id IS NULL AND id = ANY($2)
+		RETURNING id
 	`
 
-	_, err := repo.pool.Exec(ctx, query, userID, id)
+	rows, err := repo.pool.Query(ctx, query, userID, id)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return scanIDRows(rows)
+}
+
+// DeleteCollectionBatch - delete collection records with given ids and return ids of records which were deleted
+func (repo *UserStoredDataRepository) DeleteCollectionBatch(ctx context.Context, collectionID int, id []int) ([]int, error) {
+	ctx, span := tracing.Start(ctx, "UserStoredDataRepository.DeleteCollectionBatch")
+	defer span.End()
+
+	query := `
+		DELETE FROM user_stored_data
+		WHERE collection_id = $1 AND id = ANY($2)
+		RETURNING id
+	`
+
+	rows, err := repo.pool.Query(ctx, query, collectionID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanIDRows(rows)
+}
+
+func (repo *UserStoredDataRepository) GetUserChanges(ctx context.Context, userID int, since int64, limit int) ([]domain.DataChange, error) {
+	ctx, span := tracing.Start(ctx, "UserStoredDataRepository.GetUserChanges")
+	defer span.End()
+
+	query := `
+		SELECT change_seq, id, uuid, FALSE, user_id, 0, data_type, data, meta, version, created_at, updated_at
+		FROM user_stored_data
+		WHERE user_id = $1 AND collection_id IS NULL AND change_seq > $2
+		UNION ALL
+		SELECT change_seq, data_id, data_uuid, TRUE, user_id, 0, '', '', '', 0, deleted_at, deleted_at
+		FROM user_stored_data_tombstones
+		WHERE user_id = $1 AND collection_id IS NULL AND change_seq > $2
+		ORDER BY 1
+		LIMIT $3
+	`
+
+	rows, err := repo.pool.Query(ctx, query, userID, since, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanDataChangeRows(rows)
+}
+
+func (repo *UserStoredDataRepository) GetCollectionChanges(ctx context.Context, collectionID int, since int64, limit int) ([]domain.DataChange, error) {
+	ctx, span := tracing.Start(ctx, "UserStoredDataRepository.GetCollectionChanges")
+	defer span.End()
+
+	query := `
+		SELECT change_seq, id, uuid, FALSE, user_id, collection_id, data_type, data, meta, version, created_at, updated_at
+		FROM user_stored_data
+		WHERE collection_id = $1 AND change_seq > $2
+		UNION ALL
+		SELECT change_seq, data_id, data_uuid, TRUE, user_id, collection_id, '', '', '', 0, deleted_at, deleted_at
+		FROM user_stored_data_tombstones
+		WHERE collection_id = $1 AND change_seq > $2
+		ORDER BY 1
+		LIMIT $3
+	`
+
+	rows, err := repo.pool.Query(ctx, query, collectionID, since, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanDataChangeRows(rows)
+}
+
+// GetCollectedTombstonesSeq - highest sequence of personal vault tombstones removed by CollectTombstones
+func (repo *UserStoredDataRepository) GetCollectedTombstonesSeq(ctx context.Context, userID int) (int64, error) {
+	ctx, span := tracing.Start(ctx, "UserStoredDataRepository.GetCollectedTombstonesSeq")
+	defer span.End()
+
+	var seq int64
+	err := repo.pool.QueryRow(ctx, `SELECT tombstones_collected_seq FROM users WHERE id = $1`, userID).Scan(&seq)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, domain.ErrUserNotFound
+		}
+
+		return 0, err
+	}
+
+	return seq, nil
+}
+
+// CollectTombstones - remove personal vault tombstones acknowledged by every active device of their owner and
+// remember highest removed sequence. Devices not seen for domain.DeviceInactiveAfter are not waited for.
+// Tombstones of users without active devices are kept, collections are not synced by devices, so their
+// tombstones are kept too
+func (repo *UserStoredDataRepository) CollectTombstones(ctx context.Context) (int64, error) {
+	ctx, span := tracing.Start(ctx, "UserStoredDataRepository.CollectTombstones")
+	defer span.End()
+
+	query := `
+		WITH acknowledged AS (
+			SELECT user_id, MIN(sync_cursor) AS seq
+			FROM devices
+			WHERE last_seen_at > NOW() - make_interval(secs => $1)
+			GROUP BY user_id
+		), collected AS (
+			DELETE FROM user_stored_data_tombstones t
+			USING acknowledged a
+			WHERE t.user_id = a.user_id AND t.collection_id IS NULL AND t.change_seq <= a.seq
+			RETURNING t.user_id, t.change_seq
+		), watermarks AS (
+			UPDATE users u
+			SET tombstones_collected_seq = GREATEST(u.tombstones_collected_seq, c.seq)
+			FROM (SELECT user_id, MAX(change_seq) AS seq FROM collected GROUP BY user_id) c
+			WHERE u.id = c.user_id
+		)
+		SELECT COUNT(*) FROM collected
+	`
+
+	var collected int64
+	if err := repo.pool.QueryRow(ctx, query, domain.DeviceInactiveAfter.Seconds()).Scan(&collected); err != nil {
+		return 0, err
+	}
+
+	return collected, nil
+}
+
+// ApplyPush - apply batch of personal vault changes in one transaction. Transaction is committed only if
+// every item is applied, otherwise failed items get their status, the rest are skipped
+func (repo *UserStoredDataRepository) ApplyPush(ctx context.Context, userID int, items []domain.SyncPushItem) (*domain.SyncPushResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserStoredDataRepository.ApplyPush")
+	defer span.End()
+
+	tx, err := repo.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rollback(ctx, tx, repo.logger)
+
+	response := &domain.SyncPushResponse{
+		Applied: true,
+		Results: make([]domain.SyncPushResult, 0, len(items)),
+	}
+
+	for _, item := range items {
+		result, err := applyPushItem(ctx, tx, userID, item)
+		if err != nil {
+			return nil, err
+		}
+
+		if result.Status != domain.SyncPushStatusApplied {
+			response.Applied = false
+		}
+
+		response.Results = append(response.Results, *result)
+	}
+
+	if !response.Applied {
+		for idx, result := range response.Results {
+			if result.Status == domain.SyncPushStatusApplied {
+				response.Results[idx] = domain.SyncPushResult{UUID: result.UUID, Status: domain.SyncPushStatusSkipped}
+			}
+		}
+
+		return response, nil
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func applyPushItem(ctx context.Context, tx pgx.Tx, userID int, item domain.SyncPushItem) (*domain.SyncPushResult, error) {
+	result := &domain.SyncPushResult{
+		UUID:   item.UUID,
+		Status: domain.SyncPushStatusApplied,
+	}
+
+	var err error
+
+	switch item.Operation {
+	case domain.SyncOperationCreate:
+		// record pushed again after lost response is recognized by service before the batch gets here
+		err = tx.QueryRow(
+			ctx,
+			`
+				INSERT INTO user_stored_data (uuid, user_id, data_type, data, meta)
+				VALUES ($1, $2, $3, $4, $5)
+				ON CONFLICT (uuid) DO NOTHING
+				RETURNING id, version
+			`,
+			item.UUID, userID, item.DataType, item.CryptedData, item.Meta,
+		).Scan(&result.ID, &result.Version)
+	case domain.SyncOperationUpdate:
+		err = tx.QueryRow(
+			ctx,
+			`
+				UPDATE user_stored_data
+				SET data = $1, meta = $2, version = version + 1
+				WHERE uuid = $3 AND user_id = $4 AND collection_id IS NULL AND data_type = $5 AND version = $6
+				RETURNING id, version
+			`,
+			item.CryptedData, item.Meta, item.UUID, userID, item.DataType, item.Version,
+		).Scan(&result.ID, &result.Version)
+	case domain.SyncOperationDelete:
+		err = tx.QueryRow(
+			ctx,
+			`
+				DELETE FROM user_stored_data
+				WHERE uuid = $1 AND user_id = $2 AND collection_id IS NULL AND version = $3
+				RETURNING id
+			`,
+			item.UUID, userID, item.Version,
+		).Scan(&result.ID)
+	}
+
+	if err == nil {
+		return result, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+
+	if item.Operation == domain.SyncOperationCreate {
+		result.Status = domain.SyncPushStatusConflict
+		return result, nil
+	}
+
+	result.Status, err = classifyFailedPushItem(ctx, tx, userID, item)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// classifyFailedPushItem - find out why update or delete did not match any row
+func classifyFailedPushItem(ctx context.Context, tx pgx.Tx, userID int, item domain.SyncPushItem) (string, error) {
+	var dataType string
+	err := tx.QueryRow(
+		ctx,
+		`SELECT data_type FROM user_stored_data WHERE uuid = $1 AND user_id = $2 AND collection_id IS NULL`,
+		item.UUID, userID,
+	).Scan(&dataType)
+	if err == nil {
+		if item.Operation == domain.SyncOperationUpdate && dataType != item.DataType {
+			return domain.SyncPushStatusInvalid, nil
+		}
+
+		return domain.SyncPushStatusConflict, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return "", err
+	}
+
+	// repeated delete after lost response must not fail the whole batch
+	if item.Operation == domain.SyncOperationDelete {
+		var exists bool
+		err := tx.QueryRow(
+			ctx,
+			`SELECT EXISTS (SELECT 1 FROM user_stored_data_tombstones WHERE data_uuid = $1 AND user_id = $2 AND collection_id IS NULL)`,
+			item.UUID, userID,
+		).Scan(&exists)
+		if err != nil {
+			return "", err
+		}
+
+		if exists {
+			return domain.SyncPushStatusApplied, nil
+		}
+	}
+
+	return domain.SyncPushStatusNotFound, nil
+}
+
+func scanIDRows(rows pgx.Rows) ([]int, error) {
+	defer rows.Close()
+
+	ids := make([]int, 0)
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+func scanUserStoredData(row pgx.Row) (*domain.UserStoredData, error) {
+	var userData domain.UserStoredData
+	if err := row.Scan(
+		&userData.ID,
+		&userData.UUID,
+		&userData.UserID,
+		&userData.CollectionID,
+		&userData.DataType,
+		&userData.CryptedData,
+		&userData.Meta,
+		&userData.Version,
+		&userData.CreatedAt,
+		&userData.UpdatedAt,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrUserStoredDataNotFound
+		}
+
+		return nil, err
+	}
+
+	return &userData, nil
+}
+
+func scanUserStoredDataRows(rows pgx.Rows) ([]domain.UserStoredData, error) {
+	defer rows.Close()
+
+	dataSet := make([]domain.UserStoredData, 0)
+	for rows.Next() {
+		var data domain.UserStoredData
+		if err := rows.Scan(&data.ID, &data.UUID, &data.UserID, &data.CollectionID, &data.DataType, &data.CryptedData, &data.Meta, &data.Version, &data.CreatedAt, &data.UpdatedAt); err != nil {
+			return nil, err
+		}
+
+		dataSet = append(dataSet, data)
+	}
+
+	return dataSet, rows.Err()
+}
+
+// CountByDataType - count records of all users and collections grouped by data type
+func (repo *UserStoredDataRepository) CountByDataType(ctx context.Context) (map[string]int, error) {
+	rows, err := repo.pool.Query(ctx, `SELECT data_type, COUNT(id) FROM user_stored_data GROUP BY data_type`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var dataType string
+		var count int
+
+		if err := rows.Scan(&dataType, &count); err != nil {
+			return nil, err
+		}
+
+		counts[dataType] = count
+	}
+
+	return counts, rows.Err()
+}
+
+func scanDataChangeRows(rows pgx.Rows) ([]domain.DataChange, error) {
+	defer rows.Close()
+
+	changes := make([]domain.DataChange, 0)
+	for rows.Next() {
+		var change domain.DataChange
+		var data domain.UserStoredData
+		if err := rows.Scan(
+			&change.Seq,
+			&change.ID,
+			&change.UUID,
+			&change.Deleted,
+			&data.UserID,
+			&data.CollectionID,
+			&data.DataType,
+			&data.CryptedData,
+			&data.Meta,
+			&data.Version,
+			&data.CreatedAt,
+			&data.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+
+		if !change.Deleted {
+			data.ID = change.ID
+			data.UUID = change.UUID
+			change.Data = &data
+		}
+
+		changes = append(changes, change)
+	}
+
+	return changes, rows.Err()
 }
@@ -5,6 +5,7 @@
 //
 //	mockgen -source=./internal/services/client/user_stored_data.go -destination=./internal/services/client/mocks/user_stored_data.go
 //
+
 // Package mock_client is a generated GoMock package.
 package mock_client
 
@@ -93,18 +94,31 @@ func (m *MockuserStoredDataRepository) EXPECT() *MockuserStoredDataRepositoryMoc
 }
 
 // AddData mocks base method.
-func (m *MockuserStoredDataRepository) AddData(ctx context.Context, dataType string, data []byte, meta string) (int64, error) {
+func (m *MockuserStoredDataRepository) AddData(ctx context.Context, recordUUID, dataType string, data []byte, meta string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddData", ctx, dataType, data, meta)
-	ret0, _ := ret[0].(int64)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "AddData", ctx, recordUUID, dataType, data, meta)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // AddData indicates an expected call of AddData.
-func (mr *MockuserStoredDataRepositoryMockRecorder) AddData(ctx, dataType, data, meta any) *gomock.Call {
+func (mr *MockuserStoredDataRepositoryMockRecorder) AddData(ctx, recordUUID, dataType, data, meta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddData", reflect.TypeOf((*MockuserStoredDataRepository)(nil).AddData), ctx, recordUUID, dataType, data, meta)
+}
+
+// ApplySync mocks base method.
+func (m *MockuserStoredDataRepository) ApplySync(ctx context.Context, changes domain.LocalSyncChanges) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplySync", ctx, changes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplySync indicates an expected call of ApplySync.
+func (mr *MockuserStoredDataRepositoryMockRecorder) ApplySync(ctx, changes any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddData", reflect.TypeOf((*MockuserStoredDataRepository)(nil).AddData), ctx, dataType, data, meta)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplySync", reflect.TypeOf((*MockuserStoredDataRepository)(nil).ApplySync), ctx, changes)
 }
 
 // CountUserDataOfType mocks base method.
@@ -122,32 +136,33 @@ func (mr *MockuserStoredDataRepositoryMockRecorder) CountUserDataOfType(ctx, dat
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUserDataOfType", reflect.TypeOf((*MockuserStoredDataRepository)(nil).CountUserDataOfType), ctx, dataType)
 }
 
-// DeleteBatch mocks base method.
-func (m *MockuserStoredDataRepository) DeleteBatch(ctx context.Context, ids []int) error {
+// DeleteByUUID mocks base method.
+func (m *MockuserStoredDataRepository) DeleteByUUID(ctx context.Context, recordUUID string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteBatch", ctx, ids)
+	ret := m.ctrl.Call(m, "DeleteByUUID", ctx, recordUUID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteBatch indicates an expected call of DeleteBatch.
-func (mr *MockuserStoredDataRepositoryMockRecorder) DeleteBatch(ctx, ids any) *gomock.Call {
+// DeleteByUUID indicates an expected call of DeleteByUUID.
+func (mr *MockuserStoredDataRepositoryMockRecorder) DeleteByUUID(ctx, recordUUID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBatch", reflect.TypeOf((*MockuserStoredDataRepository)(nil).DeleteBatch), ctx, ids)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUUID", reflect.TypeOf((*MockuserStoredDataRepository)(nil).DeleteByUUID), ctx, recordUUID)
 }
 
-// DeleteByID mocks base method.
-func (m *MockuserStoredDataRepository) DeleteByID(ctx context.Context, id int) error {
+// FindByRef mocks base method.
+func (m *MockuserStoredDataRepository) FindByRef(ctx context.Context, ref string) (*domain.UserStoredData, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "FindByRef", ctx, ref)
+	ret0, _ := ret[0].(*domain.UserStoredData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteByID indicates an expected call of DeleteByID.
-func (mr *MockuserStoredDataRepositoryMockRecorder) DeleteByID(ctx, id any) *gomock.Call {
+// FindByRef indicates an expected call of FindByRef.
+func (mr *MockuserStoredDataRepositoryMockRecorder) FindByRef(ctx, ref any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockuserStoredDataRepository)(nil).DeleteByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByRef", reflect.TypeOf((*MockuserStoredDataRepository)(nil).FindByRef), ctx, ref)
 }
 
 // GetAll mocks base method.
@@ -165,21 +180,6 @@ func (mr *MockuserStoredDataRepositoryMockRecorder) GetAll(ctx any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockuserStoredDataRepository)(nil).GetAll), ctx)
 }
 
-// GetByID mocks base method.
-func (m *MockuserStoredDataRepository) GetByID(ctx context.Context, id int) (*domain.UserStoredData, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByID", ctx, id)
-	ret0, _ := ret[0].(*domain.UserStoredData)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// GetByID indicates an expected call of GetByID.
-func (mr *MockuserStoredDataRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockuserStoredDataRepository)(nil).GetByID), ctx, id)
-}
-
 // GetWithType mocks base method.
 func (m *MockuserStoredDataRepository) GetWithType(ctx context.Context, dataType string, filters *domain.StorageFilters) ([]domain.UserStoredData, error) {
 	m.ctrl.T.Helper()
@@ -195,17 +195,17 @@ func (mr *MockuserStoredDataRepositoryMockRecorder) GetWithType(ctx, dataType, f
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWithType", reflect.TypeOf((*MockuserStoredDataRepository)(nil).GetWithType), ctx, dataType, filters)
 }
 
-// UpdateByID mocks base method.
-func (m *MockuserStoredDataRepository) UpdateByID(ctx context.Context, id int, data []byte, meta string) (*domain.UserStoredData, error) {
+// UpdateByUUID mocks base method.
+func (m *MockuserStoredDataRepository) UpdateByUUID(ctx context.Context, recordUUID string, data []byte, meta string) (*domain.UserStoredData, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateByID", ctx, id, data, meta)
+	ret := m.ctrl.Call(m, "UpdateByUUID", ctx, recordUUID, data, meta)
 	ret0, _ := ret[0].(*domain.UserStoredData)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateByID indicates an expected call of UpdateByID.
-func (mr *MockuserStoredDataRepositoryMockRecorder) UpdateByID(ctx, id, data, meta any) *gomock.Call {
+// UpdateByUUID indicates an expected call of UpdateByUUID.
+func (mr *MockuserStoredDataRepositoryMockRecorder) UpdateByUUID(ctx, recordUUID, data, meta any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateByID", reflect.TypeOf((*MockuserStoredDataRepository)(nil).UpdateByID), ctx, id, data, meta)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateByUUID", reflect.TypeOf((*MockuserStoredDataRepository)(nil).UpdateByUUID), ctx, recordUUID, data, meta)
 }
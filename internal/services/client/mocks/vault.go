@@ -0,0 +1,248 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/services/client/vault.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/services/client/vault.go -destination=./internal/services/client/mocks/vault.go
+//
+// Package mock_client is a generated GoMock package.
+package mock_client
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/MowlCoder/goph-keeper/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockpersonalVaultService is a mock of personalVaultService interface.
+type MockpersonalVaultService struct {
+	ctrl     *gomock.Controller
+	recorder *MockpersonalVaultServiceMockRecorder
+}
+
+// MockpersonalVaultServiceMockRecorder is the mock recorder for MockpersonalVaultService.
+type MockpersonalVaultServiceMockRecorder struct {
+	mock *MockpersonalVaultService
+}
+
+// NewMockpersonalVaultService creates a new mock instance.
+func NewMockpersonalVaultService(ctrl *gomock.Controller) *MockpersonalVaultService {
+	mock := &MockpersonalVaultService{ctrl: ctrl}
+	mock.recorder = &MockpersonalVaultServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpersonalVaultService) EXPECT() *MockpersonalVaultServiceMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockpersonalVaultService) Add(ctx context.Context, dataType string, data any, meta string) (*domain.UserStoredData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, dataType, data, meta)
+	ret0, _ := ret[0].(*domain.UserStoredData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockpersonalVaultServiceMockRecorder) Add(ctx, dataType, data, meta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockpersonalVaultService)(nil).Add), ctx, dataType, data, meta)
+}
+
+// DeleteByUUID mocks base method.
+func (m *MockpersonalVaultService) DeleteByUUID(ctx context.Context, recordUUID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUUID", ctx, recordUUID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByUUID indicates an expected call of DeleteByUUID.
+func (mr *MockpersonalVaultServiceMockRecorder) DeleteByUUID(ctx, recordUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUUID", reflect.TypeOf((*MockpersonalVaultService)(nil).DeleteByUUID), ctx, recordUUID)
+}
+
+// GetByRef mocks base method.
+func (m *MockpersonalVaultService) GetByRef(ctx context.Context, ref string) (*domain.UserStoredData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByRef", ctx, ref)
+	ret0, _ := ret[0].(*domain.UserStoredData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByRef indicates an expected call of GetByRef.
+func (mr *MockpersonalVaultServiceMockRecorder) GetByRef(ctx, ref any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByRef", reflect.TypeOf((*MockpersonalVaultService)(nil).GetByRef), ctx, ref)
+}
+
+// GetUserData mocks base method.
+func (m *MockpersonalVaultService) GetUserData(ctx context.Context, dataType string, filters *domain.StorageFilters) (*domain.PaginatedResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserData", ctx, dataType, filters)
+	ret0, _ := ret[0].(*domain.PaginatedResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserData indicates an expected call of GetUserData.
+func (mr *MockpersonalVaultServiceMockRecorder) GetUserData(ctx, dataType, filters any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserData", reflect.TypeOf((*MockpersonalVaultService)(nil).GetUserData), ctx, dataType, filters)
+}
+
+// UpdateByUUID mocks base method.
+func (m *MockpersonalVaultService) UpdateByUUID(ctx context.Context, recordUUID string, data any, meta string) (*domain.UserStoredData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateByUUID", ctx, recordUUID, data, meta)
+	ret0, _ := ret[0].(*domain.UserStoredData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateByUUID indicates an expected call of UpdateByUUID.
+func (mr *MockpersonalVaultServiceMockRecorder) UpdateByUUID(ctx, recordUUID, data, meta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateByUUID", reflect.TypeOf((*MockpersonalVaultService)(nil).UpdateByUUID), ctx, recordUUID, data, meta)
+}
+
+// MockcollectionDataAPI is a mock of collectionDataAPI interface.
+type MockcollectionDataAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockcollectionDataAPIMockRecorder
+}
+
+// MockcollectionDataAPIMockRecorder is the mock recorder for MockcollectionDataAPI.
+type MockcollectionDataAPIMockRecorder struct {
+	mock *MockcollectionDataAPI
+}
+
+// NewMockcollectionDataAPI creates a new mock instance.
+func NewMockcollectionDataAPI(ctrl *gomock.Controller) *MockcollectionDataAPI {
+	mock := &MockcollectionDataAPI{ctrl: ctrl}
+	mock.recorder = &MockcollectionDataAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcollectionDataAPI) EXPECT() *MockcollectionDataAPIMockRecorder {
+	return m.recorder
+}
+
+// AddToCollection mocks base method.
+func (m *MockcollectionDataAPI) AddToCollection(ctx context.Context, collectionID int, entity domain.UserStoredData) (*domain.UserStoredData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddToCollection", ctx, collectionID, entity)
+	ret0, _ := ret[0].(*domain.UserStoredData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddToCollection indicates an expected call of AddToCollection.
+func (mr *MockcollectionDataAPIMockRecorder) AddToCollection(ctx, collectionID, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToCollection", reflect.TypeOf((*MockcollectionDataAPI)(nil).AddToCollection), ctx, collectionID, entity)
+}
+
+// DeleteCollectionBatch mocks base method.
+func (m *MockcollectionDataAPI) DeleteCollectionBatch(ctx context.Context, collectionID int, ids []int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCollectionBatch", ctx, collectionID, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCollectionBatch indicates an expected call of DeleteCollectionBatch.
+func (mr *MockcollectionDataAPIMockRecorder) DeleteCollectionBatch(ctx, collectionID, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCollectionBatch", reflect.TypeOf((*MockcollectionDataAPI)(nil).DeleteCollectionBatch), ctx, collectionID, ids)
+}
+
+// GetByRef mocks base method.
+func (m *MockcollectionDataAPI) GetByRef(ctx context.Context, ref string) (*domain.UserStoredData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByRef", ctx, ref)
+	ret0, _ := ret[0].(*domain.UserStoredData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByRef indicates an expected call of GetByRef.
+func (mr *MockcollectionDataAPIMockRecorder) GetByRef(ctx, ref any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByRef", reflect.TypeOf((*MockcollectionDataAPI)(nil).GetByRef), ctx, ref)
+}
+
+// GetCollectionData mocks base method.
+func (m *MockcollectionDataAPI) GetCollectionData(ctx context.Context, collectionID int, dataType string, page, count int) (*domain.PaginatedResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCollectionData", ctx, collectionID, dataType, page, count)
+	ret0, _ := ret[0].(*domain.PaginatedResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCollectionData indicates an expected call of GetCollectionData.
+func (mr *MockcollectionDataAPIMockRecorder) GetCollectionData(ctx, collectionID, dataType, page, count any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCollectionData", reflect.TypeOf((*MockcollectionDataAPI)(nil).GetCollectionData), ctx, collectionID, dataType, page, count)
+}
+
+// UpdateByID mocks base method.
+func (m *MockcollectionDataAPI) UpdateByID(ctx context.Context, id, expectedVersion int, data any, meta string) (*domain.UserStoredData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateByID", ctx, id, expectedVersion, data, meta)
+	ret0, _ := ret[0].(*domain.UserStoredData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateByID indicates an expected call of UpdateByID.
+func (mr *MockcollectionDataAPIMockRecorder) UpdateByID(ctx, id, expectedVersion, data, meta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateByID", reflect.TypeOf((*MockcollectionDataAPI)(nil).UpdateByID), ctx, id, expectedVersion, data, meta)
+}
+
+// MocksessionForVaultService is a mock of sessionForVaultService interface.
+type MocksessionForVaultService struct {
+	ctrl     *gomock.Controller
+	recorder *MocksessionForVaultServiceMockRecorder
+}
+
+// MocksessionForVaultServiceMockRecorder is the mock recorder for MocksessionForVaultService.
+type MocksessionForVaultServiceMockRecorder struct {
+	mock *MocksessionForVaultService
+}
+
+// NewMocksessionForVaultService creates a new mock instance.
+func NewMocksessionForVaultService(ctrl *gomock.Controller) *MocksessionForVaultService {
+	mock := &MocksessionForVaultService{ctrl: ctrl}
+	mock.recorder = &MocksessionForVaultServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocksessionForVaultService) EXPECT() *MocksessionForVaultServiceMockRecorder {
+	return m.recorder
+}
+
+// GetActiveCollectionID mocks base method.
+func (m *MocksessionForVaultService) GetActiveCollectionID() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveCollectionID")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetActiveCollectionID indicates an expected call of GetActiveCollectionID.
+func (mr *MocksessionForVaultServiceMockRecorder) GetActiveCollectionID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveCollectionID", reflect.TypeOf((*MocksessionForVaultService)(nil).GetActiveCollectionID))
+}
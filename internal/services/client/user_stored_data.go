@@ -6,6 +6,8 @@ import (
 	"math"
 	"time"
 
+	"github.com/google/uuid"
+
 	"github.com/MowlCoder/goph-keeper/internal/domain"
 )
 
@@ -15,14 +17,14 @@ type cryptorForUserStoredDataService interface {
 }
 
 type userStoredDataRepository interface {
-	GetByID(ctx context.Context, id int) (*domain.UserStoredData, error)
+	FindByRef(ctx context.Context, ref string) (*domain.UserStoredData, error)
 	GetAll(ctx context.Context) ([]domain.UserStoredData, error)
-	AddData(ctx context.Context, dataType string, data []byte, meta string) (int64, error)
+	AddData(ctx context.Context, recordUUID string, dataType string, data []byte, meta string) error
 	GetWithType(ctx context.Context, dataType string, filters *domain.StorageFilters) ([]domain.UserStoredData, error)
 	CountUserDataOfType(ctx context.Context, dataType string) (int, error)
-	UpdateByID(ctx context.Context, id int, data []byte, meta string) (*domain.UserStoredData, error)
-	DeleteByID(ctx context.Context, id int) error
-	DeleteBatch(ctx context.Context, ids []int) error
+	UpdateByUUID(ctx context.Context, recordUUID string, data []byte, meta string) (*domain.UserStoredData, error)
+	DeleteByUUID(ctx context.Context, recordUUID string) error
+	ApplySync(ctx context.Context, changes domain.LocalSyncChanges) error
 }
 
 type UserStoredDataService struct {
@@ -37,8 +39,14 @@ func NewUserStoredDataService(repository userStoredDataRepository, cryptor crypt
 	}
 }
 
-func (s *UserStoredDataService) GetByID(ctx context.Context, id int) (*domain.UserStoredData, error) {
-	data, err := s.repository.GetByID(ctx, id)
+// GetByRef - get record by uuid or by its prefix
+func (s *UserStoredDataService) GetByRef(ctx context.Context, ref string) (*domain.UserStoredData, error) {
+	ref, err := domain.ParseRecordRef(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := s.repository.FindByRef(ctx, ref)
 	if err != nil {
 		return nil, err
 	}
@@ -72,6 +80,21 @@ func (s *UserStoredDataService) GetAll(ctx context.Context) ([]domain.UserStored
 		if err != nil {
 			return nil, err
 		}
+
+		if data.Base == nil {
+			continue
+		}
+
+		decryptedBytes, err = s.cryptor.DecryptBytes(data.Base.CryptedData)
+		if err != nil {
+			return nil, err
+		}
+
+		dataSet[idx].Base = &domain.SyncBase{Meta: data.Base.Meta}
+		dataSet[idx].Base.Data, err = domain.ParseUserStoredData(data.DataType, decryptedBytes)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return dataSet, err
@@ -107,7 +130,13 @@ func (s *UserStoredDataService) GetUserData(ctx context.Context, dataType string
 	}, nil
 }
 
+// Add - save new local record with newly generated uuid
 func (s *UserStoredDataService) Add(ctx context.Context, dataType string, data interface{}, meta string) (*domain.UserStoredData, error) {
+	return s.AddWithUUID(ctx, uuid.NewString(), dataType, data, meta)
+}
+
+// AddWithUUID - save new local record with uuid it already has on other devices
+func (s *UserStoredDataService) AddWithUUID(ctx context.Context, recordUUID string, dataType string, data interface{}, meta string) (*domain.UserStoredData, error) {
 	jsonData, err := json.Marshal(data)
 	if err != nil {
 		return nil, err
@@ -118,22 +147,21 @@ func (s *UserStoredDataService) Add(ctx context.Context, dataType string, data i
 		return nil, err
 	}
 
-	insertedID, err := s.repository.AddData(ctx, dataType, encrypted, meta)
-	if err != nil {
+	if err := s.repository.AddData(ctx, recordUUID, dataType, encrypted, meta); err != nil {
 		return nil, err
 	}
 
 	return &domain.UserStoredData{
-		ID:        int(insertedID),
+		UUID:      recordUUID,
 		DataType:  dataType,
 		Data:      data,
 		Meta:      meta,
-		Version:   1,
+		Version:   -1,
 		CreatedAt: time.Now().UTC(),
 	}, nil
 }
 
-func (s *UserStoredDataService) UpdateByID(ctx context.Context, id int, data interface{}, meta string) (*domain.UserStoredData, error) {
+func (s *UserStoredDataService) UpdateByUUID(ctx context.Context, recordUUID string, data interface{}, meta string) (*domain.UserStoredData, error) {
 	jsonData, err := json.Marshal(data)
 	if err != nil {
 		return nil, err
@@ -144,7 +172,7 @@ func (s *UserStoredDataService) UpdateByID(ctx context.Context, id int, data int
 		return nil, err
 	}
 
-	updatedData, err := s.repository.UpdateByID(ctx, id, encrypted, meta)
+	updatedData, err := s.repository.UpdateByUUID(ctx, recordUUID, encrypted, meta)
 	if err != nil {
 		return nil, err
 	}
@@ -155,10 +183,45 @@ func (s *UserStoredDataService) UpdateByID(ctx context.Context, id int, data int
 	return updatedData, nil
 }
 
-func (s *UserStoredDataService) DeleteBatch(ctx context.Context, ids []int) error {
-	return s.repository.DeleteBatch(ctx, ids)
+// ApplySync - encrypt data of records changed by sync and save all changes in one transaction
+func (s *UserStoredDataService) ApplySync(ctx context.Context, changes domain.LocalSyncChanges) error {
+	var err error
+
+	if changes.Updated, err = s.encryptRecords(changes.Updated); err != nil {
+		return err
+	}
+	if changes.Added, err = s.encryptRecords(changes.Added); err != nil {
+		return err
+	}
+	if changes.Copies, err = s.encryptRecords(changes.Copies); err != nil {
+		return err
+	}
+
+	return s.repository.ApplySync(ctx, changes)
+}
+
+// encryptRecords - copies of records with encrypted data, given records are not changed
+func (s *UserStoredDataService) encryptRecords(dataSet []domain.UserStoredData) ([]domain.UserStoredData, error) {
+	encrypted := make([]domain.UserStoredData, 0, len(dataSet))
+
+	for _, data := range dataSet {
+		jsonData, err := json.Marshal(data.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		data.CryptedData, err = s.cryptor.EncryptBytes(jsonData)
+		if err != nil {
+			return nil, err
+		}
+		data.Data = nil
+
+		encrypted = append(encrypted, data)
+	}
+
+	return encrypted, nil
 }
 
-func (s *UserStoredDataService) DeleteByID(ctx context.Context, id int) error {
-	return s.repository.DeleteByID(ctx, id)
+func (s *UserStoredDataService) DeleteByUUID(ctx context.Context, recordUUID string) error {
+	return s.repository.DeleteByUUID(ctx, recordUUID)
 }
@@ -43,17 +43,19 @@ func TestUserStoredDataSuite(t *testing.T) {
 	suite.Run(t, new(userStoredDataTestSuite))
 }
 
-func (suite *userStoredDataTestSuite) TestGetByID() {
+const testRecordUUID = "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+
+func (suite *userStoredDataTestSuite) TestGetByRef() {
 	testCases := []struct {
 		name    string
 		err     error
-		prepare func() int
+		prepare func() string
 	}{
 		{
 			name: "valid",
 			err:  nil,
-			prepare: func() int {
-				id := 1
+			prepare: func() string {
+				ref := testRecordUUID
 				data := domain.LogPassData{
 					Login:    "Test",
 					Password: "test",
@@ -62,22 +64,22 @@ func (suite *userStoredDataTestSuite) TestGetByID() {
 
 				suite.repository.
 					EXPECT().
-					GetByID(gomock.Any(), id).
-					Return(&domain.UserStoredData{ID: id, DataType: domain.LogPassDataType}, nil)
+					FindByRef(gomock.Any(), ref).
+					Return(&domain.UserStoredData{UUID: ref, DataType: domain.LogPassDataType}, nil)
 
 				suite.cryptor.
 					EXPECT().
 					DecryptBytes(gomock.Any()).
 					Return(b, nil)
 
-				return id
+				return ref
 			},
 		},
 		{
 			name: "invalid data type",
 			err:  domain.ErrInvalidDataType,
-			prepare: func() int {
-				id := 1
+			prepare: func() string {
+				ref := testRecordUUID
 				data := domain.LogPassData{
 					Login:    "Test",
 					Password: "test",
@@ -86,54 +88,78 @@ func (suite *userStoredDataTestSuite) TestGetByID() {
 
 				suite.repository.
 					EXPECT().
-					GetByID(gomock.Any(), id).
-					Return(&domain.UserStoredData{ID: id, DataType: "test"}, nil)
+					FindByRef(gomock.Any(), ref).
+					Return(&domain.UserStoredData{UUID: ref, DataType: "test"}, nil)
 
 				suite.cryptor.
 					EXPECT().
 					DecryptBytes(gomock.Any()).
 					Return(b, nil)
 
-				return id
+				return ref
+			},
+		},
+		{
+			name: "uuid prefix",
+			err:  nil,
+			prepare: func() string {
+				suite.repository.
+					EXPECT().
+					FindByRef(gomock.Any(), "6ba7b810").
+					Return(&domain.UserStoredData{UUID: testRecordUUID, DataType: domain.TextDataType}, nil)
+
+				suite.cryptor.
+					EXPECT().
+					DecryptBytes(gomock.Any()).
+					Return([]byte(`{"text":"text"}`), nil)
+
+				return " 6BA7B810 "
+			},
+		},
+		{
+			name: "invalid ref",
+			err:  domain.ErrInvalidRecordRef,
+			prepare: func() string {
+				return "abc"
 			},
 		},
 		{
 			name: "user data not found",
 			err:  domain.ErrUserStoredDataNotFound,
-			prepare: func() int {
-				id := 1
+			prepare: func() string {
+				ref := testRecordUUID
 				suite.repository.
 					EXPECT().
-					GetByID(gomock.Any(), id).
+					FindByRef(gomock.Any(), ref).
 					Return(nil, domain.ErrUserStoredDataNotFound)
 
-				return id
+				return ref
 			},
 		},
 		{
 			name: "decryption err",
 			err:  domain.ErrInternal,
-			prepare: func() int {
-				id := 1
+			prepare: func() string {
+				ref := testRecordUUID
 				suite.repository.
 					EXPECT().
-					GetByID(gomock.Any(), id).
-					Return(&domain.UserStoredData{ID: id}, nil)
+					FindByRef(gomock.Any(), ref).
+					Return(&domain.UserStoredData{UUID: ref}, nil)
 
 				suite.cryptor.
 					EXPECT().
 					DecryptBytes(gomock.Any()).
 					Return(nil, domain.ErrInternal)
 
-				return id
+				return ref
 			},
 		},
 	}
 
 	for _, testCase := range testCases {
 		suite.Run(testCase.name, func() {
-			id := testCase.prepare()
-			_, err := suite.service.GetByID(context.Background(), id)
+			ref := testCase.prepare()
+			_, err := suite.service.GetByRef(context.Background(), ref)
 			suite.Equal(testCase.err, err)
 		})
 	}
@@ -166,6 +192,34 @@ func (suite *userStoredDataTestSuite) TestGetAll() {
 					Return(b, nil)
 			},
 		},
+		{
+			name: "with sync base",
+			err:  nil,
+			prepare: func() {
+				data, _ := json.Marshal(domain.LogPassData{Login: "Test", Password: "new"})
+				base, _ := json.Marshal(domain.LogPassData{Login: "Test", Password: "old"})
+
+				suite.repository.
+					EXPECT().
+					GetAll(gomock.Any()).
+					Return([]domain.UserStoredData{{
+						ID:          1,
+						DataType:    domain.LogPassDataType,
+						CryptedData: []byte{1},
+						Base:        &domain.SyncBase{CryptedData: []byte{2}, Meta: "meta"},
+					}}, nil)
+
+				suite.cryptor.
+					EXPECT().
+					DecryptBytes([]byte{1}).
+					Return(data, nil)
+
+				suite.cryptor.
+					EXPECT().
+					DecryptBytes([]byte{2}).
+					Return(base, nil)
+			},
+		},
 		{
 			name: "invalid data type",
 			err:  domain.ErrInvalidDataType,
@@ -368,8 +422,8 @@ func (suite *userStoredDataTestSuite) TestAdd() {
 
 				suite.repository.
 					EXPECT().
-					AddData(gomock.Any(), dataType, cryptedBytes, meta).
-					Return(int64(1), nil)
+					AddData(gomock.Any(), gomock.Any(), dataType, cryptedBytes, meta).
+					Return(nil)
 
 				return dataType, data, meta
 			},
@@ -414,8 +468,8 @@ func (suite *userStoredDataTestSuite) TestAdd() {
 
 				suite.repository.
 					EXPECT().
-					AddData(gomock.Any(), dataType, cryptedBytes, meta).
-					Return(int64(0), domain.ErrInternal)
+					AddData(gomock.Any(), gomock.Any(), dataType, cryptedBytes, meta).
+					Return(domain.ErrInternal)
 
 				return dataType, data, meta
 			},
@@ -425,23 +479,27 @@ func (suite *userStoredDataTestSuite) TestAdd() {
 	for _, testCase := range testCases {
 		suite.Run(testCase.name, func() {
 			dataType, data, meta := testCase.prepare()
-			_, err := suite.service.Add(context.Background(), dataType, data, meta)
+			added, err := suite.service.Add(context.Background(), dataType, data, meta)
 			suite.Equal(testCase.err, err)
+			if err == nil {
+				suite.True(added.IsLocal())
+				suite.True(domain.IsValidUUID(added.UUID))
+			}
 		})
 	}
 }
 
-func (suite *userStoredDataTestSuite) TestUpdateByID() {
+func (suite *userStoredDataTestSuite) TestUpdateByUUID() {
 	testCases := []struct {
 		name    string
 		err     error
-		prepare func() (int, interface{}, string)
+		prepare func() (string, interface{}, string)
 	}{
 		{
 			name: "valid",
 			err:  nil,
-			prepare: func() (int, interface{}, string) {
-				id := 1
+			prepare: func() (string, interface{}, string) {
+				recordUUID := testRecordUUID
 				data := domain.LogPassData{
 					Login:    "Test",
 					Password: "test",
@@ -457,17 +515,17 @@ func (suite *userStoredDataTestSuite) TestUpdateByID() {
 
 				suite.repository.
 					EXPECT().
-					UpdateByID(gomock.Any(), id, cryptedBytes, meta).
-					Return(&domain.UserStoredData{ID: id}, nil)
+					UpdateByUUID(gomock.Any(), recordUUID, cryptedBytes, meta).
+					Return(&domain.UserStoredData{UUID: recordUUID}, nil)
 
-				return id, data, meta
+				return recordUUID, data, meta
 			},
 		},
 		{
 			name: "encrypt error",
 			err:  domain.ErrInternal,
-			prepare: func() (int, interface{}, string) {
-				id := 1
+			prepare: func() (string, interface{}, string) {
+				recordUUID := testRecordUUID
 				data := domain.LogPassData{
 					Login:    "Test",
 					Password: "test",
@@ -480,14 +538,14 @@ func (suite *userStoredDataTestSuite) TestUpdateByID() {
 					EncryptBytes(b).
 					Return(nil, domain.ErrInternal)
 
-				return id, data, meta
+				return recordUUID, data, meta
 			},
 		},
 		{
 			name: "update error",
 			err:  domain.ErrInternal,
-			prepare: func() (int, interface{}, string) {
-				id := 1
+			prepare: func() (string, interface{}, string) {
+				recordUUID := testRecordUUID
 				data := domain.LogPassData{
 					Login:    "Test",
 					Password: "test",
@@ -503,100 +561,134 @@ func (suite *userStoredDataTestSuite) TestUpdateByID() {
 
 				suite.repository.
 					EXPECT().
-					UpdateByID(gomock.Any(), id, cryptedBytes, meta).
+					UpdateByUUID(gomock.Any(), recordUUID, cryptedBytes, meta).
 					Return(nil, domain.ErrInternal)
 
-				return id, data, meta
+				return recordUUID, data, meta
 			},
 		},
 	}
 
 	for _, testCase := range testCases {
 		suite.Run(testCase.name, func() {
-			id, data, meta := testCase.prepare()
-			_, err := suite.service.UpdateByID(context.Background(), id, data, meta)
+			recordUUID, data, meta := testCase.prepare()
+			_, err := suite.service.UpdateByUUID(context.Background(), recordUUID, data, meta)
 			suite.Equal(testCase.err, err)
 		})
 	}
 }
 
-func (suite *userStoredDataTestSuite) TestDeleteBatch() {
+func (suite *userStoredDataTestSuite) TestApplySync() {
 	testCases := []struct {
 		name    string
 		err     error
-		prepare func() []int
+		prepare func() domain.LocalSyncChanges
 	}{
 		{
 			name: "valid",
 			err:  nil,
-			prepare: func() []int {
-				ids := []int{1}
+			prepare: func() domain.LocalSyncChanges {
+				changes := domain.LocalSyncChanges{
+					Deleted: []string{testRecordUUID},
+					Added: []domain.UserStoredData{
+						{UUID: testRecordUUID, ID: 1, Version: 1, DataType: domain.TextDataType, Data: domain.TextData{Text: "text"}},
+					},
+				}
+				jsonData, _ := json.Marshal(domain.TextData{Text: "text"})
+
+				suite.cryptor.
+					EXPECT().
+					EncryptBytes(jsonData).
+					Return([]byte("crypted"), nil)
+
 				suite.repository.
 					EXPECT().
-					DeleteBatch(gomock.Any(), ids).
+					ApplySync(gomock.Any(), domain.LocalSyncChanges{
+						Deleted: []string{testRecordUUID},
+						Updated: []domain.UserStoredData{},
+						Added: []domain.UserStoredData{
+							{UUID: testRecordUUID, ID: 1, Version: 1, DataType: domain.TextDataType, CryptedData: []byte("crypted")},
+						},
+						Copies: []domain.UserStoredData{},
+					}).
 					Return(nil)
-				return ids
+
+				return changes
+			},
+		},
+		{
+			name: "err when encrypt",
+			err:  domain.ErrInternal,
+			prepare: func() domain.LocalSyncChanges {
+				suite.cryptor.
+					EXPECT().
+					EncryptBytes(gomock.Any()).
+					Return(nil, domain.ErrInternal)
+
+				return domain.LocalSyncChanges{
+					Updated: []domain.UserStoredData{{UUID: testRecordUUID, Data: domain.TextData{Text: "text"}}},
+				}
 			},
 		},
 		{
 			name: "err",
 			err:  domain.ErrInternal,
-			prepare: func() []int {
-				ids := []int{1}
+			prepare: func() domain.LocalSyncChanges {
 				suite.repository.
 					EXPECT().
-					DeleteBatch(gomock.Any(), ids).
+					ApplySync(gomock.Any(), gomock.Any()).
 					Return(domain.ErrInternal)
-				return ids
+
+				return domain.LocalSyncChanges{Deleted: []string{testRecordUUID}}
 			},
 		},
 	}
 
 	for _, testCase := range testCases {
 		suite.Run(testCase.name, func() {
-			ids := testCase.prepare()
-			err := suite.service.DeleteBatch(context.Background(), ids)
+			changes := testCase.prepare()
+			err := suite.service.ApplySync(context.Background(), changes)
 			suite.Equal(testCase.err, err)
 		})
 	}
 }
 
-func (suite *userStoredDataTestSuite) TestDeleteByID() {
+func (suite *userStoredDataTestSuite) TestDeleteByUUID() {
 	testCases := []struct {
 		name    string
 		err     error
-		prepare func() int
+		prepare func() string
 	}{
 		{
 			name: "valid",
 			err:  nil,
-			prepare: func() int {
-				id := 1
+			prepare: func() string {
+				recordUUID := testRecordUUID
 				suite.repository.
 					EXPECT().
-					DeleteByID(gomock.Any(), id).
+					DeleteByUUID(gomock.Any(), recordUUID).
 					Return(nil)
-				return id
+				return recordUUID
 			},
 		},
 		{
 			name: "err",
 			err:  domain.ErrInternal,
-			prepare: func() int {
-				id := 1
+			prepare: func() string {
+				recordUUID := testRecordUUID
 				suite.repository.
 					EXPECT().
-					DeleteByID(gomock.Any(), id).
+					DeleteByUUID(gomock.Any(), recordUUID).
 					Return(domain.ErrInternal)
-				return id
+				return recordUUID
 			},
 		},
 	}
 
 	for _, testCase := range testCases {
 		suite.Run(testCase.name, func() {
-			id := testCase.prepare()
-			err := suite.service.DeleteByID(context.Background(), id)
+			recordUUID := testCase.prepare()
+			err := suite.service.DeleteByUUID(context.Background(), recordUUID)
 			suite.Equal(testCase.err, err)
 		})
 	}
@@ -0,0 +1,116 @@
+package client
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+)
+
+type personalVaultService interface {
+	GetUserData(ctx context.Context, dataType string, filters *domain.StorageFilters) (*domain.PaginatedResult, error)
+	Add(ctx context.Context, dataType string, data interface{}, meta string) (*domain.UserStoredData, error)
+	GetByRef(ctx context.Context, ref string) (*domain.UserStoredData, error)
+	UpdateByUUID(ctx context.Context, recordUUID string, data interface{}, meta string) (*domain.UserStoredData, error)
+	DeleteByUUID(ctx context.Context, recordUUID string) error
+}
+
+type collectionDataAPI interface {
+	GetByRef(ctx context.Context, ref string) (*domain.UserStoredData, error)
+	GetCollectionData(ctx context.Context, collectionID int, dataType string, page int, count int) (*domain.PaginatedResult, error)
+	AddToCollection(ctx context.Context, collectionID int, entity domain.UserStoredData) (*domain.UserStoredData, error)
+	UpdateByID(ctx context.Context, id int, expectedVersion int, data interface{}, meta string) (*domain.UserStoredData, error)
+	DeleteCollectionBatch(ctx context.Context, collectionID int, ids []int) error
+}
+
+type sessionForVaultService interface {
+	GetActiveCollectionID() int
+}
+
+// VaultService - routes data commands to local personal vault or to organization collection
+// on server, depending on which vault is active in session. Collections are not cached locally,
+// so working with them requires connection to server
+type VaultService struct {
+	personal      personalVaultService
+	collectionAPI collectionDataAPI
+	session       sessionForVaultService
+}
+
+func NewVaultService(
+	personal personalVaultService,
+	collectionAPI collectionDataAPI,
+	session sessionForVaultService,
+) *VaultService {
+	return &VaultService{
+		personal:      personal,
+		collectionAPI: collectionAPI,
+		session:       session,
+	}
+}
+
+func (s *VaultService) GetUserData(ctx context.Context, dataType string, filters *domain.StorageFilters) (*domain.PaginatedResult, error) {
+	collectionID := s.session.GetActiveCollectionID()
+	if collectionID == 0 {
+		return s.personal.GetUserData(ctx, dataType, filters)
+	}
+
+	return s.collectionAPI.GetCollectionData(ctx, collectionID, dataType, filters.Pagination.Page, filters.Pagination.Count)
+}
+
+func (s *VaultService) Add(ctx context.Context, dataType string, data interface{}, meta string) (*domain.UserStoredData, error) {
+	collectionID := s.session.GetActiveCollectionID()
+	if collectionID == 0 {
+		return s.personal.Add(ctx, dataType, data, meta)
+	}
+
+	return s.collectionAPI.AddToCollection(ctx, collectionID, domain.UserStoredData{
+		UUID:     uuid.NewString(),
+		DataType: dataType,
+		Data:     data,
+		Meta:     meta,
+	})
+}
+
+// GetByRef - get record by uuid or by its prefix, collection records are searched by server
+func (s *VaultService) GetByRef(ctx context.Context, ref string) (*domain.UserStoredData, error) {
+	collectionID := s.session.GetActiveCollectionID()
+	if collectionID == 0 {
+		return s.personal.GetByRef(ctx, ref)
+	}
+
+	ref, err := domain.ParseRecordRef(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := s.collectionAPI.GetByRef(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	if data.CollectionID != collectionID {
+		return nil, domain.ErrUserStoredDataNotFound
+	}
+
+	return data, nil
+}
+
+// Update - expected version is checked only for collection records, which can be edited by other members
+// at the same time. Personal records are checked on sync
+func (s *VaultService) Update(ctx context.Context, record *domain.UserStoredData, expectedVersion int, data interface{}, meta string) (*domain.UserStoredData, error) {
+	if s.session.GetActiveCollectionID() == 0 {
+		return s.personal.UpdateByUUID(ctx, record.UUID, data, meta)
+	}
+
+	return s.collectionAPI.UpdateByID(ctx, record.ID, expectedVersion, data, meta)
+}
+
+func (s *VaultService) Delete(ctx context.Context, record *domain.UserStoredData) error {
+	collectionID := s.session.GetActiveCollectionID()
+	if collectionID == 0 {
+		return s.personal.DeleteByUUID(ctx, record.UUID)
+	}
+
+	return s.collectionAPI.DeleteCollectionBatch(ctx, collectionID, []int{record.ID})
+}
@@ -0,0 +1,231 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+	mock_client "github.com/MowlCoder/goph-keeper/internal/services/client/mocks"
+)
+
+type vaultTestSuite struct {
+	suite.Suite
+
+	personal      *mock_client.MockpersonalVaultService
+	collectionAPI *mock_client.MockcollectionDataAPI
+	session       *mock_client.MocksessionForVaultService
+
+	service *VaultService
+}
+
+func (suite *vaultTestSuite) SetupSuite() {
+}
+
+func (suite *vaultTestSuite) TearDownSuite() {
+}
+
+func (suite *vaultTestSuite) SetupTest() {
+	ctrl := gomock.NewController(suite.T())
+
+	suite.personal = mock_client.NewMockpersonalVaultService(ctrl)
+	suite.collectionAPI = mock_client.NewMockcollectionDataAPI(ctrl)
+	suite.session = mock_client.NewMocksessionForVaultService(ctrl)
+
+	suite.service = NewVaultService(suite.personal, suite.collectionAPI, suite.session)
+}
+
+func (suite *vaultTestSuite) TearDownTest() {
+}
+
+func TestVaultSuite(t *testing.T) {
+	suite.Run(t, new(vaultTestSuite))
+}
+
+func (suite *vaultTestSuite) TestGetByRef() {
+	testCases := []struct {
+		name    string
+		err     error
+		prepare func() string
+	}{
+		{
+			name: "personal vault",
+			err:  nil,
+			prepare: func() string {
+				ref := testRecordUUID
+
+				suite.session.EXPECT().GetActiveCollectionID().Return(0)
+				suite.personal.
+					EXPECT().
+					GetByRef(gomock.Any(), ref).
+					Return(&domain.UserStoredData{UUID: ref}, nil)
+
+				return ref
+			},
+		},
+		{
+			name: "collection",
+			err:  nil,
+			prepare: func() string {
+				ref := testRecordUUID
+
+				suite.session.EXPECT().GetActiveCollectionID().Return(3)
+				suite.collectionAPI.
+					EXPECT().
+					GetByRef(gomock.Any(), ref).
+					Return(&domain.UserStoredData{UUID: ref, CollectionID: 3}, nil)
+
+				return ref
+			},
+		},
+		{
+			name: "record from another vault",
+			err:  domain.ErrUserStoredDataNotFound,
+			prepare: func() string {
+				ref := testRecordUUID
+
+				suite.session.EXPECT().GetActiveCollectionID().Return(3)
+				suite.collectionAPI.
+					EXPECT().
+					GetByRef(gomock.Any(), ref).
+					Return(&domain.UserStoredData{UUID: ref}, nil)
+
+				return ref
+			},
+		},
+		{
+			name: "invalid ref in collection",
+			err:  domain.ErrInvalidRecordRef,
+			prepare: func() string {
+				suite.session.EXPECT().GetActiveCollectionID().Return(3)
+
+				return "not-uuid"
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.name, func() {
+			ref := testCase.prepare()
+			_, err := suite.service.GetByRef(context.Background(), ref)
+			suite.Equal(testCase.err, err)
+		})
+	}
+}
+
+func (suite *vaultTestSuite) TestAdd() {
+	testCases := []struct {
+		name    string
+		err     error
+		prepare func()
+	}{
+		{
+			name: "personal vault",
+			err:  nil,
+			prepare: func() {
+				suite.session.EXPECT().GetActiveCollectionID().Return(0)
+				suite.personal.
+					EXPECT().
+					Add(gomock.Any(), domain.TextDataType, domain.TextData{Text: "text"}, "meta").
+					Return(&domain.UserStoredData{}, nil)
+			},
+		},
+		{
+			name: "collection",
+			err:  nil,
+			prepare: func() {
+				suite.session.EXPECT().GetActiveCollectionID().Return(3)
+				suite.collectionAPI.
+					EXPECT().
+					AddToCollection(gomock.Any(), 3, gomock.Any()).
+					Return(&domain.UserStoredData{}, nil)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.name, func() {
+			testCase.prepare()
+			_, err := suite.service.Add(context.Background(), domain.TextDataType, domain.TextData{Text: "text"}, "meta")
+			suite.Equal(testCase.err, err)
+		})
+	}
+}
+
+func (suite *vaultTestSuite) TestDelete() {
+	testCases := []struct {
+		name    string
+		err     error
+		prepare func()
+	}{
+		{
+			name: "personal vault",
+			err:  nil,
+			prepare: func() {
+				suite.session.EXPECT().GetActiveCollectionID().Return(0)
+				suite.personal.
+					EXPECT().
+					DeleteByUUID(gomock.Any(), testRecordUUID).
+					Return(nil)
+			},
+		},
+		{
+			name: "collection",
+			err:  nil,
+			prepare: func() {
+				suite.session.EXPECT().GetActiveCollectionID().Return(3)
+				suite.collectionAPI.
+					EXPECT().
+					DeleteCollectionBatch(gomock.Any(), 3, []int{1}).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.name, func() {
+			testCase.prepare()
+			err := suite.service.Delete(context.Background(), &domain.UserStoredData{ID: 1, UUID: testRecordUUID})
+			suite.Equal(testCase.err, err)
+		})
+	}
+}
+
+func (suite *vaultTestSuite) TestUpdate() {
+	testCases := []struct {
+		name    string
+		prepare func()
+	}{
+		{
+			name: "personal vault",
+			prepare: func() {
+				suite.session.EXPECT().GetActiveCollectionID().Return(0)
+				suite.personal.
+					EXPECT().
+					UpdateByUUID(gomock.Any(), testRecordUUID, domain.TextData{Text: "text"}, "meta").
+					Return(&domain.UserStoredData{ID: 1, UUID: testRecordUUID}, nil)
+			},
+		},
+		{
+			name: "collection",
+			prepare: func() {
+				suite.session.EXPECT().GetActiveCollectionID().Return(3)
+				suite.collectionAPI.
+					EXPECT().
+					UpdateByID(gomock.Any(), 1, 2, domain.TextData{Text: "text"}, "meta").
+					Return(&domain.UserStoredData{ID: 1, Version: 3}, nil)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.name, func() {
+			testCase.prepare()
+			record := &domain.UserStoredData{ID: 1, UUID: testRecordUUID}
+			_, err := suite.service.Update(context.Background(), record, 2, domain.TextData{Text: "text"}, "meta")
+			suite.NoError(err)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+)
+
+type auditRepository interface {
+	Create(ctx context.Context, event domain.AuditEvent) error
+	GetUserEvents(ctx context.Context, userID int, filters *domain.StorageFilters) ([]domain.AuditEvent, error)
+	CountUserEvents(ctx context.Context, userID int) (int, error)
+}
+
+type userRepositoryForAuditService interface {
+	GetByEmail(ctx context.Context, email string) (*domain.User, error)
+}
+
+type AuditService struct {
+	repository     auditRepository
+	userRepository userRepositoryForAuditService
+	logger         *slog.Logger
+}
+
+func NewAuditService(
+	repository auditRepository,
+	userRepository userRepositoryForAuditService,
+	logger *slog.Logger,
+) *AuditService {
+	return &AuditService{
+		repository:     repository,
+		userRepository: userRepository,
+		logger:         logger,
+	}
+}
+
+// Record - save audit event. Failure to write event must not break user request, so error is only logged.
+// Event is written even if request context was canceled because client went away.
+func (s *AuditService) Record(ctx context.Context, event domain.AuditEvent) {
+	ctx = context.WithoutCancel(ctx)
+
+	if event.UserID == 0 && event.Email != "" {
+		user, err := s.userRepository.GetByEmail(ctx, event.Email)
+		switch {
+		case err == nil:
+			event.UserID = user.ID
+		case !errors.Is(err, domain.ErrUserNotFound):
+			s.logger.ErrorContext(ctx, "failed to find user of audit event", "type", event.Type, "error", err.Error())
+		}
+	}
+
+	if err := s.repository.Create(ctx, event); err != nil {
+		s.logger.ErrorContext(ctx, "failed to record audit event", "type", event.Type, "error", err.Error())
+	}
+}
+
+func (s *AuditService) GetUserEvents(ctx context.Context, userID int, filters *domain.StorageFilters) (*domain.PaginatedResult, error) {
+	events, err := s.repository.GetUserEvents(ctx, userID, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	eventsCount, err := s.repository.CountUserEvents(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.PaginatedResult{
+		Count:       filters.Pagination.Count,
+		CurrentPage: filters.Pagination.Page,
+		PageCount:   int(math.Ceil(float64(eventsCount) / float64(filters.Pagination.Count))),
+		Data:        events,
+	}, nil
+}
@@ -0,0 +1,182 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+	mock_server "github.com/MowlCoder/goph-keeper/internal/services/server/mocks"
+)
+
+type auditTestSuite struct {
+	suite.Suite
+
+	repository     *mock_server.MockauditRepository
+	userRepository *mock_server.MockuserRepositoryForAuditService
+
+	service *AuditService
+}
+
+func (suite *auditTestSuite) SetupSuite() {
+}
+
+func (suite *auditTestSuite) TearDownSuite() {
+}
+
+func (suite *auditTestSuite) SetupTest() {
+	ctrl := gomock.NewController(suite.T())
+
+	suite.repository = mock_server.NewMockauditRepository(ctrl)
+	suite.userRepository = mock_server.NewMockuserRepositoryForAuditService(ctrl)
+
+	suite.service = NewAuditService(suite.repository, suite.userRepository, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func (suite *auditTestSuite) TearDownTest() {
+}
+
+func TestAuditSuite(t *testing.T) {
+	suite.Run(t, new(auditTestSuite))
+}
+
+func (suite *auditTestSuite) TestRecord() {
+	testCases := []struct {
+		name    string
+		prepare func() domain.AuditEvent
+	}{
+		{
+			name: "event with user",
+			prepare: func() domain.AuditEvent {
+				event := domain.AuditEvent{UserID: 1, Type: domain.AuditEventLogin, ClientIP: "127.0.0.1"}
+
+				suite.repository.
+					EXPECT().
+					Create(gomock.Any(), event).
+					Return(nil)
+
+				return event
+			},
+		},
+		{
+			name: "failed login of existing user",
+			prepare: func() domain.AuditEvent {
+				event := domain.AuditEvent{Type: domain.AuditEventLoginFailed, Email: "test@gmail.com"}
+
+				suite.userRepository.
+					EXPECT().
+					GetByEmail(gomock.Any(), event.Email).
+					Return(&domain.User{ID: 5, Email: event.Email}, nil)
+
+				suite.repository.
+					EXPECT().
+					Create(gomock.Any(), domain.AuditEvent{UserID: 5, Type: domain.AuditEventLoginFailed, Email: event.Email}).
+					Return(nil)
+
+				return event
+			},
+		},
+		{
+			name: "failed login of unknown user",
+			prepare: func() domain.AuditEvent {
+				event := domain.AuditEvent{Type: domain.AuditEventLoginFailed, Email: "test@gmail.com"}
+
+				suite.userRepository.
+					EXPECT().
+					GetByEmail(gomock.Any(), event.Email).
+					Return(nil, domain.ErrUserNotFound)
+
+				suite.repository.
+					EXPECT().
+					Create(gomock.Any(), event).
+					Return(nil)
+
+				return event
+			},
+		},
+		{
+			name: "failed lookup of user still records event",
+			prepare: func() domain.AuditEvent {
+				event := domain.AuditEvent{Type: domain.AuditEventLoginFailed, Email: "test@gmail.com"}
+
+				suite.userRepository.
+					EXPECT().
+					GetByEmail(gomock.Any(), event.Email).
+					Return(nil, errors.New("db is down"))
+
+				suite.repository.
+					EXPECT().
+					Create(gomock.Any(), event).
+					Return(nil)
+
+				return event
+			},
+		},
+		{
+			name: "repository error is not propagated",
+			prepare: func() domain.AuditEvent {
+				event := domain.AuditEvent{UserID: 1, Type: domain.AuditEventDataCreate, RecordID: 2}
+
+				suite.repository.
+					EXPECT().
+					Create(gomock.Any(), event).
+					Return(errors.New("db is down"))
+
+				return event
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.name, func() {
+			event := testCase.prepare()
+			suite.service.Record(context.Background(), event)
+		})
+	}
+}
+
+func (suite *auditTestSuite) TestRecordWithCanceledContext() {
+	event := domain.AuditEvent{UserID: 1, Type: domain.AuditEventDataDelete, RecordID: 2}
+
+	suite.repository.
+		EXPECT().
+		Create(gomock.Any(), event).
+		DoAndReturn(func(ctx context.Context, event domain.AuditEvent) error {
+			suite.NoError(ctx.Err())
+			return nil
+		})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	suite.service.Record(ctx, event)
+}
+
+func (suite *auditTestSuite) TestGetUserEvents() {
+	userID := 1
+	filters := &domain.StorageFilters{
+		IsPaginated: true,
+		Pagination:  domain.PaginationFilters{Page: 1, Count: 2},
+	}
+	events := []domain.AuditEvent{{ID: 1, UserID: userID}, {ID: 2, UserID: userID}}
+
+	suite.repository.
+		EXPECT().
+		GetUserEvents(gomock.Any(), userID, filters).
+		Return(events, nil)
+
+	suite.repository.
+		EXPECT().
+		CountUserEvents(gomock.Any(), userID).
+		Return(5, nil)
+
+	result, err := suite.service.GetUserEvents(context.Background(), userID, filters)
+	suite.NoError(err)
+	suite.Equal(3, result.PageCount)
+	suite.Equal(events, result.Data)
+}
@@ -0,0 +1,104 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+	"github.com/MowlCoder/goph-keeper/internal/tracing"
+)
+
+// deviceSeenPrecision - last seen time is not updated more often, so every request does not write to database
+const deviceSeenPrecision = time.Minute
+
+type deviceRepository interface {
+	Create(ctx context.Context, userID int, name string, platform string) (*domain.Device, error)
+	GetByID(ctx context.Context, id int) (*domain.Device, error)
+	GetByUser(ctx context.Context, userID int) ([]domain.Device, error)
+	UpdateLastSeen(ctx context.Context, id int) error
+	UpdateSyncCursor(ctx context.Context, userID int, id int, cursor int64) error
+	Delete(ctx context.Context, userID int, id int) error
+}
+
+type DeviceService struct {
+	repository deviceRepository
+}
+
+func NewDeviceService(repository deviceRepository) *DeviceService {
+	return &DeviceService{
+		repository: repository,
+	}
+}
+
+// Register - add device user has just logged in from. Device of user client logged in from before is reused,
+// so every login does not leave device, which holds back collection of tombstones. Revoked device or device
+// of other user is not reused, new one is registered instead
+func (s *DeviceService) Register(ctx context.Context, userID int, deviceID int, name string, platform string) (*domain.Device, error) {
+	if deviceID > 0 {
+		device, err := s.repository.GetByID(ctx, deviceID)
+		if err != nil && !errors.Is(err, domain.ErrDeviceNotFound) {
+			return nil, err
+		}
+
+		if err == nil && device.UserID == userID {
+			return device, s.repository.UpdateLastSeen(ctx, device.ID)
+		}
+	}
+
+	if name == "" {
+		name = domain.DefaultDeviceName
+	}
+
+	return s.repository.Create(ctx, userID, name, platform)
+}
+
+// Touch - check that device of token is not revoked and remember that it was seen
+func (s *DeviceService) Touch(ctx context.Context, userID int, deviceID int) error {
+	ctx, span := tracing.Start(ctx, "DeviceService.Touch")
+	defer span.End()
+
+	device, err := s.repository.GetByID(ctx, deviceID)
+	if err != nil {
+		return err
+	}
+
+	if device.UserID != userID {
+		return domain.ErrDeviceNotFound
+	}
+
+	if time.Since(device.LastSeenAt) < deviceSeenPrecision {
+		return nil
+	}
+
+	return s.repository.UpdateLastSeen(ctx, deviceID)
+}
+
+// GetUserDevices - devices of user, device which made request is marked as current
+func (s *DeviceService) GetUserDevices(ctx context.Context, userID int, currentDeviceID int) ([]domain.Device, error) {
+	devices, err := s.repository.GetByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for idx := range devices {
+		devices[idx].Current = devices[idx].ID == currentDeviceID
+	}
+
+	return devices, nil
+}
+
+// Revoke - remove device, its token stops working on next request
+func (s *DeviceService) Revoke(ctx context.Context, userID int, deviceID int) error {
+	return s.repository.Delete(ctx, userID, deviceID)
+}
+
+// AcknowledgeSync - remember cursor of personal vault change feed device has fully applied, tombstones
+// before cursors of all user devices can be collected
+func (s *DeviceService) AcknowledgeSync(ctx context.Context, userID int, deviceID int, cursor int64) error {
+	if cursor < 0 {
+		return domain.ErrInvalidSyncCursor
+	}
+
+	return s.repository.UpdateSyncCursor(ctx, userID, deviceID, cursor)
+}
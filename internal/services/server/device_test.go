@@ -0,0 +1,251 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+	mock_server "github.com/MowlCoder/goph-keeper/internal/services/server/mocks"
+)
+
+type deviceTestSuite struct {
+	suite.Suite
+
+	repository *mock_server.MockdeviceRepository
+
+	service *DeviceService
+}
+
+func (suite *deviceTestSuite) SetupSuite() {
+}
+
+func (suite *deviceTestSuite) TearDownSuite() {
+}
+
+func (suite *deviceTestSuite) SetupTest() {
+	ctrl := gomock.NewController(suite.T())
+
+	suite.repository = mock_server.NewMockdeviceRepository(ctrl)
+
+	suite.service = NewDeviceService(suite.repository)
+}
+
+func (suite *deviceTestSuite) TearDownTest() {
+}
+
+func TestDeviceSuite(t *testing.T) {
+	suite.Run(t, new(deviceTestSuite))
+}
+
+func (suite *deviceTestSuite) TestRegister() {
+	testCases := []struct {
+		name         string
+		deviceID     int
+		deviceName   string
+		expectedID   int
+		expectedName string
+		prepare      func()
+	}{
+		{
+			name:         "with name",
+			deviceName:   "laptop",
+			expectedID:   2,
+			expectedName: "laptop",
+			prepare: func() {
+				suite.repository.
+					EXPECT().
+					Create(gomock.Any(), 1, "laptop", "linux/amd64").
+					Return(&domain.Device{ID: 2, UserID: 1, Name: "laptop"}, nil)
+			},
+		},
+		{
+			name:         "without name",
+			deviceName:   "",
+			expectedID:   2,
+			expectedName: domain.DefaultDeviceName,
+			prepare: func() {
+				suite.repository.
+					EXPECT().
+					Create(gomock.Any(), 1, domain.DefaultDeviceName, "linux/amd64").
+					Return(&domain.Device{ID: 2, UserID: 1, Name: domain.DefaultDeviceName}, nil)
+			},
+		},
+		{
+			name:         "known device",
+			deviceID:     3,
+			deviceName:   "laptop",
+			expectedID:   3,
+			expectedName: "old laptop",
+			prepare: func() {
+				suite.repository.
+					EXPECT().
+					GetByID(gomock.Any(), 3).
+					Return(&domain.Device{ID: 3, UserID: 1, Name: "old laptop"}, nil)
+
+				suite.repository.
+					EXPECT().
+					UpdateLastSeen(gomock.Any(), 3).
+					Return(nil)
+			},
+		},
+		{
+			name:         "revoked device",
+			deviceID:     3,
+			deviceName:   "laptop",
+			expectedID:   2,
+			expectedName: "laptop",
+			prepare: func() {
+				suite.repository.
+					EXPECT().
+					GetByID(gomock.Any(), 3).
+					Return(nil, domain.ErrDeviceNotFound)
+
+				suite.repository.
+					EXPECT().
+					Create(gomock.Any(), 1, "laptop", "linux/amd64").
+					Return(&domain.Device{ID: 2, UserID: 1, Name: "laptop"}, nil)
+			},
+		},
+		{
+			name:         "device of other user",
+			deviceID:     3,
+			deviceName:   "laptop",
+			expectedID:   2,
+			expectedName: "laptop",
+			prepare: func() {
+				suite.repository.
+					EXPECT().
+					GetByID(gomock.Any(), 3).
+					Return(&domain.Device{ID: 3, UserID: 7, Name: "other laptop"}, nil)
+
+				suite.repository.
+					EXPECT().
+					Create(gomock.Any(), 1, "laptop", "linux/amd64").
+					Return(&domain.Device{ID: 2, UserID: 1, Name: "laptop"}, nil)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.name, func() {
+			testCase.prepare()
+
+			device, err := suite.service.Register(context.Background(), 1, testCase.deviceID, testCase.deviceName, "linux/amd64")
+			suite.NoError(err)
+			suite.Equal(testCase.expectedID, device.ID)
+			suite.Equal(testCase.expectedName, device.Name)
+		})
+	}
+}
+
+func (suite *deviceTestSuite) TestTouch() {
+	testCases := []struct {
+		name    string
+		err     error
+		prepare func()
+	}{
+		{
+			name: "seen recently",
+			prepare: func() {
+				suite.repository.
+					EXPECT().
+					GetByID(gomock.Any(), 2).
+					Return(&domain.Device{ID: 2, UserID: 1, LastSeenAt: time.Now()}, nil)
+			},
+		},
+		{
+			name: "seen long ago",
+			prepare: func() {
+				suite.repository.
+					EXPECT().
+					GetByID(gomock.Any(), 2).
+					Return(&domain.Device{ID: 2, UserID: 1, LastSeenAt: time.Now().Add(-time.Hour)}, nil)
+
+				suite.repository.
+					EXPECT().
+					UpdateLastSeen(gomock.Any(), 2).
+					Return(nil)
+			},
+		},
+		{
+			name: "revoked",
+			err:  domain.ErrDeviceNotFound,
+			prepare: func() {
+				suite.repository.
+					EXPECT().
+					GetByID(gomock.Any(), 2).
+					Return(nil, domain.ErrDeviceNotFound)
+			},
+		},
+		{
+			name: "device of other user",
+			err:  domain.ErrDeviceNotFound,
+			prepare: func() {
+				suite.repository.
+					EXPECT().
+					GetByID(gomock.Any(), 2).
+					Return(&domain.Device{ID: 2, UserID: 3}, nil)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.name, func() {
+			testCase.prepare()
+
+			err := suite.service.Touch(context.Background(), 1, 2)
+			suite.Equal(testCase.err, err)
+		})
+	}
+}
+
+func (suite *deviceTestSuite) TestGetUserDevices() {
+	suite.repository.
+		EXPECT().
+		GetByUser(gomock.Any(), 1).
+		Return([]domain.Device{{ID: 2, UserID: 1}, {ID: 3, UserID: 1}}, nil)
+
+	devices, err := suite.service.GetUserDevices(context.Background(), 1, 3)
+	suite.NoError(err)
+	suite.False(devices[0].Current)
+	suite.True(devices[1].Current)
+}
+
+func (suite *deviceTestSuite) TestAcknowledgeSync() {
+	testCases := []struct {
+		name    string
+		cursor  int64
+		err     error
+		prepare func()
+	}{
+		{
+			name:   "valid",
+			cursor: 15,
+			prepare: func() {
+				suite.repository.
+					EXPECT().
+					UpdateSyncCursor(gomock.Any(), 1, 2, int64(15)).
+					Return(nil)
+			},
+		},
+		{
+			name:    "negative cursor",
+			cursor:  -1,
+			err:     domain.ErrInvalidSyncCursor,
+			prepare: func() {},
+		},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.name, func() {
+			testCase.prepare()
+
+			err := suite.service.AcknowledgeSync(context.Background(), 1, 2, testCase.cursor)
+			suite.Equal(testCase.err, err)
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package server
+
+import (
+	"context"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+)
+
+type emergencyAccessRepository interface {
+	Create(ctx context.Context, grantorID int, granteeID int, waitDays int) (*domain.EmergencyAccess, error)
+	GetByID(ctx context.Context, id int) (*domain.EmergencyAccess, error)
+	GetByGrantor(ctx context.Context, grantorID int) ([]domain.EmergencyAccess, error)
+	GetByGrantee(ctx context.Context, granteeID int) ([]domain.EmergencyAccess, error)
+	UpdateStatus(ctx context.Context, id int, fromStatus string, toStatus string) error
+	ApproveExpired(ctx context.Context) (int64, error)
+	DeleteByID(ctx context.Context, id int) error
+}
+
+type userRepositoryForEmergencyAccessService interface {
+	GetByEmail(ctx context.Context, email string) (*domain.User, error)
+}
+
+type dataRepositoryForEmergencyAccessService interface {
+	GetUserAllData(ctx context.Context, userID int) ([]domain.UserStoredData, error)
+}
+
+type cryptorForEmergencyAccessService interface {
+	DecryptBytes(crypted []byte) ([]byte, error)
+}
+
+type EmergencyAccessService struct {
+	repository     emergencyAccessRepository
+	userRepository userRepositoryForEmergencyAccessService
+	dataRepository dataRepositoryForEmergencyAccessService
+	cryptor        cryptorForEmergencyAccessService
+}
+
+func NewEmergencyAccessService(
+	repository emergencyAccessRepository,
+	userRepository userRepositoryForEmergencyAccessService,
+	dataRepository dataRepositoryForEmergencyAccessService,
+	cryptor cryptorForEmergencyAccessService,
+) *EmergencyAccessService {
+	return &EmergencyAccessService{
+		repository:     repository,
+		userRepository: userRepository,
+		dataRepository: dataRepository,
+		cryptor:        cryptor,
+	}
+}
+
+// Invite - grantor designates user with given email as trusted contact
+func (s *EmergencyAccessService) Invite(ctx context.Context, grantorID int, granteeEmail string, waitDays int) (*domain.EmergencyAccess, error) {
+	if !domain.IsValidEmergencyWaitDays(waitDays) {
+		return nil, domain.ErrInvalidEmergencyWaitDays
+	}
+
+	grantee, err := s.userRepository.GetByEmail(ctx, granteeEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	if grantee.ID == grantorID {
+		return nil, domain.ErrEmergencyAccessToYourself
+	}
+
+	access, err := s.repository.Create(ctx, grantorID, grantee.ID, waitDays)
+	if err != nil {
+		return nil, err
+	}
+
+	access.GranteeEmail = grantee.Email
+
+	return access, nil
+}
+
+// Confirm - grantee agrees to be trusted contact
+func (s *EmergencyAccessService) Confirm(ctx context.Context, granteeID int, id int) error {
+	access, err := s.getAsGrantee(ctx, granteeID, id)
+	if err != nil {
+		return err
+	}
+
+	return s.transition(ctx, access, domain.EmergencyAccessConfirmed)
+}
+
+// Initiate - grantee requests access to grantor vault, waiting period starts
+func (s *EmergencyAccessService) Initiate(ctx context.Context, granteeID int, id int) error {
+	access, err := s.getAsGrantee(ctx, granteeID, id)
+	if err != nil {
+		return err
+	}
+
+	return s.transition(ctx, access, domain.EmergencyAccessRecoveryInitiated)
+}
+
+// Approve - grantor approves recovery without waiting
+func (s *EmergencyAccessService) Approve(ctx context.Context, grantorID int, id int) error {
+	access, err := s.getAsGrantor(ctx, grantorID, id)
+	if err != nil {
+		return err
+	}
+
+	return s.transition(ctx, access, domain.EmergencyAccessApproved)
+}
+
+// Reject - grantor rejects recovery before waiting period is over
+func (s *EmergencyAccessService) Reject(ctx context.Context, grantorID int, id int) error {
+	access, err := s.getAsGrantor(ctx, grantorID, id)
+	if err != nil {
+		return err
+	}
+
+	return s.transition(ctx, access, domain.EmergencyAccessRejected)
+}
+
+// Revoke - remove emergency access in any status (available for grantor and grantee)
+func (s *EmergencyAccessService) Revoke(ctx context.Context, userID int, id int) error {
+	access, err := s.repository.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if access.GrantorID != userID && access.GranteeID != userID {
+		return domain.ErrEmergencyAccessNotFound
+	}
+
+	return s.repository.DeleteByID(ctx, id)
+}
+
+func (s *EmergencyAccessService) GetGranted(ctx context.Context, grantorID int) ([]domain.EmergencyAccess, error) {
+	return s.repository.GetByGrantor(ctx, grantorID)
+}
+
+func (s *EmergencyAccessService) GetTrusted(ctx context.Context, granteeID int) ([]domain.EmergencyAccess, error) {
+	return s.repository.GetByGrantee(ctx, granteeID)
+}
+
+// GetVault - read-only view of grantor personal vault for grantee with approved access
+func (s *EmergencyAccessService) GetVault(ctx context.Context, granteeID int, id int) ([]domain.UserStoredData, error) {
+	access, err := s.getAsGrantee(ctx, granteeID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if access.Status != domain.EmergencyAccessApproved {
+		return nil, domain.ErrEmergencyAccessInvalidStatus
+	}
+
+	dataSet, err := s.dataRepository.GetUserAllData(ctx, access.GrantorID)
+	if err != nil {
+		return nil, err
+	}
+
+	for idx, data := range dataSet {
+		decryptedBytes, err := s.cryptor.DecryptBytes(data.CryptedData)
+		if err != nil {
+			return nil, err
+		}
+		parsedData, err := domain.ParseUserStoredData(data.DataType, decryptedBytes)
+		if err != nil {
+			return nil, err
+		}
+
+		dataSet[idx].Data = parsedData
+		dataSet[idx].CryptedData = nil
+	}
+
+	return dataSet, nil
+}
+
+// ApproveExpired - approve recoveries which were not rejected during waiting period
+func (s *EmergencyAccessService) ApproveExpired(ctx context.Context) (int64, error) {
+	return s.repository.ApproveExpired(ctx)
+}
+
+func (s *EmergencyAccessService) transition(ctx context.Context, access *domain.EmergencyAccess, status string) error {
+	if !access.CanTransitionTo(status) {
+		return domain.ErrEmergencyAccessInvalidStatus
+	}
+
+	return s.repository.UpdateStatus(ctx, access.ID, access.Status, status)
+}
+
+func (s *EmergencyAccessService) getAsGrantor(ctx context.Context, grantorID int, id int) (*domain.EmergencyAccess, error) {
+	access, err := s.repository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if access.GrantorID != grantorID {
+		return nil, domain.ErrEmergencyAccessNotFound
+	}
+
+	return access, nil
+}
+
+func (s *EmergencyAccessService) getAsGrantee(ctx context.Context, granteeID int, id int) (*domain.EmergencyAccess, error) {
+	access, err := s.repository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if access.GranteeID != granteeID {
+		return nil, domain.ErrEmergencyAccessNotFound
+	}
+
+	return access, nil
+}
@@ -0,0 +1,355 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+	mock_server "github.com/MowlCoder/goph-keeper/internal/services/server/mocks"
+)
+
+type emergencyAccessTestSuite struct {
+	suite.Suite
+
+	repository     *mock_server.MockemergencyAccessRepository
+	userRepository *mock_server.MockuserRepositoryForEmergencyAccessService
+	dataRepository *mock_server.MockdataRepositoryForEmergencyAccessService
+	cryptor        *mock_server.MockcryptorForEmergencyAccessService
+
+	service *EmergencyAccessService
+}
+
+func (suite *emergencyAccessTestSuite) SetupSuite() {
+}
+
+func (suite *emergencyAccessTestSuite) TearDownSuite() {
+}
+
+func (suite *emergencyAccessTestSuite) SetupTest() {
+	ctrl := gomock.NewController(suite.T())
+
+	suite.repository = mock_server.NewMockemergencyAccessRepository(ctrl)
+	suite.userRepository = mock_server.NewMockuserRepositoryForEmergencyAccessService(ctrl)
+	suite.dataRepository = mock_server.NewMockdataRepositoryForEmergencyAccessService(ctrl)
+	suite.cryptor = mock_server.NewMockcryptorForEmergencyAccessService(ctrl)
+
+	suite.service = NewEmergencyAccessService(
+		suite.repository,
+		suite.userRepository,
+		suite.dataRepository,
+		suite.cryptor,
+	)
+}
+
+func (suite *emergencyAccessTestSuite) TearDownTest() {
+}
+
+func TestEmergencyAccessSuite(t *testing.T) {
+	suite.Run(t, new(emergencyAccessTestSuite))
+}
+
+func (suite *emergencyAccessTestSuite) TestInvite() {
+	testCases := []struct {
+		name    string
+		err     error
+		prepare func() (int, string, int)
+	}{
+		{
+			name: "valid",
+			err:  nil,
+			prepare: func() (int, string, int) {
+				grantorID := 1
+				email := "test@gmail.com"
+
+				suite.userRepository.
+					EXPECT().
+					GetByEmail(gomock.Any(), email).
+					Return(&domain.User{ID: 2, Email: email}, nil)
+
+				suite.repository.
+					EXPECT().
+					Create(gomock.Any(), grantorID, 2, 7).
+					Return(&domain.EmergencyAccess{ID: 1}, nil)
+
+				return grantorID, email, 7
+			},
+		},
+		{
+			name: "invalid wait days",
+			err:  domain.ErrInvalidEmergencyWaitDays,
+			prepare: func() (int, string, int) {
+				return 1, "test@gmail.com", 0
+			},
+		},
+		{
+			name: "to yourself",
+			err:  domain.ErrEmergencyAccessToYourself,
+			prepare: func() (int, string, int) {
+				grantorID := 1
+				email := "test@gmail.com"
+
+				suite.userRepository.
+					EXPECT().
+					GetByEmail(gomock.Any(), email).
+					Return(&domain.User{ID: grantorID, Email: email}, nil)
+
+				return grantorID, email, 7
+			},
+		},
+		{
+			name: "already exists",
+			err:  domain.ErrEmergencyAccessAlreadyExists,
+			prepare: func() (int, string, int) {
+				grantorID := 1
+				email := "test@gmail.com"
+
+				suite.userRepository.
+					EXPECT().
+					GetByEmail(gomock.Any(), email).
+					Return(&domain.User{ID: 2, Email: email}, nil)
+
+				suite.repository.
+					EXPECT().
+					Create(gomock.Any(), grantorID, 2, 7).
+					Return(nil, domain.ErrEmergencyAccessAlreadyExists)
+
+				return grantorID, email, 7
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.name, func() {
+			grantorID, email, waitDays := testCase.prepare()
+			_, err := suite.service.Invite(context.Background(), grantorID, email, waitDays)
+			suite.Equal(testCase.err, err)
+		})
+	}
+}
+
+func (suite *emergencyAccessTestSuite) TestInitiate() {
+	testCases := []struct {
+		name    string
+		err     error
+		prepare func() (int, int)
+	}{
+		{
+			name: "valid (confirmed)",
+			err:  nil,
+			prepare: func() (int, int) {
+				granteeID, id := 2, 1
+
+				suite.repository.
+					EXPECT().
+					GetByID(gomock.Any(), id).
+					Return(&domain.EmergencyAccess{ID: id, GrantorID: 1, GranteeID: granteeID, Status: domain.EmergencyAccessConfirmed}, nil)
+
+				suite.repository.
+					EXPECT().
+					UpdateStatus(gomock.Any(), id, domain.EmergencyAccessConfirmed, domain.EmergencyAccessRecoveryInitiated).
+					Return(nil)
+
+				return granteeID, id
+			},
+		},
+		{
+			name: "valid (rejected before)",
+			err:  nil,
+			prepare: func() (int, int) {
+				granteeID, id := 2, 1
+
+				suite.repository.
+					EXPECT().
+					GetByID(gomock.Any(), id).
+					Return(&domain.EmergencyAccess{ID: id, GrantorID: 1, GranteeID: granteeID, Status: domain.EmergencyAccessRejected}, nil)
+
+				suite.repository.
+					EXPECT().
+					UpdateStatus(gomock.Any(), id, domain.EmergencyAccessRejected, domain.EmergencyAccessRecoveryInitiated).
+					Return(nil)
+
+				return granteeID, id
+			},
+		},
+		{
+			name: "not confirmed",
+			err:  domain.ErrEmergencyAccessInvalidStatus,
+			prepare: func() (int, int) {
+				granteeID, id := 2, 1
+
+				suite.repository.
+					EXPECT().
+					GetByID(gomock.Any(), id).
+					Return(&domain.EmergencyAccess{ID: id, GrantorID: 1, GranteeID: granteeID, Status: domain.EmergencyAccessInvited}, nil)
+
+				return granteeID, id
+			},
+		},
+		{
+			name: "grantor can not initiate",
+			err:  domain.ErrEmergencyAccessNotFound,
+			prepare: func() (int, int) {
+				grantorID, id := 1, 1
+
+				suite.repository.
+					EXPECT().
+					GetByID(gomock.Any(), id).
+					Return(&domain.EmergencyAccess{ID: id, GrantorID: grantorID, GranteeID: 2, Status: domain.EmergencyAccessConfirmed}, nil)
+
+				return grantorID, id
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.name, func() {
+			userID, id := testCase.prepare()
+			err := suite.service.Initiate(context.Background(), userID, id)
+			suite.Equal(testCase.err, err)
+		})
+	}
+}
+
+func (suite *emergencyAccessTestSuite) TestReject() {
+	testCases := []struct {
+		name    string
+		err     error
+		prepare func() (int, int)
+	}{
+		{
+			name: "valid",
+			err:  nil,
+			prepare: func() (int, int) {
+				grantorID, id := 1, 1
+
+				suite.repository.
+					EXPECT().
+					GetByID(gomock.Any(), id).
+					Return(&domain.EmergencyAccess{ID: id, GrantorID: grantorID, GranteeID: 2, Status: domain.EmergencyAccessRecoveryInitiated}, nil)
+
+				suite.repository.
+					EXPECT().
+					UpdateStatus(gomock.Any(), id, domain.EmergencyAccessRecoveryInitiated, domain.EmergencyAccessRejected).
+					Return(nil)
+
+				return grantorID, id
+			},
+		},
+		{
+			name: "already approved",
+			err:  domain.ErrEmergencyAccessInvalidStatus,
+			prepare: func() (int, int) {
+				grantorID, id := 1, 1
+
+				suite.repository.
+					EXPECT().
+					GetByID(gomock.Any(), id).
+					Return(&domain.EmergencyAccess{ID: id, GrantorID: grantorID, GranteeID: 2, Status: domain.EmergencyAccessApproved}, nil)
+
+				return grantorID, id
+			},
+		},
+		{
+			name: "approved by worker concurrently",
+			err:  domain.ErrEmergencyAccessInvalidStatus,
+			prepare: func() (int, int) {
+				grantorID, id := 1, 1
+
+				suite.repository.
+					EXPECT().
+					GetByID(gomock.Any(), id).
+					Return(&domain.EmergencyAccess{ID: id, GrantorID: grantorID, GranteeID: 2, Status: domain.EmergencyAccessRecoveryInitiated}, nil)
+
+				suite.repository.
+					EXPECT().
+					UpdateStatus(gomock.Any(), id, domain.EmergencyAccessRecoveryInitiated, domain.EmergencyAccessRejected).
+					Return(domain.ErrEmergencyAccessInvalidStatus)
+
+				return grantorID, id
+			},
+		},
+		{
+			name: "grantee can not reject",
+			err:  domain.ErrEmergencyAccessNotFound,
+			prepare: func() (int, int) {
+				granteeID, id := 2, 1
+
+				suite.repository.
+					EXPECT().
+					GetByID(gomock.Any(), id).
+					Return(&domain.EmergencyAccess{ID: id, GrantorID: 1, GranteeID: granteeID, Status: domain.EmergencyAccessRecoveryInitiated}, nil)
+
+				return granteeID, id
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.name, func() {
+			userID, id := testCase.prepare()
+			err := suite.service.Reject(context.Background(), userID, id)
+			suite.Equal(testCase.err, err)
+		})
+	}
+}
+
+func (suite *emergencyAccessTestSuite) TestGetVault() {
+	testCases := []struct {
+		name    string
+		err     error
+		prepare func() (int, int)
+	}{
+		{
+			name: "valid",
+			err:  nil,
+			prepare: func() (int, int) {
+				granteeID, grantorID, id := 2, 1, 1
+				b, _ := json.Marshal(domain.TextData{Text: "text"})
+				crypted := []byte{1, 2, 3}
+
+				suite.repository.
+					EXPECT().
+					GetByID(gomock.Any(), id).
+					Return(&domain.EmergencyAccess{ID: id, GrantorID: grantorID, GranteeID: granteeID, Status: domain.EmergencyAccessApproved}, nil)
+
+				suite.dataRepository.
+					EXPECT().
+					GetUserAllData(gomock.Any(), grantorID).
+					Return([]domain.UserStoredData{{ID: 1, UserID: grantorID, CryptedData: crypted, DataType: domain.TextDataType}}, nil)
+
+				suite.cryptor.
+					EXPECT().
+					DecryptBytes(crypted).
+					Return(b, nil)
+
+				return granteeID, id
+			},
+		},
+		{
+			name: "waiting period is not over",
+			err:  domain.ErrEmergencyAccessInvalidStatus,
+			prepare: func() (int, int) {
+				granteeID, id := 2, 1
+
+				suite.repository.
+					EXPECT().
+					GetByID(gomock.Any(), id).
+					Return(&domain.EmergencyAccess{ID: id, GrantorID: 1, GranteeID: granteeID, Status: domain.EmergencyAccessRecoveryInitiated}, nil)
+
+				return granteeID, id
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.name, func() {
+			granteeID, id := testCase.prepare()
+			_, err := suite.service.GetVault(context.Background(), granteeID, id)
+			suite.Equal(testCase.err, err)
+		})
+	}
+}
@@ -0,0 +1,122 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/services/server/audit.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/services/server/audit.go -destination=./internal/services/server/mocks/audit.go
+//
+// Package mock_server is a generated GoMock package.
+package mock_server
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/MowlCoder/goph-keeper/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockauditRepository is a mock of auditRepository interface.
+type MockauditRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockauditRepositoryMockRecorder
+}
+
+// MockauditRepositoryMockRecorder is the mock recorder for MockauditRepository.
+type MockauditRepositoryMockRecorder struct {
+	mock *MockauditRepository
+}
+
+// NewMockauditRepository creates a new mock instance.
+func NewMockauditRepository(ctrl *gomock.Controller) *MockauditRepository {
+	mock := &MockauditRepository{ctrl: ctrl}
+	mock.recorder = &MockauditRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockauditRepository) EXPECT() *MockauditRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountUserEvents mocks base method.
+func (m *MockauditRepository) CountUserEvents(ctx context.Context, userID int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUserEvents", ctx, userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUserEvents indicates an expected call of CountUserEvents.
+func (mr *MockauditRepositoryMockRecorder) CountUserEvents(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUserEvents", reflect.TypeOf((*MockauditRepository)(nil).CountUserEvents), ctx, userID)
+}
+
+// Create mocks base method.
+func (m *MockauditRepository) Create(ctx context.Context, event domain.AuditEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockauditRepositoryMockRecorder) Create(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockauditRepository)(nil).Create), ctx, event)
+}
+
+// GetUserEvents mocks base method.
+func (m *MockauditRepository) GetUserEvents(ctx context.Context, userID int, filters *domain.StorageFilters) ([]domain.AuditEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserEvents", ctx, userID, filters)
+	ret0, _ := ret[0].([]domain.AuditEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserEvents indicates an expected call of GetUserEvents.
+func (mr *MockauditRepositoryMockRecorder) GetUserEvents(ctx, userID, filters any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserEvents", reflect.TypeOf((*MockauditRepository)(nil).GetUserEvents), ctx, userID, filters)
+}
+
+// MockuserRepositoryForAuditService is a mock of userRepositoryForAuditService interface.
+type MockuserRepositoryForAuditService struct {
+	ctrl     *gomock.Controller
+	recorder *MockuserRepositoryForAuditServiceMockRecorder
+}
+
+// MockuserRepositoryForAuditServiceMockRecorder is the mock recorder for MockuserRepositoryForAuditService.
+type MockuserRepositoryForAuditServiceMockRecorder struct {
+	mock *MockuserRepositoryForAuditService
+}
+
+// NewMockuserRepositoryForAuditService creates a new mock instance.
+func NewMockuserRepositoryForAuditService(ctrl *gomock.Controller) *MockuserRepositoryForAuditService {
+	mock := &MockuserRepositoryForAuditService{ctrl: ctrl}
+	mock.recorder = &MockuserRepositoryForAuditServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockuserRepositoryForAuditService) EXPECT() *MockuserRepositoryForAuditServiceMockRecorder {
+	return m.recorder
+}
+
+// GetByEmail mocks base method.
+func (m *MockuserRepositoryForAuditService) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByEmail", ctx, email)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByEmail indicates an expected call of GetByEmail.
+func (mr *MockuserRepositoryForAuditServiceMockRecorder) GetByEmail(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEmail", reflect.TypeOf((*MockuserRepositoryForAuditService)(nil).GetByEmail), ctx, email)
+}
@@ -0,0 +1,128 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/services/server/device.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/services/server/device.go -destination=./internal/services/server/mocks/device.go
+//
+
+// Package mock_server is a generated GoMock package.
+package mock_server
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/MowlCoder/goph-keeper/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockdeviceRepository is a mock of deviceRepository interface.
+type MockdeviceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockdeviceRepositoryMockRecorder
+}
+
+// MockdeviceRepositoryMockRecorder is the mock recorder for MockdeviceRepository.
+type MockdeviceRepositoryMockRecorder struct {
+	mock *MockdeviceRepository
+}
+
+// NewMockdeviceRepository creates a new mock instance.
+func NewMockdeviceRepository(ctrl *gomock.Controller) *MockdeviceRepository {
+	mock := &MockdeviceRepository{ctrl: ctrl}
+	mock.recorder = &MockdeviceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockdeviceRepository) EXPECT() *MockdeviceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockdeviceRepository) Create(ctx context.Context, userID int, name, platform string) (*domain.Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, userID, name, platform)
+	ret0, _ := ret[0].(*domain.Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockdeviceRepositoryMockRecorder) Create(ctx, userID, name, platform any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockdeviceRepository)(nil).Create), ctx, userID, name, platform)
+}
+
+// Delete mocks base method.
+func (m *MockdeviceRepository) Delete(ctx context.Context, userID, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockdeviceRepositoryMockRecorder) Delete(ctx, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockdeviceRepository)(nil).Delete), ctx, userID, id)
+}
+
+// GetByID mocks base method.
+func (m *MockdeviceRepository) GetByID(ctx context.Context, id int) (*domain.Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*domain.Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockdeviceRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockdeviceRepository)(nil).GetByID), ctx, id)
+}
+
+// GetByUser mocks base method.
+func (m *MockdeviceRepository) GetByUser(ctx context.Context, userID int) ([]domain.Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUser", ctx, userID)
+	ret0, _ := ret[0].([]domain.Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUser indicates an expected call of GetByUser.
+func (mr *MockdeviceRepositoryMockRecorder) GetByUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUser", reflect.TypeOf((*MockdeviceRepository)(nil).GetByUser), ctx, userID)
+}
+
+// UpdateLastSeen mocks base method.
+func (m *MockdeviceRepository) UpdateLastSeen(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLastSeen", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLastSeen indicates an expected call of UpdateLastSeen.
+func (mr *MockdeviceRepositoryMockRecorder) UpdateLastSeen(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLastSeen", reflect.TypeOf((*MockdeviceRepository)(nil).UpdateLastSeen), ctx, id)
+}
+
+// UpdateSyncCursor mocks base method.
+func (m *MockdeviceRepository) UpdateSyncCursor(ctx context.Context, userID, id int, cursor int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSyncCursor", ctx, userID, id, cursor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSyncCursor indicates an expected call of UpdateSyncCursor.
+func (mr *MockdeviceRepositoryMockRecorder) UpdateSyncCursor(ctx, userID, id, cursor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSyncCursor", reflect.TypeOf((*MockdeviceRepository)(nil).UpdateSyncCursor), ctx, userID, id, cursor)
+}
@@ -0,0 +1,257 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/services/server/emergency_access.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/services/server/emergency_access.go -destination=./internal/services/server/mocks/emergency_access.go
+//
+// Package mock_server is a generated GoMock package.
+package mock_server
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/MowlCoder/goph-keeper/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockemergencyAccessRepository is a mock of emergencyAccessRepository interface.
+type MockemergencyAccessRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockemergencyAccessRepositoryMockRecorder
+}
+
+// MockemergencyAccessRepositoryMockRecorder is the mock recorder for MockemergencyAccessRepository.
+type MockemergencyAccessRepositoryMockRecorder struct {
+	mock *MockemergencyAccessRepository
+}
+
+// NewMockemergencyAccessRepository creates a new mock instance.
+func NewMockemergencyAccessRepository(ctrl *gomock.Controller) *MockemergencyAccessRepository {
+	mock := &MockemergencyAccessRepository{ctrl: ctrl}
+	mock.recorder = &MockemergencyAccessRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockemergencyAccessRepository) EXPECT() *MockemergencyAccessRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ApproveExpired mocks base method.
+func (m *MockemergencyAccessRepository) ApproveExpired(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveExpired", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApproveExpired indicates an expected call of ApproveExpired.
+func (mr *MockemergencyAccessRepositoryMockRecorder) ApproveExpired(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveExpired", reflect.TypeOf((*MockemergencyAccessRepository)(nil).ApproveExpired), ctx)
+}
+
+// Create mocks base method.
+func (m *MockemergencyAccessRepository) Create(ctx context.Context, grantorID, granteeID, waitDays int) (*domain.EmergencyAccess, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, grantorID, granteeID, waitDays)
+	ret0, _ := ret[0].(*domain.EmergencyAccess)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockemergencyAccessRepositoryMockRecorder) Create(ctx, grantorID, granteeID, waitDays any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockemergencyAccessRepository)(nil).Create), ctx, grantorID, granteeID, waitDays)
+}
+
+// DeleteByID mocks base method.
+func (m *MockemergencyAccessRepository) DeleteByID(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockemergencyAccessRepositoryMockRecorder) DeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockemergencyAccessRepository)(nil).DeleteByID), ctx, id)
+}
+
+// GetByGrantee mocks base method.
+func (m *MockemergencyAccessRepository) GetByGrantee(ctx context.Context, granteeID int) ([]domain.EmergencyAccess, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByGrantee", ctx, granteeID)
+	ret0, _ := ret[0].([]domain.EmergencyAccess)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByGrantee indicates an expected call of GetByGrantee.
+func (mr *MockemergencyAccessRepositoryMockRecorder) GetByGrantee(ctx, granteeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByGrantee", reflect.TypeOf((*MockemergencyAccessRepository)(nil).GetByGrantee), ctx, granteeID)
+}
+
+// GetByGrantor mocks base method.
+func (m *MockemergencyAccessRepository) GetByGrantor(ctx context.Context, grantorID int) ([]domain.EmergencyAccess, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByGrantor", ctx, grantorID)
+	ret0, _ := ret[0].([]domain.EmergencyAccess)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByGrantor indicates an expected call of GetByGrantor.
+func (mr *MockemergencyAccessRepositoryMockRecorder) GetByGrantor(ctx, grantorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByGrantor", reflect.TypeOf((*MockemergencyAccessRepository)(nil).GetByGrantor), ctx, grantorID)
+}
+
+// GetByID mocks base method.
+func (m *MockemergencyAccessRepository) GetByID(ctx context.Context, id int) (*domain.EmergencyAccess, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*domain.EmergencyAccess)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockemergencyAccessRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockemergencyAccessRepository)(nil).GetByID), ctx, id)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockemergencyAccessRepository) UpdateStatus(ctx context.Context, id int, fromStatus, toStatus string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, id, fromStatus, toStatus)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockemergencyAccessRepositoryMockRecorder) UpdateStatus(ctx, id, fromStatus, toStatus any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockemergencyAccessRepository)(nil).UpdateStatus), ctx, id, fromStatus, toStatus)
+}
+
+// MockuserRepositoryForEmergencyAccessService is a mock of userRepositoryForEmergencyAccessService interface.
+type MockuserRepositoryForEmergencyAccessService struct {
+	ctrl     *gomock.Controller
+	recorder *MockuserRepositoryForEmergencyAccessServiceMockRecorder
+}
+
+// MockuserRepositoryForEmergencyAccessServiceMockRecorder is the mock recorder for MockuserRepositoryForEmergencyAccessService.
+type MockuserRepositoryForEmergencyAccessServiceMockRecorder struct {
+	mock *MockuserRepositoryForEmergencyAccessService
+}
+
+// NewMockuserRepositoryForEmergencyAccessService creates a new mock instance.
+func NewMockuserRepositoryForEmergencyAccessService(ctrl *gomock.Controller) *MockuserRepositoryForEmergencyAccessService {
+	mock := &MockuserRepositoryForEmergencyAccessService{ctrl: ctrl}
+	mock.recorder = &MockuserRepositoryForEmergencyAccessServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockuserRepositoryForEmergencyAccessService) EXPECT() *MockuserRepositoryForEmergencyAccessServiceMockRecorder {
+	return m.recorder
+}
+
+// GetByEmail mocks base method.
+func (m *MockuserRepositoryForEmergencyAccessService) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByEmail", ctx, email)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByEmail indicates an expected call of GetByEmail.
+func (mr *MockuserRepositoryForEmergencyAccessServiceMockRecorder) GetByEmail(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEmail", reflect.TypeOf((*MockuserRepositoryForEmergencyAccessService)(nil).GetByEmail), ctx, email)
+}
+
+// MockdataRepositoryForEmergencyAccessService is a mock of dataRepositoryForEmergencyAccessService interface.
+type MockdataRepositoryForEmergencyAccessService struct {
+	ctrl     *gomock.Controller
+	recorder *MockdataRepositoryForEmergencyAccessServiceMockRecorder
+}
+
+// MockdataRepositoryForEmergencyAccessServiceMockRecorder is the mock recorder for MockdataRepositoryForEmergencyAccessService.
+type MockdataRepositoryForEmergencyAccessServiceMockRecorder struct {
+	mock *MockdataRepositoryForEmergencyAccessService
+}
+
+// NewMockdataRepositoryForEmergencyAccessService creates a new mock instance.
+func NewMockdataRepositoryForEmergencyAccessService(ctrl *gomock.Controller) *MockdataRepositoryForEmergencyAccessService {
+	mock := &MockdataRepositoryForEmergencyAccessService{ctrl: ctrl}
+	mock.recorder = &MockdataRepositoryForEmergencyAccessServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockdataRepositoryForEmergencyAccessService) EXPECT() *MockdataRepositoryForEmergencyAccessServiceMockRecorder {
+	return m.recorder
+}
+
+// GetUserAllData mocks base method.
+func (m *MockdataRepositoryForEmergencyAccessService) GetUserAllData(ctx context.Context, userID int) ([]domain.UserStoredData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserAllData", ctx, userID)
+	ret0, _ := ret[0].([]domain.UserStoredData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserAllData indicates an expected call of GetUserAllData.
+func (mr *MockdataRepositoryForEmergencyAccessServiceMockRecorder) GetUserAllData(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAllData", reflect.TypeOf((*MockdataRepositoryForEmergencyAccessService)(nil).GetUserAllData), ctx, userID)
+}
+
+// MockcryptorForEmergencyAccessService is a mock of cryptorForEmergencyAccessService interface.
+type MockcryptorForEmergencyAccessService struct {
+	ctrl     *gomock.Controller
+	recorder *MockcryptorForEmergencyAccessServiceMockRecorder
+}
+
+// MockcryptorForEmergencyAccessServiceMockRecorder is the mock recorder for MockcryptorForEmergencyAccessService.
+type MockcryptorForEmergencyAccessServiceMockRecorder struct {
+	mock *MockcryptorForEmergencyAccessService
+}
+
+// NewMockcryptorForEmergencyAccessService creates a new mock instance.
+func NewMockcryptorForEmergencyAccessService(ctrl *gomock.Controller) *MockcryptorForEmergencyAccessService {
+	mock := &MockcryptorForEmergencyAccessService{ctrl: ctrl}
+	mock.recorder = &MockcryptorForEmergencyAccessServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcryptorForEmergencyAccessService) EXPECT() *MockcryptorForEmergencyAccessServiceMockRecorder {
+	return m.recorder
+}
+
+// DecryptBytes mocks base method.
+func (m *MockcryptorForEmergencyAccessService) DecryptBytes(crypted []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecryptBytes", crypted)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DecryptBytes indicates an expected call of DecryptBytes.
+func (mr *MockcryptorForEmergencyAccessServiceMockRecorder) DecryptBytes(crypted any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecryptBytes", reflect.TypeOf((*MockcryptorForEmergencyAccessService)(nil).DecryptBytes), crypted)
+}
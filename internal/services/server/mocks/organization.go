@@ -0,0 +1,197 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/services/server/organization.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/services/server/organization.go -destination=./internal/services/server/mocks/organization.go
+//
+// Package mock_server is a generated GoMock package.
+package mock_server
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/MowlCoder/goph-keeper/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockorganizationRepository is a mock of organizationRepository interface.
+type MockorganizationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockorganizationRepositoryMockRecorder
+}
+
+// MockorganizationRepositoryMockRecorder is the mock recorder for MockorganizationRepository.
+type MockorganizationRepositoryMockRecorder struct {
+	mock *MockorganizationRepository
+}
+
+// NewMockorganizationRepository creates a new mock instance.
+func NewMockorganizationRepository(ctrl *gomock.Controller) *MockorganizationRepository {
+	mock := &MockorganizationRepository{ctrl: ctrl}
+	mock.recorder = &MockorganizationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockorganizationRepository) EXPECT() *MockorganizationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AcceptInvite mocks base method.
+func (m *MockorganizationRepository) AcceptInvite(ctx context.Context, organizationID, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptInvite", ctx, organizationID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AcceptInvite indicates an expected call of AcceptInvite.
+func (mr *MockorganizationRepositoryMockRecorder) AcceptInvite(ctx, organizationID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvite", reflect.TypeOf((*MockorganizationRepository)(nil).AcceptInvite), ctx, organizationID, userID)
+}
+
+// AddMember mocks base method.
+func (m *MockorganizationRepository) AddMember(ctx context.Context, organizationID, userID int, role, status string) (*domain.OrganizationMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMember", ctx, organizationID, userID, role, status)
+	ret0, _ := ret[0].(*domain.OrganizationMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddMember indicates an expected call of AddMember.
+func (mr *MockorganizationRepositoryMockRecorder) AddMember(ctx, organizationID, userID, role, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMember", reflect.TypeOf((*MockorganizationRepository)(nil).AddMember), ctx, organizationID, userID, role, status)
+}
+
+// Create mocks base method.
+func (m *MockorganizationRepository) Create(ctx context.Context, ownerID int, name string) (*domain.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, ownerID, name)
+	ret0, _ := ret[0].(*domain.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockorganizationRepositoryMockRecorder) Create(ctx, ownerID, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockorganizationRepository)(nil).Create), ctx, ownerID, name)
+}
+
+// CreateCollection mocks base method.
+func (m *MockorganizationRepository) CreateCollection(ctx context.Context, organizationID int, name string) (*domain.Collection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCollection", ctx, organizationID, name)
+	ret0, _ := ret[0].(*domain.Collection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCollection indicates an expected call of CreateCollection.
+func (mr *MockorganizationRepositoryMockRecorder) CreateCollection(ctx, organizationID, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCollection", reflect.TypeOf((*MockorganizationRepository)(nil).CreateCollection), ctx, organizationID, name)
+}
+
+// GetCollections mocks base method.
+func (m *MockorganizationRepository) GetCollections(ctx context.Context, organizationID int) ([]domain.Collection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCollections", ctx, organizationID)
+	ret0, _ := ret[0].([]domain.Collection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCollections indicates an expected call of GetCollections.
+func (mr *MockorganizationRepositoryMockRecorder) GetCollections(ctx, organizationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCollections", reflect.TypeOf((*MockorganizationRepository)(nil).GetCollections), ctx, organizationID)
+}
+
+// GetMember mocks base method.
+func (m *MockorganizationRepository) GetMember(ctx context.Context, organizationID, userID int) (*domain.OrganizationMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMember", ctx, organizationID, userID)
+	ret0, _ := ret[0].(*domain.OrganizationMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMember indicates an expected call of GetMember.
+func (mr *MockorganizationRepositoryMockRecorder) GetMember(ctx, organizationID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMember", reflect.TypeOf((*MockorganizationRepository)(nil).GetMember), ctx, organizationID, userID)
+}
+
+// GetMembers mocks base method.
+func (m *MockorganizationRepository) GetMembers(ctx context.Context, organizationID int) ([]domain.OrganizationMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembers", ctx, organizationID)
+	ret0, _ := ret[0].([]domain.OrganizationMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembers indicates an expected call of GetMembers.
+func (mr *MockorganizationRepositoryMockRecorder) GetMembers(ctx, organizationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembers", reflect.TypeOf((*MockorganizationRepository)(nil).GetMembers), ctx, organizationID)
+}
+
+// GetUserOrganizations mocks base method.
+func (m *MockorganizationRepository) GetUserOrganizations(ctx context.Context, userID int) ([]domain.OrganizationMembership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserOrganizations", ctx, userID)
+	ret0, _ := ret[0].([]domain.OrganizationMembership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserOrganizations indicates an expected call of GetUserOrganizations.
+func (mr *MockorganizationRepositoryMockRecorder) GetUserOrganizations(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserOrganizations", reflect.TypeOf((*MockorganizationRepository)(nil).GetUserOrganizations), ctx, userID)
+}
+
+// MockuserRepositoryForOrganizationService is a mock of userRepositoryForOrganizationService interface.
+type MockuserRepositoryForOrganizationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockuserRepositoryForOrganizationServiceMockRecorder
+}
+
+// MockuserRepositoryForOrganizationServiceMockRecorder is the mock recorder for MockuserRepositoryForOrganizationService.
+type MockuserRepositoryForOrganizationServiceMockRecorder struct {
+	mock *MockuserRepositoryForOrganizationService
+}
+
+// NewMockuserRepositoryForOrganizationService creates a new mock instance.
+func NewMockuserRepositoryForOrganizationService(ctrl *gomock.Controller) *MockuserRepositoryForOrganizationService {
+	mock := &MockuserRepositoryForOrganizationService{ctrl: ctrl}
+	mock.recorder = &MockuserRepositoryForOrganizationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockuserRepositoryForOrganizationService) EXPECT() *MockuserRepositoryForOrganizationServiceMockRecorder {
+	return m.recorder
+}
+
+// GetByEmail mocks base method.
+func (m *MockuserRepositoryForOrganizationService) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByEmail", ctx, email)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByEmail indicates an expected call of GetByEmail.
+func (mr *MockuserRepositoryForOrganizationServiceMockRecorder) GetByEmail(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEmail", reflect.TypeOf((*MockuserRepositoryForOrganizationService)(nil).GetByEmail), ctx, email)
+}
@@ -0,0 +1,228 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/services/server/share.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/services/server/share.go -destination=./internal/services/server/mocks/share.go
+//
+// Package mock_server is a generated GoMock package.
+package mock_server
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/MowlCoder/goph-keeper/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockshareRepository is a mock of shareRepository interface.
+type MockshareRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockshareRepositoryMockRecorder
+}
+
+// MockshareRepositoryMockRecorder is the mock recorder for MockshareRepository.
+type MockshareRepositoryMockRecorder struct {
+	mock *MockshareRepository
+}
+
+// NewMockshareRepository creates a new mock instance.
+func NewMockshareRepository(ctrl *gomock.Controller) *MockshareRepository {
+	mock := &MockshareRepository{ctrl: ctrl}
+	mock.recorder = &MockshareRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockshareRepository) EXPECT() *MockshareRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockshareRepository) Create(ctx context.Context, dataID, ownerID, recipientID int, permission string) (*domain.Share, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, dataID, ownerID, recipientID, permission)
+	ret0, _ := ret[0].(*domain.Share)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockshareRepositoryMockRecorder) Create(ctx, dataID, ownerID, recipientID, permission any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockshareRepository)(nil).Create), ctx, dataID, ownerID, recipientID, permission)
+}
+
+// DeleteByID mocks base method.
+func (m *MockshareRepository) DeleteByID(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockshareRepositoryMockRecorder) DeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockshareRepository)(nil).DeleteByID), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockshareRepository) GetByID(ctx context.Context, id int) (*domain.Share, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*domain.Share)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockshareRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockshareRepository)(nil).GetByID), ctx, id)
+}
+
+// GetSharedByUser mocks base method.
+func (m *MockshareRepository) GetSharedByUser(ctx context.Context, ownerID int) ([]domain.Share, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSharedByUser", ctx, ownerID)
+	ret0, _ := ret[0].([]domain.Share)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSharedByUser indicates an expected call of GetSharedByUser.
+func (mr *MockshareRepositoryMockRecorder) GetSharedByUser(ctx, ownerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSharedByUser", reflect.TypeOf((*MockshareRepository)(nil).GetSharedByUser), ctx, ownerID)
+}
+
+// GetSharedWithUser mocks base method.
+func (m *MockshareRepository) GetSharedWithUser(ctx context.Context, recipientID int) ([]domain.SharedUserStoredData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSharedWithUser", ctx, recipientID)
+	ret0, _ := ret[0].([]domain.SharedUserStoredData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSharedWithUser indicates an expected call of GetSharedWithUser.
+func (mr *MockshareRepositoryMockRecorder) GetSharedWithUser(ctx, recipientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSharedWithUser", reflect.TypeOf((*MockshareRepository)(nil).GetSharedWithUser), ctx, recipientID)
+}
+
+// MockuserRepositoryForShareService is a mock of userRepositoryForShareService interface.
+type MockuserRepositoryForShareService struct {
+	ctrl     *gomock.Controller
+	recorder *MockuserRepositoryForShareServiceMockRecorder
+}
+
+// MockuserRepositoryForShareServiceMockRecorder is the mock recorder for MockuserRepositoryForShareService.
+type MockuserRepositoryForShareServiceMockRecorder struct {
+	mock *MockuserRepositoryForShareService
+}
+
+// NewMockuserRepositoryForShareService creates a new mock instance.
+func NewMockuserRepositoryForShareService(ctrl *gomock.Controller) *MockuserRepositoryForShareService {
+	mock := &MockuserRepositoryForShareService{ctrl: ctrl}
+	mock.recorder = &MockuserRepositoryForShareServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockuserRepositoryForShareService) EXPECT() *MockuserRepositoryForShareServiceMockRecorder {
+	return m.recorder
+}
+
+// GetByEmail mocks base method.
+func (m *MockuserRepositoryForShareService) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByEmail", ctx, email)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByEmail indicates an expected call of GetByEmail.
+func (mr *MockuserRepositoryForShareServiceMockRecorder) GetByEmail(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEmail", reflect.TypeOf((*MockuserRepositoryForShareService)(nil).GetByEmail), ctx, email)
+}
+
+// MockdataRepositoryForShareService is a mock of dataRepositoryForShareService interface.
+type MockdataRepositoryForShareService struct {
+	ctrl     *gomock.Controller
+	recorder *MockdataRepositoryForShareServiceMockRecorder
+}
+
+// MockdataRepositoryForShareServiceMockRecorder is the mock recorder for MockdataRepositoryForShareService.
+type MockdataRepositoryForShareServiceMockRecorder struct {
+	mock *MockdataRepositoryForShareService
+}
+
+// NewMockdataRepositoryForShareService creates a new mock instance.
+func NewMockdataRepositoryForShareService(ctrl *gomock.Controller) *MockdataRepositoryForShareService {
+	mock := &MockdataRepositoryForShareService{ctrl: ctrl}
+	mock.recorder = &MockdataRepositoryForShareServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockdataRepositoryForShareService) EXPECT() *MockdataRepositoryForShareServiceMockRecorder {
+	return m.recorder
+}
+
+// GetByID mocks base method.
+func (m *MockdataRepositoryForShareService) GetByID(ctx context.Context, id int) (*domain.UserStoredData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*domain.UserStoredData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockdataRepositoryForShareServiceMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockdataRepositoryForShareService)(nil).GetByID), ctx, id)
+}
+
+// MockcryptorForShareService is a mock of cryptorForShareService interface.
+type MockcryptorForShareService struct {
+	ctrl     *gomock.Controller
+	recorder *MockcryptorForShareServiceMockRecorder
+}
+
+// MockcryptorForShareServiceMockRecorder is the mock recorder for MockcryptorForShareService.
+type MockcryptorForShareServiceMockRecorder struct {
+	mock *MockcryptorForShareService
+}
+
+// NewMockcryptorForShareService creates a new mock instance.
+func NewMockcryptorForShareService(ctrl *gomock.Controller) *MockcryptorForShareService {
+	mock := &MockcryptorForShareService{ctrl: ctrl}
+	mock.recorder = &MockcryptorForShareServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcryptorForShareService) EXPECT() *MockcryptorForShareServiceMockRecorder {
+	return m.recorder
+}
+
+// DecryptBytes mocks base method.
+func (m *MockcryptorForShareService) DecryptBytes(crypted []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecryptBytes", crypted)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DecryptBytes indicates an expected call of DecryptBytes.
+func (mr *MockcryptorForShareServiceMockRecorder) DecryptBytes(crypted any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecryptBytes", reflect.TypeOf((*MockcryptorForShareService)(nil).DecryptBytes), crypted)
+}
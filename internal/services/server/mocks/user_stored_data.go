@@ -5,6 +5,7 @@
 //
 //	mockgen -source=./internal/services/server/user_stored_data.go -destination=./internal/services/server/mocks/user_stored_data.go
 //
+
 // Package mock_server is a generated GoMock package.
 package mock_server
 
@@ -92,19 +93,79 @@ func (m *MockuserStoredDataRepository) EXPECT() *MockuserStoredDataRepositoryMoc
 	return m.recorder
 }
 
+// AddCollectionData mocks base method.
+func (m *MockuserStoredDataRepository) AddCollectionData(ctx context.Context, userID, collectionID int, recordUUID, dataType string, data []byte, meta string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCollectionData", ctx, userID, collectionID, recordUUID, dataType, data, meta)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddCollectionData indicates an expected call of AddCollectionData.
+func (mr *MockuserStoredDataRepositoryMockRecorder) AddCollectionData(ctx, userID, collectionID, recordUUID, dataType, data, meta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCollectionData", reflect.TypeOf((*MockuserStoredDataRepository)(nil).AddCollectionData), ctx, userID, collectionID, recordUUID, dataType, data, meta)
+}
+
 // AddData mocks base method.
-func (m *MockuserStoredDataRepository) AddData(ctx context.Context, userID int, dataType string, data []byte, meta string) (int64, error) {
+func (m *MockuserStoredDataRepository) AddData(ctx context.Context, userID int, recordUUID, dataType string, data []byte, meta string) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddData", ctx, userID, dataType, data, meta)
+	ret := m.ctrl.Call(m, "AddData", ctx, userID, recordUUID, dataType, data, meta)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // AddData indicates an expected call of AddData.
-func (mr *MockuserStoredDataRepositoryMockRecorder) AddData(ctx, userID, dataType, data, meta any) *gomock.Call {
+func (mr *MockuserStoredDataRepositoryMockRecorder) AddData(ctx, userID, recordUUID, dataType, data, meta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddData", reflect.TypeOf((*MockuserStoredDataRepository)(nil).AddData), ctx, userID, recordUUID, dataType, data, meta)
+}
+
+// ApplyPush mocks base method.
+func (m *MockuserStoredDataRepository) ApplyPush(ctx context.Context, userID int, items []domain.SyncPushItem) (*domain.SyncPushResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyPush", ctx, userID, items)
+	ret0, _ := ret[0].(*domain.SyncPushResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyPush indicates an expected call of ApplyPush.
+func (mr *MockuserStoredDataRepositoryMockRecorder) ApplyPush(ctx, userID, items any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyPush", reflect.TypeOf((*MockuserStoredDataRepository)(nil).ApplyPush), ctx, userID, items)
+}
+
+// CollectTombstones mocks base method.
+func (m *MockuserStoredDataRepository) CollectTombstones(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CollectTombstones", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CollectTombstones indicates an expected call of CollectTombstones.
+func (mr *MockuserStoredDataRepositoryMockRecorder) CollectTombstones(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CollectTombstones", reflect.TypeOf((*MockuserStoredDataRepository)(nil).CollectTombstones), ctx)
+}
+
+// CountCollectionDataOfType mocks base method.
+func (m *MockuserStoredDataRepository) CountCollectionDataOfType(ctx context.Context, collectionID int, dataType string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCollectionDataOfType", ctx, collectionID, dataType)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCollectionDataOfType indicates an expected call of CountCollectionDataOfType.
+func (mr *MockuserStoredDataRepositoryMockRecorder) CountCollectionDataOfType(ctx, collectionID, dataType any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddData", reflect.TypeOf((*MockuserStoredDataRepository)(nil).AddData), ctx, userID, dataType, data, meta)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCollectionDataOfType", reflect.TypeOf((*MockuserStoredDataRepository)(nil).CountCollectionDataOfType), ctx, collectionID, dataType)
 }
 
 // CountUserDataOfType mocks base method.
@@ -123,11 +184,12 @@ func (mr *MockuserStoredDataRepositoryMockRecorder) CountUserDataOfType(ctx, use
 }
 
 // DeleteBatch mocks base method.
-func (m *MockuserStoredDataRepository) DeleteBatch(ctx context.Context, userID int, id []int) error {
+func (m *MockuserStoredDataRepository) DeleteBatch(ctx context.Context, userID int, id []int) ([]int, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "DeleteBatch", ctx, userID, id)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // DeleteBatch indicates an expected call of DeleteBatch.
@@ -136,6 +198,36 @@ func (mr *MockuserStoredDataRepositoryMockRecorder) DeleteBatch(ctx, userID, id
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBatch", reflect.TypeOf((*MockuserStoredDataRepository)(nil).DeleteBatch), ctx, userID, id)
 }
 
+// DeleteCollectionBatch mocks base method.
+func (m *MockuserStoredDataRepository) DeleteCollectionBatch(ctx context.Context, collectionID int, id []int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCollectionBatch", ctx, collectionID, id)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCollectionBatch indicates an expected call of DeleteCollectionBatch.
+func (mr *MockuserStoredDataRepositoryMockRecorder) DeleteCollectionBatch(ctx, collectionID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCollectionBatch", reflect.TypeOf((*MockuserStoredDataRepository)(nil).DeleteCollectionBatch), ctx, collectionID, id)
+}
+
+// FindIDsByUUIDPrefix mocks base method.
+func (m *MockuserStoredDataRepository) FindIDsByUUIDPrefix(ctx context.Context, userID int, prefix string, limit int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindIDsByUUIDPrefix", ctx, userID, prefix, limit)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindIDsByUUIDPrefix indicates an expected call of FindIDsByUUIDPrefix.
+func (mr *MockuserStoredDataRepositoryMockRecorder) FindIDsByUUIDPrefix(ctx, userID, prefix, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindIDsByUUIDPrefix", reflect.TypeOf((*MockuserStoredDataRepository)(nil).FindIDsByUUIDPrefix), ctx, userID, prefix, limit)
+}
+
 // GetByID mocks base method.
 func (m *MockuserStoredDataRepository) GetByID(ctx context.Context, id int) (*domain.UserStoredData, error) {
 	m.ctrl.T.Helper()
@@ -151,6 +243,81 @@ func (mr *MockuserStoredDataRepositoryMockRecorder) GetByID(ctx, id any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockuserStoredDataRepository)(nil).GetByID), ctx, id)
 }
 
+// GetByUUID mocks base method.
+func (m *MockuserStoredDataRepository) GetByUUID(ctx context.Context, recordUUID string) (*domain.UserStoredData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUUID", ctx, recordUUID)
+	ret0, _ := ret[0].(*domain.UserStoredData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUUID indicates an expected call of GetByUUID.
+func (mr *MockuserStoredDataRepositoryMockRecorder) GetByUUID(ctx, recordUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUUID", reflect.TypeOf((*MockuserStoredDataRepository)(nil).GetByUUID), ctx, recordUUID)
+}
+
+// GetCollectedTombstonesSeq mocks base method.
+func (m *MockuserStoredDataRepository) GetCollectedTombstonesSeq(ctx context.Context, userID int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCollectedTombstonesSeq", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCollectedTombstonesSeq indicates an expected call of GetCollectedTombstonesSeq.
+func (mr *MockuserStoredDataRepositoryMockRecorder) GetCollectedTombstonesSeq(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCollectedTombstonesSeq", reflect.TypeOf((*MockuserStoredDataRepository)(nil).GetCollectedTombstonesSeq), ctx, userID)
+}
+
+// GetCollectionAllData mocks base method.
+func (m *MockuserStoredDataRepository) GetCollectionAllData(ctx context.Context, collectionID int) ([]domain.UserStoredData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCollectionAllData", ctx, collectionID)
+	ret0, _ := ret[0].([]domain.UserStoredData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCollectionAllData indicates an expected call of GetCollectionAllData.
+func (mr *MockuserStoredDataRepositoryMockRecorder) GetCollectionAllData(ctx, collectionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCollectionAllData", reflect.TypeOf((*MockuserStoredDataRepository)(nil).GetCollectionAllData), ctx, collectionID)
+}
+
+// GetCollectionChanges mocks base method.
+func (m *MockuserStoredDataRepository) GetCollectionChanges(ctx context.Context, collectionID int, since int64, limit int) ([]domain.DataChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCollectionChanges", ctx, collectionID, since, limit)
+	ret0, _ := ret[0].([]domain.DataChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCollectionChanges indicates an expected call of GetCollectionChanges.
+func (mr *MockuserStoredDataRepositoryMockRecorder) GetCollectionChanges(ctx, collectionID, since, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCollectionChanges", reflect.TypeOf((*MockuserStoredDataRepository)(nil).GetCollectionChanges), ctx, collectionID, since, limit)
+}
+
+// GetCollectionDataWithType mocks base method.
+func (m *MockuserStoredDataRepository) GetCollectionDataWithType(ctx context.Context, collectionID int, dataType string, filters *domain.StorageFilters) ([]domain.UserStoredData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCollectionDataWithType", ctx, collectionID, dataType, filters)
+	ret0, _ := ret[0].([]domain.UserStoredData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCollectionDataWithType indicates an expected call of GetCollectionDataWithType.
+func (mr *MockuserStoredDataRepositoryMockRecorder) GetCollectionDataWithType(ctx, collectionID, dataType, filters any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCollectionDataWithType", reflect.TypeOf((*MockuserStoredDataRepository)(nil).GetCollectionDataWithType), ctx, collectionID, dataType, filters)
+}
+
 // GetUserAllData mocks base method.
 func (m *MockuserStoredDataRepository) GetUserAllData(ctx context.Context, userID int) ([]domain.UserStoredData, error) {
 	m.ctrl.T.Helper()
@@ -166,6 +333,21 @@ func (mr *MockuserStoredDataRepositoryMockRecorder) GetUserAllData(ctx, userID a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAllData", reflect.TypeOf((*MockuserStoredDataRepository)(nil).GetUserAllData), ctx, userID)
 }
 
+// GetUserChanges mocks base method.
+func (m *MockuserStoredDataRepository) GetUserChanges(ctx context.Context, userID int, since int64, limit int) ([]domain.DataChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserChanges", ctx, userID, since, limit)
+	ret0, _ := ret[0].([]domain.DataChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserChanges indicates an expected call of GetUserChanges.
+func (mr *MockuserStoredDataRepositoryMockRecorder) GetUserChanges(ctx, userID, since, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserChanges", reflect.TypeOf((*MockuserStoredDataRepository)(nil).GetUserChanges), ctx, userID, since, limit)
+}
+
 // GetWithType mocks base method.
 func (m *MockuserStoredDataRepository) GetWithType(ctx context.Context, userID int, dataType string, filters *domain.StorageFilters) ([]domain.UserStoredData, error) {
 	m.ctrl.T.Helper()
@@ -181,17 +363,181 @@ func (mr *MockuserStoredDataRepositoryMockRecorder) GetWithType(ctx, userID, dat
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWithType", reflect.TypeOf((*MockuserStoredDataRepository)(nil).GetWithType), ctx, userID, dataType, filters)
 }
 
+// UpdateCollectionData mocks base method.
+func (m *MockuserStoredDataRepository) UpdateCollectionData(ctx context.Context, collectionID, dataID, expectedVersion int, data any, meta string) (*domain.UserStoredData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCollectionData", ctx, collectionID, dataID, expectedVersion, data, meta)
+	ret0, _ := ret[0].(*domain.UserStoredData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCollectionData indicates an expected call of UpdateCollectionData.
+func (mr *MockuserStoredDataRepositoryMockRecorder) UpdateCollectionData(ctx, collectionID, dataID, expectedVersion, data, meta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCollectionData", reflect.TypeOf((*MockuserStoredDataRepository)(nil).UpdateCollectionData), ctx, collectionID, dataID, expectedVersion, data, meta)
+}
+
 // UpdateUserData mocks base method.
-func (m *MockuserStoredDataRepository) UpdateUserData(ctx context.Context, userID, dataID int, data any, meta string) (*domain.UserStoredData, error) {
+func (m *MockuserStoredDataRepository) UpdateUserData(ctx context.Context, userID, dataID, expectedVersion int, data any, meta string) (*domain.UserStoredData, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateUserData", ctx, userID, dataID, data, meta)
+	ret := m.ctrl.Call(m, "UpdateUserData", ctx, userID, dataID, expectedVersion, data, meta)
 	ret0, _ := ret[0].(*domain.UserStoredData)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateUserData indicates an expected call of UpdateUserData.
-func (mr *MockuserStoredDataRepositoryMockRecorder) UpdateUserData(ctx, userID, dataID, data, meta any) *gomock.Call {
+func (mr *MockuserStoredDataRepositoryMockRecorder) UpdateUserData(ctx, userID, dataID, expectedVersion, data, meta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserData", reflect.TypeOf((*MockuserStoredDataRepository)(nil).UpdateUserData), ctx, userID, dataID, expectedVersion, data, meta)
+}
+
+// MockshareRepositoryForUserStoredDataService is a mock of shareRepositoryForUserStoredDataService interface.
+type MockshareRepositoryForUserStoredDataService struct {
+	ctrl     *gomock.Controller
+	recorder *MockshareRepositoryForUserStoredDataServiceMockRecorder
+}
+
+// MockshareRepositoryForUserStoredDataServiceMockRecorder is the mock recorder for MockshareRepositoryForUserStoredDataService.
+type MockshareRepositoryForUserStoredDataServiceMockRecorder struct {
+	mock *MockshareRepositoryForUserStoredDataService
+}
+
+// NewMockshareRepositoryForUserStoredDataService creates a new mock instance.
+func NewMockshareRepositoryForUserStoredDataService(ctrl *gomock.Controller) *MockshareRepositoryForUserStoredDataService {
+	mock := &MockshareRepositoryForUserStoredDataService{ctrl: ctrl}
+	mock.recorder = &MockshareRepositoryForUserStoredDataServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockshareRepositoryForUserStoredDataService) EXPECT() *MockshareRepositoryForUserStoredDataServiceMockRecorder {
+	return m.recorder
+}
+
+// GetByDataAndRecipient mocks base method.
+func (m *MockshareRepositoryForUserStoredDataService) GetByDataAndRecipient(ctx context.Context, dataID, recipientID int) (*domain.Share, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByDataAndRecipient", ctx, dataID, recipientID)
+	ret0, _ := ret[0].(*domain.Share)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByDataAndRecipient indicates an expected call of GetByDataAndRecipient.
+func (mr *MockshareRepositoryForUserStoredDataServiceMockRecorder) GetByDataAndRecipient(ctx, dataID, recipientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByDataAndRecipient", reflect.TypeOf((*MockshareRepositoryForUserStoredDataService)(nil).GetByDataAndRecipient), ctx, dataID, recipientID)
+}
+
+// MockorganizationRepositoryForUserStoredDataService is a mock of organizationRepositoryForUserStoredDataService interface.
+type MockorganizationRepositoryForUserStoredDataService struct {
+	ctrl     *gomock.Controller
+	recorder *MockorganizationRepositoryForUserStoredDataServiceMockRecorder
+}
+
+// MockorganizationRepositoryForUserStoredDataServiceMockRecorder is the mock recorder for MockorganizationRepositoryForUserStoredDataService.
+type MockorganizationRepositoryForUserStoredDataServiceMockRecorder struct {
+	mock *MockorganizationRepositoryForUserStoredDataService
+}
+
+// NewMockorganizationRepositoryForUserStoredDataService creates a new mock instance.
+func NewMockorganizationRepositoryForUserStoredDataService(ctrl *gomock.Controller) *MockorganizationRepositoryForUserStoredDataService {
+	mock := &MockorganizationRepositoryForUserStoredDataService{ctrl: ctrl}
+	mock.recorder = &MockorganizationRepositoryForUserStoredDataServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockorganizationRepositoryForUserStoredDataService) EXPECT() *MockorganizationRepositoryForUserStoredDataServiceMockRecorder {
+	return m.recorder
+}
+
+// GetCollectionMember mocks base method.
+func (m *MockorganizationRepositoryForUserStoredDataService) GetCollectionMember(ctx context.Context, collectionID, userID int) (*domain.OrganizationMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCollectionMember", ctx, collectionID, userID)
+	ret0, _ := ret[0].(*domain.OrganizationMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCollectionMember indicates an expected call of GetCollectionMember.
+func (mr *MockorganizationRepositoryForUserStoredDataServiceMockRecorder) GetCollectionMember(ctx, collectionID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCollectionMember", reflect.TypeOf((*MockorganizationRepositoryForUserStoredDataService)(nil).GetCollectionMember), ctx, collectionID, userID)
+}
+
+// MockchangePublisher is a mock of changePublisher interface.
+type MockchangePublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockchangePublisherMockRecorder
+}
+
+// MockchangePublisherMockRecorder is the mock recorder for MockchangePublisher.
+type MockchangePublisherMockRecorder struct {
+	mock *MockchangePublisher
+}
+
+// NewMockchangePublisher creates a new mock instance.
+func NewMockchangePublisher(ctrl *gomock.Controller) *MockchangePublisher {
+	mock := &MockchangePublisher{ctrl: ctrl}
+	mock.recorder = &MockchangePublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockchangePublisher) EXPECT() *MockchangePublisherMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockchangePublisher) Publish(ctx context.Context, event domain.DataChangeEvent) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Publish", ctx, event)
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockchangePublisherMockRecorder) Publish(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockchangePublisher)(nil).Publish), ctx, event)
+}
+
+// MockchangeSubscriber is a mock of changeSubscriber interface.
+type MockchangeSubscriber struct {
+	ctrl     *gomock.Controller
+	recorder *MockchangeSubscriberMockRecorder
+}
+
+// MockchangeSubscriberMockRecorder is the mock recorder for MockchangeSubscriber.
+type MockchangeSubscriberMockRecorder struct {
+	mock *MockchangeSubscriber
+}
+
+// NewMockchangeSubscriber creates a new mock instance.
+func NewMockchangeSubscriber(ctrl *gomock.Controller) *MockchangeSubscriber {
+	mock := &MockchangeSubscriber{ctrl: ctrl}
+	mock.recorder = &MockchangeSubscriberMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockchangeSubscriber) EXPECT() *MockchangeSubscriberMockRecorder {
+	return m.recorder
+}
+
+// Subscribe mocks base method.
+func (m *MockchangeSubscriber) Subscribe(userID, collectionID int) (<-chan domain.DataChangeEvent, func()) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", userID, collectionID)
+	ret0, _ := ret[0].(<-chan domain.DataChangeEvent)
+	ret1, _ := ret[1].(func())
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockchangeSubscriberMockRecorder) Subscribe(userID, collectionID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserData", reflect.TypeOf((*MockuserStoredDataRepository)(nil).UpdateUserData), ctx, userID, dataID, data, meta)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockchangeSubscriber)(nil).Subscribe), userID, collectionID)
 }
@@ -0,0 +1,125 @@
+package server
+
+import (
+	"context"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+)
+
+type organizationRepository interface {
+	Create(ctx context.Context, ownerID int, name string) (*domain.Organization, error)
+	GetUserOrganizations(ctx context.Context, userID int) ([]domain.OrganizationMembership, error)
+	GetMember(ctx context.Context, organizationID int, userID int) (*domain.OrganizationMember, error)
+	GetMembers(ctx context.Context, organizationID int) ([]domain.OrganizationMember, error)
+	AddMember(ctx context.Context, organizationID int, userID int, role string, status string) (*domain.OrganizationMember, error)
+	AcceptInvite(ctx context.Context, organizationID int, userID int) error
+	CreateCollection(ctx context.Context, organizationID int, name string) (*domain.Collection, error)
+	GetCollections(ctx context.Context, organizationID int) ([]domain.Collection, error)
+}
+
+type userRepositoryForOrganizationService interface {
+	GetByEmail(ctx context.Context, email string) (*domain.User, error)
+}
+
+type OrganizationService struct {
+	repository     organizationRepository
+	userRepository userRepositoryForOrganizationService
+}
+
+func NewOrganizationService(
+	repository organizationRepository,
+	userRepository userRepositoryForOrganizationService,
+) *OrganizationService {
+	return &OrganizationService{
+		repository:     repository,
+		userRepository: userRepository,
+	}
+}
+
+func (s *OrganizationService) Create(ctx context.Context, userID int, name string) (*domain.Organization, error) {
+	return s.repository.Create(ctx, userID, name)
+}
+
+func (s *OrganizationService) GetUserOrganizations(ctx context.Context, userID int) ([]domain.OrganizationMembership, error) {
+	return s.repository.GetUserOrganizations(ctx, userID)
+}
+
+// Invite - owner or admin of organization invites user with given email, invited user has to accept invite
+func (s *OrganizationService) Invite(ctx context.Context, userID int, organizationID int, email string, role string) (*domain.OrganizationMember, error) {
+	if !domain.IsValidInviteRole(role) {
+		return nil, domain.ErrInvalidOrganizationRole
+	}
+
+	if _, err := s.getManager(ctx, organizationID, userID); err != nil {
+		return nil, err
+	}
+
+	invitedUser, err := s.userRepository.GetByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := s.repository.AddMember(ctx, organizationID, invitedUser.ID, role, domain.OrganizationMemberInvited)
+	if err != nil {
+		return nil, err
+	}
+
+	member.Email = invitedUser.Email
+
+	return member, nil
+}
+
+func (s *OrganizationService) Accept(ctx context.Context, userID int, organizationID int) error {
+	return s.repository.AcceptInvite(ctx, organizationID, userID)
+}
+
+func (s *OrganizationService) GetMembers(ctx context.Context, userID int, organizationID int) ([]domain.OrganizationMember, error) {
+	if _, err := s.getAcceptedMember(ctx, organizationID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.repository.GetMembers(ctx, organizationID)
+}
+
+func (s *OrganizationService) CreateCollection(ctx context.Context, userID int, organizationID int, name string) (*domain.Collection, error) {
+	if _, err := s.getManager(ctx, organizationID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.repository.CreateCollection(ctx, organizationID, name)
+}
+
+func (s *OrganizationService) GetCollections(ctx context.Context, userID int, organizationID int) ([]domain.Collection, error) {
+	if _, err := s.getAcceptedMember(ctx, organizationID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.repository.GetCollections(ctx, organizationID)
+}
+
+// getAcceptedMember - users with not accepted invite should not know anything about organization
+func (s *OrganizationService) getAcceptedMember(ctx context.Context, organizationID int, userID int) (*domain.OrganizationMember, error) {
+	member, err := s.repository.GetMember(ctx, organizationID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !member.IsAccepted() {
+		return nil, domain.ErrOrganizationNotFound
+	}
+
+	return member, nil
+}
+
+func (s *OrganizationService) getManager(ctx context.Context, organizationID int, userID int) (*domain.OrganizationMember, error) {
+	member, err := s.getAcceptedMember(ctx, organizationID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !member.CanManage() {
+		return nil, domain.ErrNotEnoughPermissions
+	}
+
+	return member, nil
+}
@@ -0,0 +1,266 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+	mock_server "github.com/MowlCoder/goph-keeper/internal/services/server/mocks"
+)
+
+type organizationTestSuite struct {
+	suite.Suite
+
+	repository     *mock_server.MockorganizationRepository
+	userRepository *mock_server.MockuserRepositoryForOrganizationService
+
+	service *OrganizationService
+}
+
+func (suite *organizationTestSuite) SetupSuite() {
+}
+
+func (suite *organizationTestSuite) TearDownSuite() {
+}
+
+func (suite *organizationTestSuite) SetupTest() {
+	ctrl := gomock.NewController(suite.T())
+
+	suite.repository = mock_server.NewMockorganizationRepository(ctrl)
+	suite.userRepository = mock_server.NewMockuserRepositoryForOrganizationService(ctrl)
+
+	suite.service = NewOrganizationService(suite.repository, suite.userRepository)
+}
+
+func (suite *organizationTestSuite) TearDownTest() {
+}
+
+func TestOrganizationSuite(t *testing.T) {
+	suite.Run(t, new(organizationTestSuite))
+}
+
+func (suite *organizationTestSuite) TestInvite() {
+	testCases := []struct {
+		name    string
+		err     error
+		prepare func() (int, int, string, string)
+	}{
+		{
+			name: "valid",
+			err:  nil,
+			prepare: func() (int, int, string, string) {
+				userID, organizationID := 1, 5
+				email := "test@gmail.com"
+
+				suite.repository.
+					EXPECT().
+					GetMember(gomock.Any(), organizationID, userID).
+					Return(&domain.OrganizationMember{UserID: userID, Role: domain.OrganizationOwnerRole, Status: domain.OrganizationMemberAccepted}, nil)
+
+				suite.userRepository.
+					EXPECT().
+					GetByEmail(gomock.Any(), email).
+					Return(&domain.User{ID: 2, Email: email}, nil)
+
+				suite.repository.
+					EXPECT().
+					AddMember(gomock.Any(), organizationID, 2, domain.OrganizationMemberRole, domain.OrganizationMemberInvited).
+					Return(&domain.OrganizationMember{ID: 1}, nil)
+
+				return userID, organizationID, email, domain.OrganizationMemberRole
+			},
+		},
+		{
+			name: "invalid role",
+			err:  domain.ErrInvalidOrganizationRole,
+			prepare: func() (int, int, string, string) {
+				return 1, 5, "test@gmail.com", domain.OrganizationOwnerRole
+			},
+		},
+		{
+			name: "not enough permissions",
+			err:  domain.ErrNotEnoughPermissions,
+			prepare: func() (int, int, string, string) {
+				userID, organizationID := 1, 5
+
+				suite.repository.
+					EXPECT().
+					GetMember(gomock.Any(), organizationID, userID).
+					Return(&domain.OrganizationMember{UserID: userID, Role: domain.OrganizationMemberRole, Status: domain.OrganizationMemberAccepted}, nil)
+
+				return userID, organizationID, "test@gmail.com", domain.OrganizationMemberRole
+			},
+		},
+		{
+			name: "invite not accepted",
+			err:  domain.ErrOrganizationNotFound,
+			prepare: func() (int, int, string, string) {
+				userID, organizationID := 1, 5
+
+				suite.repository.
+					EXPECT().
+					GetMember(gomock.Any(), organizationID, userID).
+					Return(&domain.OrganizationMember{UserID: userID, Role: domain.OrganizationAdminRole, Status: domain.OrganizationMemberInvited}, nil)
+
+				return userID, organizationID, "test@gmail.com", domain.OrganizationMemberRole
+			},
+		},
+		{
+			name: "user not found",
+			err:  domain.ErrUserNotFound,
+			prepare: func() (int, int, string, string) {
+				userID, organizationID := 1, 5
+				email := "test@gmail.com"
+
+				suite.repository.
+					EXPECT().
+					GetMember(gomock.Any(), organizationID, userID).
+					Return(&domain.OrganizationMember{UserID: userID, Role: domain.OrganizationAdminRole, Status: domain.OrganizationMemberAccepted}, nil)
+
+				suite.userRepository.
+					EXPECT().
+					GetByEmail(gomock.Any(), email).
+					Return(nil, domain.ErrUserNotFound)
+
+				return userID, organizationID, email, domain.OrganizationReadOnlyRole
+			},
+		},
+		{
+			name: "already member",
+			err:  domain.ErrAlreadyOrganizationMember,
+			prepare: func() (int, int, string, string) {
+				userID, organizationID := 1, 5
+				email := "test@gmail.com"
+
+				suite.repository.
+					EXPECT().
+					GetMember(gomock.Any(), organizationID, userID).
+					Return(&domain.OrganizationMember{UserID: userID, Role: domain.OrganizationAdminRole, Status: domain.OrganizationMemberAccepted}, nil)
+
+				suite.userRepository.
+					EXPECT().
+					GetByEmail(gomock.Any(), email).
+					Return(&domain.User{ID: 2, Email: email}, nil)
+
+				suite.repository.
+					EXPECT().
+					AddMember(gomock.Any(), organizationID, 2, domain.OrganizationAdminRole, domain.OrganizationMemberInvited).
+					Return(nil, domain.ErrAlreadyOrganizationMember)
+
+				return userID, organizationID, email, domain.OrganizationAdminRole
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.name, func() {
+			userID, organizationID, email, role := testCase.prepare()
+			_, err := suite.service.Invite(context.Background(), userID, organizationID, email, role)
+			suite.Equal(testCase.err, err)
+		})
+	}
+}
+
+func (suite *organizationTestSuite) TestGetMembers() {
+	testCases := []struct {
+		name    string
+		err     error
+		prepare func() (int, int)
+	}{
+		{
+			name: "valid",
+			err:  nil,
+			prepare: func() (int, int) {
+				userID, organizationID := 1, 5
+
+				suite.repository.
+					EXPECT().
+					GetMember(gomock.Any(), organizationID, userID).
+					Return(&domain.OrganizationMember{UserID: userID, Role: domain.OrganizationReadOnlyRole, Status: domain.OrganizationMemberAccepted}, nil)
+
+				suite.repository.
+					EXPECT().
+					GetMembers(gomock.Any(), organizationID).
+					Return([]domain.OrganizationMember{}, nil)
+
+				return userID, organizationID
+			},
+		},
+		{
+			name: "not member",
+			err:  domain.ErrOrganizationNotFound,
+			prepare: func() (int, int) {
+				userID, organizationID := 1, 5
+
+				suite.repository.
+					EXPECT().
+					GetMember(gomock.Any(), organizationID, userID).
+					Return(nil, domain.ErrOrganizationNotFound)
+
+				return userID, organizationID
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.name, func() {
+			userID, organizationID := testCase.prepare()
+			_, err := suite.service.GetMembers(context.Background(), userID, organizationID)
+			suite.Equal(testCase.err, err)
+		})
+	}
+}
+
+func (suite *organizationTestSuite) TestCreateCollection() {
+	testCases := []struct {
+		name    string
+		err     error
+		prepare func() (int, int, string)
+	}{
+		{
+			name: "valid",
+			err:  nil,
+			prepare: func() (int, int, string) {
+				userID, organizationID := 1, 5
+				name := "Servers"
+
+				suite.repository.
+					EXPECT().
+					GetMember(gomock.Any(), organizationID, userID).
+					Return(&domain.OrganizationMember{UserID: userID, Role: domain.OrganizationAdminRole, Status: domain.OrganizationMemberAccepted}, nil)
+
+				suite.repository.
+					EXPECT().
+					CreateCollection(gomock.Any(), organizationID, name).
+					Return(&domain.Collection{ID: 1}, nil)
+
+				return userID, organizationID, name
+			},
+		},
+		{
+			name: "read-only member",
+			err:  domain.ErrNotEnoughPermissions,
+			prepare: func() (int, int, string) {
+				userID, organizationID := 1, 5
+
+				suite.repository.
+					EXPECT().
+					GetMember(gomock.Any(), organizationID, userID).
+					Return(&domain.OrganizationMember{UserID: userID, Role: domain.OrganizationReadOnlyRole, Status: domain.OrganizationMemberAccepted}, nil)
+
+				return userID, organizationID, "Servers"
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.name, func() {
+			userID, organizationID, name := testCase.prepare()
+			_, err := suite.service.CreateCollection(context.Background(), userID, organizationID, name)
+			suite.Equal(testCase.err, err)
+		})
+	}
+}
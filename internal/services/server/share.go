@@ -0,0 +1,121 @@
+package server
+
+import (
+	"context"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+)
+
+type shareRepository interface {
+	Create(ctx context.Context, dataID int, ownerID int, recipientID int, permission string) (*domain.Share, error)
+	GetByID(ctx context.Context, id int) (*domain.Share, error)
+	GetSharedByUser(ctx context.Context, ownerID int) ([]domain.Share, error)
+	GetSharedWithUser(ctx context.Context, recipientID int) ([]domain.SharedUserStoredData, error)
+	DeleteByID(ctx context.Context, id int) error
+}
+
+type userRepositoryForShareService interface {
+	GetByEmail(ctx context.Context, email string) (*domain.User, error)
+}
+
+type dataRepositoryForShareService interface {
+	GetByID(ctx context.Context, id int) (*domain.UserStoredData, error)
+}
+
+type cryptorForShareService interface {
+	DecryptBytes(crypted []byte) ([]byte, error)
+}
+
+type ShareService struct {
+	repository     shareRepository
+	userRepository userRepositoryForShareService
+	dataRepository dataRepositoryForShareService
+	cryptor        cryptorForShareService
+}
+
+func NewShareService(
+	repository shareRepository,
+	userRepository userRepositoryForShareService,
+	dataRepository dataRepositoryForShareService,
+	cryptor cryptorForShareService,
+) *ShareService {
+	return &ShareService{
+		repository:     repository,
+		userRepository: userRepository,
+		dataRepository: dataRepository,
+		cryptor:        cryptor,
+	}
+}
+
+func (s *ShareService) Share(ctx context.Context, ownerID int, dataID int, recipientEmail string, permission string) (*domain.Share, error) {
+	if !domain.IsValidSharePermission(permission) {
+		return nil, domain.ErrInvalidSharePermission
+	}
+
+	data, err := s.dataRepository.GetByID(ctx, dataID)
+	if err != nil {
+		return nil, err
+	}
+
+	if data.UserID != ownerID || !data.IsPersonal() {
+		return nil, domain.ErrUserStoredDataNotFound
+	}
+
+	recipient, err := s.userRepository.GetByEmail(ctx, recipientEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	if recipient.ID == ownerID {
+		return nil, domain.ErrShareWithYourself
+	}
+
+	share, err := s.repository.Create(ctx, dataID, ownerID, recipient.ID, permission)
+	if err != nil {
+		return nil, err
+	}
+
+	share.RecipientEmail = recipient.Email
+
+	return share, nil
+}
+
+func (s *ShareService) Unshare(ctx context.Context, userID int, shareID int) error {
+	share, err := s.repository.GetByID(ctx, shareID)
+	if err != nil {
+		return err
+	}
+
+	if share.OwnerID != userID && share.RecipientID != userID {
+		return domain.ErrShareNotFound
+	}
+
+	return s.repository.DeleteByID(ctx, shareID)
+}
+
+func (s *ShareService) GetSharedByUser(ctx context.Context, userID int) ([]domain.Share, error) {
+	return s.repository.GetSharedByUser(ctx, userID)
+}
+
+func (s *ShareService) GetSharedWithUser(ctx context.Context, userID int) ([]domain.SharedUserStoredData, error) {
+	dataSet, err := s.repository.GetSharedWithUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for idx, shared := range dataSet {
+		decryptedBytes, err := s.cryptor.DecryptBytes(shared.Data.CryptedData)
+		if err != nil {
+			return nil, err
+		}
+		parsedData, err := domain.ParseUserStoredData(shared.Data.DataType, decryptedBytes)
+		if err != nil {
+			return nil, err
+		}
+
+		dataSet[idx].Data.Data = parsedData
+		dataSet[idx].Data.CryptedData = nil
+	}
+
+	return dataSet, nil
+}
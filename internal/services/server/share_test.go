@@ -0,0 +1,294 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+	mock_server "github.com/MowlCoder/goph-keeper/internal/services/server/mocks"
+)
+
+type shareTestSuite struct {
+	suite.Suite
+
+	repository     *mock_server.MockshareRepository
+	userRepository *mock_server.MockuserRepositoryForShareService
+	dataRepository *mock_server.MockdataRepositoryForShareService
+	cryptor        *mock_server.MockcryptorForShareService
+
+	service *ShareService
+}
+
+func (suite *shareTestSuite) SetupSuite() {
+}
+
+func (suite *shareTestSuite) TearDownSuite() {
+}
+
+func (suite *shareTestSuite) SetupTest() {
+	ctrl := gomock.NewController(suite.T())
+
+	suite.repository = mock_server.NewMockshareRepository(ctrl)
+	suite.userRepository = mock_server.NewMockuserRepositoryForShareService(ctrl)
+	suite.dataRepository = mock_server.NewMockdataRepositoryForShareService(ctrl)
+	suite.cryptor = mock_server.NewMockcryptorForShareService(ctrl)
+
+	suite.service = NewShareService(suite.repository, suite.userRepository, suite.dataRepository, suite.cryptor)
+}
+
+func (suite *shareTestSuite) TearDownTest() {
+}
+
+func TestShareSuite(t *testing.T) {
+	suite.Run(t, new(shareTestSuite))
+}
+
+func (suite *shareTestSuite) TestShare() {
+	testCases := []struct {
+		name    string
+		err     error
+		prepare func() (int, int, string, string)
+	}{
+		{
+			name: "valid",
+			err:  nil,
+			prepare: func() (int, int, string, string) {
+				ownerID, dataID := 1, 10
+				email := "test@gmail.com"
+
+				suite.dataRepository.
+					EXPECT().
+					GetByID(gomock.Any(), dataID).
+					Return(&domain.UserStoredData{ID: dataID, UserID: ownerID}, nil)
+
+				suite.userRepository.
+					EXPECT().
+					GetByEmail(gomock.Any(), email).
+					Return(&domain.User{ID: 2, Email: email}, nil)
+
+				suite.repository.
+					EXPECT().
+					Create(gomock.Any(), dataID, ownerID, 2, domain.ShareReadPermission).
+					Return(&domain.Share{ID: 1}, nil)
+
+				return ownerID, dataID, email, domain.ShareReadPermission
+			},
+		},
+		{
+			name: "invalid permission",
+			err:  domain.ErrInvalidSharePermission,
+			prepare: func() (int, int, string, string) {
+				return 1, 10, "test@gmail.com", "admin"
+			},
+		},
+		{
+			name: "not owner",
+			err:  domain.ErrUserStoredDataNotFound,
+			prepare: func() (int, int, string, string) {
+				ownerID, dataID := 1, 10
+
+				suite.dataRepository.
+					EXPECT().
+					GetByID(gomock.Any(), dataID).
+					Return(&domain.UserStoredData{ID: dataID, UserID: 3}, nil)
+
+				return ownerID, dataID, "test@gmail.com", domain.ShareReadPermission
+			},
+		},
+		{
+			name: "collection record",
+			err:  domain.ErrUserStoredDataNotFound,
+			prepare: func() (int, int, string, string) {
+				ownerID, dataID := 1, 10
+
+				suite.dataRepository.
+					EXPECT().
+					GetByID(gomock.Any(), dataID).
+					Return(&domain.UserStoredData{ID: dataID, UserID: ownerID, CollectionID: 3}, nil)
+
+				return ownerID, dataID, "test@gmail.com", domain.ShareReadPermission
+			},
+		},
+		{
+			name: "recipient not found",
+			err:  domain.ErrUserNotFound,
+			prepare: func() (int, int, string, string) {
+				ownerID, dataID := 1, 10
+				email := "test@gmail.com"
+
+				suite.dataRepository.
+					EXPECT().
+					GetByID(gomock.Any(), dataID).
+					Return(&domain.UserStoredData{ID: dataID, UserID: ownerID}, nil)
+
+				suite.userRepository.
+					EXPECT().
+					GetByEmail(gomock.Any(), email).
+					Return(nil, domain.ErrUserNotFound)
+
+				return ownerID, dataID, email, domain.ShareWritePermission
+			},
+		},
+		{
+			name: "share with yourself",
+			err:  domain.ErrShareWithYourself,
+			prepare: func() (int, int, string, string) {
+				ownerID, dataID := 1, 10
+				email := "test@gmail.com"
+
+				suite.dataRepository.
+					EXPECT().
+					GetByID(gomock.Any(), dataID).
+					Return(&domain.UserStoredData{ID: dataID, UserID: ownerID}, nil)
+
+				suite.userRepository.
+					EXPECT().
+					GetByEmail(gomock.Any(), email).
+					Return(&domain.User{ID: ownerID, Email: email}, nil)
+
+				return ownerID, dataID, email, domain.ShareWritePermission
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.name, func() {
+			ownerID, dataID, email, permission := testCase.prepare()
+			_, err := suite.service.Share(context.Background(), ownerID, dataID, email, permission)
+			suite.Equal(testCase.err, err)
+		})
+	}
+}
+
+func (suite *shareTestSuite) TestUnshare() {
+	testCases := []struct {
+		name    string
+		err     error
+		prepare func() (int, int)
+	}{
+		{
+			name: "valid (owner)",
+			err:  nil,
+			prepare: func() (int, int) {
+				userID, shareID := 1, 5
+
+				suite.repository.
+					EXPECT().
+					GetByID(gomock.Any(), shareID).
+					Return(&domain.Share{ID: shareID, OwnerID: userID, RecipientID: 2}, nil)
+
+				suite.repository.
+					EXPECT().
+					DeleteByID(gomock.Any(), shareID).
+					Return(nil)
+
+				return userID, shareID
+			},
+		},
+		{
+			name: "valid (recipient)",
+			err:  nil,
+			prepare: func() (int, int) {
+				userID, shareID := 2, 5
+
+				suite.repository.
+					EXPECT().
+					GetByID(gomock.Any(), shareID).
+					Return(&domain.Share{ID: shareID, OwnerID: 1, RecipientID: userID}, nil)
+
+				suite.repository.
+					EXPECT().
+					DeleteByID(gomock.Any(), shareID).
+					Return(nil)
+
+				return userID, shareID
+			},
+		},
+		{
+			name: "foreign share",
+			err:  domain.ErrShareNotFound,
+			prepare: func() (int, int) {
+				userID, shareID := 3, 5
+
+				suite.repository.
+					EXPECT().
+					GetByID(gomock.Any(), shareID).
+					Return(&domain.Share{ID: shareID, OwnerID: 1, RecipientID: 2}, nil)
+
+				return userID, shareID
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.name, func() {
+			userID, shareID := testCase.prepare()
+			err := suite.service.Unshare(context.Background(), userID, shareID)
+			suite.Equal(testCase.err, err)
+		})
+	}
+}
+
+func (suite *shareTestSuite) TestGetSharedWithUser() {
+	testCases := []struct {
+		name    string
+		err     error
+		prepare func() int
+	}{
+		{
+			name: "valid",
+			err:  nil,
+			prepare: func() int {
+				userID := 2
+				data := domain.TextData{
+					Text: "text",
+				}
+				b, _ := json.Marshal(data)
+				crypted := []byte{1, 2, 3}
+
+				suite.repository.
+					EXPECT().
+					GetSharedWithUser(gomock.Any(), userID).
+					Return([]domain.SharedUserStoredData{
+						{
+							Share: domain.Share{ID: 1, RecipientID: userID},
+							Data:  domain.UserStoredData{ID: 1, UserID: 1, CryptedData: crypted, DataType: domain.TextDataType},
+						},
+					}, nil)
+
+				suite.cryptor.
+					EXPECT().
+					DecryptBytes(crypted).
+					Return(b, nil)
+
+				return userID
+			},
+		},
+		{
+			name: "repository error",
+			err:  domain.ErrInternal,
+			prepare: func() int {
+				userID := 2
+
+				suite.repository.
+					EXPECT().
+					GetSharedWithUser(gomock.Any(), userID).
+					Return(nil, domain.ErrInternal)
+
+				return userID
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.name, func() {
+			userID := testCase.prepare()
+			_, err := suite.service.GetSharedWithUser(context.Background(), userID)
+			suite.Equal(testCase.err, err)
+		})
+	}
+}
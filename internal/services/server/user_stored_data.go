@@ -1,12 +1,20 @@
 package server
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"log/slog"
 	"math"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
+
 	"github.com/MowlCoder/goph-keeper/internal/domain"
+	"github.com/MowlCoder/goph-keeper/internal/tracing"
 )
 
 type cryptorForUserStoredDataService interface {
@@ -16,70 +24,143 @@ type cryptorForUserStoredDataService interface {
 
 type userStoredDataRepository interface {
 	GetByID(ctx context.Context, id int) (*domain.UserStoredData, error)
-	AddData(ctx context.Context, userID int, dataType string, data []byte, meta string) (int64, error)
+	GetByUUID(ctx context.Context, recordUUID string) (*domain.UserStoredData, error)
+	FindIDsByUUIDPrefix(ctx context.Context, userID int, prefix string, limit int) ([]int, error)
+	AddData(ctx context.Context, userID int, recordUUID string, dataType string, data []byte, meta string) (int64, error)
+	AddCollectionData(ctx context.Context, userID int, collectionID int, recordUUID string, dataType string, data []byte, meta string) (int64, error)
 	GetUserAllData(ctx context.Context, userID int) ([]domain.UserStoredData, error)
+	GetCollectionAllData(ctx context.Context, collectionID int) ([]domain.UserStoredData, error)
 	GetWithType(ctx context.Context, userID int, dataType string, filters *domain.StorageFilters) ([]domain.UserStoredData, error)
+	GetCollectionDataWithType(ctx context.Context, collectionID int, dataType string, filters *domain.StorageFilters) ([]domain.UserStoredData, error)
 	CountUserDataOfType(ctx context.Context, userID int, dataType string) (int, error)
-	UpdateUserData(ctx context.Context, userID int, dataID int, data interface{}, meta string) (*domain.UserStoredData, error)
-	DeleteBatch(ctx context.Context, userID int, id []int) error
+	CountCollectionDataOfType(ctx context.Context, collectionID int, dataType string) (int, error)
+	UpdateUserData(ctx context.Context, userID int, dataID int, expectedVersion int, data interface{}, meta string) (*domain.UserStoredData, error)
+	UpdateCollectionData(ctx context.Context, collectionID int, dataID int, expectedVersion int, data interface{}, meta string) (*domain.UserStoredData, error)
+	DeleteBatch(ctx context.Context, userID int, id []int) ([]int, error)
+	DeleteCollectionBatch(ctx context.Context, collectionID int, id []int) ([]int, error)
+	GetUserChanges(ctx context.Context, userID int, since int64, limit int) ([]domain.DataChange, error)
+	GetCollectionChanges(ctx context.Context, collectionID int, since int64, limit int) ([]domain.DataChange, error)
+	GetCollectedTombstonesSeq(ctx context.Context, userID int) (int64, error)
+	CollectTombstones(ctx context.Context) (int64, error)
+	ApplyPush(ctx context.Context, userID int, items []domain.SyncPushItem) (*domain.SyncPushResponse, error)
+}
+
+type shareRepositoryForUserStoredDataService interface {
+	GetByDataAndRecipient(ctx context.Context, dataID int, recipientID int) (*domain.Share, error)
+}
+
+type organizationRepositoryForUserStoredDataService interface {
+	GetCollectionMember(ctx context.Context, collectionID int, userID int) (*domain.OrganizationMember, error)
+}
+
+// changePublisher - notifies connected clients that records were changed, must not block on slow clients
+type changePublisher interface {
+	Publish(ctx context.Context, event domain.DataChangeEvent)
+}
+
+type changeSubscriber interface {
+	Subscribe(userID int, collectionID int) (<-chan domain.DataChangeEvent, func())
 }
 
 type UserStoredDataService struct {
-	repository userStoredDataRepository
-	cryptor    cryptorForUserStoredDataService
+	repository             userStoredDataRepository
+	shareRepository        shareRepositoryForUserStoredDataService
+	organizationRepository organizationRepositoryForUserStoredDataService
+	cryptor                cryptorForUserStoredDataService
+	changePublisher        changePublisher
+	changeSubscriber       changeSubscriber
+	logger                 *slog.Logger
 }
 
-func NewUserStoredDataService(repository userStoredDataRepository, cryptor cryptorForUserStoredDataService) *UserStoredDataService {
+func NewUserStoredDataService(
+	repository userStoredDataRepository,
+	shareRepository shareRepositoryForUserStoredDataService,
+	organizationRepository organizationRepositoryForUserStoredDataService,
+	cryptor cryptorForUserStoredDataService,
+	changePublisher changePublisher,
+	changeSubscriber changeSubscriber,
+	logger *slog.Logger,
+) *UserStoredDataService {
 	return &UserStoredDataService{
-		repository: repository,
-		cryptor:    cryptor,
+		repository:             repository,
+		shareRepository:        shareRepository,
+		organizationRepository: organizationRepository,
+		cryptor:                cryptor,
+		changePublisher:        changePublisher,
+		changeSubscriber:       changeSubscriber,
+		logger:                 logger,
 	}
 }
 
-func (s *UserStoredDataService) GetAllUserData(ctx context.Context, userID int) ([]domain.UserStoredData, error) {
-	dataSet, err := s.repository.GetUserAllData(ctx, userID)
+// GetAllUserData - get all records of personal vault (collectionID = 0) or of organization collection
+func (s *UserStoredDataService) GetAllUserData(ctx context.Context, userID int, collectionID int) ([]domain.UserStoredData, error) {
+	ctx, span := tracing.Start(ctx, "UserStoredDataService.GetAllUserData")
+	defer span.End()
+
+	var dataSet []domain.UserStoredData
+	var err error
+
+	if collectionID == 0 {
+		dataSet, err = s.repository.GetUserAllData(ctx, userID)
+	} else {
+		if err := s.checkCollectionAccess(ctx, userID, collectionID, false); err != nil {
+			return nil, err
+		}
+
+		dataSet, err = s.repository.GetCollectionAllData(ctx, collectionID)
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	for idx, data := range dataSet {
-		decryptedBytes, err := s.cryptor.DecryptBytes(data.CryptedData)
-		if err != nil {
-			return nil, err
-		}
-		parsedData, err := domain.ParseUserStoredData(data.DataType, decryptedBytes)
-		if err != nil {
+	for idx := range dataSet {
+		if err := s.decryptRecord(ctx, &dataSet[idx]); err != nil {
 			return nil, err
 		}
 
-		dataSet[idx].Data = parsedData
 		dataSet[idx].CryptedData = nil
 	}
 
 	return dataSet, nil
 }
 
-func (s *UserStoredDataService) GetUserData(ctx context.Context, userID int, dataType string, filters *domain.StorageFilters) (*domain.PaginatedResult, error) {
-	dataSet, err := s.repository.GetWithType(ctx, userID, dataType, filters)
-	if err != nil {
-		return nil, err
-	}
-	pairsCount, err := s.repository.CountUserDataOfType(ctx, userID, dataType)
-	if err != nil {
-		return nil, err
-	}
+func (s *UserStoredDataService) GetUserData(ctx context.Context, userID int, collectionID int, dataType string, filters *domain.StorageFilters) (*domain.PaginatedResult, error) {
+	ctx, span := tracing.Start(ctx, "UserStoredDataService.GetUserData")
+	defer span.End()
+
+	var dataSet []domain.UserStoredData
+	var pairsCount int
+	var err error
+
+	if collectionID == 0 {
+		dataSet, err = s.repository.GetWithType(ctx, userID, dataType, filters)
+		if err != nil {
+			return nil, err
+		}
+		pairsCount, err = s.repository.CountUserDataOfType(ctx, userID, dataType)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		if err := s.checkCollectionAccess(ctx, userID, collectionID, false); err != nil {
+			return nil, err
+		}
 
-	for idx, data := range dataSet {
-		decryptedBytes, err := s.cryptor.DecryptBytes(data.CryptedData)
+		dataSet, err = s.repository.GetCollectionDataWithType(ctx, collectionID, dataType, filters)
 		if err != nil {
 			return nil, err
 		}
-		parsedData, err := domain.ParseUserStoredData(data.DataType, decryptedBytes)
+		pairsCount, err = s.repository.CountCollectionDataOfType(ctx, collectionID, dataType)
 		if err != nil {
 			return nil, err
 		}
+	}
+
+	for idx := range dataSet {
+		if err := s.decryptRecord(ctx, &dataSet[idx]); err != nil {
+			return nil, err
+		}
 
-		dataSet[idx].Data = parsedData
 		dataSet[idx].CryptedData = nil
 	}
 
@@ -92,30 +173,139 @@ func (s *UserStoredDataService) GetUserData(ctx context.Context, userID int, dat
 }
 
 func (s *UserStoredDataService) GetUserDataByID(ctx context.Context, userID int, id int) (*domain.UserStoredData, error) {
+	ctx, span := tracing.Start(ctx, "UserStoredDataService.GetUserDataByID")
+	defer span.End()
+
 	userData, err := s.repository.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	if userData.UserID != userID {
-		return nil, domain.ErrUserStoredDataNotFound
+	if err := s.checkAccess(ctx, userID, userData, false); err != nil {
+		return nil, err
 	}
 
-	decryptedBytes, err := s.cryptor.DecryptBytes(userData.CryptedData)
-	if err != nil {
+	if err := s.decryptRecord(ctx, userData); err != nil {
 		return nil, err
 	}
-	parsedData, err := domain.ParseUserStoredData(userData.DataType, decryptedBytes)
+
+	return userData, nil
+}
+
+// ResolveDataRef - id of record referenced by uuid, uuid prefix or id in explicit form (id:<id>). Prefix is
+// searched only among records user can access, access to found record must still be checked by caller.
+// Bare number is uuid prefix first, it is taken for id only if no uuid starts with it. Number which is both
+// prefix and id of different records user can access is ambiguous
+func (s *UserStoredDataService) ResolveDataRef(ctx context.Context, userID int, ref string) (int, error) {
+	ctx, span := tracing.Start(ctx, "UserStoredDataService.ResolveDataRef")
+	defer span.End()
+
+	if idRef, ok := strings.CutPrefix(ref, domain.RecordIDRefPrefix); ok {
+		id, err := strconv.Atoi(idRef)
+		if err != nil || id <= 0 {
+			return 0, domain.ErrInvalidRecordRef
+		}
+
+		return id, nil
+	}
+
+	numericID, err := strconv.Atoi(ref)
+	if err != nil || numericID <= 0 {
+		numericID = 0
+	}
+
+	uuidRef, err := domain.ParseRecordRef(ref)
 	if err != nil {
-		return nil, err
+		// number too short to be uuid prefix
+		if numericID > 0 {
+			return numericID, nil
+		}
+
+		return 0, err
 	}
 
-	userData.Data = parsedData
+	if domain.IsValidUUID(uuidRef) {
+		userData, err := s.repository.GetByUUID(ctx, uuidRef)
+		if err != nil {
+			return 0, err
+		}
 
-	return userData, nil
+		return userData.ID, nil
+	}
+
+	ids, err := s.repository.FindIDsByUUIDPrefix(ctx, userID, uuidRef, 2)
+	if err != nil {
+		return 0, err
+	}
+
+	switch {
+	case len(ids) == 0 && numericID > 0:
+		return numericID, nil
+	case len(ids) == 0:
+		return 0, domain.ErrUserStoredDataNotFound
+	case len(ids) > 1 && numericID > 0:
+		return 0, domain.ErrAmbiguousRecordID
+	case len(ids) > 1:
+		return 0, domain.ErrAmbiguousRecordRef
+	case numericID == 0 || ids[0] == numericID:
+		return ids[0], nil
+	}
+
+	accessible, err := s.isAccessibleRecord(ctx, userID, numericID)
+	if err != nil {
+		return 0, err
+	}
+
+	if accessible {
+		return 0, domain.ErrAmbiguousRecordID
+	}
+
+	return ids[0], nil
+}
+
+// isAccessibleRecord - check that record with given id exists and user can read it
+func (s *UserStoredDataService) isAccessibleRecord(ctx context.Context, userID int, id int) (bool, error) {
+	userData, err := s.repository.GetByID(ctx, id)
+	if errors.Is(err, domain.ErrUserStoredDataNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	err = s.checkAccess(ctx, userID, userData, false)
+	if errors.Is(err, domain.ErrUserStoredDataNotFound) || errors.Is(err, domain.ErrCollectionNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
 }
 
-func (s *UserStoredDataService) UpdateUserData(ctx context.Context, userID int, dataID int, data interface{}, meta string) (*domain.UserStoredData, error) {
+// UpdateUserData - update record if it still has expected version, which is required
+func (s *UserStoredDataService) UpdateUserData(ctx context.Context, userID int, dataID int, expectedVersion int, data interface{}, meta string) (*domain.UserStoredData, error) {
+	ctx, span := tracing.Start(ctx, "UserStoredDataService.UpdateUserData")
+	defer span.End()
+
+	if expectedVersion <= 0 {
+		return nil, domain.ErrDataVersionRequired
+	}
+
+	userData, err := s.repository.GetByID(ctx, dataID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkAccess(ctx, userID, userData, true); err != nil {
+		return nil, err
+	}
+
+	if userData.Version != expectedVersion {
+		return nil, domain.ErrDataVersionConflict
+	}
+
 	jsonData, err := json.Marshal(data)
 	if err != nil {
 		return nil, err
@@ -126,18 +316,35 @@ func (s *UserStoredDataService) UpdateUserData(ctx context.Context, userID int,
 		return nil, err
 	}
 
-	newDate, err := s.repository.UpdateUserData(ctx, userID, dataID, encrypted, meta)
+	var newDate *domain.UserStoredData
+	if userData.IsPersonal() {
+		newDate, err = s.repository.UpdateUserData(ctx, userData.UserID, dataID, expectedVersion, encrypted, meta)
+	} else {
+		newDate, err = s.repository.UpdateCollectionData(ctx, userData.CollectionID, dataID, expectedVersion, encrypted, meta)
+	}
 	if err != nil {
 		return nil, err
 	}
 
+	s.changePublisher.Publish(ctx, domain.DataChangeEvent{UserID: userData.UserID, CollectionID: userData.CollectionID})
+
 	newDate.Data = data
 	newDate.CryptedData = nil
 
 	return newDate, nil
 }
 
-func (s *UserStoredDataService) Add(ctx context.Context, userID int, dataType string, data interface{}, meta string) (*domain.UserStoredData, error) {
+// Add - save new record, recordUUID is generated by client or is empty if server must generate it
+func (s *UserStoredDataService) Add(ctx context.Context, userID int, collectionID int, recordUUID string, dataType string, data interface{}, meta string) (*domain.UserStoredData, error) {
+	ctx, span := tracing.Start(ctx, "UserStoredDataService.Add")
+	defer span.End()
+
+	if collectionID != 0 {
+		if err := s.checkCollectionAccess(ctx, userID, collectionID, true); err != nil {
+			return nil, err
+		}
+	}
+
 	jsonData, err := json.Marshal(data)
 	if err != nil {
 		return nil, err
@@ -148,22 +355,325 @@ func (s *UserStoredDataService) Add(ctx context.Context, userID int, dataType st
 		return nil, err
 	}
 
-	insertedID, err := s.repository.AddData(ctx, userID, dataType, encrypted, meta)
+	if recordUUID == "" {
+		recordUUID = uuid.NewString()
+	}
+
+	var insertedID int64
+	if collectionID == 0 {
+		insertedID, err = s.repository.AddData(ctx, userID, recordUUID, dataType, encrypted, meta)
+	} else {
+		insertedID, err = s.repository.AddCollectionData(ctx, userID, collectionID, recordUUID, dataType, encrypted, meta)
+	}
 	if err != nil {
 		return nil, err
 	}
 
+	s.changePublisher.Publish(ctx, domain.DataChangeEvent{UserID: userID, CollectionID: collectionID})
+
 	return &domain.UserStoredData{
-		ID:        int(insertedID),
-		UserID:    userID,
-		DataType:  dataType,
-		Data:      data,
-		Meta:      meta,
-		Version:   1,
-		CreatedAt: time.Now().UTC(),
+		ID:           int(insertedID),
+		UUID:         recordUUID,
+		UserID:       userID,
+		CollectionID: collectionID,
+		DataType:     dataType,
+		Data:         data,
+		Meta:         meta,
+		Version:      1,
+		CreatedAt:    time.Now().UTC(),
+	}, nil
+}
+
+// DeleteBatch - delete records of personal vault (collectionID = 0) or of organization collection with given
+// ids and return ids of records which were deleted, records user can not delete are skipped
+func (s *UserStoredDataService) DeleteBatch(ctx context.Context, userID int, collectionID int, ids []int) ([]int, error) {
+	ctx, span := tracing.Start(ctx, "UserStoredDataService.DeleteBatch")
+	defer span.End()
+
+	var deleted []int
+	var err error
+	if collectionID == 0 {
+		deleted, err = s.repository.DeleteBatch(ctx, userID, ids)
+	} else {
+		if err := s.checkCollectionAccess(ctx, userID, collectionID, true); err != nil {
+			return nil, err
+		}
+
+		deleted, err = s.repository.DeleteCollectionBatch(ctx, collectionID, ids)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(deleted) > 0 {
+		s.changePublisher.Publish(ctx, domain.DataChangeEvent{UserID: userID, CollectionID: collectionID})
+	}
+
+	return deleted, nil
+}
+
+// GetChanges - get records of personal vault (collectionID = 0) or of organization collection changed after
+// since cursor, deleted records are returned as tombstones
+func (s *UserStoredDataService) GetChanges(ctx context.Context, userID int, collectionID int, since int64, limit int) (*domain.ChangeFeed, error) {
+	ctx, span := tracing.Start(ctx, "UserStoredDataService.GetChanges")
+	defer span.End()
+
+	if since < 0 {
+		since = 0
+	}
+	if limit <= 0 || limit > domain.SyncChangesMaxLimit {
+		limit = domain.SyncChangesDefaultLimit
+	}
+
+	var changes []domain.DataChange
+	var err error
+
+	// one extra change tells if there is next page without counting
+	if collectionID == 0 {
+		changes, err = s.repository.GetUserChanges(ctx, userID, since, limit+1)
+		if err == nil {
+			err = s.checkCursorNotCollected(ctx, userID, since)
+		}
+	} else {
+		if err := s.checkCollectionAccess(ctx, userID, collectionID, false); err != nil {
+			return nil, err
+		}
+
+		changes, err = s.repository.GetCollectionChanges(ctx, collectionID, since, limit+1)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	feed := &domain.ChangeFeed{
+		Changes: changes,
+		Cursor:  since,
+	}
+
+	if len(changes) > limit {
+		feed.Changes = changes[:limit]
+		feed.HasMore = true
+	}
+
+	for _, change := range feed.Changes {
+		feed.Cursor = change.Seq
+
+		if change.Data == nil {
+			continue
+		}
+
+		if err := s.decryptRecord(ctx, change.Data); err != nil {
+			return nil, err
+		}
+
+		change.Data.CryptedData = nil
+	}
+
+	return feed, nil
+}
+
+// checkCursorNotCollected - tombstones newer than cursor could be collected while device was offline, then
+// device would never learn about these deletions. Collection only moves forward, so checking it after changes
+// were read guarantees that no tombstone after cursor was missing from them
+func (s *UserStoredDataService) checkCursorNotCollected(ctx context.Context, userID int, since int64) error {
+	if since == 0 {
+		return nil
+	}
+
+	collectedSeq, err := s.repository.GetCollectedTombstonesSeq(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if since < collectedSeq {
+		return domain.ErrSyncCursorExpired
+	}
+
+	return nil
+}
+
+// CollectTombstones - remove tombstones of personal vaults which every device of user has already synced
+func (s *UserStoredDataService) CollectTombstones(ctx context.Context) (int64, error) {
+	ctx, span := tracing.Start(ctx, "UserStoredDataService.CollectTombstones")
+	defer span.End()
+
+	return s.repository.CollectTombstones(ctx)
+}
+
+// Push - apply batch of personal vault changes atomically, data of items must be already validated.
+// Create of record, which is already stored with the same content, is applied without changing it, so batch
+// pushed again after lost response does not fail
+func (s *UserStoredDataService) Push(ctx context.Context, userID int, items []domain.SyncPushItem) (*domain.SyncPushResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserStoredDataService.Push")
+	defer span.End()
+
+	pending := make([]domain.SyncPushItem, 0, len(items))
+	stored := make(map[int]domain.SyncPushResult)
+
+	for idx, item := range items {
+		if item.Operation == domain.SyncOperationDelete {
+			pending = append(pending, item)
+			continue
+		}
+
+		jsonData, err := json.Marshal(item.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		if item.Operation == domain.SyncOperationCreate {
+			result, err := s.storedCreateResult(ctx, userID, item, jsonData)
+			if err != nil {
+				return nil, err
+			}
+
+			if result != nil {
+				stored[idx] = *result
+				continue
+			}
+		}
+
+		item.CryptedData, err = s.cryptor.EncryptBytes(jsonData)
+		if err != nil {
+			return nil, err
+		}
+
+		pending = append(pending, item)
+	}
+
+	pushed, err := s.repository.ApplyPush(ctx, userID, pending)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &domain.SyncPushResponse{
+		Applied: pushed.Applied,
+		Results: make([]domain.SyncPushResult, 0, len(items)),
+	}
+
+	for idx := range items {
+		result, ok := stored[idx]
+		if !ok {
+			result, pushed.Results = pushed.Results[0], pushed.Results[1:]
+		} else if !pushed.Applied {
+			result = domain.SyncPushResult{UUID: result.UUID, Status: domain.SyncPushStatusSkipped}
+		}
+
+		response.Results = append(response.Results, result)
+	}
+
+	if response.Applied && len(stored) < len(items) {
+		s.changePublisher.Publish(ctx, domain.DataChangeEvent{UserID: userID})
+	}
+
+	return response, nil
+}
+
+// storedCreateResult - get result of record created by earlier push, if record with uuid of created one is
+// personal record of user with the same content. Otherwise nil is returned and create is left to repository
+func (s *UserStoredDataService) storedCreateResult(ctx context.Context, userID int, item domain.SyncPushItem, jsonData []byte) (*domain.SyncPushResult, error) {
+	existing, err := s.repository.GetByUUID(ctx, item.UUID)
+	if errors.Is(err, domain.ErrUserStoredDataNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if existing.UserID != userID || !existing.IsPersonal() || existing.DataType != item.DataType || existing.Meta != item.Meta {
+		return nil, nil
+	}
+
+	if err := s.decryptRecord(ctx, existing); err != nil {
+		return nil, err
+	}
+
+	existingJSON, err := json.Marshal(existing.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(existingJSON, jsonData) {
+		return nil, nil
+	}
+
+	return &domain.SyncPushResult{
+		UUID:    item.UUID,
+		Status:  domain.SyncPushStatusApplied,
+		ID:      existing.ID,
+		Version: existing.Version,
 	}, nil
 }
 
-func (s *UserStoredDataService) DeleteBatch(ctx context.Context, userID int, ids []int) error {
-	return s.repository.DeleteBatch(ctx, userID, ids)
+// SubscribeChanges - get notifications about changes of personal vault (collectionID = 0) or of organization
+// collection user can read. Returned function must be called when client disconnects
+func (s *UserStoredDataService) SubscribeChanges(ctx context.Context, userID int, collectionID int) (<-chan domain.DataChangeEvent, func(), error) {
+	if collectionID != 0 {
+		if err := s.checkCollectionAccess(ctx, userID, collectionID, false); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	events, unsubscribe := s.changeSubscriber.Subscribe(userID, collectionID)
+
+	return events, unsubscribe, nil
+}
+
+// checkAccess - owner of personal record has full access, organization members access collection records
+// according to their role, other users can access record only if it was shared with them
+func (s *UserStoredDataService) checkAccess(ctx context.Context, userID int, userData *domain.UserStoredData, needWrite bool) error {
+	if !userData.IsPersonal() {
+		return s.checkCollectionAccess(ctx, userID, userData.CollectionID, needWrite)
+	}
+
+	if userData.UserID == userID {
+		return nil
+	}
+
+	share, err := s.shareRepository.GetByDataAndRecipient(ctx, userData.ID, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrShareNotFound) {
+			return domain.ErrUserStoredDataNotFound
+		}
+
+		return err
+	}
+
+	if needWrite && !share.CanWrite() {
+		return domain.ErrNotEnoughPermissions
+	}
+
+	return nil
+}
+
+func (s *UserStoredDataService) checkCollectionAccess(ctx context.Context, userID int, collectionID int, needWrite bool) error {
+	member, err := s.organizationRepository.GetCollectionMember(ctx, collectionID, userID)
+	if err != nil {
+		return err
+	}
+
+	if !member.CanRead() {
+		return domain.ErrCollectionNotFound
+	}
+
+	if needWrite && !member.CanWrite() {
+		return domain.ErrNotEnoughPermissions
+	}
+
+	return nil
+}
+
+// decryptRecord - decrypt and parse data of record. Record, which can not be decrypted, is corrupted or was
+// encrypted with another key, error does not tell which record it is, so it is logged with record id
+func (s *UserStoredDataService) decryptRecord(ctx context.Context, data *domain.UserStoredData) error {
+	decryptedBytes, err := s.cryptor.DecryptBytes(data.CryptedData)
+	if err == nil {
+		data.Data, err = domain.ParseUserStoredData(data.DataType, decryptedBytes)
+	}
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to decrypt stored record", "record_id", data.ID, "data_type", data.DataType, "error", err.Error())
+		return err
+	}
+
+	return nil
 }
@@ -1,22 +1,32 @@
 package server
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"log/slog"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/mock/gomock"
 
 	"github.com/MowlCoder/goph-keeper/internal/domain"
 	mock_server "github.com/MowlCoder/goph-keeper/internal/services/server/mocks"
+	"github.com/MowlCoder/goph-keeper/internal/tracing"
+	"github.com/MowlCoder/goph-keeper/internal/tracing/tracingtest"
 )
 
 type userStoredDataTestSuite struct {
 	suite.Suite
 
-	repository *mock_server.MockuserStoredDataRepository
-	cryptor    *mock_server.MockcryptorForUserStoredDataService
+	repository             *mock_server.MockuserStoredDataRepository
+	shareRepository        *mock_server.MockshareRepositoryForUserStoredDataService
+	organizationRepository *mock_server.MockorganizationRepositoryForUserStoredDataService
+	cryptor                *mock_server.MockcryptorForUserStoredDataService
+	changePublisher        *mock_server.MockchangePublisher
+	changeSubscriber       *mock_server.MockchangeSubscriber
+	logs                   *bytes.Buffer
 
 	service *UserStoredDataService
 }
@@ -31,9 +41,22 @@ func (suite *userStoredDataTestSuite) SetupTest() {
 	ctrl := gomock.NewController(suite.T())
 
 	suite.repository = mock_server.NewMockuserStoredDataRepository(ctrl)
+	suite.shareRepository = mock_server.NewMockshareRepositoryForUserStoredDataService(ctrl)
+	suite.organizationRepository = mock_server.NewMockorganizationRepositoryForUserStoredDataService(ctrl)
 	suite.cryptor = mock_server.NewMockcryptorForUserStoredDataService(ctrl)
-
-	suite.service = NewUserStoredDataService(suite.repository, suite.cryptor)
+	suite.changePublisher = mock_server.NewMockchangePublisher(ctrl)
+	suite.changeSubscriber = mock_server.NewMockchangeSubscriber(ctrl)
+	suite.logs = &bytes.Buffer{}
+
+	suite.service = NewUserStoredDataService(
+		suite.repository,
+		suite.shareRepository,
+		suite.organizationRepository,
+		suite.cryptor,
+		suite.changePublisher,
+		suite.changeSubscriber,
+		slog.New(slog.NewTextHandler(suite.logs, nil)),
+	)
 }
 
 func (suite *userStoredDataTestSuite) TearDownTest() {
@@ -43,16 +66,18 @@ func TestUserStoredDataSuite(t *testing.T) {
 	suite.Run(t, new(userStoredDataTestSuite))
 }
 
+const testRecordUUID = "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+
 func (suite *userStoredDataTestSuite) TestGetAllUserData() {
 	testCases := []struct {
 		name    string
 		err     error
-		prepare func() int
+		prepare func() (int, int)
 	}{
 		{
 			name: "valid",
 			err:  nil,
-			prepare: func() int {
+			prepare: func() (int, int) {
 				userID := 1
 				data := domain.TextData{
 					Text: "123",
@@ -70,13 +95,13 @@ func (suite *userStoredDataTestSuite) TestGetAllUserData() {
 					DecryptBytes(crypted).
 					Return(b, nil)
 
-				return userID
+				return userID, 0
 			},
 		},
 		{
 			name: "invalid GetUserAllData",
 			err:  domain.ErrInternal,
-			prepare: func() int {
+			prepare: func() (int, int) {
 				userID := 1
 
 				suite.repository.
@@ -84,15 +109,65 @@ func (suite *userStoredDataTestSuite) TestGetAllUserData() {
 					GetUserAllData(gomock.Any(), userID).
 					Return(nil, domain.ErrInternal)
 
-				return userID
+				return userID, 0
+			},
+		},
+		{
+			name: "valid (collection)",
+			err:  nil,
+			prepare: func() (int, int) {
+				userID := 1
+				collectionID := 3
+
+				suite.organizationRepository.
+					EXPECT().
+					GetCollectionMember(gomock.Any(), collectionID, userID).
+					Return(&domain.OrganizationMember{UserID: userID, Role: domain.OrganizationReadOnlyRole, Status: domain.OrganizationMemberAccepted}, nil)
+
+				suite.repository.
+					EXPECT().
+					GetCollectionAllData(gomock.Any(), collectionID).
+					Return([]domain.UserStoredData{}, nil)
+
+				return userID, collectionID
+			},
+		},
+		{
+			name: "collection invite not accepted",
+			err:  domain.ErrCollectionNotFound,
+			prepare: func() (int, int) {
+				userID := 1
+				collectionID := 3
+
+				suite.organizationRepository.
+					EXPECT().
+					GetCollectionMember(gomock.Any(), collectionID, userID).
+					Return(&domain.OrganizationMember{UserID: userID, Role: domain.OrganizationMemberRole, Status: domain.OrganizationMemberInvited}, nil)
+
+				return userID, collectionID
+			},
+		},
+		{
+			name: "not member of collection organization",
+			err:  domain.ErrCollectionNotFound,
+			prepare: func() (int, int) {
+				userID := 1
+				collectionID := 3
+
+				suite.organizationRepository.
+					EXPECT().
+					GetCollectionMember(gomock.Any(), collectionID, userID).
+					Return(nil, domain.ErrCollectionNotFound)
+
+				return userID, collectionID
 			},
 		},
 	}
 
 	for _, testCase := range testCases {
 		suite.Run(testCase.name, func() {
-			userID := testCase.prepare()
-			_, err := suite.service.GetAllUserData(context.Background(), userID)
+			userID, collectionID := testCase.prepare()
+			_, err := suite.service.GetAllUserData(context.Background(), userID, collectionID)
 			suite.Equal(testCase.err, err)
 		})
 	}
@@ -187,7 +262,7 @@ func (suite *userStoredDataTestSuite) TestGetUserData() {
 	for _, testCase := range testCases {
 		suite.Run(testCase.name, func() {
 			userID, dataType, filters := testCase.prepare()
-			_, err := suite.service.GetUserData(context.Background(), userID, dataType, filters)
+			_, err := suite.service.GetUserData(context.Background(), userID, 0, dataType, filters)
 			suite.Equal(testCase.err, err)
 		})
 	}
@@ -252,6 +327,41 @@ func (suite *userStoredDataTestSuite) TestGetUserDataByID() {
 					GetByID(gomock.Any(), id).
 					Return(&domain.UserStoredData{ID: id, UserID: 2, CryptedData: crypted}, nil)
 
+				suite.shareRepository.
+					EXPECT().
+					GetByDataAndRecipient(gomock.Any(), id, userID).
+					Return(nil, domain.ErrShareNotFound)
+
+				return userID, id
+			},
+		},
+		{
+			name: "shared with user",
+			err:  nil,
+			prepare: func() (int, int) {
+				userID := 1
+				id := 1
+				data := domain.TextData{
+					Text: "text",
+				}
+				b, _ := json.Marshal(data)
+				crypted := []byte("123")
+
+				suite.repository.
+					EXPECT().
+					GetByID(gomock.Any(), id).
+					Return(&domain.UserStoredData{ID: id, UserID: 2, CryptedData: crypted, DataType: domain.TextDataType}, nil)
+
+				suite.shareRepository.
+					EXPECT().
+					GetByDataAndRecipient(gomock.Any(), id, userID).
+					Return(&domain.Share{DataID: id, OwnerID: 2, RecipientID: userID, Permission: domain.ShareReadPermission}, nil)
+
+				suite.cryptor.
+					EXPECT().
+					DecryptBytes(crypted).
+					Return(b, nil)
+
 				return userID, id
 			},
 		},
@@ -287,15 +397,210 @@ func (suite *userStoredDataTestSuite) TestGetUserDataByID() {
 	}
 }
 
-func (suite *userStoredDataTestSuite) UpdateUserData() {
+func (suite *userStoredDataTestSuite) TestDecryptFailureIsLogged() {
+	crypted := []byte("123")
+
+	suite.repository.
+		EXPECT().
+		GetUserAllData(gomock.Any(), 1).
+		Return([]domain.UserStoredData{{ID: 7, UserID: 1, CryptedData: crypted, DataType: domain.TextDataType}}, nil)
+
+	suite.cryptor.
+		EXPECT().
+		DecryptBytes(crypted).
+		Return(nil, domain.ErrInternal)
+
+	_, err := suite.service.GetAllUserData(context.Background(), 1, 0)
+	suite.ErrorIs(err, domain.ErrInternal)
+	suite.Contains(suite.logs.String(), "failed to decrypt stored record")
+	suite.Contains(suite.logs.String(), "record_id=7")
+}
+
+func (suite *userStoredDataTestSuite) TestResolveDataRef() {
 	testCases := []struct {
-		name    string
-		err     error
-		prepare func() (int, int, interface{}, string)
+		name       string
+		ref        string
+		err        error
+		expectedID int
+		prepare    func()
 	}{
 		{
-			name: "valid",
-			err:  nil,
+			name:       "explicit id",
+			ref:        "id:1234",
+			expectedID: 1234,
+			prepare:    func() {},
+		},
+		{
+			name:    "invalid explicit id",
+			ref:     "id:abc",
+			err:     domain.ErrInvalidRecordRef,
+			prepare: func() {},
+		},
+		{
+			name:       "short numeric id",
+			ref:        "5",
+			expectedID: 5,
+			prepare:    func() {},
+		},
+		{
+			name:       "numeric id not matching uuid prefix",
+			ref:        "1234",
+			expectedID: 1234,
+			prepare: func() {
+				suite.repository.
+					EXPECT().
+					FindIDsByUUIDPrefix(gomock.Any(), 1, "1234", 2).
+					Return([]int{}, nil)
+			},
+		},
+		{
+			name:       "numeric uuid prefix",
+			ref:        "12345678",
+			expectedID: 7,
+			prepare: func() {
+				suite.repository.
+					EXPECT().
+					FindIDsByUUIDPrefix(gomock.Any(), 1, "12345678", 2).
+					Return([]int{7}, nil)
+
+				suite.repository.
+					EXPECT().
+					GetByID(gomock.Any(), 12345678).
+					Return(nil, domain.ErrUserStoredDataNotFound)
+			},
+		},
+		{
+			name:       "numeric uuid prefix and id of other user record",
+			ref:        "1234",
+			expectedID: 7,
+			prepare: func() {
+				suite.repository.
+					EXPECT().
+					FindIDsByUUIDPrefix(gomock.Any(), 1, "1234", 2).
+					Return([]int{7}, nil)
+
+				suite.repository.
+					EXPECT().
+					GetByID(gomock.Any(), 1234).
+					Return(&domain.UserStoredData{ID: 1234, UserID: 2}, nil)
+
+				suite.shareRepository.
+					EXPECT().
+					GetByDataAndRecipient(gomock.Any(), 1234, 1).
+					Return(nil, domain.ErrShareNotFound)
+			},
+		},
+		{
+			name: "numeric uuid prefix and id of own record",
+			ref:  "1234",
+			err:  domain.ErrAmbiguousRecordID,
+			prepare: func() {
+				suite.repository.
+					EXPECT().
+					FindIDsByUUIDPrefix(gomock.Any(), 1, "1234", 2).
+					Return([]int{7}, nil)
+
+				suite.repository.
+					EXPECT().
+					GetByID(gomock.Any(), 1234).
+					Return(&domain.UserStoredData{ID: 1234, UserID: 1}, nil)
+			},
+		},
+		{
+			name:       "numeric id of record matching its own uuid prefix",
+			ref:        "1234",
+			expectedID: 1234,
+			prepare: func() {
+				suite.repository.
+					EXPECT().
+					FindIDsByUUIDPrefix(gomock.Any(), 1, "1234", 2).
+					Return([]int{1234}, nil)
+			},
+		},
+		{
+			name: "ambiguous numeric ref",
+			ref:  "1234",
+			err:  domain.ErrAmbiguousRecordID,
+			prepare: func() {
+				suite.repository.
+					EXPECT().
+					FindIDsByUUIDPrefix(gomock.Any(), 1, "1234", 2).
+					Return([]int{7, 8}, nil)
+			},
+		},
+		{
+			name:       "full uuid",
+			ref:        testRecordUUID,
+			expectedID: 7,
+			prepare: func() {
+				suite.repository.
+					EXPECT().
+					GetByUUID(gomock.Any(), testRecordUUID).
+					Return(&domain.UserStoredData{ID: 7, UUID: testRecordUUID}, nil)
+			},
+		},
+		{
+			name:       "unique prefix",
+			ref:        "6BA7B810",
+			expectedID: 7,
+			prepare: func() {
+				suite.repository.
+					EXPECT().
+					FindIDsByUUIDPrefix(gomock.Any(), 1, "6ba7b810", 2).
+					Return([]int{7}, nil)
+			},
+		},
+		{
+			name: "ambiguous prefix",
+			ref:  "6ba7",
+			err:  domain.ErrAmbiguousRecordRef,
+			prepare: func() {
+				suite.repository.
+					EXPECT().
+					FindIDsByUUIDPrefix(gomock.Any(), 1, "6ba7", 2).
+					Return([]int{7, 8}, nil)
+			},
+		},
+		{
+			name: "prefix not found",
+			ref:  "6ba7",
+			err:  domain.ErrUserStoredDataNotFound,
+			prepare: func() {
+				suite.repository.
+					EXPECT().
+					FindIDsByUUIDPrefix(gomock.Any(), 1, "6ba7", 2).
+					Return([]int{}, nil)
+			},
+		},
+		{
+			name:    "invalid ref",
+			ref:     "abc",
+			err:     domain.ErrInvalidRecordRef,
+			prepare: func() {},
+		},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.name, func() {
+			testCase.prepare()
+			id, err := suite.service.ResolveDataRef(context.Background(), 1, testCase.ref)
+			suite.Equal(testCase.err, err)
+			suite.Equal(testCase.expectedID, id)
+		})
+	}
+}
+
+func (suite *userStoredDataTestSuite) TestUpdateUserData() {
+	testCases := []struct {
+		name            string
+		err             error
+		expectedVersion int
+		prepare         func() (int, int, interface{}, string)
+	}{
+		{
+			name:            "valid",
+			err:             nil,
+			expectedVersion: 1,
 			prepare: func() (int, int, interface{}, string) {
 				userID := 1
 				id := 1
@@ -306,6 +611,11 @@ func (suite *userStoredDataTestSuite) UpdateUserData() {
 				meta := "meta"
 				encrypted := []uint8{1, 2, 3}
 
+				suite.repository.
+					EXPECT().
+					GetByID(gomock.Any(), id).
+					Return(&domain.UserStoredData{ID: id, UserID: userID, Version: 1}, nil)
+
 				suite.cryptor.
 					EXPECT().
 					EncryptBytes(b).
@@ -313,81 +623,92 @@ func (suite *userStoredDataTestSuite) UpdateUserData() {
 
 				suite.repository.
 					EXPECT().
-					UpdateUserData(gomock.Any(), userID, id, encrypted, meta).
+					UpdateUserData(gomock.Any(), userID, id, 1, encrypted, meta).
 					Return(&domain.UserStoredData{}, nil)
 
+				suite.changePublisher.
+					EXPECT().
+					Publish(gomock.Any(), domain.DataChangeEvent{UserID: userID})
+
 				return userID, id, data, meta
 			},
 		},
 		{
-			name: "error when encrypted",
-			err:  domain.ErrInternal,
+			name:            "valid (shared with write permission)",
+			err:             nil,
+			expectedVersion: 1,
 			prepare: func() (int, int, interface{}, string) {
 				userID := 1
+				ownerID := 2
 				id := 1
 				data := domain.TextData{
 					Text: "text",
 				}
 				b, _ := json.Marshal(data)
 				meta := "meta"
+				encrypted := []uint8{1, 2, 3}
+
+				suite.repository.
+					EXPECT().
+					GetByID(gomock.Any(), id).
+					Return(&domain.UserStoredData{ID: id, UserID: ownerID, Version: 1}, nil)
+
+				suite.shareRepository.
+					EXPECT().
+					GetByDataAndRecipient(gomock.Any(), id, userID).
+					Return(&domain.Share{DataID: id, OwnerID: ownerID, RecipientID: userID, Permission: domain.ShareWritePermission}, nil)
 
 				suite.cryptor.
 					EXPECT().
 					EncryptBytes(b).
-					Return(nil, domain.ErrInternal)
+					Return(encrypted, nil)
+
+				suite.repository.
+					EXPECT().
+					UpdateUserData(gomock.Any(), ownerID, id, 1, encrypted, meta).
+					Return(&domain.UserStoredData{}, nil)
+
+				suite.changePublisher.
+					EXPECT().
+					Publish(gomock.Any(), domain.DataChangeEvent{UserID: ownerID})
 
 				return userID, id, data, meta
 			},
 		},
 		{
-			name: "invalid update",
-			err:  domain.ErrInternal,
+			name:            "shared with read permission",
+			err:             domain.ErrNotEnoughPermissions,
+			expectedVersion: 1,
 			prepare: func() (int, int, interface{}, string) {
 				userID := 1
+				ownerID := 2
 				id := 1
 				data := domain.TextData{
 					Text: "text",
 				}
-				b, _ := json.Marshal(data)
 				meta := "meta"
-				encrypted := []uint8{1, 2, 3}
 
-				suite.cryptor.
+				suite.repository.
 					EXPECT().
-					EncryptBytes(b).
-					Return(encrypted, nil)
+					GetByID(gomock.Any(), id).
+					Return(&domain.UserStoredData{ID: id, UserID: ownerID, Version: 1}, nil)
 
-				suite.repository.
+				suite.shareRepository.
 					EXPECT().
-					UpdateUserData(gomock.Any(), userID, id, encrypted, meta).
-					Return(nil, domain.ErrInternal)
+					GetByDataAndRecipient(gomock.Any(), id, userID).
+					Return(&domain.Share{DataID: id, OwnerID: ownerID, RecipientID: userID, Permission: domain.ShareReadPermission}, nil)
 
 				return userID, id, data, meta
 			},
 		},
-	}
-
-	for _, testCase := range testCases {
-		suite.Run(testCase.name, func() {
-			userID, id, data, meta := testCase.prepare()
-			_, err := suite.service.UpdateUserData(context.Background(), userID, id, data, meta)
-			suite.Equal(testCase.err, err)
-		})
-	}
-}
-
-func (suite *userStoredDataTestSuite) TestAdd() {
-	testCases := []struct {
-		name    string
-		err     error
-		prepare func() (int, string, interface{}, string)
-	}{
 		{
-			name: "valid",
-			err:  nil,
-			prepare: func() (int, string, interface{}, string) {
+			name:            "valid (collection member)",
+			err:             nil,
+			expectedVersion: 1,
+			prepare: func() (int, int, interface{}, string) {
 				userID := 1
-				dataType := domain.TextDataType
+				collectionID := 3
+				id := 1
 				data := domain.TextData{
 					Text: "text",
 				}
@@ -395,6 +716,16 @@ func (suite *userStoredDataTestSuite) TestAdd() {
 				meta := "meta"
 				encrypted := []uint8{1, 2, 3}
 
+				suite.repository.
+					EXPECT().
+					GetByID(gomock.Any(), id).
+					Return(&domain.UserStoredData{ID: id, UserID: 2, CollectionID: collectionID, Version: 1}, nil)
+
+				suite.organizationRepository.
+					EXPECT().
+					GetCollectionMember(gomock.Any(), collectionID, userID).
+					Return(&domain.OrganizationMember{UserID: userID, Role: domain.OrganizationMemberRole, Status: domain.OrganizationMemberAccepted}, nil)
+
 				suite.cryptor.
 					EXPECT().
 					EncryptBytes(b).
@@ -402,86 +733,434 @@ func (suite *userStoredDataTestSuite) TestAdd() {
 
 				suite.repository.
 					EXPECT().
-					AddData(gomock.Any(), userID, dataType, encrypted, meta).
-					Return(int64(1), nil)
+					UpdateCollectionData(gomock.Any(), collectionID, id, 1, encrypted, meta).
+					Return(&domain.UserStoredData{}, nil)
+
+				suite.changePublisher.
+					EXPECT().
+					Publish(gomock.Any(), domain.DataChangeEvent{UserID: 2, CollectionID: collectionID})
 
-				return userID, dataType, data, meta
+				return userID, id, data, meta
 			},
 		},
 		{
-			name: "error when encrypt",
-			err:  domain.ErrInternal,
-			prepare: func() (int, string, interface{}, string) {
+			name:            "collection read-only member",
+			err:             domain.ErrNotEnoughPermissions,
+			expectedVersion: 1,
+			prepare: func() (int, int, interface{}, string) {
 				userID := 1
-				dataType := domain.TextDataType
+				collectionID := 3
+				id := 1
 				data := domain.TextData{
 					Text: "text",
 				}
-				b, _ := json.Marshal(data)
 				meta := "meta"
 
-				suite.cryptor.
+				suite.repository.
 					EXPECT().
-					EncryptBytes(b).
-					Return(nil, domain.ErrInternal)
+					GetByID(gomock.Any(), id).
+					Return(&domain.UserStoredData{ID: id, UserID: 2, CollectionID: collectionID, Version: 1}, nil)
+
+				suite.organizationRepository.
+					EXPECT().
+					GetCollectionMember(gomock.Any(), collectionID, userID).
+					Return(&domain.OrganizationMember{UserID: userID, Role: domain.OrganizationReadOnlyRole, Status: domain.OrganizationMemberAccepted}, nil)
 
-				return userID, dataType, data, meta
+				return userID, id, data, meta
 			},
 		},
 		{
-			name: "error when adding",
-			err:  domain.ErrInternal,
-			prepare: func() (int, string, interface{}, string) {
+			name:            "not found",
+			err:             domain.ErrUserStoredDataNotFound,
+			expectedVersion: 1,
+			prepare: func() (int, int, interface{}, string) {
 				userID := 1
-				dataType := domain.TextDataType
+				id := 1
 				data := domain.TextData{
 					Text: "text",
 				}
-				b, _ := json.Marshal(data)
 				meta := "meta"
-				encrypted := []uint8{1, 2, 3}
-
-				suite.cryptor.
-					EXPECT().
-					EncryptBytes(b).
-					Return(encrypted, nil)
 
 				suite.repository.
 					EXPECT().
-					AddData(gomock.Any(), userID, dataType, encrypted, meta).
-					Return(int64(0), domain.ErrInternal)
+					GetByID(gomock.Any(), id).
+					Return(nil, domain.ErrUserStoredDataNotFound)
 
-				return userID, dataType, data, meta
+				return userID, id, data, meta
 			},
 		},
-	}
+		{
+			name:            "error when encrypted",
+			err:             domain.ErrInternal,
+			expectedVersion: 1,
+			prepare: func() (int, int, interface{}, string) {
+				userID := 1
+				id := 1
+				data := domain.TextData{
+					Text: "text",
+				}
+				b, _ := json.Marshal(data)
+				meta := "meta"
 
-	for _, testCase := range testCases {
-		suite.Run(testCase.name, func() {
-			userID, dataType, data, meta := testCase.prepare()
-			_, err := suite.service.Add(context.Background(), userID, dataType, data, meta)
+				suite.repository.
+					EXPECT().
+					GetByID(gomock.Any(), id).
+					Return(&domain.UserStoredData{ID: id, UserID: userID, Version: 1}, nil)
+
+				suite.cryptor.
+					EXPECT().
+					EncryptBytes(b).
+					Return(nil, domain.ErrInternal)
+
+				return userID, id, data, meta
+			},
+		},
+		{
+			name:            "expected version",
+			expectedVersion: 3,
+			prepare: func() (int, int, interface{}, string) {
+				userID := 1
+				id := 1
+				data := domain.TextData{
+					Text: "text",
+				}
+				b, _ := json.Marshal(data)
+				meta := "meta"
+				encrypted := []uint8{1, 2, 3}
+
+				suite.repository.
+					EXPECT().
+					GetByID(gomock.Any(), id).
+					Return(&domain.UserStoredData{ID: id, UserID: userID, Version: 3}, nil)
+
+				suite.cryptor.
+					EXPECT().
+					EncryptBytes(b).
+					Return(encrypted, nil)
+
+				suite.repository.
+					EXPECT().
+					UpdateUserData(gomock.Any(), userID, id, 3, encrypted, meta).
+					Return(&domain.UserStoredData{Version: 4}, nil)
+
+				suite.changePublisher.
+					EXPECT().
+					Publish(gomock.Any(), domain.DataChangeEvent{UserID: userID})
+
+				return userID, id, data, meta
+			},
+		},
+		{
+			name:            "stale version",
+			err:             domain.ErrDataVersionConflict,
+			expectedVersion: 2,
+			prepare: func() (int, int, interface{}, string) {
+				userID := 1
+				id := 1
+
+				suite.repository.
+					EXPECT().
+					GetByID(gomock.Any(), id).
+					Return(&domain.UserStoredData{ID: id, UserID: userID, Version: 3}, nil)
+
+				return userID, id, domain.TextData{Text: "text"}, "meta"
+			},
+		},
+		{
+			name:            "changed between read and update",
+			err:             domain.ErrDataVersionConflict,
+			expectedVersion: 3,
+			prepare: func() (int, int, interface{}, string) {
+				userID := 1
+				id := 1
+				data := domain.TextData{
+					Text: "text",
+				}
+				b, _ := json.Marshal(data)
+				encrypted := []uint8{1, 2, 3}
+
+				suite.repository.
+					EXPECT().
+					GetByID(gomock.Any(), id).
+					Return(&domain.UserStoredData{ID: id, UserID: userID, Version: 3}, nil)
+
+				suite.cryptor.
+					EXPECT().
+					EncryptBytes(b).
+					Return(encrypted, nil)
+
+				suite.repository.
+					EXPECT().
+					UpdateUserData(gomock.Any(), userID, id, 3, encrypted, "meta").
+					Return(nil, domain.ErrDataVersionConflict)
+
+				return userID, id, data, "meta"
+			},
+		},
+		{
+			name: "version is required",
+			err:  domain.ErrDataVersionRequired,
+			prepare: func() (int, int, interface{}, string) {
+				return 1, 1, domain.TextData{Text: "text"}, "meta"
+			},
+		},
+		{
+			name:            "invalid update",
+			err:             domain.ErrInternal,
+			expectedVersion: 1,
+			prepare: func() (int, int, interface{}, string) {
+				userID := 1
+				id := 1
+				data := domain.TextData{
+					Text: "text",
+				}
+				b, _ := json.Marshal(data)
+				meta := "meta"
+				encrypted := []uint8{1, 2, 3}
+
+				suite.repository.
+					EXPECT().
+					GetByID(gomock.Any(), id).
+					Return(&domain.UserStoredData{ID: id, UserID: userID, Version: 1}, nil)
+
+				suite.cryptor.
+					EXPECT().
+					EncryptBytes(b).
+					Return(encrypted, nil)
+
+				suite.repository.
+					EXPECT().
+					UpdateUserData(gomock.Any(), userID, id, 1, encrypted, meta).
+					Return(nil, domain.ErrInternal)
+
+				return userID, id, data, meta
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.name, func() {
+			userID, id, data, meta := testCase.prepare()
+			_, err := suite.service.UpdateUserData(context.Background(), userID, id, testCase.expectedVersion, data, meta)
 			suite.Equal(testCase.err, err)
 		})
 	}
 }
 
-func (suite *userStoredDataTestSuite) TestDeleteBatch() {
+func (suite *userStoredDataTestSuite) TestAdd() {
 	testCases := []struct {
-		name    string
-		err     error
-		prepare func() (int, []int)
+		name       string
+		err        error
+		recordUUID string
+		prepare    func() (int, int, string, interface{}, string)
 	}{
 		{
 			name: "valid",
 			err:  nil,
+			prepare: func() (int, int, string, interface{}, string) {
+				userID := 1
+				dataType := domain.TextDataType
+				data := domain.TextData{
+					Text: "text",
+				}
+				b, _ := json.Marshal(data)
+				meta := "meta"
+				encrypted := []uint8{1, 2, 3}
+
+				suite.cryptor.
+					EXPECT().
+					EncryptBytes(b).
+					Return(encrypted, nil)
+
+				suite.repository.
+					EXPECT().
+					AddData(gomock.Any(), userID, gomock.Any(), dataType, encrypted, meta).
+					Return(int64(1), nil)
+
+				suite.changePublisher.
+					EXPECT().
+					Publish(gomock.Any(), domain.DataChangeEvent{UserID: userID})
+
+				return userID, 0, dataType, data, meta
+			},
+		},
+		{
+			name:       "client uuid already taken",
+			err:        domain.ErrRecordUUIDTaken,
+			recordUUID: testRecordUUID,
+			prepare: func() (int, int, string, interface{}, string) {
+				userID := 1
+				dataType := domain.TextDataType
+				data := domain.TextData{
+					Text: "text",
+				}
+				b, _ := json.Marshal(data)
+				meta := "meta"
+				encrypted := []uint8{1, 2, 3}
+
+				suite.cryptor.
+					EXPECT().
+					EncryptBytes(b).
+					Return(encrypted, nil)
+
+				suite.repository.
+					EXPECT().
+					AddData(gomock.Any(), userID, testRecordUUID, dataType, encrypted, meta).
+					Return(int64(0), domain.ErrRecordUUIDTaken)
+
+				return userID, 0, dataType, data, meta
+			},
+		},
+		{
+			name: "error when encrypt",
+			err:  domain.ErrInternal,
+			prepare: func() (int, int, string, interface{}, string) {
+				userID := 1
+				dataType := domain.TextDataType
+				data := domain.TextData{
+					Text: "text",
+				}
+				b, _ := json.Marshal(data)
+				meta := "meta"
+
+				suite.cryptor.
+					EXPECT().
+					EncryptBytes(b).
+					Return(nil, domain.ErrInternal)
+
+				return userID, 0, dataType, data, meta
+			},
+		},
+		{
+			name: "error when adding",
+			err:  domain.ErrInternal,
+			prepare: func() (int, int, string, interface{}, string) {
+				userID := 1
+				dataType := domain.TextDataType
+				data := domain.TextData{
+					Text: "text",
+				}
+				b, _ := json.Marshal(data)
+				meta := "meta"
+				encrypted := []uint8{1, 2, 3}
+
+				suite.cryptor.
+					EXPECT().
+					EncryptBytes(b).
+					Return(encrypted, nil)
+
+				suite.repository.
+					EXPECT().
+					AddData(gomock.Any(), userID, gomock.Any(), dataType, encrypted, meta).
+					Return(int64(0), domain.ErrInternal)
+
+				return userID, 0, dataType, data, meta
+			},
+		},
+		{
+			name: "valid (collection)",
+			err:  nil,
+			prepare: func() (int, int, string, interface{}, string) {
+				userID := 1
+				collectionID := 3
+				dataType := domain.TextDataType
+				data := domain.TextData{
+					Text: "text",
+				}
+				b, _ := json.Marshal(data)
+				meta := "meta"
+				encrypted := []uint8{1, 2, 3}
+
+				suite.organizationRepository.
+					EXPECT().
+					GetCollectionMember(gomock.Any(), collectionID, userID).
+					Return(&domain.OrganizationMember{UserID: userID, Role: domain.OrganizationAdminRole, Status: domain.OrganizationMemberAccepted}, nil)
+
+				suite.cryptor.
+					EXPECT().
+					EncryptBytes(b).
+					Return(encrypted, nil)
+
+				suite.repository.
+					EXPECT().
+					AddCollectionData(gomock.Any(), userID, collectionID, gomock.Any(), dataType, encrypted, meta).
+					Return(int64(1), nil)
+
+				suite.changePublisher.
+					EXPECT().
+					Publish(gomock.Any(), domain.DataChangeEvent{UserID: userID, CollectionID: collectionID})
+
+				return userID, collectionID, dataType, data, meta
+			},
+		},
+		{
+			name: "collection read-only member",
+			err:  domain.ErrNotEnoughPermissions,
+			prepare: func() (int, int, string, interface{}, string) {
+				userID := 1
+				collectionID := 3
+
+				suite.organizationRepository.
+					EXPECT().
+					GetCollectionMember(gomock.Any(), collectionID, userID).
+					Return(&domain.OrganizationMember{UserID: userID, Role: domain.OrganizationReadOnlyRole, Status: domain.OrganizationMemberAccepted}, nil)
+
+				return userID, collectionID, domain.TextDataType, domain.TextData{Text: "text"}, "meta"
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.name, func() {
+			userID, collectionID, dataType, data, meta := testCase.prepare()
+			added, err := suite.service.Add(context.Background(), userID, collectionID, testCase.recordUUID, dataType, data, meta)
+			suite.Equal(testCase.err, err)
+			if err == nil {
+				suite.True(domain.IsValidUUID(added.UUID))
+			}
+		})
+	}
+}
+
+func (suite *userStoredDataTestSuite) TestDeleteBatch() {
+	testCases := []struct {
+		name     string
+		err      error
+		expected []int
+		prepare  func() (int, []int)
+	}{
+		{
+			name:     "valid",
+			err:      nil,
+			expected: []int{1},
 			prepare: func() (int, []int) {
 				userID := 1
-				ids := []int{1}
+				ids := []int{1, 2}
 
 				suite.repository.
 					EXPECT().
 					DeleteBatch(gomock.Any(), userID, ids).
-					Return(nil)
+					Return([]int{1}, nil)
+
+				suite.changePublisher.
+					EXPECT().
+					Publish(gomock.Any(), domain.DataChangeEvent{UserID: userID})
+
+				return userID, ids
+			},
+		},
+		{
+			name:     "nothing deleted",
+			err:      nil,
+			expected: []int{},
+			prepare: func() (int, []int) {
+				userID := 1
+				ids := []int{2}
+
+				suite.repository.
+					EXPECT().
+					DeleteBatch(gomock.Any(), userID, ids).
+					Return([]int{}, nil)
 
 				return userID, ids
 			},
@@ -496,7 +1175,7 @@ func (suite *userStoredDataTestSuite) TestDeleteBatch() {
 				suite.repository.
 					EXPECT().
 					DeleteBatch(gomock.Any(), userID, ids).
-					Return(domain.ErrInternal)
+					Return(nil, domain.ErrInternal)
 
 				return userID, ids
 			},
@@ -506,8 +1185,389 @@ func (suite *userStoredDataTestSuite) TestDeleteBatch() {
 	for _, testCase := range testCases {
 		suite.Run(testCase.name, func() {
 			userID, ids := testCase.prepare()
-			err := suite.service.DeleteBatch(context.Background(), userID, ids)
+			deleted, err := suite.service.DeleteBatch(context.Background(), userID, 0, ids)
+			suite.Equal(testCase.err, err)
+			suite.Equal(testCase.expected, deleted)
+		})
+	}
+}
+
+func (suite *userStoredDataTestSuite) TestTracing() {
+	exporter := tracingtest.Setup(suite.T())
+
+	ctx, requestSpan := tracing.Start(context.Background(), "GET /api/v1/data")
+
+	suite.repository.
+		EXPECT().
+		GetUserAllData(gomock.Any(), 1).
+		DoAndReturn(func(ctx context.Context, userID int) ([]domain.UserStoredData, error) {
+			suite.True(trace.SpanContextFromContext(ctx).IsValid(), "repository must receive context with service span")
+			return []domain.UserStoredData{}, nil
+		})
+
+	_, err := suite.service.GetAllUserData(ctx, 1, 0)
+	requestSpan.End()
+	suite.NoError(err)
+
+	spans := exporter.GetSpans()
+	suite.Require().Len(spans, 2)
+	suite.Equal("UserStoredDataService.GetAllUserData", spans[0].Name)
+	suite.Equal(requestSpan.SpanContext().SpanID(), spans[0].Parent.SpanID())
+}
+
+func (suite *userStoredDataTestSuite) TestGetChanges() {
+	testCases := []struct {
+		name           string
+		err            error
+		expectedCursor int64
+		hasMore        bool
+		prepare        func() (int, int, int64, int)
+	}{
+		{
+			name:           "personal vault",
+			expectedCursor: 12,
+			prepare: func() (int, int, int64, int) {
+				userID := 1
+				b, _ := json.Marshal(domain.TextData{Text: "text"})
+				crypted := []byte{1, 2, 3}
+
+				suite.repository.
+					EXPECT().
+					GetUserChanges(gomock.Any(), userID, int64(10), 3).
+					Return([]domain.DataChange{
+						{Seq: 11, ID: 1, Data: &domain.UserStoredData{ID: 1, DataType: domain.TextDataType, CryptedData: crypted}},
+						{Seq: 12, ID: 2, Deleted: true},
+					}, nil)
+
+				suite.repository.
+					EXPECT().
+					GetCollectedTombstonesSeq(gomock.Any(), userID).
+					Return(int64(10), nil)
+
+				suite.cryptor.
+					EXPECT().
+					DecryptBytes(crypted).
+					Return(b, nil)
+
+				return userID, 0, 10, 2
+			},
+		},
+		{
+			name:           "next page exists",
+			expectedCursor: 11,
+			hasMore:        true,
+			prepare: func() (int, int, int64, int) {
+				userID := 1
+
+				suite.repository.
+					EXPECT().
+					GetUserChanges(gomock.Any(), userID, int64(0), 2).
+					Return([]domain.DataChange{
+						{Seq: 11, ID: 1, Deleted: true},
+						{Seq: 12, ID: 2, Deleted: true},
+					}, nil)
+
+				return userID, 0, 0, 1
+			},
+		},
+		{
+			name:           "no changes keep cursor",
+			expectedCursor: 42,
+			prepare: func() (int, int, int64, int) {
+				userID := 1
+
+				suite.repository.
+					EXPECT().
+					GetUserChanges(gomock.Any(), userID, int64(42), domain.SyncChangesDefaultLimit+1).
+					Return([]domain.DataChange{}, nil)
+
+				suite.repository.
+					EXPECT().
+					GetCollectedTombstonesSeq(gomock.Any(), userID).
+					Return(int64(0), nil)
+
+				return userID, 0, 42, 0
+			},
+		},
+		{
+			name: "cursor older than collected tombstones",
+			err:  domain.ErrSyncCursorExpired,
+			prepare: func() (int, int, int64, int) {
+				userID := 1
+
+				suite.repository.
+					EXPECT().
+					GetUserChanges(gomock.Any(), userID, int64(5), domain.SyncChangesDefaultLimit+1).
+					Return([]domain.DataChange{{Seq: 20, ID: 1, Deleted: true}}, nil)
+
+				suite.repository.
+					EXPECT().
+					GetCollectedTombstonesSeq(gomock.Any(), userID).
+					Return(int64(15), nil)
+
+				return userID, 0, 5, 0
+			},
+		},
+		{
+			name: "collection without access",
+			err:  domain.ErrCollectionNotFound,
+			prepare: func() (int, int, int64, int) {
+				userID, collectionID := 1, 3
+
+				suite.organizationRepository.
+					EXPECT().
+					GetCollectionMember(gomock.Any(), collectionID, userID).
+					Return(nil, domain.ErrCollectionNotFound)
+
+				return userID, collectionID, 0, 0
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.name, func() {
+			userID, collectionID, since, limit := testCase.prepare()
+			feed, err := suite.service.GetChanges(context.Background(), userID, collectionID, since, limit)
+			suite.Equal(testCase.err, err)
+
+			if err == nil {
+				suite.Equal(testCase.expectedCursor, feed.Cursor)
+				suite.Equal(testCase.hasMore, feed.HasMore)
+			}
+		})
+	}
+}
+
+func (suite *userStoredDataTestSuite) TestCollectTombstones() {
+	suite.repository.
+		EXPECT().
+		CollectTombstones(gomock.Any()).
+		Return(int64(4), nil)
+
+	collected, err := suite.service.CollectTombstones(context.Background())
+	suite.NoError(err)
+	suite.Equal(int64(4), collected)
+}
+
+func (suite *userStoredDataTestSuite) TestPush() {
+	userID := 1
+	deletedUUID := "7ca7b810-9dad-11d1-80b4-00c04fd430c8"
+	crypted := []byte{1, 2, 3}
+	storedCrypted := []byte{4, 5, 6}
+	b, _ := json.Marshal(domain.TextData{Text: "text"})
+
+	createItem := domain.SyncPushItem{UUID: testRecordUUID, Operation: domain.SyncOperationCreate, DataType: domain.TextDataType, Data: domain.TextData{Text: "text"}}
+	deleteItem := domain.SyncPushItem{UUID: deletedUUID, Operation: domain.SyncOperationDelete, Version: 2}
+
+	pushedCreate := createItem
+	pushedCreate.CryptedData = crypted
+
+	testCases := []struct {
+		name     string
+		prepare  func()
+		expected *domain.SyncPushResponse
+	}{
+		{
+			name: "new record",
+			prepare: func() {
+				suite.repository.
+					EXPECT().
+					GetByUUID(gomock.Any(), testRecordUUID).
+					Return(nil, domain.ErrUserStoredDataNotFound)
+
+				suite.cryptor.
+					EXPECT().
+					EncryptBytes(b).
+					Return(crypted, nil)
+
+				suite.repository.
+					EXPECT().
+					ApplyPush(gomock.Any(), userID, []domain.SyncPushItem{pushedCreate, deleteItem}).
+					Return(&domain.SyncPushResponse{
+						Applied: true,
+						Results: []domain.SyncPushResult{
+							{UUID: testRecordUUID, Status: domain.SyncPushStatusApplied, ID: 3, Version: 1},
+							{UUID: deletedUUID, Status: domain.SyncPushStatusApplied, ID: 4},
+						},
+					}, nil)
+
+				suite.changePublisher.
+					EXPECT().
+					Publish(gomock.Any(), domain.DataChangeEvent{UserID: userID})
+			},
+			expected: &domain.SyncPushResponse{
+				Applied: true,
+				Results: []domain.SyncPushResult{
+					{UUID: testRecordUUID, Status: domain.SyncPushStatusApplied, ID: 3, Version: 1},
+					{UUID: deletedUUID, Status: domain.SyncPushStatusApplied, ID: 4},
+				},
+			},
+		},
+		{
+			name: "record created by lost push",
+			prepare: func() {
+				suite.repository.
+					EXPECT().
+					GetByUUID(gomock.Any(), testRecordUUID).
+					Return(&domain.UserStoredData{ID: 3, UUID: testRecordUUID, UserID: userID, DataType: domain.TextDataType, CryptedData: storedCrypted, Version: 1}, nil)
+
+				suite.cryptor.
+					EXPECT().
+					DecryptBytes(storedCrypted).
+					Return(b, nil)
+
+				suite.repository.
+					EXPECT().
+					ApplyPush(gomock.Any(), userID, []domain.SyncPushItem{deleteItem}).
+					Return(&domain.SyncPushResponse{
+						Applied: true,
+						Results: []domain.SyncPushResult{{UUID: deletedUUID, Status: domain.SyncPushStatusApplied, ID: 4}},
+					}, nil)
+
+				suite.changePublisher.
+					EXPECT().
+					Publish(gomock.Any(), domain.DataChangeEvent{UserID: userID})
+			},
+			expected: &domain.SyncPushResponse{
+				Applied: true,
+				Results: []domain.SyncPushResult{
+					{UUID: testRecordUUID, Status: domain.SyncPushStatusApplied, ID: 3, Version: 1},
+					{UUID: deletedUUID, Status: domain.SyncPushStatusApplied, ID: 4},
+				},
+			},
+		},
+		{
+			name: "record with the same uuid and other content",
+			prepare: func() {
+				otherContent, _ := json.Marshal(domain.TextData{Text: "other"})
+
+				suite.repository.
+					EXPECT().
+					GetByUUID(gomock.Any(), testRecordUUID).
+					Return(&domain.UserStoredData{ID: 3, UUID: testRecordUUID, UserID: userID, DataType: domain.TextDataType, CryptedData: storedCrypted, Version: 2}, nil)
+
+				suite.cryptor.
+					EXPECT().
+					DecryptBytes(storedCrypted).
+					Return(otherContent, nil)
+
+				suite.cryptor.
+					EXPECT().
+					EncryptBytes(b).
+					Return(crypted, nil)
+
+				suite.repository.
+					EXPECT().
+					ApplyPush(gomock.Any(), userID, []domain.SyncPushItem{pushedCreate, deleteItem}).
+					Return(&domain.SyncPushResponse{
+						Results: []domain.SyncPushResult{
+							{UUID: testRecordUUID, Status: domain.SyncPushStatusConflict},
+							{UUID: deletedUUID, Status: domain.SyncPushStatusSkipped},
+						},
+					}, nil)
+			},
+			expected: &domain.SyncPushResponse{
+				Results: []domain.SyncPushResult{
+					{UUID: testRecordUUID, Status: domain.SyncPushStatusConflict},
+					{UUID: deletedUUID, Status: domain.SyncPushStatusSkipped},
+				},
+			},
+		},
+		{
+			name: "record created by lost push in rejected batch",
+			prepare: func() {
+				suite.repository.
+					EXPECT().
+					GetByUUID(gomock.Any(), testRecordUUID).
+					Return(&domain.UserStoredData{ID: 3, UUID: testRecordUUID, UserID: userID, DataType: domain.TextDataType, CryptedData: storedCrypted, Version: 1}, nil)
+
+				suite.cryptor.
+					EXPECT().
+					DecryptBytes(storedCrypted).
+					Return(b, nil)
+
+				suite.repository.
+					EXPECT().
+					ApplyPush(gomock.Any(), userID, []domain.SyncPushItem{deleteItem}).
+					Return(&domain.SyncPushResponse{
+						Results: []domain.SyncPushResult{{UUID: deletedUUID, Status: domain.SyncPushStatusConflict}},
+					}, nil)
+			},
+			expected: &domain.SyncPushResponse{
+				Results: []domain.SyncPushResult{
+					{UUID: testRecordUUID, Status: domain.SyncPushStatusSkipped},
+					{UUID: deletedUUID, Status: domain.SyncPushStatusConflict},
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.name, func() {
+			testCase.prepare()
+
+			response, err := suite.service.Push(context.Background(), userID, []domain.SyncPushItem{createItem, deleteItem})
+			suite.NoError(err)
+			suite.Equal(testCase.expected, response)
+		})
+	}
+}
+
+func (suite *userStoredDataTestSuite) TestSubscribeChanges() {
+	testCases := []struct {
+		name         string
+		collectionID int
+		err          error
+		prepare      func(userID int)
+	}{
+		{
+			name: "personal vault",
+			prepare: func(userID int) {
+				suite.changeSubscriber.
+					EXPECT().
+					Subscribe(userID, 0).
+					Return(make(chan domain.DataChangeEvent), func() {})
+			},
+		},
+		{
+			name:         "collection member",
+			collectionID: 3,
+			prepare: func(userID int) {
+				suite.organizationRepository.
+					EXPECT().
+					GetCollectionMember(gomock.Any(), 3, userID).
+					Return(&domain.OrganizationMember{UserID: userID, Role: domain.OrganizationReadOnlyRole, Status: domain.OrganizationMemberAccepted}, nil)
+
+				suite.changeSubscriber.
+					EXPECT().
+					Subscribe(userID, 3).
+					Return(make(chan domain.DataChangeEvent), func() {})
+			},
+		},
+		{
+			name:         "not collection member",
+			collectionID: 3,
+			err:          domain.ErrCollectionNotFound,
+			prepare: func(userID int) {
+				suite.organizationRepository.
+					EXPECT().
+					GetCollectionMember(gomock.Any(), 3, userID).
+					Return(nil, domain.ErrCollectionNotFound)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.name, func() {
+			userID := 1
+			testCase.prepare(userID)
+
+			events, unsubscribe, err := suite.service.SubscribeChanges(context.Background(), userID, testCase.collectionID)
 			suite.Equal(testCase.err, err)
+			if err == nil {
+				suite.NotNil(events)
+				suite.NotNil(unsubscribe)
+			}
 		})
 	}
 }
@@ -1,51 +1,209 @@
 package session
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
-	"io"
 	"os"
+	"sort"
 	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+	"github.com/MowlCoder/goph-keeper/internal/storage/file"
 )
 
-// ClientSession - struct responsible for keeping client session state
+// sessionFilePerm - session keeps auth token, so only owner can read it
+const sessionFilePerm = 0600
+
+// sessionFileMagic - encrypted session file starts with it, files saved by previous versions are plain json
+var sessionFileMagic = []byte("GKSESSION")
+
+type sessionSealer interface {
+	Seal(plaintext []byte, additionalData []byte) ([]byte, error)
+	Open(sealed []byte, additionalData []byte) ([]byte, error)
+}
+
+// ClientSession - struct responsible for keeping client session state. Deleted and edited records are
+// remembered by uuid, which does not change when record is synced. Deleted records keep version they had
+// when they were deleted, server deletes them only if nobody changed them since
 type ClientSession struct {
-	mu   *sync.RWMutex
-	file *os.File
+	mu     *sync.RWMutex
+	path   string
+	sealer sessionSealer
+
+	Token              string                `json:"token"`
+	DeviceID           int                   `json:"device_id"`
+	Deleted            map[string]int        `json:"deleted_versions"`
+	Edited             map[string]struct{}   `json:"edited"`
+	ActiveCollectionID int                   `json:"active_collection_id"`
+	SyncCursor         int64                 `json:"sync_cursor"`
+	Conflicts          []domain.SyncConflict `json:"conflicts"`
+}
 
-	Token      string           `json:"token"`
-	DeletedIDs map[int]struct{} `json:"deleted_ids"`
-	EditedIDs  map[int]struct{} `json:"edited_ids"`
+// legacySession - state saved by previous versions of client. Records were remembered by server id before
+// they had uuids, later deleted records were remembered without their versions
+type legacySession struct {
+	Deleted    map[string]struct{} `json:"deleted"`
+	DeletedIDs map[int]struct{}    `json:"deleted_ids"`
+	EditedIDs  map[int]struct{}    `json:"edited_ids"`
+	Conflicts  []struct {
+		RecordID int `json:"record_id"`
+		CopyID   int `json:"copy_id"`
+	} `json:"conflicts"`
 }
 
-// NewClientSession - constructor for ClientSession struct
-func NewClientSession(file *os.File) *ClientSession {
+// NewClientSession - constructor for ClientSession struct, state is loaded from file at path if it exists.
+// Session file is encrypted by sealer, plain file of previous versions is encrypted right after loading
+func NewClientSession(path string, sealer sessionSealer) (*ClientSession, error) {
 	session := &ClientSession{
 		mu: &sync.RWMutex{},
 
-		DeletedIDs: map[int]struct{}{},
-		EditedIDs:  map[int]struct{}{},
+		Deleted: map[string]int{},
+		Edited:  map[string]struct{}{},
+	}
+
+	session.path = path
+	session.sealer = sealer
+
+	content, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	isPlain := len(content) > 0 && !bytes.HasPrefix(content, sessionFileMagic)
+	if !isPlain && len(content) > 0 {
+		content, err = sealer.Open(content[len(sessionFileMagic):], sessionFileMagic)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(content) > 0 {
+		if err := json.Unmarshal(content, &session); err != nil {
+			return nil, err
+		}
+
+		var legacy legacySession
+		if err := json.Unmarshal(content, &legacy); err != nil {
+			return nil, err
+		}
+		session.migrateLegacy(legacy)
+	}
+
+	// token is kept sealed between runs, so commands executed one by one stay authorized until it expires
+	if !isTokenUsable(session.Token) {
+		session.Token = ""
+	}
+	// collections are accessible only with auth, so start in personal vault until user logs in
+	session.ActiveCollectionID = 0
+
+	if isPlain {
+		if err := session.SaveInFile(); err != nil {
+			return nil, err
+		}
+	}
+
+	return session, nil
+}
+
+// isTokenUsable - check that token is well formed and not expired. Client does not know key token is signed
+// with, so signature is checked only by server
+func isTokenUsable(token string) bool {
+	claims, ok := parseTokenClaims(token)
+	if !ok {
+		return false
+	}
+
+	return claims.ExpiresAt == nil || claims.ExpiresAt.After(time.Now())
+}
+
+// parseTokenClaims - read claims of token without checking its signature
+func parseTokenClaims(token string) (*domain.TokenClaim, bool) {
+	if token == "" {
+		return nil, false
 	}
 
-	session.file = file
+	claims := &domain.TokenClaim{}
+	if _, _, err := jwt.NewParser().ParseUnverified(token, claims); err != nil {
+		return nil, false
+	}
+
+	return claims, true
+}
+
+// migrateLegacy - convert server ids to uuids derived from them. Local records had negative ids and were
+// never sent to server, so they are not tracked as deleted or edited. Versions of deleted records were not
+// remembered, they are unknown (0) until sync finds them out
+func (s *ClientSession) migrateLegacy(legacy legacySession) {
+	for recordUUID := range legacy.Deleted {
+		if _, ok := s.Deleted[recordUUID]; !ok {
+			s.Deleted[recordUUID] = 0
+		}
+	}
+
+	for id := range legacy.DeletedIDs {
+		if id > 0 {
+			s.Deleted[domain.LegacyRecordUUID(id)] = 0
+		}
+	}
 
-	if err := json.NewDecoder(file).Decode(&session); err != nil {
-		if !errors.Is(err, io.EOF) {
-			panic(err)
+	for id := range legacy.EditedIDs {
+		if id > 0 {
+			s.Edited[domain.LegacyRecordUUID(id)] = struct{}{}
 		}
 	}
 
-	session.Token = "" // TODO: validate token
+	for idx, conflict := range legacy.Conflicts {
+		if idx >= len(s.Conflicts) || s.Conflicts[idx].RecordUUID != "" {
+			continue
+		}
 
-	return session
+		if conflict.RecordID > 0 {
+			s.Conflicts[idx].RecordUUID = domain.LegacyRecordUUID(conflict.RecordID)
+		}
+		if conflict.CopyID > 0 {
+			s.Conflicts[idx].CopyUUID = domain.LegacyRecordUUID(conflict.CopyID)
+		}
+	}
 }
 
-// SetToken - save user token in session state
-func (s *ClientSession) SetToken(token string) {
+// SetToken - save user token in session state, empty token logs user out. Device token was issued for is
+// remembered after logout, so next login reuses it
+func (s *ClientSession) SetToken(token string) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	s.Token = token
+	if claims, ok := parseTokenClaims(token); ok {
+		s.DeviceID = claims.DeviceID
+	}
+
+	return s.SaveInFile()
+}
+
+// GetDeviceID - get id of device client was registered as on last login, 0 means client never logged in
+func (s *ClientSession) GetDeviceID() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.DeviceID
+}
+
+// TokenExpiresWithin - check if user token expires in less than given duration, token without expiration
+// never does
+func (s *ClientSession) TokenExpiresWithin(d time.Duration) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	claims, ok := parseTokenClaims(s.Token)
+	if !ok || claims.ExpiresAt == nil {
+		return false
+	}
+
+	return claims.ExpiresAt.Before(time.Now().Add(d))
 }
 
 // IsAuth - check if user already authorized
@@ -64,69 +222,188 @@ func (s *ClientSession) GetToken() string {
 	return s.Token
 }
 
-// AddDeleted - add id of deleted record in session state
-func (s *ClientSession) AddDeleted(id int) error {
+// SetActiveCollectionID - switch vault to organization collection with given id, 0 means personal vault
+func (s *ClientSession) SetActiveCollectionID(collectionID int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.ActiveCollectionID = collectionID
+	return s.SaveInFile()
+}
+
+// GetActiveCollectionID - get id of active organization collection, 0 means personal vault
+func (s *ClientSession) GetActiveCollectionID() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.ActiveCollectionID
+}
+
+// IsPersonalVault - check if personal vault is active
+func (s *ClientSession) IsPersonalVault() bool {
+	return s.GetActiveCollectionID() == 0
+}
+
+// SetSyncCursor - save cursor of last change received from server
+func (s *ClientSession) SetSyncCursor(cursor int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.SyncCursor = cursor
+	return s.SaveInFile()
+}
+
+// GetSyncCursor - get cursor of last change received from server, 0 means nothing was synced yet
+func (s *ClientSession) GetSyncCursor() int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.SyncCursor
+}
+
+// AddDeleted - add uuid of deleted record and version it had when it was deleted in session state
+func (s *ClientSession) AddDeleted(recordUUID string, version int) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	s.DeletedIDs[id] = struct{}{}
+	s.Deleted[recordUUID] = version
 	return s.SaveInFile()
 }
 
-// IsDeleted - check if record with given id was deleted
-func (s *ClientSession) IsDeleted(id int) bool {
+// IsDeleted - check if record with given uuid was deleted
+func (s *ClientSession) IsDeleted(recordUUID string) bool {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	_, ok := s.DeletedIDs[id]
+	_, ok := s.Deleted[recordUUID]
 	return ok
 }
 
-// ClearDeleted - clear deleted record ids from session state
+// GetDeletedVersion - get version deleted record had when it was deleted, 0 means version is unknown
+func (s *ClientSession) GetDeletedVersion(recordUUID string) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.Deleted[recordUUID]
+}
+
+// HasDeletedWithoutVersion - check if some of deleted records were deleted by previous version of client,
+// which did not remember their versions
+func (s *ClientSession) HasDeletedWithoutVersion() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, version := range s.Deleted {
+		if version == 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetDeleted - get uuids of records deleted since last sync in ascending order
+func (s *ClientSession) GetDeleted() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	uuids := make([]string, 0, len(s.Deleted))
+	for recordUUID := range s.Deleted {
+		uuids = append(uuids, recordUUID)
+	}
+	sort.Strings(uuids)
+
+	return uuids
+}
+
+// ClearDeleted - clear deleted record uuids from session state
 func (s *ClientSession) ClearDeleted() error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	clear(s.DeletedIDs)
+	clear(s.Deleted)
 	return s.SaveInFile()
 }
 
-// AddEdited - add id of edited record in session state
-func (s *ClientSession) AddEdited(id int) error {
+// AddEdited - add uuid of edited record in session state
+func (s *ClientSession) AddEdited(recordUUID string) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	s.EditedIDs[id] = struct{}{}
+	s.Edited[recordUUID] = struct{}{}
 	return s.SaveInFile()
 }
 
-// IsEdited - check if record with given id was edited
-func (s *ClientSession) IsEdited(id int) bool {
+// IsEdited - check if record with given uuid was edited
+func (s *ClientSession) IsEdited(recordUUID string) bool {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	_, ok := s.EditedIDs[id]
+	_, ok := s.Edited[recordUUID]
 	return ok
 }
 
-// ClearEdited - clear edited record ids from session state
+// ClearEdited - clear edited record uuids from session state
 func (s *ClientSession) ClearEdited() error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	clear(s.EditedIDs)
+	clear(s.Edited)
+	return s.SaveInFile()
+}
+
+// AddConflict - remember sync conflict until user resolves it
+func (s *ClientSession) AddConflict(conflict domain.SyncConflict) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.Conflicts = append(s.Conflicts, conflict)
+	return s.SaveInFile()
+}
+
+// GetConflicts - get unresolved sync conflicts in order they were detected
+func (s *ClientSession) GetConflicts() []domain.SyncConflict {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	conflicts := make([]domain.SyncConflict, len(s.Conflicts))
+	copy(conflicts, s.Conflicts)
+
+	return conflicts
+}
+
+// RemoveConflict - mark conflicts of record with given uuid as resolved
+func (s *ClientSession) RemoveConflict(recordUUID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	conflicts := make([]domain.SyncConflict, 0, len(s.Conflicts))
+	for _, conflict := range s.Conflicts {
+		if conflict.RecordUUID != recordUUID {
+			conflicts = append(conflicts, conflict)
+		}
+	}
+
+	if len(conflicts) == len(s.Conflicts) {
+		return domain.ErrSyncConflictNotFound
+	}
+
+	s.Conflicts = conflicts
 	return s.SaveInFile()
 }
 
-// SaveInFile - save session state in file
+// SaveInFile - save encrypted session state in file, file is replaced atomically so crash never leaves it
+// half written
 func (s *ClientSession) SaveInFile() error {
-	if err := s.file.Truncate(0); err != nil {
+	content, err := json.Marshal(s)
+	if err != nil {
 		return err
 	}
-	if _, err := s.file.Seek(0, 0); err != nil {
+
+	sealed, err := s.sealer.Seal(content, sessionFileMagic)
+	if err != nil {
 		return err
 	}
 
-	writer := json.NewEncoder(s.file)
-	return writer.Encode(s)
+	return file.WriteFileAtomic(s.path, append(sessionFileMagic[:len(sessionFileMagic):len(sessionFileMagic)], sealed...), sessionFilePerm)
 }
@@ -3,27 +3,83 @@ package session
 import (
 	"os"
 	"testing"
+	"time"
 
+	"github.com/golang-jwt/jwt/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+	"github.com/MowlCoder/goph-keeper/internal/utils/cryptor"
 )
 
-func TestClientSession_SetToken(t *testing.T) {
-	file, err := os.Create(t.TempDir() + "/test.json")
-	defer file.Close()
+func newTestSession(t *testing.T, path string) *ClientSession {
+	sealer, err := cryptor.NewSealer("test-vault-key")
+	require.NoError(t, err)
+
+	session, err := NewClientSession(path, sealer)
+	require.NoError(t, err)
+
+	return session
+}
+
+func newTestToken(t *testing.T, expiresAt time.Time) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, domain.TokenClaim{
+		ID:       1,
+		DeviceID: 3,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}).SignedString([]byte("secret"))
 	require.NoError(t, err)
-	session := NewClientSession(file)
+
+	return token
+}
+
+func TestClientSession_SetToken(t *testing.T) {
+	session := newTestSession(t, t.TempDir()+"/test.json")
 	token := "test-token"
 
-	session.SetToken(token)
+	require.NoError(t, session.SetToken(token))
 	assert.Equal(t, token, session.Token)
 }
 
+func TestClientSession_TokenKeptBetweenRuns(t *testing.T) {
+	testCases := []struct {
+		name     string
+		token    string
+		expected bool
+	}{
+		{name: "valid token", token: newTestToken(t, time.Now().Add(time.Hour)), expected: true},
+		{name: "expired token", token: newTestToken(t, time.Now().Add(-time.Hour)), expected: false},
+		{name: "malformed token", token: "not-a-token", expected: false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			path := t.TempDir() + "/test.json"
+			require.NoError(t, newTestSession(t, path).SetToken(testCase.token))
+
+			loaded := newTestSession(t, path)
+			assert.Equal(t, testCase.expected, loaded.IsAuth())
+		})
+	}
+}
+
+func TestClientSession_DeviceKeptAfterLogout(t *testing.T) {
+	path := t.TempDir() + "/test.json"
+	session := newTestSession(t, path)
+
+	require.NoError(t, session.SetToken(newTestToken(t, time.Now().Add(time.Hour))))
+	assert.Equal(t, 3, session.GetDeviceID())
+
+	require.NoError(t, session.SetToken(""))
+	assert.Equal(t, 3, session.GetDeviceID())
+	assert.Equal(t, 3, newTestSession(t, path).GetDeviceID())
+}
+
 func TestClientSession_IsAuth(t *testing.T) {
-	file, err := os.Create(t.TempDir() + "/test.json")
-	defer file.Close()
-	require.NoError(t, err)
-	session := NewClientSession(file)
+	session := newTestSession(t, t.TempDir()+"/test.json")
 	token := "test-token"
 
 	assert.Equal(t, false, session.IsAuth())
@@ -31,3 +87,100 @@ func TestClientSession_IsAuth(t *testing.T) {
 	assert.Equal(t, token, session.Token)
 	assert.Equal(t, true, session.IsAuth())
 }
+
+func TestClientSession_SetActiveCollectionID(t *testing.T) {
+	session := newTestSession(t, t.TempDir()+"/test.json")
+
+	assert.Equal(t, true, session.IsPersonalVault())
+	require.NoError(t, session.SetActiveCollectionID(3))
+	assert.Equal(t, 3, session.GetActiveCollectionID())
+	assert.Equal(t, false, session.IsPersonalVault())
+}
+
+func TestClientSession_SetSyncCursor(t *testing.T) {
+	path := t.TempDir() + "/test.json"
+	session := newTestSession(t, path)
+
+	assert.Equal(t, int64(0), session.GetSyncCursor())
+	require.NoError(t, session.SetSyncCursor(42))
+	assert.Equal(t, int64(42), session.GetSyncCursor())
+
+	assert.Equal(t, int64(42), newTestSession(t, path).GetSyncCursor())
+}
+
+func TestClientSession_Conflicts(t *testing.T) {
+	path := t.TempDir() + "/test.json"
+	session := newTestSession(t, path)
+
+	assert.Empty(t, session.GetConflicts())
+	require.NoError(t, session.AddConflict(domain.SyncConflict{RecordUUID: "record-1", CopyUUID: "copy-1", Fields: []string{"password"}}))
+	require.NoError(t, session.AddConflict(domain.SyncConflict{RecordUUID: "record-2", CopyUUID: "copy-2", Fields: []string{"meta"}}))
+
+	conflicts := newTestSession(t, path).GetConflicts()
+	require.Len(t, conflicts, 2)
+	assert.Equal(t, "copy-1", conflicts[0].CopyUUID)
+
+	require.NoError(t, session.RemoveConflict("record-2"))
+	assert.ErrorIs(t, session.RemoveConflict("record-2"), domain.ErrSyncConflictNotFound)
+	assert.Len(t, session.GetConflicts(), 1)
+}
+
+func TestClientSession_MigrateLegacyIDs(t *testing.T) {
+	path := t.TempDir() + "/test.json"
+	legacy := `{"deleted_ids":{"5":{},"-2":{}},"edited_ids":{"7":{}},"conflicts":[{"record_id":7,"copy_id":-3,"fields":["meta"]}]}`
+	require.NoError(t, os.WriteFile(path, []byte(legacy), 0600))
+
+	session := newTestSession(t, path)
+
+	assert.Equal(t, []string{domain.LegacyRecordUUID(5)}, session.GetDeleted())
+	assert.True(t, session.HasDeletedWithoutVersion())
+	assert.True(t, session.IsEdited(domain.LegacyRecordUUID(7)))
+
+	conflicts := session.GetConflicts()
+	require.Len(t, conflicts, 1)
+	assert.Equal(t, domain.LegacyRecordUUID(7), conflicts[0].RecordUUID)
+	assert.Equal(t, "", conflicts[0].CopyUUID)
+}
+
+func TestClientSession_DeletedVersions(t *testing.T) {
+	path := t.TempDir() + "/test.json"
+	session := newTestSession(t, path)
+
+	require.NoError(t, session.AddDeleted("deleted-record", 3))
+	assert.False(t, session.HasDeletedWithoutVersion())
+
+	loaded := newTestSession(t, path)
+	assert.Equal(t, []string{"deleted-record"}, loaded.GetDeleted())
+	assert.Equal(t, 3, loaded.GetDeletedVersion("deleted-record"))
+}
+
+func TestClientSession_Encrypted(t *testing.T) {
+	path := t.TempDir() + "/test.json"
+	plain := `{"deleted":{"deleted-record":{}},"sync_cursor":7}`
+	require.NoError(t, os.WriteFile(path, []byte(plain), 0600))
+
+	session := newTestSession(t, path)
+	assert.Equal(t, int64(7), session.GetSyncCursor())
+
+	// plain file of previous version is encrypted as soon as it is loaded
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), "deleted-record")
+	assert.Equal(t, []string{"deleted-record"}, newTestSession(t, path).GetDeleted())
+
+	otherSealer, err := cryptor.NewSealer("other-vault-key")
+	require.NoError(t, err)
+	_, err = NewClientSession(path, otherSealer)
+	assert.ErrorIs(t, err, domain.ErrLocalDataCorrupted)
+}
+
+func TestClientSession_TokenExpiresWithin(t *testing.T) {
+	session := newTestSession(t, t.TempDir()+"/test.json")
+	assert.False(t, session.TokenExpiresWithin(time.Hour))
+
+	require.NoError(t, session.SetToken(newTestToken(t, time.Now().Add(30*time.Minute))))
+	assert.True(t, session.TokenExpiresWithin(time.Hour))
+
+	require.NoError(t, session.SetToken(newTestToken(t, time.Now().Add(2*time.Hour))))
+	assert.False(t, session.TokenExpiresWithin(time.Hour))
+}
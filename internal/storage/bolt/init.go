@@ -0,0 +1,14 @@
+package bolt
+
+import (
+	"time"
+
+	"go.etcd.io/bbolt"
+)
+
+// openTimeout - database file is locked by process which opened it, second client waits for lock this long
+const openTimeout = time.Second
+
+func InitBoltStorage(path string) (*bbolt.DB, error) {
+	return bbolt.Open(path, 0600, &bbolt.Options{Timeout: openTimeout})
+}
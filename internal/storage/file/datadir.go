@@ -0,0 +1,176 @@
+package file
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+)
+
+const (
+	appDirName = "goph-keeper"
+
+	privateDirPerm  = 0700
+	privateFilePerm = 0600
+)
+
+// DefaultDataDir - directory for client data, which is not wiped like cache directory. It is
+// $XDG_DATA_HOME/goph-keeper (~/.local/share/goph-keeper) on unix systems
+func DefaultDataDir() (string, error) {
+	if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+		return filepath.Join(dir, appDirName), nil
+	}
+
+	if runtime.GOOS == "windows" {
+		dir := os.Getenv("LocalAppData")
+		if dir == "" {
+			return "", errors.New("%LocalAppData% is not defined")
+		}
+
+		return filepath.Join(dir, appDirName), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	if runtime.GOOS == "darwin" {
+		return filepath.Join(home, "Library", "Application Support", appDirName), nil
+	}
+
+	return filepath.Join(home, ".local", "share", appDirName), nil
+}
+
+// LegacyDataDir - directory in user cache, where previous versions kept client data
+func LegacyDataDir() (string, error) {
+	userCache, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(userCache, appDirName), nil
+}
+
+// PrepareDataDir - create data directory accessible only by owner. If it does not exist yet, data from
+// legacy directory is moved into it and permissions of moved files are restricted
+func PrepareDataDir(dir string, legacyDir string) error {
+	_, err := os.Stat(dir)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dir), privateDirPerm); err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(legacyDir); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return os.Mkdir(dir, privateDirPerm)
+		}
+
+		return err
+	}
+
+	// rename fails when directories are on different file systems, then data is copied
+	if err := os.Rename(legacyDir, dir); err != nil {
+		if err := copyDir(legacyDir, dir); err != nil {
+			os.RemoveAll(dir)
+			return err
+		}
+
+		if err := os.RemoveAll(legacyDir); err != nil {
+			return err
+		}
+	}
+
+	return restrictPermissions(dir)
+}
+
+// CheckPrivate - fail if file or directory at path can be accessed by group or other users. Missing path
+// is not checked. Windows does not have unix permissions, so nothing is checked there
+func CheckPrivate(path string) error {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if info.Mode().Perm()&0077 != 0 {
+		return domain.ErrVaultNotPrivate
+	}
+
+	return nil
+}
+
+func restrictPermissions(dir string) error {
+	return filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			return os.Chmod(path, privateDirPerm)
+		}
+
+		return os.Chmod(path, privateFilePerm)
+	})
+}
+
+func copyDir(src string, dst string) error {
+	return filepath.WalkDir(src, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		if entry.IsDir() {
+			return os.MkdirAll(target, privateDirPerm)
+		}
+
+		return copyFile(path, target)
+	})
+}
+
+func copyFile(src string, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, privateFilePerm)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+
+	if err := out.Sync(); err != nil {
+		out.Close()
+		return err
+	}
+
+	return out.Close()
+}
@@ -0,0 +1,97 @@
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "session.json")
+	require.NoError(t, os.WriteFile(path, []byte("old"), 0600))
+
+	require.NoError(t, WriteFileAtomic(path, []byte("new"), 0600))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "new", string(content))
+
+	// temp file is renamed, so only written file is left in directory
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestLockDir(t *testing.T) {
+	dir := t.TempDir()
+
+	lock, err := LockDir(dir)
+	require.NoError(t, err)
+
+	_, err = LockDir(dir)
+	assert.ErrorIs(t, err, domain.ErrVaultInUse)
+
+	require.NoError(t, lock.Unlock())
+
+	lock, err = LockDir(dir)
+	require.NoError(t, err)
+	require.NoError(t, lock.Unlock())
+}
+
+func TestPrepareDataDir(t *testing.T) {
+	root := t.TempDir()
+	legacyDir := filepath.Join(root, "cache", "goph-keeper")
+	dir := filepath.Join(root, "data", "goph-keeper")
+
+	require.NoError(t, os.MkdirAll(filepath.Join(legacyDir, "profiles", "default"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(legacyDir, "profiles", "default", "vault.db"), []byte("vault"), 0644))
+
+	require.NoError(t, PrepareDataDir(dir, legacyDir))
+
+	content, err := os.ReadFile(filepath.Join(dir, "profiles", "default", "vault.db"))
+	require.NoError(t, err)
+	assert.Equal(t, "vault", string(content))
+	assert.NoDirExists(t, legacyDir)
+
+	// moved data is accessible only by owner
+	assert.NoError(t, CheckPrivate(dir))
+	assert.NoError(t, CheckPrivate(filepath.Join(dir, "profiles", "default")))
+	assert.NoError(t, CheckPrivate(filepath.Join(dir, "profiles", "default", "vault.db")))
+
+	// existing data directory is left as is
+	require.NoError(t, os.MkdirAll(legacyDir, 0700))
+	require.NoError(t, PrepareDataDir(dir, legacyDir))
+	assert.DirExists(t, legacyDir)
+}
+
+func TestPrepareDataDir_WithoutLegacy(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "data", "goph-keeper")
+
+	require.NoError(t, PrepareDataDir(dir, filepath.Join(root, "cache", "goph-keeper")))
+	assert.NoError(t, CheckPrivate(dir))
+}
+
+func TestCheckPrivate(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("windows does not have unix permissions")
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "vault.db")
+
+	assert.NoError(t, CheckPrivate(path))
+
+	require.NoError(t, os.WriteFile(path, []byte("vault"), 0600))
+	assert.NoError(t, CheckPrivate(path))
+
+	require.NoError(t, os.Chmod(path, 0644))
+	assert.ErrorIs(t, CheckPrivate(path), domain.ErrVaultNotPrivate)
+}
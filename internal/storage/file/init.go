@@ -1,9 +0,0 @@
-package file
-
-import (
-	"os"
-)
-
-func InitFileStorage(path string) (*os.File, error) {
-	return os.OpenFile(path, os.O_CREATE|os.O_RDWR, os.ModePerm)
-}
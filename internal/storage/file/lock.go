@@ -0,0 +1,29 @@
+package file
+
+import (
+	"path/filepath"
+
+	"github.com/gofrs/flock"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+)
+
+// lockFileName - file in data directory locked by running client
+const lockFileName = ".lock"
+
+// LockDir - take advisory lock of data directory for lifetime of client, so two clients never change
+// the same vault. Lock is released by Unlock or when process exits
+func LockDir(dir string) (*flock.Flock, error) {
+	lock := flock.New(filepath.Join(dir, lockFileName))
+
+	locked, err := lock.TryLock()
+	if err != nil {
+		return nil, err
+	}
+
+	if !locked {
+		return nil, domain.ErrVaultInUse
+	}
+
+	return lock, nil
+}
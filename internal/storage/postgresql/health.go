@@ -0,0 +1,64 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pressly/goose/v3"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+)
+
+// HealthChecker - checks that database is reachable and all embedded migrations are applied
+type HealthChecker struct {
+	pool          *pgxpool.Pool
+	latestVersion int64
+}
+
+func NewHealthChecker(pool *pgxpool.Pool) (*HealthChecker, error) {
+	goose.SetBaseFS(embedMigrations)
+
+	migrations, err := goose.CollectMigrations("migrations", 0, goose.MaxVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	last, err := migrations.Last()
+	if err != nil {
+		return nil, err
+	}
+
+	return &HealthChecker{
+		pool:          pool,
+		latestVersion: last.Version,
+	}, nil
+}
+
+func (c *HealthChecker) CheckReadiness(ctx context.Context) error {
+	if err := c.pool.Ping(ctx); err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrDatabaseUnavailable, err)
+	}
+
+	// goose keeps every up and down run, the latest row of each version tells if it is applied now.
+	// Reading the table directly avoids goose creating it as a side effect of a probe
+	query := `
+		SELECT COALESCE(MAX(version_id), 0) FROM (
+			SELECT DISTINCT ON (version_id) version_id, is_applied
+			FROM goose_db_version
+			ORDER BY version_id, id DESC
+		) AS versions
+		WHERE is_applied
+	`
+
+	var currentVersion int64
+	if err := c.pool.QueryRow(ctx, query).Scan(&currentVersion); err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrDatabaseSchemaOutdated, err)
+	}
+
+	if currentVersion < c.latestVersion {
+		return domain.ErrDatabaseSchemaOutdated
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"embed"
+	"log/slog"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	_ "github.com/jackc/pgx/v5/stdlib"
@@ -13,9 +14,15 @@ import (
 //go:embed migrations/*.sql
 var embedMigrations embed.FS
 
-func InitPool(databaseDNS string) (*pgxpool.Pool, error) {
-	pool, err := pgxpool.New(context.Background(), databaseDNS)
+func InitPool(databaseDNS string, logger *slog.Logger) (*pgxpool.Pool, error) {
+	poolConfig, err := pgxpool.ParseConfig(databaseDNS)
+	if err != nil {
+		return nil, err
+	}
+
+	poolConfig.ConnConfig.Tracer = &queryTracer{logger: logger}
 
+	pool, err := pgxpool.NewWithConfig(context.Background(), poolConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -27,7 +34,7 @@ func InitPool(databaseDNS string) (*pgxpool.Pool, error) {
 	return pool, nil
 }
 
-func RunMigrations(databaseDNS string) error {
+func RunMigrations(databaseDNS string, logger *slog.Logger) error {
 	db, err := sql.Open("pgx", databaseDNS)
 
 	if err != nil {
@@ -35,6 +42,7 @@ func RunMigrations(databaseDNS string) error {
 	}
 
 	goose.SetBaseFS(embedMigrations)
+	goose.SetLogger(slog.NewLogLogger(logger.Handler(), slog.LevelInfo))
 
 	if err := goose.SetDialect("postgres"); err != nil {
 		return err
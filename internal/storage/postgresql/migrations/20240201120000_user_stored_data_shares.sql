@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+CREATE TABLE IF NOT EXISTS user_stored_data_shares (
+    id SERIAL PRIMARY KEY,
+    data_id INT NOT NULL REFERENCES user_stored_data (id) ON DELETE CASCADE,
+    owner_id INT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    recipient_id INT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    permission VARCHAR(16) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (data_id, recipient_id)
+);
+
+CREATE INDEX IF NOT EXISTS user_stored_data_shares_recipient_id_idx ON user_stored_data_shares (recipient_id);
+CREATE INDEX IF NOT EXISTS user_stored_data_shares_owner_id_idx ON user_stored_data_shares (owner_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS user_stored_data_shares;
+-- +goose StatementEnd
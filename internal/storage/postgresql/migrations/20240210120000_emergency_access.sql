@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+CREATE TABLE IF NOT EXISTS emergency_access (
+    id SERIAL PRIMARY KEY,
+    grantor_id INT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    grantee_id INT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    status VARCHAR(32) NOT NULL,
+    wait_days INT NOT NULL,
+    recovery_initiated_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (grantor_id, grantee_id)
+);
+
+CREATE INDEX IF NOT EXISTS emergency_access_grantee_id_idx ON emergency_access (grantee_id);
+CREATE INDEX IF NOT EXISTS emergency_access_status_idx ON emergency_access (status);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS emergency_access;
+-- +goose StatementEnd
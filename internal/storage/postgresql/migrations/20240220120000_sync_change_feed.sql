@@ -0,0 +1,74 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+CREATE SEQUENCE IF NOT EXISTS user_stored_data_change_seq AS BIGINT;
+
+ALTER TABLE user_stored_data
+    ADD COLUMN IF NOT EXISTS change_seq BIGINT NOT NULL DEFAULT nextval('user_stored_data_change_seq');
+
+CREATE INDEX IF NOT EXISTS user_stored_data_user_id_change_seq_idx ON user_stored_data (user_id, change_seq) WHERE collection_id IS NULL;
+CREATE INDEX IF NOT EXISTS user_stored_data_collection_id_change_seq_idx ON user_stored_data (collection_id, change_seq);
+
+CREATE TABLE IF NOT EXISTS user_stored_data_tombstones (
+    data_id INT PRIMARY KEY,
+    user_id INT NOT NULL,
+    collection_id INT NULL,
+    change_seq BIGINT NOT NULL DEFAULT nextval('user_stored_data_change_seq'),
+    deleted_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS user_stored_data_tombstones_user_id_change_seq_idx ON user_stored_data_tombstones (user_id, change_seq) WHERE collection_id IS NULL;
+CREATE INDEX IF NOT EXISTS user_stored_data_tombstones_collection_id_change_seq_idx ON user_stored_data_tombstones (collection_id, change_seq);
+
+-- Sequence numbers are taken under transaction lock of the feed (personal vault or collection), so writers
+-- of one feed commit in sequence order and reader never skips change which was not committed yet.
+CREATE OR REPLACE FUNCTION user_stored_data_lock_feed(feed_user_id INT, feed_collection_id INT) RETURNS VOID AS $$
+BEGIN
+    IF feed_collection_id IS NULL THEN
+        PERFORM pg_advisory_xact_lock(1, feed_user_id);
+    ELSE
+        PERFORM pg_advisory_xact_lock(2, feed_collection_id);
+    END IF;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION user_stored_data_track_change() RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM user_stored_data_lock_feed(NEW.user_id, NEW.collection_id);
+    NEW.change_seq := nextval('user_stored_data_change_seq');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER user_stored_data_track_change
+BEFORE INSERT OR UPDATE ON user_stored_data
+FOR EACH ROW EXECUTE FUNCTION user_stored_data_track_change();
+
+CREATE OR REPLACE FUNCTION user_stored_data_track_delete() RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM user_stored_data_lock_feed(OLD.user_id, OLD.collection_id);
+    INSERT INTO user_stored_data_tombstones (data_id, user_id, collection_id)
+    VALUES (OLD.id, OLD.user_id, OLD.collection_id);
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER user_stored_data_track_delete
+AFTER DELETE ON user_stored_data
+FOR EACH ROW EXECUTE FUNCTION user_stored_data_track_delete();
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TRIGGER IF EXISTS user_stored_data_track_delete ON user_stored_data;
+DROP TRIGGER IF EXISTS user_stored_data_track_change ON user_stored_data;
+DROP FUNCTION IF EXISTS user_stored_data_track_delete;
+DROP FUNCTION IF EXISTS user_stored_data_track_change;
+DROP FUNCTION IF EXISTS user_stored_data_lock_feed;
+DROP TABLE IF EXISTS user_stored_data_tombstones;
+ALTER TABLE user_stored_data DROP COLUMN IF EXISTS change_seq;
+DROP SEQUENCE IF EXISTS user_stored_data_change_seq;
+-- +goose StatementEnd
@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+ALTER TABLE user_stored_data ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP NOT NULL DEFAULT NOW();
+
+-- backfill must not move records in change feed, otherwise every client downloads whole vault again
+ALTER TABLE user_stored_data DISABLE TRIGGER user_stored_data_track_change;
+UPDATE user_stored_data SET updated_at = created_at;
+ALTER TABLE user_stored_data ENABLE TRIGGER user_stored_data_track_change;
+
+CREATE OR REPLACE FUNCTION user_stored_data_track_change() RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM user_stored_data_lock_feed(NEW.user_id, NEW.collection_id);
+    NEW.change_seq := nextval('user_stored_data_change_seq');
+    NEW.updated_at := NOW();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+
+CREATE OR REPLACE FUNCTION user_stored_data_track_change() RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM user_stored_data_lock_feed(NEW.user_id, NEW.collection_id);
+    NEW.change_seq := nextval('user_stored_data_change_seq');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE user_stored_data DROP COLUMN IF EXISTS updated_at;
+-- +goose StatementEnd
@@ -0,0 +1,49 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+ALTER TABLE user_stored_data ADD COLUMN IF NOT EXISTS uuid UUID NULL;
+ALTER TABLE user_stored_data_tombstones ADD COLUMN IF NOT EXISTS data_uuid UUID NULL;
+
+-- existing records get uuid derived from id, clients derive the same uuid for records they have already synced.
+-- Backfill must not move records in change feed, otherwise every client downloads whole vault again
+ALTER TABLE user_stored_data DISABLE TRIGGER user_stored_data_track_change;
+UPDATE user_stored_data SET uuid = md5('user_stored_data:' || id)::uuid WHERE uuid IS NULL;
+ALTER TABLE user_stored_data ENABLE TRIGGER user_stored_data_track_change;
+
+UPDATE user_stored_data_tombstones SET data_uuid = md5('user_stored_data:' || data_id)::uuid WHERE data_uuid IS NULL;
+
+ALTER TABLE user_stored_data ALTER COLUMN uuid SET DEFAULT gen_random_uuid();
+ALTER TABLE user_stored_data ALTER COLUMN uuid SET NOT NULL;
+ALTER TABLE user_stored_data_tombstones ALTER COLUMN data_uuid SET NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS user_stored_data_uuid_idx ON user_stored_data (uuid);
+
+CREATE OR REPLACE FUNCTION user_stored_data_track_delete() RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM user_stored_data_lock_feed(OLD.user_id, OLD.collection_id);
+    INSERT INTO user_stored_data_tombstones (data_id, data_uuid, user_id, collection_id)
+    VALUES (OLD.id, OLD.uuid, OLD.user_id, OLD.collection_id);
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+
+CREATE OR REPLACE FUNCTION user_stored_data_track_delete() RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM user_stored_data_lock_feed(OLD.user_id, OLD.collection_id);
+    INSERT INTO user_stored_data_tombstones (data_id, user_id, collection_id)
+    VALUES (OLD.id, OLD.user_id, OLD.collection_id);
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP INDEX IF EXISTS user_stored_data_uuid_idx;
+ALTER TABLE user_stored_data_tombstones DROP COLUMN IF EXISTS data_uuid;
+ALTER TABLE user_stored_data DROP COLUMN IF EXISTS uuid;
+-- +goose StatementEnd
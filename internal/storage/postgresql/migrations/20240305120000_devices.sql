@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+CREATE TABLE IF NOT EXISTS devices (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL,
+    name VARCHAR(64) NOT NULL,
+    platform VARCHAR(32) NOT NULL DEFAULT '',
+    sync_cursor BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_seen_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS devices_user_id_idx ON devices (user_id);
+
+-- highest sequence of personal vault tombstones already collected, device which cursor is behind it
+-- may have missed deletions and must download whole vault again
+ALTER TABLE users ADD COLUMN IF NOT EXISTS tombstones_collected_seq BIGINT NOT NULL DEFAULT 0;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+ALTER TABLE users DROP COLUMN IF EXISTS tombstones_collected_seq;
+DROP TABLE IF EXISTS devices;
+-- +goose StatementEnd
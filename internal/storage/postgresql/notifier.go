@@ -0,0 +1,101 @@
+package postgresql
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+)
+
+const (
+	changesChannel = "user_stored_data_changes"
+
+	notifierReconnectDelay = 5 * time.Second
+)
+
+type localChangePublisher interface {
+	Publish(ctx context.Context, event domain.DataChangeEvent)
+}
+
+// ChangeNotifier - fan-out of record changes between server instances via LISTEN/NOTIFY. Event is sent to
+// Postgres only, every instance including the publishing one receives it back and passes it to local broker
+type ChangeNotifier struct {
+	pool   *pgxpool.Pool
+	local  localChangePublisher
+	logger *slog.Logger
+}
+
+func NewChangeNotifier(pool *pgxpool.Pool, local localChangePublisher, logger *slog.Logger) *ChangeNotifier {
+	return &ChangeNotifier{
+		pool:   pool,
+		local:  local,
+		logger: logger.With("worker", "change_notifier"),
+	}
+}
+
+// Publish - notify all server instances. Notification is best effort, client which missed it still gets
+// changes on next sync, so failure is only logged and clients of this instance are notified directly
+func (n *ChangeNotifier) Publish(ctx context.Context, event domain.DataChangeEvent) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		n.logger.ErrorContext(ctx, "failed to encode change event", "error", err.Error())
+		return
+	}
+
+	if _, err := n.pool.Exec(ctx, "SELECT pg_notify($1, $2)", changesChannel, string(payload)); err != nil {
+		n.logger.ErrorContext(ctx, "failed to notify about change", "error", err.Error())
+		n.local.Publish(ctx, event)
+	}
+}
+
+// Run - listen notifications until ctx is done, reconnecting when connection is lost
+func (n *ChangeNotifier) Run(ctx context.Context) {
+	for {
+		err := n.listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		n.logger.ErrorContext(ctx, "stopped listening changes, reconnecting", "error", err.Error())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(notifierReconnectDelay):
+		}
+	}
+}
+
+func (n *ChangeNotifier) listen(ctx context.Context) error {
+	pooled, err := n.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+
+	// listening connection must not return to pool, otherwise other queries would receive notifications
+	conn := pooled.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+changesChannel); err != nil {
+		return err
+	}
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		var event domain.DataChangeEvent
+		if err := json.Unmarshal([]byte(notification.Payload), &event); err != nil {
+			n.logger.ErrorContext(ctx, "failed to decode change event", "error", err.Error())
+			continue
+		}
+
+		n.local.Publish(ctx, event)
+	}
+}
@@ -0,0 +1,68 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/MowlCoder/goph-keeper/internal/tracing"
+)
+
+type querySQLKey struct{}
+
+// queryTracer - log failed queries with request id from context and record span for every query made
+// inside traced request. Only SQL text is logged and traced, arguments are never recorded because
+// they contain user data and password hashes.
+type queryTracer struct {
+	logger *slog.Logger
+}
+
+func (t *queryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	ctx = context.WithValue(ctx, querySQLKey{}, data.SQL)
+
+	// queries of background jobs and metrics scrapes are not part of any request, they would only produce
+	// lots of single span traces
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx
+	}
+
+	ctx, _ = tracing.Start(
+		ctx,
+		"postgresql.query",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBStatement(data.SQL),
+		),
+	)
+
+	return ctx
+}
+
+func (t *queryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	span := trace.SpanFromContext(ctx)
+	defer span.End()
+
+	if data.Err == nil || errors.Is(data.Err, pgx.ErrNoRows) {
+		return
+	}
+
+	span.RecordError(data.Err)
+	span.SetStatus(codes.Error, data.Err.Error())
+
+	sql, _ := ctx.Value(querySQLKey{}).(string)
+	level := slog.LevelError
+
+	var pgErr *pgconn.PgError
+	if errors.Is(data.Err, context.Canceled) || (errors.As(data.Err, &pgErr) && pgErr.Code == PgUniqueIndexErrorCode) {
+		level = slog.LevelDebug
+	}
+
+	t.logger.Log(ctx, level, "query failed", "sql", sql, "error", data.Err.Error())
+}
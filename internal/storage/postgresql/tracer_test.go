@@ -0,0 +1,63 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/MowlCoder/goph-keeper/internal/tracing"
+	"github.com/MowlCoder/goph-keeper/internal/tracing/tracingtest"
+)
+
+func TestQueryTracer(t *testing.T) {
+	tracer := &queryTracer{logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	sql := "SELECT id FROM users WHERE email = $1"
+
+	t.Run("query span is child of request span", func(t *testing.T) {
+		exporter := tracingtest.Setup(t)
+
+		ctx, parent := tracing.Start(context.Background(), "UserRepository.GetByEmail")
+		queryCtx := tracer.TraceQueryStart(ctx, nil, pgx.TraceQueryStartData{SQL: sql, Args: []any{"test@gmail.com"}})
+		tracer.TraceQueryEnd(queryCtx, nil, pgx.TraceQueryEndData{Err: pgx.ErrNoRows})
+		parent.End()
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 2)
+		assert.Equal(t, "postgresql.query", spans[0].Name)
+		assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent.SpanID())
+		assert.Equal(t, codes.Unset, spans[0].Status.Code)
+
+		for _, attr := range spans[0].Attributes {
+			assert.NotContains(t, attr.Value.Emit(), "test@gmail.com")
+		}
+	})
+
+	t.Run("failed query", func(t *testing.T) {
+		exporter := tracingtest.Setup(t)
+
+		ctx, parent := tracing.Start(context.Background(), "UserRepository.GetByEmail")
+		queryCtx := tracer.TraceQueryStart(ctx, nil, pgx.TraceQueryStartData{SQL: sql})
+		tracer.TraceQueryEnd(queryCtx, nil, pgx.TraceQueryEndData{Err: errors.New("connection reset")})
+		parent.End()
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 2)
+		assert.Equal(t, codes.Error, spans[0].Status.Code)
+	})
+
+	t.Run("query without request span", func(t *testing.T) {
+		exporter := tracingtest.Setup(t)
+
+		queryCtx := tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: sql})
+		tracer.TraceQueryEnd(queryCtx, nil, pgx.TraceQueryEndData{})
+
+		assert.Empty(t, exporter.GetSpans())
+	})
+}
@@ -0,0 +1,89 @@
+package cryptor
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+)
+
+// sealVersion - first byte of sealed data, changes when format or key derivation changes
+const sealVersion byte = 1
+
+// Sealer - authenticated encryption of local files under vault key. Sealed data is version, nonce and
+// ciphertext, version and nonce form header, which is authenticated together with ciphertext
+type Sealer struct {
+	aead     cipher.AEAD
+	blindKey []byte
+}
+
+// NewSealer - constructor for Sealer structure, encryption and blinding keys are derived from vault key
+func NewSealer(vaultKey string) (*Sealer, error) {
+	keys := hkdf.New(sha256.New, []byte(vaultKey), nil, []byte("goph-keeper local vault"))
+
+	sealKey := make([]byte, 32)
+	blindKey := make([]byte, 32)
+	if _, err := io.ReadFull(keys, sealKey); err != nil {
+		return nil, err
+	}
+	if _, err := io.ReadFull(keys, blindKey); err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(sealKey)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Sealer{
+		aead:     aead,
+		blindKey: blindKey,
+	}, nil
+}
+
+// Seal - encrypt plaintext, additional data is not stored but must be the same when data is opened
+func (s *Sealer) Seal(plaintext []byte, additionalData []byte) ([]byte, error) {
+	header := make([]byte, 1+s.aead.NonceSize())
+	header[0] = sealVersion
+	if _, err := io.ReadFull(rand.Reader, header[1:]); err != nil {
+		return nil, err
+	}
+
+	return s.aead.Seal(header, header[1:], plaintext, append(header[:len(header):len(header)], additionalData...)), nil
+}
+
+// Open - decrypt sealed data, fails if data or its header was changed or it was sealed with other key
+func (s *Sealer) Open(sealed []byte, additionalData []byte) ([]byte, error) {
+	headerSize := 1 + s.aead.NonceSize()
+	if len(sealed) < headerSize+s.aead.Overhead() || sealed[0] != sealVersion {
+		return nil, domain.ErrLocalDataCorrupted
+	}
+
+	header := sealed[:headerSize]
+	plaintext, err := s.aead.Open(nil, header[1:], sealed[headerSize:], append(header[:headerSize:headerSize], additionalData...))
+	if err != nil {
+		return nil, domain.ErrLocalDataCorrupted
+	}
+
+	return plaintext, nil
+}
+
+// Blind - deterministic keyed hash of value, used instead of value where it must be looked up, e.g. as
+// database key, so value itself is not stored
+func (s *Sealer) Blind(value []byte) []byte {
+	mac := hmac.New(sha256.New, s.blindKey)
+	mac.Write(value)
+
+	return mac.Sum(nil)
+}
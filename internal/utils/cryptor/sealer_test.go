@@ -0,0 +1,39 @@
+package cryptor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MowlCoder/goph-keeper/internal/domain"
+)
+
+func TestSealer(t *testing.T) {
+	sealer, err := NewSealer("vault-key")
+	require.NoError(t, err)
+
+	sealed, err := sealer.Seal([]byte("secret"), []byte("session"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(sealed), "secret")
+
+	opened, err := sealer.Open(sealed, []byte("session"))
+	require.NoError(t, err)
+	assert.Equal(t, "secret", string(opened))
+
+	_, err = sealer.Open(sealed, []byte("other"))
+	assert.ErrorIs(t, err, domain.ErrLocalDataCorrupted)
+
+	tampered := append([]byte{}, sealed...)
+	tampered[1] ^= 1
+	_, err = sealer.Open(tampered, []byte("session"))
+	assert.ErrorIs(t, err, domain.ErrLocalDataCorrupted)
+
+	otherSealer, err := NewSealer("other-key")
+	require.NoError(t, err)
+	_, err = otherSealer.Open(sealed, []byte("session"))
+	assert.ErrorIs(t, err, domain.ErrLocalDataCorrupted)
+
+	assert.Equal(t, sealer.Blind([]byte("uuid")), sealer.Blind([]byte("uuid")))
+	assert.NotEqual(t, sealer.Blind([]byte("uuid")), otherSealer.Blind([]byte("uuid")))
+}
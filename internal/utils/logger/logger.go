@@ -0,0 +1,95 @@
+// Package logger - structured logger of server. Error returned to handler is logged once, by access logger
+// with request id, so it is not logged again on its way up. Logger is given only to components, which
+// handle errors that never reach response: background workers, rollback of transactions, audit writes,
+// records failing to decrypt, readiness checks and event streams.
+package logger
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/MowlCoder/goph-keeper/internal/utils/requestid"
+)
+
+// RedactedValue - replacement for values of sensitive attributes
+const RedactedValue = "[REDACTED]"
+
+// sensitiveKeyParts - attribute is redacted if its key contains one of these parts
+var sensitiveKeyParts = []string{
+	"password",
+	"token",
+	"secret",
+	"authorization",
+	"cookie",
+	"cvv",
+	"dsn",
+}
+
+// New - create JSON logger with given level (debug, info, warn, error). Request id and trace id from context are added
+// to every record written with *Context methods, values of sensitive attributes are redacted.
+func New(w io.Writer, level string) *slog.Logger {
+	handler := slog.NewJSONHandler(w, &slog.HandlerOptions{
+		Level:       ParseLevel(level),
+		ReplaceAttr: redact,
+	})
+
+	return slog.New(&contextHandler{Handler: handler})
+}
+
+// ParseLevel - parse level name, info is used for unknown names
+func ParseLevel(level string) slog.Level {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(level)); err != nil {
+		return slog.LevelInfo
+	}
+
+	return l
+}
+
+// IsSensitiveKey - check if attribute with given key must not be logged as is
+func IsSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+
+	for _, part := range sensitiveKeyParts {
+		if strings.Contains(key, part) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func redact(_ []string, attr slog.Attr) slog.Attr {
+	if attr.Value.Kind() != slog.KindGroup && IsSensitiveKey(attr.Key) {
+		return slog.String(attr.Key, RedactedValue)
+	}
+
+	return attr
+}
+
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h *contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if id, err := requestid.GetRequestIDFromContext(ctx); err == nil {
+		record.AddAttrs(slog.String("request_id", id))
+	}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.HasTraceID() {
+		record.AddAttrs(slog.String("trace_id", spanContext.TraceID().String()))
+	}
+
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
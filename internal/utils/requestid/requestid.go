@@ -0,0 +1,39 @@
+package requestid
+
+import (
+	"context"
+	"errors"
+)
+
+type contextKey string
+
+// RequestIDKey represent key in context to store request id. Need for avoiding magic string.
+const RequestIDKey = contextKey("request_id")
+
+// Possible errors when working with package.
+var (
+	ErrRequestIDKeyNotFound = errors.New("request id key not found in context")
+	ErrRequestIDInvalidType = errors.New("request id found, but with invalid type")
+)
+
+// SetRequestIDToContext save request id in given context.
+func SetRequestIDToContext(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, RequestIDKey, requestID)
+}
+
+// GetRequestIDFromContext try to get request id from given context. If context not found or wrong value found return error.
+func GetRequestIDFromContext(ctx context.Context) (string, error) {
+	val := ctx.Value(RequestIDKey)
+
+	if val == nil {
+		return "", ErrRequestIDKeyNotFound
+	}
+
+	id, ok := val.(string)
+
+	if !ok {
+		return "", ErrRequestIDInvalidType
+	}
+
+	return id, nil
+}
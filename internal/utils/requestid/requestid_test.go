@@ -0,0 +1,51 @@
+package requestid
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRequestIDFromContext(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		ctx := context.Background()
+		ctx = context.WithValue(ctx, RequestIDKey, "abc")
+
+		requestID, err := GetRequestIDFromContext(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "abc", requestID)
+	})
+
+	t.Run("not found key", func(t *testing.T) {
+		ctx := context.Background()
+		_, err := GetRequestIDFromContext(ctx)
+
+		assert.ErrorIs(t, err, ErrRequestIDKeyNotFound)
+	})
+
+	t.Run("found invalid key", func(t *testing.T) {
+		ctx := context.Background()
+		ctx = context.WithValue(ctx, RequestIDKey, 30)
+
+		_, err := GetRequestIDFromContext(ctx)
+
+		assert.ErrorIs(t, err, ErrRequestIDInvalidType)
+	})
+}
+
+func TestSetRequestIDToContext(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		ctx := context.Background()
+		ctx = SetRequestIDToContext(ctx, "abc")
+		val := ctx.Value(RequestIDKey)
+
+		require.NotNil(t, val)
+		id, ok := val.(string)
+
+		require.True(t, ok)
+		assert.Equal(t, "abc", id)
+	})
+}
@@ -18,10 +18,11 @@ func NewGenerator() *Generator {
 	return &Generator{}
 }
 
-// Generate - generate JWT token from domain.User struct
-func (g *Generator) Generate(ctx context.Context, user domain.User) (string, error) {
+// Generate - generate JWT token from domain.User struct bound to device user logged in from
+func (g *Generator) Generate(ctx context.Context, user domain.User, deviceID int) (string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, domain.TokenClaim{
-		ID: user.ID,
+		ID:       user.ID,
+		DeviceID: deviceID,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().UTC().Add(time.Hour * 24)),
 		},
@@ -26,7 +26,7 @@ func TestGenerator_Generate(t *testing.T) {
 		generator := NewGenerator()
 
 		t.Run(testCase.name, func(t *testing.T) {
-			token, err := generator.Generate(context.Background(), testCase.user)
+			token, err := generator.Generate(context.Background(), testCase.user, 1)
 			assert.Equal(t, testCase.err, err)
 			if err == nil {
 				assert.NotEqual(t, 0, len(token))
@@ -37,7 +37,7 @@ func TestParser_Parse(t *testing.T) {
 			var err error
 
 			if testCase.user != nil {
-				token, err = generator.Generate(context.Background(), *testCase.user)
+				token, err = generator.Generate(context.Background(), *testCase.user, 2)
 				require.NoError(t, err)
 			}
 
@@ -46,6 +46,7 @@ func TestParser_Parse(t *testing.T) {
 				assert.Error(t, err)
 			} else {
 				assert.Equal(t, testCase.user.ID, tokenClaim.ID)
+				assert.Equal(t, 2, tokenClaim.DeviceID)
 			}
 		})
 	}
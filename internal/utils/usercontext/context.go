@@ -10,10 +10,15 @@ type contextKey string
 // UserIDKey represent key in context to store user id. Need for avoiding magic string.
 const UserIDKey = contextKey("user_id")
 
+// DeviceIDKey represent key in context to store id of device token was issued for.
+const DeviceIDKey = contextKey("device_id")
+
 // Possible errors when working with package.
 var (
-	ErrUserIDKeyNotFound = errors.New("user id key not found in context")
-	ErrUserIDInvalidType = errors.New("user id found, but with invalid type")
+	ErrUserIDKeyNotFound   = errors.New("user id key not found in context")
+	ErrUserIDInvalidType   = errors.New("user id found, but with invalid type")
+	ErrDeviceIDKeyNotFound = errors.New("device id key not found in context")
+	ErrDeviceIDInvalidType = errors.New("device id found, but with invalid type")
 )
 
 // SetUserIDToContext save user id in given context.
@@ -37,3 +42,25 @@ func GetUserIDFromContext(ctx context.Context) (int, error) {
 
 	return id, nil
 }
+
+// SetDeviceIDToContext save device id in given context.
+func SetDeviceIDToContext(ctx context.Context, deviceID int) context.Context {
+	return context.WithValue(ctx, DeviceIDKey, deviceID)
+}
+
+// GetDeviceIDFromContext try to get device id from given context. If context not found or wrong value found return error.
+func GetDeviceIDFromContext(ctx context.Context) (int, error) {
+	val := ctx.Value(DeviceIDKey)
+
+	if val == nil {
+		return -1, ErrDeviceIDKeyNotFound
+	}
+
+	id, ok := val.(int)
+
+	if !ok {
+		return -1, ErrDeviceIDInvalidType
+	}
+
+	return id, nil
+}
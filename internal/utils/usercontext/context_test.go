@@ -49,3 +49,28 @@ func TestSetUserIDToContext(t *testing.T) {
 		assert.Equal(t, 30, id)
 	})
 }
+
+func TestDeviceIDContext(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		ctx := SetDeviceIDToContext(context.Background(), 7)
+
+		deviceID, err := GetDeviceIDFromContext(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 7, deviceID)
+	})
+
+	t.Run("not found key", func(t *testing.T) {
+		_, err := GetDeviceIDFromContext(context.Background())
+
+		assert.ErrorIs(t, err, ErrDeviceIDKeyNotFound)
+	})
+
+	t.Run("found invalid key", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), DeviceIDKey, "7")
+
+		_, err := GetDeviceIDFromContext(ctx)
+
+		assert.ErrorIs(t, err, ErrDeviceIDInvalidType)
+	})
+}
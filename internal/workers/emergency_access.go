@@ -0,0 +1,57 @@
+package workers
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type emergencyAccessApprover interface {
+	ApproveExpired(ctx context.Context) (int64, error)
+}
+
+// EmergencyAccessWorker - periodically approves emergency recoveries which waiting period is over
+type EmergencyAccessWorker struct {
+	approver emergencyAccessApprover
+	interval time.Duration
+	logger   *slog.Logger
+}
+
+// NewEmergencyAccessWorker - constructor for EmergencyAccessWorker struct
+func NewEmergencyAccessWorker(approver emergencyAccessApprover, interval time.Duration, logger *slog.Logger) *EmergencyAccessWorker {
+	return &EmergencyAccessWorker{
+		approver: approver,
+		interval: interval,
+		logger:   logger.With("worker", "emergency_access"),
+	}
+}
+
+// Run - start worker, blocks until ctx is done
+func (w *EmergencyAccessWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.approve(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *EmergencyAccessWorker) approve(ctx context.Context) {
+	approved, err := w.approver.ApproveExpired(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			w.logger.ErrorContext(ctx, "failed to approve expired recoveries", "error", err.Error())
+		}
+		return
+	}
+
+	if approved > 0 {
+		w.logger.InfoContext(ctx, "approved expired recoveries", "count", approved)
+	}
+}
@@ -0,0 +1,57 @@
+package workers
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type tombstoneCollector interface {
+	CollectTombstones(ctx context.Context) (int64, error)
+}
+
+// TombstoneWorker - periodically removes deleted records which deletion is acknowledged by every device of owner
+type TombstoneWorker struct {
+	collector tombstoneCollector
+	interval  time.Duration
+	logger    *slog.Logger
+}
+
+// NewTombstoneWorker - constructor for TombstoneWorker struct
+func NewTombstoneWorker(collector tombstoneCollector, interval time.Duration, logger *slog.Logger) *TombstoneWorker {
+	return &TombstoneWorker{
+		collector: collector,
+		interval:  interval,
+		logger:    logger.With("worker", "tombstones"),
+	}
+}
+
+// Run - start worker, blocks until ctx is done
+func (w *TombstoneWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.collect(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *TombstoneWorker) collect(ctx context.Context) {
+	collected, err := w.collector.CollectTombstones(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			w.logger.ErrorContext(ctx, "failed to collect tombstones", "error", err.Error())
+		}
+		return
+	}
+
+	if collected > 0 {
+		w.logger.InfoContext(ctx, "collected tombstones", "count", collected)
+	}
+}
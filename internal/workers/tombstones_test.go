@@ -0,0 +1,46 @@
+package workers
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type collectorStub struct {
+	calls atomic.Int32
+}
+
+func (c *collectorStub) CollectTombstones(ctx context.Context) (int64, error) {
+	c.calls.Add(1)
+	return 3, nil
+}
+
+func TestTombstoneWorker_Run(t *testing.T) {
+	collector := &collectorStub{}
+	worker := NewTombstoneWorker(collector, 10*time.Millisecond, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		worker.Run(ctx)
+		close(done)
+	}()
+
+	assert.Eventually(t, func() bool {
+		return collector.calls.Load() >= 2
+	}, time.Second, 5*time.Millisecond)
+
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("worker did not stop after context cancel")
+	}
+}
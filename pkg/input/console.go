@@ -2,31 +2,92 @@ package input
 
 import (
 	"bufio"
-	"fmt"
+	"errors"
+	"io"
 	"os"
 	"strings"
 )
 
-var consoleReader *bufio.Reader
+// StdinValue - flag with this value is read from standard input, so secrets are not passed in arguments
+const StdinValue = "-"
+
+var (
+	consoleReader *bufio.Reader
+
+	// interactive - false when client executes single command given in arguments, then user is not
+	// prompted and values are read from standard input as is
+	interactive = true
+	// flagValues - flags of executed command, they are used instead of asking user
+	flagValues map[string]string
+)
 
 func init() {
 	consoleReader = bufio.NewReader(os.Stdin)
 }
 
-// GetConsoleInput - display given placeholder and wait for input from user from standard input
-func GetConsoleInput(placeholder string, defaultValue string) string {
-	fmt.Print(placeholder)
+// SetInteractive - switch prompting of user on and off
+func SetInteractive(value bool) {
+	interactive = value
+}
+
+// IsInteractive - whether user is prompted for input
+func IsInteractive() bool {
+	return interactive
+}
+
+// SetFlags - remember flags of command, which is executed now, nil when command is finished
+func SetFlags(flags map[string]string) {
+	flagValues = flags
+}
+
+// GetFlag - value of flag of executed command as it is given
+func GetFlag(name string) (string, bool) {
+	value, ok := flagValues[name]
+	return value, ok
+}
+
+// GetInput - value of flag with given name, if command is executed without it, value is got from user
+// like in GetConsoleInput. Flag with value "-" is read from standard input, in interactive mode standard
+// input is terminal, so value is asked like without flag
+func GetInput(name string, placeholder string, defaultValue string) (string, error) {
+	value, ok := GetFlag(name)
+	if !ok || (value == StdinValue && lineEditor != nil) {
+		return GetConsoleInput(placeholder, defaultValue)
+	}
+
+	if value == StdinValue {
+		content, err := io.ReadAll(consoleReader)
+		if err != nil {
+			return "", err
+		}
+
+		value = strings.TrimRight(string(content), "\n\r")
+	}
+
+	if value == "" {
+		return defaultValue, nil
+	}
 
-	text, err := consoleReader.ReadString('\n')
-	if err != nil {
-		return defaultValue
+	return value, nil
+}
+
+// GetConsoleInput - display given placeholder and wait for input from user from standard input. In
+// non-interactive mode placeholder is not displayed, default value is returned when input is over.
+// When line editor is open, value is read by it and Ctrl-C returns ErrInputCanceled
+func GetConsoleInput(placeholder string, defaultValue string) (string, error) {
+	text, err := readLine(placeholder)
+	if errors.Is(err, ErrInputCanceled) {
+		return "", err
+	}
+	if err != nil && (text == "" || err != io.EOF) {
+		return defaultValue, nil
 	}
 
 	text = strings.Trim(text, "\n\r")
 
 	if text == "" {
-		return defaultValue
+		return defaultValue, nil
 	}
 
-	return text
+	return text, nil
 }
@@ -0,0 +1,161 @@
+package input
+
+import (
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/chzyer/readline"
+	"github.com/google/shlex"
+)
+
+// ErrInputCanceled - user pressed Ctrl-C while value was asked, command asking for it is canceled
+var ErrInputCanceled = errors.New("input canceled")
+
+// lineEditor - editor reading from terminal in interactive mode, values are read from it instead of
+// standard input, because editor reads standard input all the time it is open
+var lineEditor *Editor
+
+// Editor - line editor of command line with history, completion and editing by arrow keys
+type Editor struct {
+	instance *readline.Instance
+	isSecret func(words []string) bool
+}
+
+// NewEditor - constructor for Editor structure. History is kept in file by given path, command lines, for
+// which isSecret returns true, are not put in it. Complete returns candidates for the last of given words,
+// it is empty when cursor is after space
+func NewEditor(
+	historyPath string,
+	complete func(words []string) []string,
+	isSecret func(words []string) bool,
+) (*Editor, error) {
+	return newEditor(editorConfig(historyPath, complete), isSecret)
+}
+
+func editorConfig(historyPath string, complete func(words []string) []string) *readline.Config {
+	return &readline.Config{
+		HistoryFile: historyPath,
+		// values asked by commands are never put in history, command lines are saved one by one
+		DisableAutoSaveHistory: true,
+		HistorySearchFold:      true,
+		AutoComplete:           completer(complete),
+	}
+}
+
+func newEditor(config *readline.Config, isSecret func(words []string) bool) (*Editor, error) {
+	// editor creates history file with default permissions and rewrites it when it is too long on start,
+	// so file is created private beforehand and its permissions are restored after start
+	historyFile, err := os.OpenFile(config.HistoryFile, os.O_CREATE|os.O_RDONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+	historyFile.Close()
+
+	instance, err := readline.NewEx(config)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.Chmod(config.HistoryFile, 0600); err != nil {
+		instance.Close()
+		return nil, err
+	}
+
+	editor := &Editor{
+		instance: instance,
+		isSecret: isSecret,
+	}
+	lineEditor = editor
+
+	return editor, nil
+}
+
+// ReadCommand - read command line and split it to words like shell does. Ctrl-C returns ErrInputCanceled
+// and Ctrl-D on empty line returns io.EOF
+func (e *Editor) ReadCommand(prompt string) ([]string, error) {
+	e.instance.SetPrompt(prompt)
+
+	line, err := e.instance.Readline()
+	if errors.Is(err, readline.ErrInterrupt) {
+		return nil, ErrInputCanceled
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	words, err := shlex.Split(line)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(words) > 0 && !e.isSecret(words) {
+		if err := e.instance.SaveHistory(line); err != nil {
+			return nil, err
+		}
+	}
+
+	return words, nil
+}
+
+// Close - restore terminal, after it values are read from standard input again
+func (e *Editor) Close() error {
+	if lineEditor == e {
+		lineEditor = nil
+	}
+
+	return e.instance.Close()
+}
+
+// readValue - read value asked by command, it is never put in history
+func (e *Editor) readValue(prompt string) (string, error) {
+	e.instance.SetPrompt(prompt)
+
+	value, err := e.instance.Readline()
+	if errors.Is(err, readline.ErrInterrupt) {
+		return "", ErrInputCanceled
+	}
+
+	return value, err
+}
+
+// readLine - read value asked by command from editor if it is open, otherwise from standard input
+func readLine(prompt string) (string, error) {
+	if lineEditor == nil {
+		if interactive {
+			os.Stdout.WriteString(prompt)
+		}
+
+		return consoleReader.ReadString('\n')
+	}
+
+	value, err := lineEditor.readValue(prompt)
+	if err == nil {
+		// editor returns line without line break, it is added to be the same as line of standard input
+		value += "\n"
+	}
+
+	return value, err
+}
+
+// completer - adapter of complete function to completion of editor
+type completer func(words []string) []string
+
+func (c completer) Do(line []rune, pos int) ([][]rune, int) {
+	beforeCursor := string(line[:pos])
+
+	words := strings.Fields(beforeCursor)
+	if len(words) == 0 || strings.HasSuffix(beforeCursor, " ") {
+		words = append(words, "")
+	}
+	prefix := words[len(words)-1]
+
+	candidates := make([][]rune, 0)
+	for _, candidate := range c(words) {
+		if strings.HasPrefix(candidate, prefix) {
+			candidates = append(candidates, []rune(candidate[len(prefix):]+" "))
+		}
+	}
+
+	return candidates, len([]rune(prefix))
+}
@@ -0,0 +1,161 @@
+package input
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testEditor - editor reading given text instead of terminal
+func testEditor(t *testing.T, historyPath string, text string) *Editor {
+	t.Helper()
+
+	config := editorConfig(historyPath, func(words []string) []string { return nil })
+	config.Stdin = io.NopCloser(strings.NewReader(text))
+	config.Stdout = io.Discard
+	config.Stderr = io.Discard
+	config.FuncIsTerminal = func() bool { return false }
+
+	editor, err := newEditor(config, func(words []string) bool {
+		return slices.Contains(words, "--password")
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { editor.Close() })
+
+	return editor
+}
+
+func TestEditor_ReadCommand(t *testing.T) {
+	historyPath := filepath.Join(t.TempDir(), "history")
+	editor := testEditor(t, historyPath, strings.Join([]string{
+		`lp save --login "john doe" --source 'my  bank'`,
+		`login --email user@mail.com --password s3cret`,
+		`lp-save`,
+		`value-asked-by-command`,
+		`lp get "unterminated`,
+		``,
+	}, "\n"))
+
+	words, err := editor.ReadCommand("> ")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"lp", "save", "--login", "john doe", "--source", "my  bank"}, words)
+
+	words, err = editor.ReadCommand("> ")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"login", "--email", "user@mail.com", "--password", "s3cret"}, words)
+
+	words, err = editor.ReadCommand("> ")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"lp-save"}, words)
+
+	value, err := GetConsoleInput("Enter password: ", "")
+	require.NoError(t, err)
+	assert.Equal(t, "value-asked-by-command", value)
+
+	_, err = editor.ReadCommand("> ")
+	assert.Error(t, err)
+
+	require.NoError(t, editor.Close())
+
+	// only valid command lines without secrets are in history, values asked by commands never are
+	history, err := os.ReadFile(historyPath)
+	require.NoError(t, err)
+	assert.Equal(t, "lp save --login \"john doe\" --source 'my  bank'\nlp-save\n", string(history))
+
+	info, err := os.Stat(historyPath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+func TestEditor_LongHistoryStaysPrivate(t *testing.T) {
+	historyPath := filepath.Join(t.TempDir(), "history")
+	require.NoError(t, os.WriteFile(historyPath, []byte(strings.Repeat("lp get\n", 600)), 0600))
+
+	// history longer than limit is rewritten by editor on start
+	editor := testEditor(t, historyPath, "lp get\n")
+
+	// line is read, so editor has started before it is closed
+	_, err := editor.ReadCommand("> ")
+	require.NoError(t, err)
+
+	info, err := os.Stat(historyPath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+func TestEditor_Cancel(t *testing.T) {
+	editor := testEditor(t, filepath.Join(t.TempDir(), "history"), "half typed\x03lp-save\n\x03")
+
+	_, err := editor.ReadCommand("> ")
+	assert.ErrorIs(t, err, ErrInputCanceled)
+
+	words, err := editor.ReadCommand("> ")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"lp-save"}, words)
+
+	_, err = GetInput("password", "Enter password: ", "")
+	assert.ErrorIs(t, err, ErrInputCanceled)
+}
+
+func TestCompleter_Do(t *testing.T) {
+	complete := completer(func(words []string) []string {
+		if len(words) == 1 {
+			return []string{"lp-get", "lp-save", "login"}
+		}
+
+		return []string{"--id", "--field"}
+	})
+
+	testCases := []struct {
+		name           string
+		line           string
+		expected       []string
+		expectedLength int
+	}{
+		{
+			name:           "first word",
+			line:           "lp-",
+			expected:       []string{"get ", "save "},
+			expectedLength: 3,
+		},
+		{
+			name:           "empty line",
+			line:           "",
+			expected:       []string{"lp-get ", "lp-save ", "login "},
+			expectedLength: 0,
+		},
+		{
+			name:           "word after space",
+			line:           "lp-get  --i",
+			expected:       []string{"d "},
+			expectedLength: 3,
+		},
+		{
+			name:           "no candidates",
+			line:           "lp-get --x",
+			expected:       []string{},
+			expectedLength: 3,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			line := []rune(testCase.line)
+			candidates, length := complete.Do(line, len(line))
+
+			got := make([]string, 0, len(candidates))
+			for _, candidate := range candidates {
+				got = append(got, string(candidate))
+			}
+
+			assert.Equal(t, testCase.expected, got)
+			assert.Equal(t, testCase.expectedLength, length)
+		})
+	}
+}